@@ -0,0 +1,28 @@
+package cmd
+
+import (
+	"github.com/kyma-incubator/reconciler/internal/cli"
+	"github.com/kyma-incubator/reconciler/internal/cli/mothership"
+	"github.com/spf13/cobra"
+)
+
+func NewCmd(o *cli.Options) *cobra.Command {
+	clientOpts := mothership.NewClientOptions(o)
+	cmd := &cobra.Command{
+		Use:     "cluster",
+		Aliases: []string{"clusters", "cl"},
+		Short:   "Manage clusters of a running mothership reconciler",
+		Long:    "Day-2 operations for clusters using the REST API of a running mothership reconciler",
+	}
+	clientOpts.AddFlags(cmd.PersistentFlags())
+
+	cmd.AddCommand(newListCmd(clientOpts))
+	cmd.AddCommand(newGetCmd(clientOpts))
+	cmd.AddCommand(newStatusCmd(clientOpts))
+	cmd.AddCommand(newHistoryCmd(clientOpts))
+	cmd.AddCommand(newDeleteCmd(clientOpts))
+	cmd.AddCommand(newRestoreCmd(clientOpts))
+	cmd.AddCommand(newReconcileCmd(clientOpts))
+
+	return cmd
+}
@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"context"
+
+	"github.com/kyma-incubator/reconciler/internal/cli/mothership"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/spf13/cobra"
+)
+
+func newDeleteCmd(o *mothership.ClientOptions) *cobra.Command {
+	var force bool
+	cmd := &cobra.Command{
+		Use:     "delete RUNTIME_ID",
+		Aliases: []string{"del"},
+		Short:   "Delete a cluster.",
+		Long: `Mark a cluster for deletion: Kyma will be uninstalled from the cluster by the mothership reconciler
+after the deletion grace period expired and the preflight checks of the component reconcilers passed.
+The deletion can be revoked by the 'restore' command until then. Use '--force' to skip the preflight checks.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.Validate(); err != nil {
+				return err
+			}
+			return runDelete(o, args[0], force)
+		},
+	}
+	cmd.Flags().BoolVar(&force, "force", false, "Delete the cluster even if preflight checks of the component reconcilers fail")
+	return cmd
+}
+
+func runDelete(o *mothership.ClientOptions, runtimeID string, force bool) error {
+	client, err := o.Client()
+	if err != nil {
+		return err
+	}
+	resp, err := client.DeleteClustersRuntimeIDWithResponse(context.Background(), runtimeID,
+		&keb.DeleteClustersRuntimeIDParams{Force: &force})
+	if err != nil {
+		return err
+	}
+	if err := mothership.CheckResponse(resp.HTTPResponse, resp.Body, 200); err != nil {
+		return err
+	}
+	if resp.JSON200 == nil {
+		return mothership.MissingPayloadError(resp.HTTPResponse, resp.Body)
+	}
+	return renderClusters(o.Options, *resp.JSON200)
+}
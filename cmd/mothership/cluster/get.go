@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/kyma-incubator/reconciler/internal/cli"
+	"github.com/kyma-incubator/reconciler/internal/cli/mothership"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/spf13/cobra"
+)
+
+type getOptions struct {
+	*mothership.ClientOptions
+	ConfigVersion int64
+}
+
+func newGetCmd(clientOpts *mothership.ClientOptions) *cobra.Command {
+	o := &getOptions{ClientOptions: clientOpts}
+	cmd := &cobra.Command{
+		Use:   "get RUNTIME_ID",
+		Short: "Get Kyma configuration of a cluster.",
+		Long:  `Get the Kyma configuration (version, profile, components) of a cluster.`,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.Validate(); err != nil {
+				return err
+			}
+			return runGet(o, args[0])
+		},
+	}
+	cmd.Flags().Int64Var(&o.ConfigVersion, "config-version", 0, "Configuration version (default is the latest configuration)")
+	return cmd
+}
+
+func runGet(o *getOptions, runtimeID string) error {
+	configVersion := o.ConfigVersion
+	if configVersion <= 0 {
+		cluster, err := clusterStatus(o.ClientOptions, runtimeID, 0)
+		if err != nil {
+			return err
+		}
+		configVersion = cluster.ConfigurationVersion
+	}
+
+	client, err := o.Client()
+	if err != nil {
+		return err
+	}
+	resp, err := client.GetClustersRuntimeIDConfigVersionWithResponse(context.Background(),
+		runtimeID, strconv.FormatInt(configVersion, 10))
+	if err != nil {
+		return err
+	}
+	if err := mothership.CheckResponse(resp.HTTPResponse, resp.Body, 200); err != nil {
+		return err
+	}
+	if resp.JSON200 == nil {
+		return mothership.MissingPayloadError(resp.HTTPResponse, resp.Body)
+	}
+	return renderConfig(o.Options, runtimeID, configVersion, resp.JSON200)
+}
+
+func renderConfig(o *cli.Options, runtimeID string, configVersion int64, kymaConfig *keb.HTTPClusterConfig) error {
+	formatter, err := cli.NewOutputFormatter(o.OutputFormat)
+	if err != nil {
+		return err
+	}
+
+	if err := formatter.Header("Runtime ID", "Config Version", "Kyma Version", "Kyma Profile",
+		"Administrators", "Components"); err != nil {
+		return err
+	}
+	components := []string{}
+	for _, component := range kymaConfig.Components {
+		if component.Version == "" {
+			components = append(components, component.Component)
+			continue
+		}
+		components = append(components, fmt.Sprintf("%s@%s", component.Component, component.Version))
+	}
+	administrators := kymaConfig.Administrators
+	if administrators == nil {
+		administrators = []string{}
+	}
+	if err := formatter.AddRow(runtimeID, configVersion, kymaConfig.Version, kymaConfig.Profile,
+		administrators, components); err != nil {
+		return err
+	}
+	return formatter.Output(os.Stdout)
+}
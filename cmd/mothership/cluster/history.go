@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/internal/cli"
+	"github.com/kyma-incubator/reconciler/internal/cli/mothership"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/spf13/cobra"
+)
+
+type historyOptions struct {
+	*mothership.ClientOptions
+	Offset time.Duration
+}
+
+func newHistoryCmd(clientOpts *mothership.ClientOptions) *cobra.Command {
+	o := &historyOptions{ClientOptions: clientOpts}
+	cmd := &cobra.Command{
+		Use:   "history RUNTIME_ID",
+		Short: "Show status history of a cluster.",
+		Long:  `Show the status changes of a cluster (latest status change first).`,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.Validate(); err != nil {
+				return err
+			}
+			return runHistory(o, args[0])
+		},
+	}
+	cmd.Flags().DurationVar(&o.Offset, "offset", 0,
+		"Show only status changes which happened within this duration (default is defined by the mothership reconciler)")
+	return cmd
+}
+
+func runHistory(o *historyOptions, runtimeID string) error {
+	client, err := o.Client()
+	if err != nil {
+		return err
+	}
+
+	params := &keb.GetClustersRuntimeIDStatusChangesParams{}
+	if o.Offset > 0 {
+		offset := o.Offset.String()
+		params.Offset = &offset
+	}
+	resp, err := client.GetClustersRuntimeIDStatusChangesWithResponse(context.Background(), runtimeID, params)
+	if err != nil {
+		return err
+	}
+	if err := mothership.CheckResponse(resp.HTTPResponse, resp.Body, 200); err != nil {
+		return err
+	}
+	if resp.JSON200 == nil {
+		return mothership.MissingPayloadError(resp.HTTPResponse, resp.Body)
+	}
+	return renderStatusChanges(o.Options, resp.JSON200.StatusChanges)
+}
+
+func renderStatusChanges(o *cli.Options, statusChanges []keb.StatusChange) error {
+	formatter, err := cli.NewOutputFormatter(o.OutputFormat)
+	if err != nil {
+		return err
+	}
+
+	if err := formatter.Header("Status", "Started at (UTC)", "Duration"); err != nil {
+		return err
+	}
+	for _, statusChange := range statusChanges {
+		if err := formatter.AddRow(statusChange.Status, statusChange.Started.UTC().Format(time.RFC822Z),
+			time.Duration(statusChange.Duration).Round(time.Second).String()); err != nil {
+			return err
+		}
+	}
+	return formatter.Output(os.Stdout)
+}
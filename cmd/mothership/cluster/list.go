@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kyma-incubator/reconciler/internal/cli/mothership"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/spf13/cobra"
+)
+
+type listOptions struct {
+	*mothership.ClientOptions
+	Statuses []string
+	Limit    int
+	Offset   int
+}
+
+func (o *listOptions) Validate() error {
+	for _, status := range o.Statuses {
+		if _, err := keb.ToStatus(status); err != nil {
+			return err
+		}
+	}
+	if o.Limit < 0 {
+		return fmt.Errorf("limit has to be >= 0 (got %d)", o.Limit)
+	}
+	if o.Offset < 0 {
+		return fmt.Errorf("offset has to be >= 0 (got %d)", o.Offset)
+	}
+	return o.ClientOptions.Validate()
+}
+
+func newListCmd(clientOpts *mothership.ClientOptions) *cobra.Command {
+	o := &listOptions{ClientOptions: clientOpts}
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List clusters.",
+		Long:    `List all clusters with their latest status.`,
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.Validate(); err != nil {
+				return err
+			}
+			return runList(o)
+		},
+	}
+	cmd.Flags().StringSliceVar(&o.Statuses, "status", nil, "Show only clusters with one of the given statuses")
+	cmd.Flags().IntVar(&o.Limit, "limit", 0, "Maximum number of clusters to show (0 uses the server default)")
+	cmd.Flags().IntVar(&o.Offset, "offset", 0, "Number of clusters to skip (clusters are ordered by runtime ID)")
+	return cmd
+}
+
+func runList(o *listOptions) error {
+	client, err := o.Client()
+	if err != nil {
+		return err
+	}
+
+	params := &keb.GetClustersParams{}
+	if len(o.Statuses) > 0 {
+		var statuses []keb.Status
+		for _, status := range o.Statuses {
+			statuses = append(statuses, keb.Status(status))
+		}
+		params.Status = &statuses
+	}
+	if o.Limit > 0 {
+		params.Limit = &o.Limit
+	}
+	if o.Offset > 0 {
+		params.Offset = &o.Offset
+	}
+
+	resp, err := client.GetClustersWithResponse(context.Background(), params)
+	if err != nil {
+		return err
+	}
+	if err := mothership.CheckResponse(resp.HTTPResponse, resp.Body, 200); err != nil {
+		return err
+	}
+	if resp.JSON200 == nil {
+		return mothership.MissingPayloadError(resp.HTTPResponse, resp.Body)
+	}
+	return renderClusters(o.Options, *resp.JSON200...)
+}
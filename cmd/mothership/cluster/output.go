@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/kyma-incubator/reconciler/internal/cli"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+)
+
+func renderClusters(o *cli.Options, clusters ...keb.HTTPClusterResponse) error {
+	formatter, err := cli.NewOutputFormatter(o.OutputFormat)
+	if err != nil {
+		return err
+	}
+
+	if err := formatter.Header("Runtime ID", "Cluster Version", "Config Version", "Status", "Failures"); err != nil {
+		return err
+	}
+	for _, cluster := range clusters {
+		if err := formatter.AddRow(cluster.Cluster, cluster.ClusterVersion, cluster.ConfigurationVersion,
+			cluster.Status, failures(cluster)); err != nil {
+			return err
+		}
+	}
+	return formatter.Output(os.Stdout)
+}
+
+func failures(cluster keb.HTTPClusterResponse) []string {
+	result := []string{}
+	if cluster.Failures == nil {
+		return result
+	}
+	for _, failure := range *cluster.Failures {
+		result = append(result, fmt.Sprintf("%s: %s", failure.Component, failure.Reason))
+	}
+	return result
+}
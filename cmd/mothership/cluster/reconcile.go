@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"context"
+
+	"github.com/kyma-incubator/reconciler/internal/cli/mothership"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/spf13/cobra"
+)
+
+func newReconcileCmd(o *mothership.ClientOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "reconcile RUNTIME_ID",
+		Short: "Trigger reconciliation of a cluster.",
+		Long:  `Set the cluster status to 'reconcile_pending': the cluster will be reconciled by the next scheduler run.`,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.Validate(); err != nil {
+				return err
+			}
+			return runReconcile(o, args[0])
+		},
+	}
+	return cmd
+}
+
+func runReconcile(o *mothership.ClientOptions, runtimeID string) error {
+	client, err := o.Client()
+	if err != nil {
+		return err
+	}
+	resp, err := client.PutClustersRuntimeIDStatusWithResponse(context.Background(), runtimeID,
+		keb.PutClustersRuntimeIDStatusJSONRequestBody{Status: keb.StatusReconcilePending})
+	if err != nil {
+		return err
+	}
+	if err := mothership.CheckResponse(resp.HTTPResponse, resp.Body, 200); err != nil {
+		return err
+	}
+	if resp.JSON200 == nil {
+		return mothership.MissingPayloadError(resp.HTTPResponse, resp.Body)
+	}
+	return renderClusters(o.Options, *resp.JSON200)
+}
@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"context"
+
+	"github.com/kyma-incubator/reconciler/internal/cli/mothership"
+	"github.com/spf13/cobra"
+)
+
+func newRestoreCmd(o *mothership.ClientOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "restore RUNTIME_ID",
+		Short: "Revoke the deletion of a cluster.",
+		Long: `Restore a cluster which is scheduled for deletion or whose deletion was blocked by preflight checks:
+the cluster will be reconciled by the next scheduler run.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.Validate(); err != nil {
+				return err
+			}
+			return runRestore(o, args[0])
+		},
+	}
+	return cmd
+}
+
+func runRestore(o *mothership.ClientOptions, runtimeID string) error {
+	client, err := o.Client()
+	if err != nil {
+		return err
+	}
+	resp, err := client.PostClustersRuntimeIDRestoreWithResponse(context.Background(), runtimeID)
+	if err != nil {
+		return err
+	}
+	if err := mothership.CheckResponse(resp.HTTPResponse, resp.Body, 200); err != nil {
+		return err
+	}
+	if resp.JSON200 == nil {
+		return mothership.MissingPayloadError(resp.HTTPResponse, resp.Body)
+	}
+	return renderClusters(o.Options, *resp.JSON200)
+}
@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/kyma-incubator/reconciler/internal/cli/mothership"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/spf13/cobra"
+)
+
+type statusOptions struct {
+	*mothership.ClientOptions
+	ConfigVersion int64
+}
+
+func newStatusCmd(clientOpts *mothership.ClientOptions) *cobra.Command {
+	o := &statusOptions{ClientOptions: clientOpts}
+	cmd := &cobra.Command{
+		Use:   "status RUNTIME_ID",
+		Short: "Show status of a cluster.",
+		Long:  `Show the latest status of a cluster or the status of a particular configuration version.`,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.Validate(); err != nil {
+				return err
+			}
+			return runStatus(o, args[0])
+		},
+	}
+	cmd.Flags().Int64Var(&o.ConfigVersion, "config-version", 0, "Configuration version (default is the latest configuration)")
+	return cmd
+}
+
+func runStatus(o *statusOptions, runtimeID string) error {
+	cluster, err := clusterStatus(o.ClientOptions, runtimeID, o.ConfigVersion)
+	if err != nil {
+		return err
+	}
+	return renderClusters(o.Options, *cluster)
+}
+
+func clusterStatus(o *mothership.ClientOptions, runtimeID string, configVersion int64) (*keb.HTTPClusterResponse, error) {
+	client, err := o.Client()
+	if err != nil {
+		return nil, err
+	}
+
+	if configVersion > 0 {
+		resp, err := client.GetClustersRuntimeIDConfigsConfigVersionStatusWithResponse(context.Background(),
+			runtimeID, strconv.FormatInt(configVersion, 10))
+		if err != nil {
+			return nil, err
+		}
+		if err := mothership.CheckResponse(resp.HTTPResponse, resp.Body, 200); err != nil {
+			return nil, err
+		}
+		if resp.JSON200 == nil {
+			return nil, mothership.MissingPayloadError(resp.HTTPResponse, resp.Body)
+		}
+		return resp.JSON200, nil
+	}
+
+	resp, err := client.GetClustersRuntimeIDStatusWithResponse(context.Background(), runtimeID)
+	if err != nil {
+		return nil, err
+	}
+	if err := mothership.CheckResponse(resp.HTTPResponse, resp.Body, 200); err != nil {
+		return nil, err
+	}
+	if resp.JSON200 == nil {
+		return nil, mothership.MissingPayloadError(resp.HTTPResponse, resp.Body)
+	}
+	return resp.JSON200, nil
+}
@@ -8,6 +8,8 @@ import (
 	getBucketCmd "github.com/kyma-incubator/reconciler/cmd/mothership/config/get/bucket"
 	getKeyCmd "github.com/kyma-incubator/reconciler/cmd/mothership/config/get/key"
 	getValueCmd "github.com/kyma-incubator/reconciler/cmd/mothership/config/get/value"
+	migrateCmd "github.com/kyma-incubator/reconciler/cmd/mothership/config/migrate"
+	migrateEncryptCmd "github.com/kyma-incubator/reconciler/cmd/mothership/config/migrate/encrypt"
 	"github.com/kyma-incubator/reconciler/internal/cli"
 	"github.com/spf13/cobra"
 )
@@ -32,5 +34,10 @@ func NewCmd(o *cli.Options) *cobra.Command {
 	createCommand.AddCommand(createKeyCmd.NewCmd(createKeyCmd.NewOptions(o)))
 	createCommand.AddCommand(createValueCmd.NewCmd(createValueCmd.NewOptions(o)))
 
+	//register migrate commands
+	migrateCommand := migrateCmd.NewCmd(o)
+	cmd.AddCommand(migrateCommand)
+	migrateCommand.AddCommand(migrateEncryptCmd.NewCmd(migrateEncryptCmd.NewOptions(o)))
+
 	return cmd
 }
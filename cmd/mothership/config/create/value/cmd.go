@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/secret"
 	"github.com/spf13/cobra"
 )
 
@@ -55,7 +56,11 @@ func Run(o *Options, val string) error {
 		return err
 	}
 
-	fmt.Printf("Value '%s' created (bucket: %s / key: %s - version %d)\n", value.Value, value.Bucket, value.Key, value.KeyVersion)
+	displayValue := value.Value
+	if key.Encrypted {
+		displayValue = secret.MaskedValue
+	}
+	fmt.Printf("Value '%s' created (bucket: %s / key: %s - version %d)\n", displayValue, value.Bucket, value.Key, value.KeyVersion)
 	return nil
 }
 
@@ -7,6 +7,7 @@ import (
 
 	"github.com/kyma-incubator/reconciler/internal/cli"
 	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/secret"
 	"github.com/spf13/cobra"
 )
 
@@ -90,7 +91,15 @@ func renderBucketsWithValues(o *cli.Options, buckets []*model.BucketEntity) erro
 		}
 		kvPairs := make(map[string]interface{}, len(values))
 		for _, value := range values {
-			kvPairs[value.Key] = value.Value
+			key, err := o.Registry.KVRepository().Key(value.Key, value.KeyVersion)
+			if err != nil {
+				return err
+			}
+			if key.Encrypted {
+				kvPairs[value.Key] = secret.MaskedValue
+			} else {
+				kvPairs[value.Key] = value.Value
+			}
 		}
 		if err := formatter.AddRow(bucket.Bucket, bucket.Username, bucket.Created.Format(time.RFC822Z), kvPairs); err != nil {
 			return err
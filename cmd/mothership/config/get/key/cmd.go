@@ -6,6 +6,7 @@ import (
 
 	"github.com/kyma-incubator/reconciler/internal/cli"
 	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/secret"
 	"github.com/spf13/cobra"
 )
 
@@ -86,7 +87,11 @@ func renderKeysWithValues(o *Options, keys []*model.KeyEntity) error {
 			if _, ok := kvPairs[value.Bucket]; !ok {
 				kvPairs[value.Bucket] = []interface{}{}
 			}
-			kvPairs[value.Bucket] = append(kvPairs[value.Bucket], value.Value)
+			if key.Encrypted {
+				kvPairs[value.Bucket] = append(kvPairs[value.Bucket], secret.MaskedValue)
+			} else {
+				kvPairs[value.Bucket] = append(kvPairs[value.Bucket], value.Value)
+			}
 		}
 		if err := formatter.AddRow(key.Key, key.DataType, key.Encrypted, key.Username,
 			key.Created.Format(time.RFC822Z), key.Validator, key.Trigger, key.Version, kvPairs); err != nil {
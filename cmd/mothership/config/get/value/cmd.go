@@ -7,6 +7,7 @@ import (
 
 	"github.com/kyma-incubator/reconciler/internal/cli"
 	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/secret"
 	"github.com/spf13/cobra"
 )
 
@@ -26,6 +27,7 @@ func NewCmd(o *Options) *cobra.Command {
 	cmd.Flags().BoolVar(&o.History, "history", false, "Show history of configuration value")
 	cmd.Flags().StringVar(&o.Key, "key", "", "Key name")
 	cmd.Flags().Int64Var(&o.KeyVersion, "key-version", 0, "Key version")
+	cmd.Flags().BoolVar(&o.Reveal, "reveal", false, "Show values of encrypted keys in plaintext")
 
 	return cmd
 }
@@ -51,10 +53,10 @@ func Run(o *Options, valueFilter []string) error {
 	}
 
 	// render all keys (without values)
-	return renderValues(o, values)
+	return renderValues(o, key, values)
 }
 
-func renderValues(o *Options, values []*model.ValueEntity) error {
+func renderValues(o *Options, key *model.KeyEntity, values []*model.ValueEntity) error {
 	formatter, err := cli.NewOutputFormatter(o.OutputFormat)
 	if err != nil {
 		return err
@@ -64,8 +66,14 @@ func renderValues(o *Options, values []*model.ValueEntity) error {
 		"Created at (UTC)", "Version"); err != nil {
 		return err
 	}
+	//all values belong to the same key: mask them if the key is encrypted and shouldn't be revealed
+	mask := key.Encrypted && !o.Reveal
 	for _, value := range values {
-		if err := formatter.AddRow(value.Bucket, value.Value, value.DataType, value.Username,
+		val := value.Value
+		if mask {
+			val = secret.MaskedValue
+		}
+		if err := formatter.AddRow(value.Bucket, val, value.DataType, value.Username,
 			value.Created.Format(time.RFC822Z), value.Version); err != nil {
 			return err
 		}
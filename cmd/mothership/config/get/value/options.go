@@ -11,10 +11,11 @@ type Options struct {
 	History    bool
 	Key        string
 	KeyVersion int64
+	Reveal     bool
 }
 
 func NewOptions(o *cli.Options) *Options {
-	return &Options{o, false, "", 0}
+	return &Options{o, false, "", 0, false}
 }
 
 func (o *Options) Validate() error {
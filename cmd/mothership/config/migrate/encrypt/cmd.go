@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+func NewCmd(o *Options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "encrypt",
+		Short: "Encrypt plaintext values of encrypted keys.",
+		Long: `Encrypt all values of keys which are marked as encrypted but were stored in plaintext ` +
+			`(e.g. because they were created by an older reconciler version).`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.Validate(); err != nil {
+				return err
+			}
+			return Run(o)
+		},
+	}
+	cmd.Flags().BoolVar(&o.DryRun, "dry-run", false, "Only count the plaintext values without encrypting them")
+	return cmd
+}
+
+func Run(o *Options) error {
+	cnt, err := o.Registry.KVRepository().EncryptValues(o.DryRun)
+	if err != nil {
+		return err
+	}
+	if o.DryRun {
+		fmt.Printf("%d plaintext value(s) of encrypted keys found\n", cnt)
+		return nil
+	}
+	fmt.Printf("%d value(s) encrypted\n", cnt)
+	return nil
+}
@@ -0,0 +1,18 @@
+package cmd
+
+import (
+	"github.com/kyma-incubator/reconciler/internal/cli"
+)
+
+type Options struct {
+	*cli.Options
+	DryRun bool
+}
+
+func NewOptions(o *cli.Options) *Options {
+	return &Options{o, false}
+}
+
+func (o *Options) Validate() error {
+	return nil
+}
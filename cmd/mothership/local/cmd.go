@@ -20,6 +20,7 @@ import (
 	_ "github.com/kyma-incubator/reconciler/pkg/reconciler/instances"
 	"github.com/kyma-incubator/reconciler/pkg/reconciler/service"
 	"github.com/spf13/cobra"
+	"go.uber.org/zap"
 )
 
 const (
@@ -39,13 +40,25 @@ func NewCmd(o *Options) *cobra.Command {
 		},
 	}
 	cmd.Flags().StringVar(&o.kubeconfigFile, "kubeconfig", "", "Path to kubeconfig file")
+	cmd.Flags().BoolVarP(&o.delete, "delete", "d", false, "Provide this flag to do a deletion instead of reconciliation")
+	addSourceFlags(cmd, o)
+	return cmd
+}
+
+//addSourceFlags adds the flags which define the Kyma sources and the components
+func addSourceFlags(cmd *cobra.Command, o *Options) {
 	cmd.Flags().StringSliceVar(&o.components, "components", []string{}, "Comma separated list of components with optional namespace, e.g. serverless,certificates@istio-system,monitoring")
 	cmd.Flags().StringVar(&o.componentsFile, "components-file", "", `Path to the components file (default "<workspace>/installation/resources/components.yaml")`)
 	cmd.Flags().StringSliceVar(&o.values, "value", []string{}, "Set configuration values. Can specify one or more values, also as a comma-separated list (e.g. --value component.a='1' --value component.b='2' or --value component.a='1',component.b='2').")
 	cmd.Flags().StringVar(&o.version, "version", "main", "Kyma version")
-	cmd.Flags().StringVar(&o.profile, "profile", "evaluation", "Kyma profile")
-	cmd.Flags().BoolVarP(&o.delete, "delete", "d", false, "Provide this flag to do a deletion instead of reconciliation")
-	return cmd
+	cmd.Flags().StringVar(&o.profile, "profile", "evaluation", "Kyma profile (profile shipped with the charts or custom profile)")
+	cmd.Flags().StringVar(&o.profilesFile, "profiles-file", "", "Path to the file with custom Kyma profiles (custom profiles of the KV store take precedence if the registry is initialized)")
+	cmd.Flags().StringVar(&o.credentialFile, "git-credentials-file", "", "Path to the file which maps GIT repositories to credentials (tokens, SSH deploy keys or GitHub Apps)")
+	cmd.Flags().StringVar(&o.keysFile, "verification-keys-file", "", "Path to the file which maps key names to public keys trusted to verify signatures of component sources")
+	cmd.Flags().BoolVar(&o.offline, "offline", false, "Refuse the download of Kyma sources: the workspace has to be imported from a workspace bundle")
+	cmd.Flags().StringVar(&o.bundle, "workspace-bundle", "", "Workspace bundle (created with 'mothership workspace export') which is imported before the installation starts")
+	cmd.Flags().StringVar(&o.bundleKeyFile, "workspace-bundle-public-key", "", "Public key used to verify the signature of the workspace bundle")
+	cmd.Flags().BoolVar(&o.bundleInsecure, "workspace-bundle-insecure-skip-verify", false, "Import the workspace bundle without verifying its signature (only for bundles of trusted sources)")
 }
 
 func RunLocal(o *Options) error {
@@ -56,7 +69,7 @@ func RunLocal(o *Options) error {
 	//use a global workspace factory to ensure all component-reconcilers are using the same workspace-directory
 	//(otherwise each component-reconciler would handle the download of Kyma resources individually which will cause
 	//collisions when sharing the same directory)
-	wsFact, err := chart.NewFactory(nil, workspaceDir, l)
+	wsFact, err := o.workspaceFactory(l)
 	if err != nil {
 		return err
 	}
@@ -71,6 +84,14 @@ func RunLocal(o *Options) error {
 	}
 	defaultComponentsYaml := filepath.Join(ws.InstallationResourceDir, "components.yaml")
 
+	profiles, err := o.profileResolver()
+	if err != nil {
+		return err
+	}
+	if _, err := resolveProfile(profiles, ws, o.profile); err != nil {
+		return err
+	}
+
 	printStatus := func(component string, msg *reconciler.CallbackMessage) {
 		errMsg := ""
 		if msg.Error != "" {
@@ -90,7 +111,7 @@ func RunLocal(o *Options) error {
 	if o.delete {
 		status = model.ClusterStatusDeletePending
 	}
-	reconResult, err := runtimeBuilder.RunLocal(preComps, printStatus).Run(cli.NewContext(), &cluster.State{
+	reconResult, err := runtimeBuilder.RunLocal(preComps, printStatus).WithProfiles(profiles).Run(cli.NewContext(), &cluster.State{
 		Cluster: &model.ClusterEntity{
 			Version:    1,
 			RuntimeID:  "local",
@@ -134,3 +155,23 @@ func RunLocal(o *Options) error {
 
 	return nil
 }
+
+//workspaceFactory creates the workspace factory and imports the workspace bundle (if defined)
+func (o *Options) workspaceFactory(l *zap.SugaredLogger) (*chart.DefaultFactory, error) {
+	wsFact, err := chart.NewFactory(nil, workspaceDir, l)
+	if err != nil {
+		return nil, err
+	}
+	wsFact.WithGitCredentials(o.gitCredentials).WithVerificationKeys(o.keys).WithOffline(o.offline)
+	if o.bundle != "" {
+		if o.bundleInsecure {
+			_, err = wsFact.ImportUnverifiedBundleFile(o.bundle)
+		} else {
+			_, err = wsFact.ImportBundleFile(o.bundle, o.bundleKeyFile)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return wsFact, nil
+}
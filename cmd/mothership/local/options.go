@@ -11,6 +11,9 @@ import (
 	"github.com/kyma-incubator/reconciler/internal/cli"
 	file "github.com/kyma-incubator/reconciler/pkg/files"
 	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/profile"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/chart"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/git"
 	"github.com/pkg/errors"
 	"helm.sh/helm/v3/pkg/strvals"
 )
@@ -21,10 +24,21 @@ type Options struct {
 	kubeconfig     string
 	version        string
 	profile        string
+	profilesFile   string
 	components     []string
 	values         []string
 	componentsFile string
 	delete         bool
+	credentialFile string
+	gitCredentials *git.CredentialsMapping
+	keysFile       string
+	keys           chart.VerificationKeys
+	offline        bool
+	bundle         string
+	bundleKeyFile  string
+	bundleInsecure bool
+	outputDir      string
+	crds           bool
 }
 
 func NewOptions(o *cli.Options) *Options {
@@ -33,16 +47,68 @@ func NewOptions(o *cli.Options) *Options {
 		"",         // kubeconfig
 		"",         // version
 		"",         // profile
+		"",         // profilesFile
 		[]string{}, // components
 		[]string{}, // values
 		"",         // componentsFile
 		false,      // delete
+		"",         // credentialFile
+		nil,        // gitCredentials
+		"",         // keysFile
+		nil,        // keys
+		false,      // offline
+		"",         // bundle
+		"",         // bundleKeyFile
+		false,      // bundleInsecure
+		"",         // outputDir
+		true,       // crds
 	}
 }
 func (o *Options) Kubeconfig() string {
 	return o.kubeconfig
 }
 
+//profileResolver creates the resolver of custom Kyma profiles defined in the profiles file or in the KV store
+//(the KV store is only used if the application registry is initialized)
+func (o *Options) profileResolver() (*profile.Resolver, error) {
+	var sources []profile.Source
+	if o.Registry != nil {
+		sources = append(sources, profile.NewKVSource(o.Registry.KVRepository()))
+	}
+	if o.profilesFile != "" {
+		fileSource, err := profile.NewFileSource(o.profilesFile)
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, fileSource)
+	}
+	return profile.NewResolver(sources...), nil
+}
+
+//resolveProfile resolves the Kyma profile and verifies that its chart profile is shipped with the charts
+//(otherwise the default values of the charts would silently be used)
+func resolveProfile(profiles *profile.Resolver, ws *chart.KymaWorkspace, name string) (*profile.Resolved, error) {
+	kymaProfile, err := profiles.Resolve(name)
+	if err != nil {
+		return nil, err
+	}
+	if kymaProfile.ChartProfile == "" {
+		return kymaProfile, nil
+	}
+	exists, err := ws.HasProfile(kymaProfile.ChartProfile)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		if kymaProfile.IsCustom() {
+			return nil, fmt.Errorf("base profile '%s' of custom profile '%s' isn't shipped with the charts",
+				kymaProfile.ChartProfile, name)
+		}
+		return nil, fmt.Errorf("profile '%s' is neither a custom profile nor shipped with the charts", name)
+	}
+	return kymaProfile, nil
+}
+
 func componentsFromFile(path string) ([][]string, []string, error) {
 	var preComps []string
 	var defaultComps []string
@@ -165,8 +231,43 @@ func (o *Options) Validate() error {
 	}
 	o.kubeconfig = string(content)
 
+	return o.validateSources()
+}
+
+//ValidateRender validates the options used to render manifests (a kubeconfig isn't required)
+func (o *Options) ValidateRender() error {
+	if err := o.Options.Validate(); err != nil {
+		return err
+	}
+	return o.validateSources()
+}
+
+//validateSources validates the options which define the Kyma sources and the components
+func (o *Options) validateSources() error {
 	if len(o.components) > 0 && o.componentsFile != "" {
 		return fmt.Errorf("use one of 'components' or 'component-file' flag")
 	}
+
+	if o.bundle != "" && o.bundleKeyFile == "" && !o.bundleInsecure {
+		return fmt.Errorf("public key file is required to verify the signature of workspace bundle '%s' "+
+			"(use 'workspace-bundle-insecure-skip-verify' to import unverified bundles)", o.bundle)
+	}
+	if o.bundleKeyFile != "" && o.bundleInsecure {
+		return fmt.Errorf("use one of 'workspace-bundle-public-key' or 'workspace-bundle-insecure-skip-verify' flag")
+	}
+
+	if o.credentialFile != "" {
+		var err error
+		if o.gitCredentials, err = git.LoadCredentialsMapping(o.credentialFile); err != nil {
+			return err
+		}
+	}
+
+	if o.keysFile != "" {
+		var err error
+		if o.keys, err = chart.LoadVerificationKeys(o.keysFile); err != nil {
+			return err
+		}
+	}
 	return nil
 }
@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/logger"
+	"github.com/kyma-incubator/reconciler/pkg/profile"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/chart"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+const crdsOutputName = "crds"
+
+//renderedComponent contains the manifests rendered for a component (or for the CRDs of the Kyma version)
+type renderedComponent struct {
+	name      string
+	manifests []*chart.Manifest
+}
+
+func NewRenderCmd(o *Options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "render",
+		Short: "Render the manifests of Kyma components",
+		Long: "Render the manifests of Kyma components without reconciling a cluster " +
+			"(e.g. to verify the configuration values of component charts)",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.ValidateRender(); err != nil {
+				return err
+			}
+			return RunRender(o, cmd.OutOrStdout())
+		},
+	}
+	cmd.Flags().StringVarP(&o.outputDir, "output-dir", "o", "", "Directory the manifests are written to (one file per component), default is stdout")
+	cmd.Flags().BoolVar(&o.crds, "crds", true, "Render the CRDs of the Kyma version")
+	addSourceFlags(cmd, o)
+	return cmd
+}
+
+func RunRender(o *Options, out io.Writer) error {
+	l := logger.NewLogger(o.Verbose)
+
+	wsFact, err := o.workspaceFactory(l)
+	if err != nil {
+		return err
+	}
+
+	ws, err := wsFact.Get(o.version)
+	if err != nil {
+		return err
+	}
+	_, comps, err := o.Components(filepath.Join(ws.InstallationResourceDir, "components.yaml"))
+	if err != nil {
+		return err
+	}
+
+	profiles, err := o.profileResolver()
+	if err != nil {
+		return err
+	}
+	kymaProfile, err := resolveProfile(profiles, ws, o.profile)
+	if err != nil {
+		return err
+	}
+
+	provider, err := chart.NewDefaultProvider(wsFact, l)
+	if err != nil {
+		return err
+	}
+
+	rendered, err := render(provider, o.version, kymaProfile, comps, o.crds)
+	if err != nil {
+		return err
+	}
+	return writeRendered(rendered, out, o.outputDir)
+}
+
+func render(provider chart.Provider, version string, kymaProfile *profile.Resolved, comps []*keb.Component, crds bool) ([]*renderedComponent, error) {
+	var result []*renderedComponent
+
+	if crds {
+		crdManifests, err := provider.RenderCRD(version)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to render CRDs of Kyma version '%s'", version)
+		}
+		result = append(result, &renderedComponent{name: crdsOutputName, manifests: crdManifests})
+	}
+
+	for _, comp := range comps {
+		profileValues, err := kymaProfile.Values(comp.Component)
+		if err != nil {
+			return nil, err
+		}
+		component := chart.NewComponentBuilder(comp.ResolveVersion(version), comp.Component).
+			WithProfile(kymaProfile.ChartProfile).
+			WithProfileValues(profileValues).
+			WithNamespace(comp.Namespace).
+			WithConfiguration(comp.ConfigurationAsMap()).
+			WithURL(comp.URL).
+			Build()
+		manifest, err := provider.RenderManifest(component)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to render manifest of component '%s'", comp.Component)
+		}
+		result = append(result, &renderedComponent{name: comp.Component, manifests: []*chart.Manifest{manifest}})
+	}
+
+	return result, nil
+}
+
+//writeRendered writes the manifests to the output directory (one file per component) or to the writer
+func writeRendered(rendered []*renderedComponent, out io.Writer, outputDir string) error {
+	if outputDir == "" {
+		for _, comp := range rendered {
+			if _, err := io.WriteString(out, chart.MergeManifestsWithHooks(comp.manifests...)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if err := os.MkdirAll(outputDir, 0700); err != nil {
+		return errors.Wrapf(err, "failed to create output directory '%s'", outputDir)
+	}
+	for _, comp := range rendered {
+		file := filepath.Join(outputDir, fmt.Sprintf("%s.yaml", comp.name))
+		if err := ioutil.WriteFile(file, []byte(chart.MergeManifestsWithHooks(comp.manifests...)), 0600); err != nil {
+			return errors.Wrapf(err, "failed to write manifest file '%s'", file)
+		}
+		if _, err := fmt.Fprintf(out, "Manifests of '%s' written to '%s'\n", comp.name, file); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/profile"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/chart"
+	chartmocks "github.com/kyma-incubator/reconciler/pkg/reconciler/chart/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRender(t *testing.T) {
+	provider := &chartmocks.Provider{}
+	provider.On("RenderCRD", "1.0.0").Return([]*chart.Manifest{
+		{Type: chart.CRD, Name: "crd.yaml", Manifest: "kind: CustomResourceDefinition"},
+	}, nil)
+	provider.On("RenderManifest", mock.AnythingOfType("*chart.Component")).Return(&chart.Manifest{
+		Type:     chart.HelmChart,
+		Name:     "comp",
+		Manifest: "kind: Deployment",
+		Hooks: chart.Hooks{
+			{Name: "job", Kind: "Job", Manifest: "kind: Job", Events: []chart.HookEvent{chart.PreInstallHook}},
+		},
+	}, nil)
+
+	comps := []*keb.Component{{Component: "comp", Namespace: "kyma-system"}}
+	evaluation, err := profile.NewResolver().Resolve("evaluation")
+	require.NoError(t, err)
+
+	t.Run("Render to writer", func(t *testing.T) {
+		rendered, err := render(provider, "1.0.0", evaluation, comps, true)
+		require.NoError(t, err)
+		require.Len(t, rendered, 2)
+
+		var out bytes.Buffer
+		require.NoError(t, writeRendered(rendered, &out, ""))
+		require.Equal(t, `---
+# Manifest of crd 'crd.yaml'
+kind: CustomResourceDefinition
+---
+# Manifest of helmChart 'comp'
+kind: Deployment
+---
+# Hook 'job' of helmChart 'comp' (events: pre-install, weight: 0)
+kind: Job
+`, out.String())
+	})
+
+	t.Run("Render to directory", func(t *testing.T) {
+		rendered, err := render(provider, "1.0.0", evaluation, comps, false)
+		require.NoError(t, err)
+		require.Len(t, rendered, 1)
+
+		outputDir := t.TempDir()
+		require.NoError(t, writeRendered(rendered, &bytes.Buffer{}, outputDir))
+		require.NoFileExists(t, filepath.Join(outputDir, "crds.yaml"))
+		manifest, err := ioutil.ReadFile(filepath.Join(outputDir, "comp.yaml"))
+		require.NoError(t, err)
+		require.Contains(t, string(manifest), "kind: Deployment")
+		require.Contains(t, string(manifest), "kind: Job")
+	})
+
+	t.Run("Render with custom profile", func(t *testing.T) {
+		profilesFile := filepath.Join(t.TempDir(), "profiles.yaml")
+		require.NoError(t, ioutil.WriteFile(profilesFile, []byte(`
+trial-small:
+  base: evaluation
+  components:
+    comp:
+      replicas: 1
+`), 0600))
+		fileSource, err := profile.NewFileSource(profilesFile)
+		require.NoError(t, err)
+		trialSmall, err := profile.NewResolver(fileSource).Resolve("trial-small")
+		require.NoError(t, err)
+
+		customProvider := &chartmocks.Provider{}
+		customProvider.On("RenderManifest", chart.NewComponentBuilder("1.0.0", "comp").
+			WithProfile("evaluation").
+			WithProfileValues(map[string]interface{}{"replicas": float64(1)}).
+			WithNamespace("kyma-system").
+			WithConfiguration(map[string]interface{}{}).
+			Build()).Return(&chart.Manifest{Type: chart.HelmChart, Name: "comp", Manifest: "kind: Deployment"}, nil)
+
+		rendered, err := render(customProvider, "1.0.0", trialSmall, comps, false)
+		require.NoError(t, err)
+		require.Len(t, rendered, 1)
+		customProvider.AssertExpectations(t)
+	})
+}
+
+func TestResolveProfile(t *testing.T) {
+	resourceDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(resourceDir, "comp"), 0700))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(resourceDir, "comp", "profile-evaluation.yaml"), []byte{}, 0600))
+	ws := &chart.KymaWorkspace{ResourceDir: resourceDir}
+
+	profilesFile := filepath.Join(t.TempDir(), "profiles.yaml")
+	require.NoError(t, ioutil.WriteFile(profilesFile, []byte(`
+trial-small:
+  base: evaluation
+broken:
+  base: unknown
+`), 0600))
+	fileSource, err := profile.NewFileSource(profilesFile)
+	require.NoError(t, err)
+	profiles := profile.NewResolver(fileSource)
+
+	kymaProfile, err := resolveProfile(profiles, ws, "trial-small")
+	require.NoError(t, err)
+	require.Equal(t, "evaluation", kymaProfile.ChartProfile)
+
+	_, err = resolveProfile(profiles, ws, "broken")
+	require.Error(t, err)
+
+	//unknown profiles aren't rendered with the default values of the charts
+	_, err = resolveProfile(profile.NewResolver(), ws, "trial-small")
+	require.Error(t, err)
+}
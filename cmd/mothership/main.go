@@ -6,9 +6,13 @@ import (
 	"path/filepath"
 	"strings"
 
+	clusterCmd "github.com/kyma-incubator/reconciler/cmd/mothership/cluster"
 	cfgCmd "github.com/kyma-incubator/reconciler/cmd/mothership/config"
 	localCmd "github.com/kyma-incubator/reconciler/cmd/mothership/local"
 	msCmd "github.com/kyma-incubator/reconciler/cmd/mothership/mothership"
+	operationCmd "github.com/kyma-incubator/reconciler/cmd/mothership/operation"
+	reconciliationCmd "github.com/kyma-incubator/reconciler/cmd/mothership/reconciliation"
+	workspaceCmd "github.com/kyma-incubator/reconciler/cmd/mothership/workspace"
 	"github.com/kyma-incubator/reconciler/internal/cli"
 	file "github.com/kyma-incubator/reconciler/pkg/files"
 	"github.com/spf13/cobra"
@@ -32,6 +36,11 @@ func main() {
 	cmd.AddCommand(cfgCmd.NewCmd(o))
 	cmd.AddCommand(msCmd.NewCmd(o))
 	cmd.AddCommand(localCmd.NewCmd(localCmd.NewOptions(o)))
+	cmd.AddCommand(localCmd.NewRenderCmd(localCmd.NewOptions(o)))
+	cmd.AddCommand(clusterCmd.NewCmd(o))
+	cmd.AddCommand(reconciliationCmd.NewCmd(o))
+	cmd.AddCommand(operationCmd.NewCmd(o))
+	cmd.AddCommand(workspaceCmd.NewCmd(o))
 
 	if err := cmd.Execute(); err != nil {
 		os.Exit(1)
@@ -9,7 +9,9 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/kyma-incubator/reconciler/pkg/auth"
 	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/secret"
 	"github.com/kyma-incubator/reconciler/pkg/server"
 
 	"github.com/google/uuid"
@@ -101,23 +103,28 @@ func auditLogRequest(w http.ResponseWriter, r *http.Request, l *zap.Logger, o *O
 		Tenant:          o.AuditLogTenantID,
 		IP:              "-",
 	}
-	jwtPayload, err := getJWTPayload(r)
-	if err != nil {
-		server.SendHTTPError(w, http.StatusInternalServerError, &keb.HTTPErrorResponse{
-			Error: errors.Wrap(err, fmt.Sprintf("Failed to parse %s header content ", XJWTHeaderName)).Error(),
-		})
-		return
-	}
+	if identity := auth.IdentityFromContext(r.Context()); identity != nil {
+		//identity was verified by the authentication middleware
+		logData.User = identity.Subject
+	} else {
+		jwtPayload, err := getJWTPayload(r)
+		if err != nil {
+			server.SendHTTPError(w, http.StatusInternalServerError, &keb.HTTPErrorResponse{
+				Error: errors.Wrap(err, fmt.Sprintf("Failed to parse %s header content ", XJWTHeaderName)).Error(),
+			})
+			return
+		}
 
-	user, err := getJWTPayloadSub(jwtPayload)
-	if err != nil {
-		server.SendHTTPError(w, http.StatusInternalServerError, &keb.HTTPErrorResponse{
-			Error: errors.Wrap(err, "failed to Unmarshal JWT payload").Error(),
-		})
-		return
-	}
-	if user != "" {
-		logData.User = user
+		user, err := getJWTPayloadSub(jwtPayload)
+		if err != nil {
+			server.SendHTTPError(w, http.StatusInternalServerError, &keb.HTTPErrorResponse{
+				Error: errors.Wrap(err, "failed to Unmarshal JWT payload").Error(),
+			})
+			return
+		}
+		if user != "" {
+			logData.User = user
+		}
 	}
 
 	// log request body if needed.
@@ -130,7 +137,7 @@ func auditLogRequest(w http.ResponseWriter, r *http.Request, l *zap.Logger, o *O
 			return
 		}
 		r.Body = ioutil.NopCloser(bytes.NewBuffer(reqBody))
-		logData.RequestBody = string(reqBody)
+		logData.RequestBody = string(secret.MaskJSON(reqBody))
 	}
 
 	ip := r.Header.Get(ExternalAddressHeaderName)
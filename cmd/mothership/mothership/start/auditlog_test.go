@@ -12,6 +12,7 @@ import (
 
 	"github.com/gorilla/mux"
 	"github.com/kyma-incubator/reconciler/internal/cli"
+	"github.com/kyma-incubator/reconciler/pkg/auth"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
@@ -74,6 +75,7 @@ func Test_Auditlog(t *testing.T) {
 		method     string
 		body       string
 		jwtHeader  string
+		identity   *auth.Identity
 		expectFail bool
 	}{
 		{
@@ -85,6 +87,11 @@ func Test_Auditlog(t *testing.T) {
 			method:    http.MethodGet,
 			jwtHeader: "eyJleHAiOjQ2ODU5ODk3MDAsImZvbyI6ImJhciIsImlhdCI6MTUzMjM4OTcwMCwiaXNzIjoidGVzdDJAdGVzdC5wbCIsInN1YiI6InRlc3QyQHRlc3QucGwifQ",
 		},
+		{
+			name:     "get request with verified identity",
+			method:   http.MethodGet,
+			identity: &auth.Identity{Subject: jwtPayloadSub, Roles: []auth.Role{auth.RoleReadOnly}},
+		},
 		{
 			name:   "post request",
 			method: http.MethodPost,
@@ -128,6 +135,9 @@ func Test_Auditlog(t *testing.T) {
 			if tc.jwtHeader != "" {
 				req.Header.Add(XJWTHeaderName, tc.jwtHeader)
 			}
+			if tc.identity != nil {
+				req = req.WithContext(auth.WithIdentity(req.Context(), tc.identity))
+			}
 
 			// clean the log sink
 			defer output.Reset()
@@ -141,7 +151,7 @@ func Test_Auditlog(t *testing.T) {
 					http.StatusInternalServerError, w.Result().StatusCode)
 			} else {
 				t.Log(output.String())
-				validateLog(t, output.String(), tc.method, tc.jwtHeader != "")
+				validateLog(t, output.String(), tc.method, tc.jwtHeader != "" || tc.identity != nil)
 			}
 
 		})
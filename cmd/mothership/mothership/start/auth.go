@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"net/http"
+
+	"github.com/kyma-incubator/reconciler/pkg/auth"
+)
+
+var (
+	rolesRead        = []auth.Role{auth.RoleReadOnly, auth.RoleOperator, auth.RoleProvisioner}
+	rolesOperate     = []auth.Role{auth.RoleOperator}
+	rolesProvision   = []auth.Role{auth.RoleProvisioner}
+	rolesStatusWrite = []auth.Role{auth.RoleOperator, auth.RoleProvisioner}
+	rolesDelete      = []auth.Role{auth.RoleOperator, auth.RoleProvisioner}
+)
+
+func newAuthenticator(o *Options) (auth.Authenticator, error) {
+	var chain auth.Chain
+	if o.AuthTokenFile != "" {
+		tokenAuth, err := auth.NewTokenAuthenticator(o.AuthTokenFile)
+		if err != nil {
+			return nil, err
+		}
+		chain = append(chain, tokenAuth)
+	}
+	if o.AuthJWKSFile != "" {
+		jwtAuth, err := auth.NewJWTAuthenticator(&auth.JWTConfig{
+			JWKSFile:   o.AuthJWKSFile,
+			Issuer:     o.AuthJWTIssuer,
+			Audience:   o.AuthJWTAudience,
+			RolesClaim: o.AuthJWTRolesClaim,
+		})
+		if err != nil {
+			return nil, err
+		}
+		chain = append(chain, jwtAuth)
+	}
+	return chain, nil
+}
+
+// authorized restricts the handler to callers owning one of the given roles (no-op if authentication is disabled)
+func authorized(o *Options, handler http.HandlerFunc, roles []auth.Role) http.HandlerFunc {
+	if !o.AuthEnabled() {
+		return handler
+	}
+	return auth.Authorize(handler, roles...)
+}
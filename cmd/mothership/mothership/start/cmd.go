@@ -37,11 +37,20 @@ func NewCmd(o *Options) *cobra.Command {
 	cmd.Flags().IntVar(&o.Port, "server-port", 8080, "Webserver port")
 	cmd.Flags().StringVar(&o.SSLCrt, "server-crt", "", "Path to SSL certificate file")
 	cmd.Flags().StringVar(&o.SSLKey, "server-key", "", "Path to SSL key file")
+	cmd.Flags().StringVar(&o.ServerClientCA, "server-client-ca", "", "Path to CA file used to verify client certificates (enables mutual TLS, all API clients require a certificate)")
+	cmd.Flags().StringVar(&o.ClientCrt, "client-crt", "", "Path to client certificate file used for mutual TLS when calling component reconcilers")
+	cmd.Flags().StringVar(&o.ClientKey, "client-key", "", "Path to client key file used for mutual TLS when calling component reconcilers")
+	cmd.Flags().StringVar(&o.ClientCA, "client-ca", "", "Path to CA file used to verify the certificates of component reconcilers")
+	cmd.Flags().StringVar(&o.SignatureKeyFile, "signature-key-file", "", "Path to the shared secret used to sign tasks and to verify callbacks of component reconcilers")
+	cmd.Flags().StringVar(&o.Workspace, "workspace", ".", "Workspace directory used to cache Kyma sources for rendering the manifests of cluster configurations")
+	cmd.Flags().BoolVar(&o.ValidateValues, "validate-values", false, "Validate the configuration of cluster components against the values schemas of their charts before a cluster configuration is stored (requests are rejected with 503 until the charts of a Kyma version are downloaded)")
+	cmd.Flags().BoolVar(&o.AllowUnknownVersions, "allow-unknown-versions", false, "Accept cluster configurations without values validation if the charts of the Kyma version can't be retrieved")
 	cmd.Flags().IntVarP(&o.MaxParallelOperations, "max-parallel", "", 0, "Maximal parallel reconciled components per cluster, 0 means unlimited")
 	cmd.Flags().IntVarP(&o.Workers, "worker-count", "", 50, "Size of the reconciler worker pool")
 	cmd.Flags().DurationVarP(&o.OrphanOperationTimeout, "orphan-timeout", "", 10*time.Minute, "Timeout until a processed operation which hasn't received status updates from its worker will be restarted")
 	cmd.Flags().DurationVarP(&o.WatchInterval, "watch-interval", "", 1*time.Minute, "Size of the reconciler worker pool")
 	cmd.Flags().DurationVarP(&o.ClusterReconcileInterval, "reconcile-interval", "", 5*time.Minute, "Defines the time when a cluster will to be reconciled since his last successful reconciliation")
+	cmd.Flags().DurationVar(&o.DeletionGracePeriod, "deletion-grace-period", 0, "Defines the time a deleted cluster stays in status 'delete_scheduled' and can be restored before its deletion starts (0 deletes clusters immediately)")
 	cmd.Flags().DurationVar(&o.PurgeEntitiesOlderThan, "purge-older-than", 14*24*time.Hour, "Defines the minimum age of entities like Reconciliations and Operations that will be removed")
 	cmd.Flags().DurationVar(&o.CleanerInterval, "cleaner-interval", 14*time.Hour, "Define the time when the cleaner will be looking for entities to remove")
 	cmd.Flags().BoolVar(&o.CreateEncyptionKey, "create-encryption-key", false, "Create new encryption key file during startup")
@@ -49,6 +58,11 @@ func NewCmd(o *Options) *cobra.Command {
 	cmd.Flags().BoolVar(&o.AuditLog, "audit-log", false, "Enable audit logging")
 	cmd.Flags().StringVar(&o.AuditLogFile, "audit-log-file", "/var/log/auditlog/mothership-audit.log", "Path for mothership audit log file")
 	cmd.Flags().StringVar(&o.AuditLogTenantID, "audit-log-tenant-id", "", "tenant id for audit logging")
+	cmd.Flags().StringVar(&o.AuthJWKSFile, "auth-jwks-file", "", "Path to the JWKS file used to verify JWT bearer tokens (enables JWT authentication)")
+	cmd.Flags().StringVar(&o.AuthJWTIssuer, "auth-jwt-issuer", "", "Expected issuer of JWT bearer tokens")
+	cmd.Flags().StringVar(&o.AuthJWTAudience, "auth-jwt-audience", "", "Expected audience of JWT bearer tokens (optional)")
+	cmd.Flags().StringVar(&o.AuthJWTRolesClaim, "auth-jwt-roles-claim", "roles", "JWT claim which contains the roles of the caller")
+	cmd.Flags().StringVar(&o.AuthTokenFile, "auth-token-file", "", "Path to the file with static API tokens (enables token authentication)")
 
 	return cmd
 }
@@ -1,8 +1,15 @@
 package cmd
 
 import (
+	"fmt"
+	"net/http"
+	"strconv"
+
 	"github.com/kyma-incubator/reconciler/pkg/keb"
 	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/profile"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/chart"
+	"github.com/kyma-incubator/reconciler/pkg/secret"
 )
 
 func components(cfg model.ClusterConfigurationEntity) []keb.Component {
@@ -27,3 +34,58 @@ func validateStatuses(statuses []string) error {
 	}
 	return nil
 }
+
+//pagination returns the limit and offset query parameters of a list request (defaults are used if undefined)
+func pagination(r *http.Request) (int, int, error) {
+	limit := defaultPageLimit
+	if value := r.URL.Query().Get(paramLimit); value != "" {
+		var err error
+		if limit, err = strconv.Atoi(value); err != nil || limit < 1 || limit > maxPageLimit {
+			return 0, 0, fmt.Errorf("query parameter '%s' has to be a number between 1 and %d (got '%s')",
+				paramLimit, maxPageLimit, value)
+		}
+	}
+	offset := 0
+	if value := r.URL.Query().Get(paramOffset); value != "" {
+		var err error
+		if offset, err = strconv.Atoi(value); err != nil || offset < 0 {
+			return 0, 0, fmt.Errorf("query parameter '%s' has to be a number >= 0 (got '%s')", paramOffset, value)
+		}
+	}
+	return limit, offset, nil
+}
+
+//chartComponent converts a component of the cluster configuration into a chart component. Secret configuration values
+//are masked and secret references aren't resolved: manifests rendered for it won't expose any secrets.
+func chartComponent(cfg model.ClusterConfigurationEntity, name string, profiles *profile.Resolver) (*chart.Component, error) {
+	component := configComponent(cfg, name)
+	if component == nil {
+		return nil, nil
+	}
+	kymaProfile, err := profiles.Resolve(cfg.KymaProfile)
+	if err != nil {
+		return nil, err
+	}
+	profileValues, err := kymaProfile.Values(component.Component)
+	if err != nil {
+		return nil, err
+	}
+	masked := keb.Component{Configuration: secret.MaskConfiguration(component.Configuration)}
+	return chart.NewComponentBuilder(component.ResolveVersion(cfg.KymaVersion), component.Component).
+		WithProfile(kymaProfile.ChartProfile).
+		WithProfileValues(profileValues).
+		WithNamespace(component.Namespace).
+		WithConfiguration(masked.ConfigurationAsMap()).
+		WithURL(component.URL).
+		Build(), nil
+}
+
+//configComponent returns the component of the cluster configuration (nil if the component isn't part of it)
+func configComponent(cfg model.ClusterConfigurationEntity, name string) *keb.Component {
+	for _, component := range cfg.Components {
+		if component != nil && component.Component == name {
+			return component
+		}
+	}
+	return nil
+}
@@ -1,11 +1,16 @@
 package cmd
 
 import (
+	"io/ioutil"
+	"path/filepath"
 	"reflect"
 	"testing"
 
 	"github.com/kyma-incubator/reconciler/pkg/keb"
 	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/profile"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/chart"
+	"github.com/kyma-incubator/reconciler/pkg/secret"
 )
 
 func Test_components(t *testing.T) {
@@ -95,3 +100,67 @@ func Test_validateStatuses(t *testing.T) {
 		})
 	}
 }
+
+func Test_chartComponent(t *testing.T) {
+	cfg := model.ClusterConfigurationEntity{
+		KymaVersion: "1.0.0",
+		KymaProfile: "evaluation",
+		Components: []*keb.Component{
+			nil,
+			{
+				Component: "comp",
+				Namespace: "kyma-system",
+				Configuration: []keb.Configuration{
+					{Key: "plain", Value: "value"},
+					{Key: "password", Value: "secret", Secret: true},
+					{Key: "token", Value: "secretRef://kyma-system/creds#token", Secret: true},
+				},
+			},
+		},
+	}
+
+	t.Run("Unknown component", func(t *testing.T) {
+		if got, err := chartComponent(cfg, "unknown", nil); err != nil || got != nil {
+			t.Errorf("chartComponent() = %v, want nil", got)
+		}
+	})
+
+	t.Run("Secrets are masked", func(t *testing.T) {
+		want := chart.NewComponentBuilder("1.0.0", "comp").
+			WithProfile("evaluation").
+			WithNamespace("kyma-system").
+			WithConfiguration(map[string]interface{}{
+				"plain":    "value",
+				"password": secret.MaskedValue,
+				"token":    "secretRef://kyma-system/creds#token",
+			}).
+			Build()
+		if got, err := chartComponent(cfg, "comp", nil); err != nil || !reflect.DeepEqual(got, want) {
+			t.Errorf("chartComponent() = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("Custom profiles are resolved", func(t *testing.T) {
+		profilesFile := filepath.Join(t.TempDir(), "profiles.yaml")
+		if err := ioutil.WriteFile(profilesFile, []byte("evaluation:\n  base: production\n  values:\n    replicas: 1\n"), 0600); err != nil {
+			t.Fatal(err)
+		}
+		fileSource, err := profile.NewFileSource(profilesFile)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := chart.NewComponentBuilder("1.0.0", "comp").
+			WithProfile("production").
+			WithProfileValues(map[string]interface{}{"replicas": float64(1)}).
+			WithNamespace("kyma-system").
+			WithConfiguration(map[string]interface{}{
+				"plain":    "value",
+				"password": secret.MaskedValue,
+				"token":    "secretRef://kyma-system/creds#token",
+			}).
+			Build()
+		if got, err := chartComponent(cfg, "comp", profile.NewResolver(fileSource)); err != nil || !reflect.DeepEqual(got, want) {
+			t.Errorf("chartComponent() = %v, want %v", got, want)
+		}
+	})
+}
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -12,15 +13,21 @@ import (
 	"time"
 
 	"github.com/kyma-incubator/reconciler/internal/converters"
+	"github.com/kyma-incubator/reconciler/pkg/auth"
 	"github.com/kyma-incubator/reconciler/pkg/cluster"
 	"github.com/kyma-incubator/reconciler/pkg/keb"
 	"github.com/kyma-incubator/reconciler/pkg/kubernetes"
 	"github.com/kyma-incubator/reconciler/pkg/metrics"
 	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/profile"
 	"github.com/kyma-incubator/reconciler/pkg/reconciler"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/chart"
 	"github.com/kyma-incubator/reconciler/pkg/repository"
+	"github.com/kyma-incubator/reconciler/pkg/scheduler/config"
 	"github.com/kyma-incubator/reconciler/pkg/scheduler/reconciliation"
+	"github.com/kyma-incubator/reconciler/pkg/secret"
 	"github.com/kyma-incubator/reconciler/pkg/server"
+	"github.com/kyma-incubator/reconciler/pkg/signature"
 
 	"github.com/gorilla/mux"
 	"github.com/pkg/errors"
@@ -35,6 +42,7 @@ const (
 	paramOffset          = "offset"
 	paramSchedulingID    = "schedulingID"
 	paramCorrelationID   = "correlationID"
+	paramComponent       = "component"
 
 	paramStatus     = "status"
 	paramRuntimeIDs = "runtimeID"
@@ -42,10 +50,44 @@ const (
 	paramBefore     = "before"
 	paramAfter      = "after"
 	paramLast       = "last"
+	paramLimit      = "limit"
 	paramTimeFormat = time.RFC3339
+
+	defaultPageLimit = 100
+	maxPageLimit     = 1000
 )
 
 func startWebserver(ctx context.Context, o *Options) error {
+	signer, err := newSigner(o)
+	if err != nil {
+		return err
+	}
+
+	//workspace factory used to render and validate the charts of cluster configurations
+	wsFactory, err := chart.NewFactory(nil, o.Workspace, o.Logger())
+	if err != nil {
+		return err
+	}
+	chartProvider, err := chart.NewDefaultProvider(wsFactory, o.Logger())
+	if err != nil {
+		return err
+	}
+	profiles, err := newProfileResolver(o, viper.GetString("mothership.profilesFile"))
+	if err != nil {
+		return err
+	}
+	//charts of Kyma versions are downloaded in the background to avoid blocking requests
+	workspaces := newWorkspacePrefetcher(wsFactory, o.Logger())
+	var validator *valuesValidator
+	if o.ValidateValues {
+		validator = &valuesValidator{
+			workspaces:          workspaces,
+			profiles:            profiles,
+			skipUnknownVersions: o.AllowUnknownVersions,
+			logger:              o.Logger(),
+		}
+	}
+
 	//routing
 	mainRouter := mux.NewRouter()
 	apiRouter := mainRouter.PathPrefix("/").Subrouter()
@@ -54,62 +96,80 @@ func startWebserver(ctx context.Context, o *Options) error {
 
 	apiRouter.HandleFunc(
 		fmt.Sprintf("/v{%s}/operations/{%s}/{%s}/stop", paramContractVersion, paramSchedulingID, paramCorrelationID),
-		callHandler(o, updateOperationStatus)).
+		authorized(o, callHandler(o, updateOperationStatus), rolesOperate)).
 		Methods("POST")
 
 	apiRouter.HandleFunc(
 		fmt.Sprintf("/v{%s}/clusters", paramContractVersion),
-		callHandler(o, createOrUpdateCluster)).
+		authorized(o, func(w http.ResponseWriter, r *http.Request) {
+			createOrUpdateCluster(o, w, r, validator)
+		}, rolesProvision)).
 		Methods("PUT", "POST")
 
+	apiRouter.HandleFunc(
+		fmt.Sprintf("/v{%s}/clusters", paramContractVersion),
+		authorized(o, callHandler(o, listClusters), rolesRead)).
+		Methods("GET")
+
 	apiRouter.HandleFunc(
 		fmt.Sprintf("/v{%s}/clusters/{%s}", paramContractVersion, paramRuntimeID),
-		callHandler(o, deleteCluster)).
+		authorized(o, callHandler(o, deleteCluster), rolesDelete)).
 		Methods("DELETE")
 
 	apiRouter.HandleFunc(
 		fmt.Sprintf("/v{%s}/clusters/{%s}", paramContractVersion, paramCluster),
-		callHandler(o, deleteCluster)).
+		authorized(o, callHandler(o, deleteCluster), rolesDelete)).
 		Methods("DELETE")
 
+	apiRouter.HandleFunc(
+		fmt.Sprintf("/v{%s}/clusters/{%s}/restore", paramContractVersion, paramRuntimeID),
+		authorized(o, callHandler(o, restoreCluster), rolesDelete)).
+		Methods("POST")
+
 	apiRouter.HandleFunc(
 		fmt.Sprintf("/v{%s}/clusters/{%s}/configs/{%s}/status", paramContractVersion, paramRuntimeID, paramConfigVersion),
-		callHandler(o, getCluster)).
+		authorized(o, callHandler(o, getCluster), rolesRead)).
 		Methods("GET")
 
 	apiRouter.HandleFunc(
 		fmt.Sprintf("/v{%s}/clusters/{%s}/status", paramContractVersion, paramRuntimeID),
-		callHandler(o, getLatestCluster)).
+		authorized(o, callHandler(o, getLatestCluster), rolesRead)).
 		Methods("GET")
 
 	apiRouter.HandleFunc(
 		fmt.Sprintf("/v{%s}/clusters/{%s}/status", paramContractVersion, paramRuntimeID),
-		callHandler(o, updateLatestCluster)).
+		authorized(o, callHandler(o, updateLatestCluster), rolesStatusWrite)).
 		Methods("PUT")
 
 	apiRouter.HandleFunc(
 		fmt.Sprintf("/v{%s}/clusters/{%s}/statusChanges", paramContractVersion, paramRuntimeID), //supports offset-param
-		callHandler(o, statusChanges)).
+		authorized(o, callHandler(o, statusChanges), rolesRead)).
 		Methods("GET")
 
+	//callbacks are sent by component reconcilers which don't own an API identity: they are verified by their signature
 	apiRouter.HandleFunc(
 		fmt.Sprintf("/v{%s}/operations/{%s}/callback/{%s}", paramContractVersion, paramSchedulingID, paramCorrelationID),
-		callHandler(o, operationCallback)).
+		verifySignature(signer, callHandler(o, operationCallback))).
 		Methods("POST")
 
 	apiRouter.HandleFunc(
 		fmt.Sprintf("/v{%s}/reconciliations", paramContractVersion),
-		callHandler(o, getReconciliations)).
+		authorized(o, callHandler(o, getReconciliations), rolesRead)).
 		Methods("GET")
 
 	apiRouter.HandleFunc(
 		fmt.Sprintf("/v{%s}/reconciliations/{%s}/info", paramContractVersion, paramSchedulingID),
-		callHandler(o, getReconciliationInfo)).
+		authorized(o, callHandler(o, getReconciliationInfo), rolesRead)).
 		Methods("GET")
 
 	apiRouter.HandleFunc(
 		fmt.Sprintf("/v{%s}/clusters/{%s}/config/{%s}", paramContractVersion, paramRuntimeID, paramConfigVersion),
-		callHandler(o, getKymaConfig)).Methods(http.MethodGet)
+		authorized(o, callHandler(o, getKymaConfig), rolesRead)).Methods(http.MethodGet)
+
+	apiRouter.HandleFunc(
+		fmt.Sprintf("/v{%s}/clusters/{%s}/config/{%s}/manifests/{%s}",
+			paramContractVersion, paramRuntimeID, paramConfigVersion, paramComponent),
+		authorized(o, callHandler(o, renderManifests(chartProvider, workspaces, profiles)), rolesRead)).Methods(http.MethodGet)
 
 	//metrics endpoint
 	metrics.RegisterAll(o.Registry.Inventory(), o.Logger())
@@ -119,6 +179,15 @@ func startWebserver(ctx context.Context, o *Options) error {
 	healthRouter.HandleFunc("/live", live)
 	healthRouter.HandleFunc("/ready", ready(o))
 
+	//authentication has to happen before audit logging to get the verified identity logged
+	if o.AuthEnabled() {
+		authenticator, err := newAuthenticator(o)
+		if err != nil {
+			return err
+		}
+		apiRouter.Use(auth.NewMiddleware(authenticator))
+	}
+
 	if o.AuditLog && o.AuditLogFile != "" && o.AuditLogTenantID != "" {
 		auditLogger, err := NewLoggerWithFile(o.AuditLogFile)
 		if err != nil {
@@ -130,11 +199,12 @@ func startWebserver(ctx context.Context, o *Options) error {
 	}
 	//start server process
 	srv := &server.Webserver{
-		Logger:     o.Logger(),
-		Port:       o.Port,
-		SSLCrtFile: o.SSLCrt,
-		SSLKeyFile: o.SSLKey,
-		Router:     mainRouter,
+		Logger:       o.Logger(),
+		Port:         o.Port,
+		SSLCrtFile:   o.SSLCrt,
+		SSLKeyFile:   o.SSLKey,
+		ClientCAFile: o.ServerClientCA,
+		Router:       mainRouter,
 	}
 	return srv.Start(ctx) //blocking call
 }
@@ -153,13 +223,55 @@ func ready(o *Options) http.HandlerFunc {
 	}
 }
 
-func callHandler(o *Options, handler func(o *Options, w http.ResponseWriter, r *http.Request)) func(http.ResponseWriter, *http.Request) {
+func callHandler(o *Options, handler func(o *Options, w http.ResponseWriter, r *http.Request)) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		handler(o, w, r)
 	}
 }
 
-func createOrUpdateCluster(o *Options, w http.ResponseWriter, r *http.Request) {
+func newSigner(o *Options) (*signature.Signer, error) {
+	cfg := &config.SecurityConfig{}
+	if viper.IsSet("mothership.security.signatureKeyFile") {
+		cfg.SignatureKeyFile = viper.GetString("mothership.security.signatureKeyFile")
+	}
+	applySecurityOptions(o, cfg)
+	return signature.NewSignerFromFile(cfg.SignatureKeyFile)
+}
+
+// verifySignature rejects requests which aren't signed for the operation addressed by the correlation ID in the URL
+func verifySignature(signer *signature.Signer, handler http.HandlerFunc) http.HandlerFunc {
+	if signer == nil {
+		return handler
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		correlationID, err := server.NewParams(r).String(paramCorrelationID)
+		if err != nil {
+			server.SendHTTPError(w, http.StatusBadRequest, &reconciler.HTTPErrorResponse{
+				Error: err.Error(),
+			})
+			return
+		}
+		reqBody, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			server.SendHTTPError(w, http.StatusInternalServerError, &reconciler.HTTPErrorResponse{
+				Error: errors.Wrap(err, "Failed to read received JSON payload").Error(),
+			})
+			return
+		}
+		if err := signer.Verify(r, correlationID, reqBody); err != nil {
+			server.SendHTTPError(w, http.StatusUnauthorized, &reconciler.HTTPErrorResponse{
+				Error: err.Error(),
+			})
+			return
+		}
+		r.Body = ioutil.NopCloser(bytes.NewBuffer(reqBody))
+		handler(w, r)
+	}
+}
+
+//createOrUpdateCluster stores a new cluster configuration: its values are validated against the values schemas
+//of the component charts if a validator is given
+func createOrUpdateCluster(o *Options, w http.ResponseWriter, r *http.Request, validator *valuesValidator) {
 	params := server.NewParams(r)
 	contractV, err := params.Int64(paramContractVersion)
 	if err != nil {
@@ -188,6 +300,28 @@ func createOrUpdateCluster(o *Options, w http.ResponseWriter, r *http.Request) {
 		})
 		return
 	}
+	if validator != nil {
+		componentErrs, err := validator.validate(clusterModel.KymaConfig)
+		if err != nil {
+			//only violations of the values schemas are client errors
+			statusCode := http.StatusInternalServerError
+			if errors.Is(err, errWorkspaceNotReady) {
+				statusCode = http.StatusServiceUnavailable
+				w.Header().Set("Retry-After", workspaceRetryAfter)
+			}
+			server.SendHTTPError(w, statusCode, &keb.HTTPErrorResponse{
+				Error: errors.Wrap(err, "configuration values not validated").Error(),
+			})
+			return
+		}
+		if len(componentErrs) > 0 {
+			server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPValuesValidationErrorResponse{
+				Error:      valuesValidationError(componentErrs),
+				Components: &componentErrs,
+			})
+			return
+		}
+	}
 	clusterState, err := o.Registry.Inventory().CreateOrUpdate(contractV, clusterModel)
 	if err != nil {
 		server.SendHTTPError(w, http.StatusInternalServerError, &keb.HTTPErrorResponse{
@@ -410,6 +544,60 @@ func getReconciliationInfo(o *Options, w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+func listClusters(o *Options, w http.ResponseWriter, r *http.Request) {
+	statuses := r.URL.Query()[paramStatus]
+	if err := validateStatuses(statuses); err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.BadRequest{Error: err.Error()})
+		return
+	}
+	var modelStatuses []model.Status
+	for _, status := range statuses {
+		modelStatuses = append(modelStatuses, model.Status(status)) //KEB statuses are equal to the cluster statuses
+	}
+
+	limit, offset, err := pagination(r)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.BadRequest{Error: err.Error()})
+		return
+	}
+
+	clusterStatuses, err := o.Registry.Inventory().ClusterStatuses(modelStatuses, limit, offset)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusInternalServerError, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Could not retrieve clusters").Error(),
+		})
+		return
+	}
+
+	failures, err := clusterFailures(o.Registry.ReconciliationRepository(), clusterStatuses...)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusInternalServerError, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Could not retrieve failures of clusters").Error(),
+		})
+		return
+	}
+
+	results := keb.HTTPClusterListResponse{}
+	for _, clusterStatus := range clusterStatuses {
+		respModel, err := newClusterStatusResponse(r, clusterStatus, failures[clusterStatus.RuntimeID])
+		if err != nil {
+			server.SendHTTPError(w, http.StatusInternalServerError, &keb.HTTPErrorResponse{
+				Error: errors.Wrap(err, "failed to generate cluster response model").Error(),
+			})
+			return
+		}
+		results = append(results, *respModel)
+	}
+
+	//respond
+	w.Header().Set("content-type", "application/json")
+	if err := json.NewEncoder(w).Encode(keb.ClusterListOKResponse(results)); err != nil {
+		server.SendHTTPError(w, http.StatusInternalServerError, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, "Failed to encode cluster list response").Error(),
+		})
+	}
+}
+
 func getLatestCluster(o *Options, w http.ResponseWriter, r *http.Request) {
 	params := server.NewParams(r)
 	runtimeID, err := params.String(paramRuntimeID)
@@ -512,7 +700,18 @@ func deleteCluster(o *Options, w http.ResponseWriter, r *http.Request) {
 		})
 		return
 	}
-	state, err := o.Registry.Inventory().MarkForDeletion(runtimeID)
+	//force skips the preflight checks of the component reconcilers
+	force := false
+	if forceParam := r.URL.Query().Get("force"); forceParam != "" {
+		force, err = strconv.ParseBool(forceParam)
+		if err != nil {
+			server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
+				Error: errors.Wrap(err, fmt.Sprintf("Invalid value '%s' for parameter 'force'", forceParam)).Error(),
+			})
+			return
+		}
+	}
+	state, err := o.Registry.Inventory().MarkForDeletion(runtimeID, force)
 	if err != nil {
 		server.SendHTTPError(w, http.StatusInternalServerError, &keb.HTTPErrorResponse{
 			Error: errors.Wrap(err, fmt.Sprintf("Failed to delete cluster '%s'", runtimeID)).Error(),
@@ -522,6 +721,35 @@ func deleteCluster(o *Options, w http.ResponseWriter, r *http.Request) {
 	sendResponse(w, r, state, o.Registry.ReconciliationRepository())
 }
 
+func restoreCluster(o *Options, w http.ResponseWriter, r *http.Request) {
+	params := server.NewParams(r)
+	runtimeID, err := params.String(paramRuntimeID)
+	if err != nil {
+		server.SendHTTPError(w, http.StatusBadRequest, &keb.HTTPErrorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+	if _, err := o.Registry.Inventory().GetLatest(runtimeID); repository.IsNotFoundError(err) {
+		server.SendHTTPError(w, http.StatusNotFound, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, fmt.Sprintf("Restore impossible: Cluster '%s' not found", runtimeID)).Error(),
+		})
+		return
+	}
+	state, err := o.Registry.Inventory().Restore(runtimeID)
+	if err != nil {
+		httpCode := http.StatusInternalServerError
+		if cluster.IsNotRestorableError(err) {
+			httpCode = http.StatusConflict
+		}
+		server.SendHTTPError(w, httpCode, &keb.HTTPErrorResponse{
+			Error: errors.Wrap(err, fmt.Sprintf("Failed to restore cluster '%s'", runtimeID)).Error(),
+		})
+		return
+	}
+	sendResponse(w, r, state, o.Registry.ReconciliationRepository())
+}
+
 func updateOperationStatus(o *Options, w http.ResponseWriter, r *http.Request) {
 	params := server.NewParams(r)
 	schedulingID, err := params.String(paramSchedulingID)
@@ -630,6 +858,8 @@ func operationCallback(o *Options, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	logDeletionReport(o, schedulingID, correlationID, body.DeletionReport)
+
 	switch body.Status {
 	case reconciler.StatusNotstarted, reconciler.StatusRunning:
 		err = updateOperationState(o, schedulingID, correlationID, model.OperationStateInProgress)
@@ -652,6 +882,25 @@ func operationCallback(o *Options, w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+//logDeletionReport logs all resources which weren't deleted regularly (e.g. because they were stuck on finalizers)
+func logDeletionReport(o *Options, schedulingID, correlationID string, report *[]reconciler.DeletedResource) {
+	if report == nil {
+		return
+	}
+	for _, resource := range *report {
+		switch resource.Status {
+		case reconciler.DeletionStatusFinalizersRemoved, reconciler.DeletionStatusStuck, reconciler.DeletionStatusFailed:
+			var finalizers []string
+			if resource.Finalizers != nil {
+				finalizers = *resource.Finalizers
+			}
+			o.Logger().Warnf("Deletion of resource (kind:%s/namespace:%s/name:%s) reported status '%s' "+
+				"(schedulingID:%s/correlationID:%s/finalizers:%s)", resource.Kind, resource.Namespace, resource.Name,
+				resource.Status, schedulingID, correlationID, strings.Join(finalizers, ","))
+		}
+	}
+}
+
 func getKymaConfig(o *Options, w http.ResponseWriter, r *http.Request) {
 	params := server.NewParams(r)
 	runtimeID, err := params.String(paramRuntimeID)
@@ -676,6 +925,7 @@ func getKymaConfig(o *Options, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	response := converters.ConvertConfig(*state.Configuration)
+	response.Components = secret.MaskComponents(response.Components)
 
 	w.Header().Set("content-type", "application/json")
 	if err := json.NewEncoder(w).Encode(response); err != nil {
@@ -685,6 +935,79 @@ func getKymaConfig(o *Options, w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+//renderManifests renders the manifest of a component of a stored cluster configuration on demand. Charts of
+//Kyma versions which are not downloaded yet are fetched in the background and the client is asked to retry later.
+func renderManifests(provider chart.Provider, workspaces *workspacePrefetcher, profiles *profile.Resolver) func(o *Options, w http.ResponseWriter, r *http.Request) {
+	return func(o *Options, w http.ResponseWriter, r *http.Request) {
+		params := server.NewParams(r)
+		runtimeID, err := params.String(paramRuntimeID)
+		if err != nil {
+			server.SendHTTPError(w, http.StatusBadRequest, &reconciler.HTTPErrorResponse{Error: err.Error()})
+			return
+		}
+		configVersion, err := params.Int64(paramConfigVersion)
+		if err != nil {
+			server.SendHTTPError(w, http.StatusBadRequest, &reconciler.HTTPErrorResponse{Error: err.Error()})
+			return
+		}
+		componentName, err := params.String(paramComponent)
+		if err != nil {
+			server.SendHTTPError(w, http.StatusBadRequest, &reconciler.HTTPErrorResponse{Error: err.Error()})
+			return
+		}
+
+		state, err := o.Registry.Inventory().Get(runtimeID, configVersion)
+		if err != nil {
+			server.SendHTTPErrorMap(w, err)
+			return
+		}
+		if state.Configuration == nil {
+			server.SendHTTPErrorMap(w, errors.New("state configuration is nil"))
+			return
+		}
+		kebComponent := configComponent(*state.Configuration, componentName)
+		if kebComponent == nil {
+			server.SendHTTPError(w, http.StatusNotFound, &reconciler.HTTPErrorResponse{
+				Error: fmt.Sprintf("component '%s' is not part of configuration version %d of cluster '%s'",
+					componentName, configVersion, runtimeID),
+			})
+			return
+		}
+		if kebComponent.URL == "" {
+			version := kebComponent.ResolveVersion(state.Configuration.KymaVersion)
+			if _, err := workspaces.workspace(version); err != nil {
+				statusCode := http.StatusInternalServerError
+				if errors.Is(err, errWorkspaceNotReady) {
+					statusCode = http.StatusServiceUnavailable
+					w.Header().Set("Retry-After", workspaceRetryAfter)
+				}
+				server.SendHTTPError(w, statusCode, &reconciler.HTTPErrorResponse{
+					Error: errors.Wrapf(err, "Failed to retrieve charts of Kyma version '%s'", version).Error(),
+				})
+				return
+			}
+		}
+		component, err := chartComponent(*state.Configuration, componentName, profiles)
+		if err != nil {
+			server.SendHTTPErrorMap(w, err)
+			return
+		}
+
+		manifest, err := provider.RenderManifest(component)
+		if err != nil {
+			server.SendHTTPError(w, http.StatusInternalServerError, &reconciler.HTTPErrorResponse{
+				Error: errors.Wrapf(err, "Failed to render manifest of component '%s'", componentName).Error(),
+			})
+			return
+		}
+
+		w.Header().Set("content-type", "application/x-yaml")
+		if _, err := w.Write([]byte(chart.MergeManifestsWithHooks(manifest))); err != nil {
+			o.Logger().Warnf("Failed to send rendered manifest of component '%s': %s", componentName, err)
+		}
+	}
+}
+
 func updateOperationState(o *Options, schedulingID, correlationID string, state model.OperationState, reason ...string) error {
 	err := o.Registry.ReconciliationRepository().UpdateOperationState(
 		schedulingID, correlationID, state, strings.Join(reason, ", "))
@@ -721,39 +1044,23 @@ func sendResponse(w http.ResponseWriter, r *http.Request, clusterState *cluster.
 }
 
 func newClusterResponse(r *http.Request, clusterState *cluster.State, reconciliationRepository reconciliation.Repository) (*keb.HTTPClusterResponse, error) {
-	kebStatus, err := clusterState.Status.GetKEBClusterStatus()
+	failures, err := clusterFailures(reconciliationRepository, clusterState.Status)
 	if err != nil {
 		return nil, err
 	}
+	return newClusterStatusResponse(r, clusterState.Status, failures[clusterState.Status.RuntimeID])
+}
 
-	var failures []keb.Failure
-	if clusterState.Status.Status == model.ClusterStatusReconcileError || clusterState.Status.Status == model.ClusterStatusDeleteError ||
-		clusterState.Status.Status == model.ClusterStatusReconciling || clusterState.Status.Status == model.ClusterStatusDeleting {
-		reconciliations, err := reconciliationRepository.GetReconciliations(&reconciliation.WithClusterConfigStatus{ClusterConfigStatus: clusterState.Status.ID})
-		if err != nil {
-			return nil, err
-		}
-		if len(reconciliations) > 0 {
-			operations, err := reconciliationRepository.GetOperations(reconciliations[0].SchedulingID)
-			if err != nil {
-				return nil, err
-			}
-
-			for _, operation := range operations {
-				if operation.State.IsError() {
-					failures = append(failures, keb.Failure{
-						Component: operation.Component,
-						Reason:    operation.Reason,
-					})
-				}
-			}
-		}
+func newClusterStatusResponse(r *http.Request, clusterStatus *model.ClusterStatusEntity, failures []keb.Failure) (*keb.HTTPClusterResponse, error) {
+	kebStatus, err := clusterStatus.GetKEBClusterStatus()
+	if err != nil {
+		return nil, err
 	}
 
 	return &keb.HTTPClusterResponse{
-		Cluster:              clusterState.Cluster.RuntimeID,
-		ClusterVersion:       clusterState.Cluster.Version,
-		ConfigurationVersion: clusterState.Configuration.Version,
+		Cluster:              clusterStatus.RuntimeID,
+		ClusterVersion:       clusterStatus.ClusterVersion,
+		ConfigurationVersion: clusterStatus.ConfigVersion,
 		Status:               kebStatus,
 		Failures:             &failures,
 		StatusURL: (&url.URL{
@@ -762,8 +1069,38 @@ func newClusterResponse(r *http.Request, clusterState *cluster.State, reconcilia
 			Path: func() string {
 				apiVersion := strings.Split(r.URL.RequestURI(), "/")[1]
 				return fmt.Sprintf("%s/clusters/%s/configs/%d/status", apiVersion,
-					clusterState.Cluster.RuntimeID, clusterState.Configuration.Version)
+					clusterStatus.RuntimeID, clusterStatus.ConfigVersion)
 			}(),
 		}).String(),
 	}, nil
 }
+
+//clusterFailures returns the failed operations of the clusters (key: runtime ID) which are in an error state or
+//currently reconciled. The operations of all clusters are retrieved within one query.
+func clusterFailures(reconciliationRepository reconciliation.Repository, clusterStatuses ...*model.ClusterStatusEntity) (map[string][]keb.Failure, error) {
+	var statusIDs []int64
+	for _, clusterStatus := range clusterStatuses {
+		switch clusterStatus.Status {
+		case model.ClusterStatusReconcileError, model.ClusterStatusDeleteError,
+			model.ClusterStatusReconciling, model.ClusterStatusDeleting:
+			statusIDs = append(statusIDs, clusterStatus.ID)
+		}
+	}
+	if len(statusIDs) == 0 {
+		return nil, nil
+	}
+
+	operations, err := reconciliationRepository.GetOperationsOfClusterConfigStatuses(statusIDs,
+		model.OperationStateError, model.OperationStateFailed, model.OperationStateClientError)
+	if err != nil {
+		return nil, err
+	}
+	failures := make(map[string][]keb.Failure)
+	for _, operation := range operations {
+		failures[operation.RuntimeID] = append(failures[operation.RuntimeID], keb.Failure{
+			Component: operation.Component,
+			Reason:    operation.Reason,
+		})
+	}
+	return failures, nil
+}
@@ -266,6 +266,24 @@ func TestMothership(t *testing.T) {
 			responseModel:    &keb.HTTPErrorResponse{},
 			verifier:         requireErrorResponseFct,
 		},
+		{
+			name:             "Get list of clusters",
+			url:              fmt.Sprintf("%s/clusters", baseURL),
+			method:           httpGet,
+			expectedHTTPCode: 200,
+			responseModel:    &keb.ClusterListOKResponse{},
+			verifier: func(t *testing.T, response interface{}) {
+				require.NotEmpty(t, *response.(*keb.ClusterListOKResponse))
+			},
+		},
+		{
+			name:             "Get list of clusters: invalid status filter",
+			url:              fmt.Sprintf("%s/clusters?status=none", baseURL),
+			method:           httpGet,
+			expectedHTTPCode: 400,
+			responseModel:    &keb.HTTPErrorResponse{},
+			verifier:         requireErrorResponseFct,
+		},
 		{
 			name:             "Get list of reconciliations: all",
 			url:              fmt.Sprintf("%s/reconciliations", baseURL),
@@ -19,6 +19,7 @@ type Options struct {
 	WatchInterval            time.Duration
 	OrphanOperationTimeout   time.Duration
 	ClusterReconcileInterval time.Duration
+	DeletionGracePeriod      time.Duration
 	PurgeEntitiesOlderThan   time.Duration
 	CleanerInterval          time.Duration
 	CreateEncyptionKey       bool
@@ -26,6 +27,19 @@ type Options struct {
 	AuditLog                 bool
 	AuditLogFile             string
 	AuditLogTenantID         string
+	AuthJWKSFile             string
+	AuthJWTIssuer            string
+	AuthJWTAudience          string
+	AuthJWTRolesClaim        string
+	AuthTokenFile            string
+	ServerClientCA           string
+	ClientCrt                string
+	ClientKey                string
+	ClientCA                 string
+	SignatureKeyFile         string
+	Workspace                string
+	ValidateValues           bool
+	AllowUnknownVersions     bool
 }
 
 func NewOptions(o *cli.Options) *Options {
@@ -37,6 +51,7 @@ func NewOptions(o *cli.Options) *Options {
 		0 * time.Second, //WatchInterval
 		0 * time.Minute, //Orphan timeout
 		0 * time.Second, //ClusterReconcileInterval
+		0 * time.Second, //DeletionGracePeriod
 		0 * time.Minute, // PurgeEntitiesOlderThan
 		0 * time.Minute, // CleanerInterval
 		false,           //CreateEncyptionKey
@@ -44,6 +59,19 @@ func NewOptions(o *cli.Options) *Options {
 		false,           //AuditLog
 		"",              //AuditLogFIle
 		"",              //AuditLogTenant
+		"",              //AuthJWKSFile
+		"",              //AuthJWTIssuer
+		"",              //AuthJWTAudience
+		"",              //AuthJWTRolesClaim
+		"",              //AuthTokenFile
+		"",              //ServerClientCA
+		"",              //ClientCrt
+		"",              //ClientKey
+		"",              //ClientCA
+		"",              //SignatureKeyFile
+		"",              //Workspace
+		false,           //ValidateValues
+		false,           //AllowUnknownVersions
 	}
 }
 
@@ -63,6 +91,9 @@ func (o *Options) Validate() error {
 	if o.ClusterReconcileInterval <= 0 {
 		return errors.New("cluster reconciliation interval cannot be <= 0")
 	}
+	if o.DeletionGracePeriod < 0 {
+		return errors.New("deletion grace period cannot be < 0")
+	}
 	if o.MaxParallelOperations < 0 {
 		return errors.New("maximal parallel reconciled components per cluster cannot be < 0")
 	}
@@ -75,5 +106,18 @@ func (o *Options) Validate() error {
 
 		}
 	}
+	if o.AuthJWKSFile != "" && o.AuthJWTIssuer == "" {
+		return errors.New("JWT issuer must be set if JWT authentication is enabled")
+	}
+	if o.ServerClientCA != "" && (o.SSLCrt == "" || o.SSLKey == "") {
+		return errors.New("server client CA requires an SSL certificate and key (mutual TLS)")
+	}
+	if err := (&ssl.ClientConfig{CrtFile: o.ClientCrt, KeyFile: o.ClientKey, CAFile: o.ClientCA}).Validate(); err != nil {
+		return err
+	}
 	return ssl.VerifyKeyPair(o.SSLCrt, o.SSLKey)
 }
+
+func (o *Options) AuthEnabled() bool {
+	return o.AuthJWKSFile != "" || o.AuthTokenFile != ""
+}
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/kyma-incubator/reconciler/pkg/logger"
+	"github.com/kyma-incubator/reconciler/pkg/profile"
 	"github.com/kyma-incubator/reconciler/pkg/scheduler/config"
 	"github.com/kyma-incubator/reconciler/pkg/scheduler/service"
 	"github.com/kyma-incubator/reconciler/pkg/scheduler/worker"
@@ -16,6 +17,11 @@ func startScheduler(ctx context.Context, o *Options, configFile string) error {
 	if err != nil {
 		return err
 	}
+	applySecurityOptions(o, &schedulerCfg.Security)
+	profiles, err := newProfileResolver(o, schedulerCfg.ProfilesFile)
+	if err != nil {
+		return err
+	}
 
 	runtimeBuilder := service.NewRuntimeBuilder(o.Registry.ReconciliationRepository(), logger.NewLogger(o.Verbose))
 
@@ -24,6 +30,7 @@ func startScheduler(ctx context.Context, o *Options, configFile string) error {
 			o.Registry.Connnection(),
 			o.Registry.Inventory(),
 			schedulerCfg).
+		WithProfiles(profiles).
 		WithWorkerPoolConfig(&worker.Config{
 			MaxParallelOperations: o.MaxParallelOperations,
 			PoolSize:              o.Workers,
@@ -38,6 +45,7 @@ func startScheduler(ctx context.Context, o *Options, configFile string) error {
 				InventoryWatchInterval:   o.WatchInterval,
 				ClusterReconcileInterval: o.ClusterReconcileInterval,
 				ClusterQueueSize:         10,
+				DeletionGracePeriod:      o.DeletionGracePeriod,
 			}).
 		WithBookkeeperConfig(&service.BookkeeperConfig{
 			OperationsWatchInterval: 30 * time.Second,
@@ -59,3 +67,32 @@ func parseSchedulerConfig(configFile string) (*config.Config, error) {
 	var cfg config.Config
 	return &cfg, viper.UnmarshalKey("mothership", &cfg)
 }
+
+// applySecurityOptions overrides the security settings of the configuration file with the values of CLI flags
+func applySecurityOptions(o *Options, cfg *config.SecurityConfig) {
+	if o.ClientCrt != "" {
+		cfg.ClientCrtFile = o.ClientCrt
+	}
+	if o.ClientKey != "" {
+		cfg.ClientKeyFile = o.ClientKey
+	}
+	if o.ClientCA != "" {
+		cfg.ClientCAFile = o.ClientCA
+	}
+	if o.SignatureKeyFile != "" {
+		cfg.SignatureKeyFile = o.SignatureKeyFile
+	}
+}
+
+//newProfileResolver creates the resolver of custom Kyma profiles defined in the KV store or in the profiles file
+func newProfileResolver(o *Options, profilesFile string) (*profile.Resolver, error) {
+	sources := []profile.Source{profile.NewKVSource(o.Registry.KVRepository())}
+	if profilesFile != "" {
+		fileSource, err := profile.NewFileSource(profilesFile)
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, fileSource)
+	}
+	return profile.NewResolver(sources...), nil
+}
@@ -0,0 +1,146 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	file "github.com/kyma-incubator/reconciler/pkg/files"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/profile"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/chart"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/service"
+	"github.com/kyma-incubator/reconciler/pkg/secret"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+//reconcilerConfigKeyPrefixes are the prefixes of configuration keys which are evaluated by the reconciler itself:
+//they are not part of the chart values and are excluded from the validation
+var reconcilerConfigKeyPrefixes = []string{
+	service.ConfigKeyPrefix,
+	chart.VerifyConfigKeyPrefix,
+}
+
+//reconcilerConfigKeys are further configuration keys which are evaluated by the reconciler itself
+var reconcilerConfigKeys = []string{
+	chart.RepoTokenNamespaceConfigKey,
+	chart.RepoChartNameConfigKey,
+}
+
+//valuesValidator validates the configuration of cluster components against the values schemas of their charts
+type valuesValidator struct {
+	workspaces          *workspacePrefetcher
+	profiles            *profile.Resolver
+	skipUnknownVersions bool //accept configurations of Kyma versions whose charts can't be retrieved
+	logger              *zap.SugaredLogger
+}
+
+//validate returns the violations of the values schemas per component. Components of external sources are not
+//validated (their charts are only retrieved by the component reconcilers).
+//Only already downloaded charts are used: missing charts are downloaded in the background and errWorkspaceNotReady
+//is returned in the meantime.
+func (v *valuesValidator) validate(kymaConfig keb.KymaConfig) ([]keb.ComponentValuesErrors, error) {
+	kymaProfile, err := v.profiles.Resolve(kymaConfig.Profile)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []keb.ComponentValuesErrors
+	for idx := range kymaConfig.Components {
+		component := kymaConfig.Components[idx]
+		if component.URL != "" {
+			v.logger.Debugf("Skipping values validation of component '%s' from external source '%s'",
+				component.Component, component.URL)
+			continue
+		}
+
+		version := component.ResolveVersion(kymaConfig.Version)
+		ws, err := v.workspaces.workspace(version)
+		if err != nil {
+			if v.skipUnknownVersions && !errors.Is(err, errWorkspaceNotReady) {
+				v.logger.Warnf("Skipping values validation of component '%s' because charts of Kyma version '%s' "+
+					"are not available: %s", component.Component, version, err)
+				continue
+			}
+			return nil, errors.Wrapf(err, "failed to retrieve charts of Kyma version '%s' "+
+				"to validate the configuration of component '%s'", version, component.Component)
+		}
+		if !file.DirExists(filepath.Join(ws.ResourceDir, component.Component)) {
+			continue //component is not deployed from a chart
+		}
+
+		valuesErrs, err := v.validateComponent(ws.ResourceDir, version, kymaProfile, &component)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to validate configuration of component '%s'", component.Component)
+		}
+		if len(valuesErrs) > 0 {
+			result = append(result, keb.ComponentValuesErrors{
+				Component: component.Component,
+				Errors:    valuesErrs,
+			})
+		}
+	}
+	return result, nil
+}
+
+func (v *valuesValidator) validateComponent(chartDir, version string, kymaProfile *profile.Resolved, component *keb.Component) ([]keb.ValuesError, error) {
+	//secret references are resolved by the scheduler: their values are unknown at admission time
+	configuration := make(map[string]interface{}, len(component.Configuration))
+	for _, cfg := range component.Configuration {
+		if secret.IsReference(cfg.Value) || isReconcilerConfigKey(cfg.Key) {
+			continue
+		}
+		configuration[cfg.Key] = cfg.Value
+	}
+
+	profileValues, err := kymaProfile.Values(component.Component)
+	if err != nil {
+		return nil, err
+	}
+
+	helmClient, err := chart.NewHelmClient(chartDir, v.logger)
+	if err != nil {
+		return nil, err
+	}
+	valuesErrs, err := helmClient.ValidateValues(chart.NewComponentBuilder(version, component.Component).
+		WithProfile(kymaProfile.ChartProfile).
+		WithProfileValues(profileValues).
+		WithNamespace(component.Namespace).
+		WithConfiguration(configuration).
+		Build())
+	if err != nil {
+		return nil, err
+	}
+
+	var result []keb.ValuesError
+	for _, valuesErr := range valuesErrs {
+		result = append(result, keb.ValuesError{Key: valuesErr.Key, Message: valuesErr.Message})
+	}
+	return result, nil
+}
+
+//isReconcilerConfigKey returns true if the configuration key is evaluated by the reconciler and not by the chart
+func isReconcilerConfigKey(key string) bool {
+	for _, prefix := range reconcilerConfigKeyPrefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	for _, reconcilerKey := range reconcilerConfigKeys {
+		if key == reconcilerKey {
+			return true
+		}
+	}
+	return false
+}
+
+//valuesValidationError summarizes the violations of the values schemas in one message
+func valuesValidationError(componentErrs []keb.ComponentValuesErrors) string {
+	var count int
+	for _, componentErr := range componentErrs {
+		count += len(componentErr.Errors)
+	}
+	return fmt.Sprintf("configuration of %d component(s) violates the values schema of their charts (%d error(s))",
+		len(componentErrs), count)
+}
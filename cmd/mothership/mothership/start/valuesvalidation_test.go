@@ -0,0 +1,138 @@
+package cmd
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/logger"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/chart"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+// validationFactory provides the test charts as workspace of the Kyma versions '1.0.0' (downloaded already) and
+// '2.0.0' (downloaded on request)
+type validationFactory struct {
+	resourceDir string
+	mu          sync.Mutex
+	downloaded  map[string]bool
+}
+
+func newValidationFactory(resourceDir string) *validationFactory {
+	return &validationFactory{
+		resourceDir: resourceDir,
+		downloaded:  map[string]bool{"1.0.0": true},
+	}
+}
+
+func (f *validationFactory) Lookup(version string) (*chart.KymaWorkspace, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if !f.downloaded[version] {
+		return nil, nil
+	}
+	return &chart.KymaWorkspace{ResourceDir: f.resourceDir}, nil
+}
+
+func (f *validationFactory) Get(version string) (*chart.KymaWorkspace, error) {
+	if version != "1.0.0" && version != "2.0.0" {
+		return nil, errors.Errorf("Kyma version '%s' not found", version)
+	}
+	f.mu.Lock()
+	f.downloaded[version] = true
+	f.mu.Unlock()
+	return f.Lookup(version)
+}
+
+func TestValuesValidator(t *testing.T) {
+	resourceDir := t.TempDir()
+	for file, content := range map[string]string{
+		"comp/Chart.yaml":         "apiVersion: v2\nname: comp\nversion: 1.0.0\n",
+		"comp/values.yaml":        "replicas: 1\npassword: default\n",
+		"comp/values.schema.json": `{"properties": {"replicas": {"type": "integer"}, "password": {"type": "integer"}}, "additionalProperties": false}`,
+	} {
+		path := filepath.Join(resourceDir, file)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0700))
+		require.NoError(t, ioutil.WriteFile(path, []byte(content), 0600))
+	}
+
+	newValidator := func(skipUnknownVersions bool) *valuesValidator {
+		return &valuesValidator{
+			workspaces:          newWorkspacePrefetcher(newValidationFactory(resourceDir), logger.NewLogger(true)),
+			skipUnknownVersions: skipUnknownVersions,
+			logger:              logger.NewLogger(true),
+		}
+	}
+
+	newKymaConfig := func(version string, configuration ...keb.Configuration) keb.KymaConfig {
+		return keb.KymaConfig{
+			Version: version,
+			Components: []keb.Component{
+				{Component: "comp", Namespace: "kyma-system", Configuration: configuration},
+				{Component: "nochart", Namespace: "kyma-system"},
+				{Component: "external", URL: "https://github.com/kyma-incubator/external.git"},
+			},
+		}
+	}
+
+	t.Run("Report violations per component", func(t *testing.T) {
+		componentErrs, err := newValidator(false).validate(newKymaConfig("1.0.0",
+			keb.Configuration{Key: "replicas", Value: "many"},
+			keb.Configuration{Key: "password", Value: "secretRef://kyma-system/comp#password", Secret: true}))
+		require.NoError(t, err)
+		require.Len(t, componentErrs, 1)
+		require.Equal(t, "comp", componentErrs[0].Component)
+		require.Len(t, componentErrs[0].Errors, 2) //secret reference isn't validated: default value is reported
+		require.Equal(t, "password", componentErrs[0].Errors[0].Key)
+		require.Equal(t, "replicas", componentErrs[0].Errors[1].Key)
+		require.Equal(t, "configuration of 1 component(s) violates the values schema of their charts (2 error(s))",
+			valuesValidationError(componentErrs))
+	})
+
+	t.Run("Accept valid configuration", func(t *testing.T) {
+		componentErrs, err := newValidator(false).validate(newKymaConfig("1.0.0",
+			keb.Configuration{Key: "replicas", Value: 3},
+			keb.Configuration{Key: "password", Value: 1234},
+			keb.Configuration{Key: "reconciler.interceptors.labels", Value: "team=kyma"},
+			keb.Configuration{Key: "reconciler.apply.mode", Value: "server-side"},
+			keb.Configuration{Key: "reconciler.apply.conflictPolicy", Value: "*=force"},
+			keb.Configuration{Key: "repo.verify.digest", Value: "sha256:1234"},
+			keb.Configuration{Key: "repo.token.namespace", Value: "kyma-system"},
+			keb.Configuration{Key: "repo.chart.name", Value: "comp"}))
+		require.NoError(t, err)
+		require.Empty(t, componentErrs)
+	})
+
+	//awaitDownload validates the configuration until the charts were downloaded in the background
+	awaitDownload := func(t *testing.T, validator *valuesValidator, version string) ([]keb.ComponentValuesErrors, error) {
+		_, err := validator.validate(newKymaConfig(version))
+		require.True(t, errors.Is(err, errWorkspaceNotReady))
+
+		var componentErrs []keb.ComponentValuesErrors
+		require.Eventually(t, func() bool {
+			componentErrs, err = validator.validate(newKymaConfig(version))
+			return !errors.Is(err, errWorkspaceNotReady)
+		}, 5*time.Second, 10*time.Millisecond)
+		return componentErrs, err
+	}
+
+	t.Run("Download charts in the background", func(t *testing.T) {
+		componentErrs, err := awaitDownload(t, newValidator(false), "2.0.0")
+		require.NoError(t, err)
+		require.Len(t, componentErrs, 1) //default value of the password violates the schema
+	})
+
+	t.Run("Unknown Kyma versions", func(t *testing.T) {
+		_, err := awaitDownload(t, newValidator(false), "9.9.9")
+		require.Error(t, err)
+
+		componentErrs, err := awaitDownload(t, newValidator(true), "9.9.9")
+		require.NoError(t, err)
+		require.Empty(t, componentErrs)
+	})
+}
@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"sync"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/chart"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+const (
+	//prefetchRetryInterval is the delay until a failed download of charts is started again
+	prefetchRetryInterval = time.Minute
+	//workspaceRetryAfter is the delay in seconds proposed to clients if the charts are not downloaded yet
+	workspaceRetryAfter = "30"
+)
+
+//errWorkspaceNotReady indicates that the charts of a Kyma version are not downloaded yet (the request can be retried)
+var errWorkspaceNotReady = errors.New("charts are not available yet: they are downloaded in the background")
+
+//workspaceFactory returns cached workspaces without blocking and downloads missing workspaces
+type workspaceFactory interface {
+	Lookup(version string) (*chart.KymaWorkspace, error)
+	Get(version string) (*chart.KymaWorkspace, error)
+}
+
+//workspacePrefetcher provides the workspaces of Kyma versions to HTTP handlers without blocking them: missing
+//workspaces are downloaded in the background and errWorkspaceNotReady is returned in the meantime
+type workspacePrefetcher struct {
+	wsFactory  workspaceFactory
+	logger     *zap.SugaredLogger
+	mu         sync.Mutex
+	prefetches map[string]*prefetch //downloads of workspaces per Kyma version
+}
+
+//prefetch is a running or finished download of a workspace
+type prefetch struct {
+	done     bool
+	err      error
+	finished time.Time
+}
+
+func newWorkspacePrefetcher(wsFactory workspaceFactory, logger *zap.SugaredLogger) *workspacePrefetcher {
+	return &workspacePrefetcher{
+		wsFactory:  wsFactory,
+		logger:     logger,
+		prefetches: make(map[string]*prefetch),
+	}
+}
+
+//workspace returns the cached workspace of the Kyma version or starts its download in the background
+func (p *workspacePrefetcher) workspace(version string) (*chart.KymaWorkspace, error) {
+	ws, err := p.wsFactory.Lookup(version)
+	if err != nil || ws != nil {
+		return ws, err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if previous, ok := p.prefetches[version]; ok {
+		if !previous.done {
+			return nil, errWorkspaceNotReady
+		}
+		//a failed download is reported until the retry interval expired
+		if previous.err != nil && time.Since(previous.finished) < prefetchRetryInterval {
+			return nil, previous.err
+		}
+		if previous.err == nil {
+			return p.wsFactory.Lookup(version)
+		}
+	}
+
+	running := &prefetch{}
+	p.prefetches[version] = running
+	go func() {
+		_, err := p.wsFactory.Get(version)
+		if err != nil {
+			p.logger.Warnf("Failed to download charts of Kyma version '%s': %s", version, err)
+		}
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		running.done = true
+		running.err = err
+		running.finished = time.Now()
+	}()
+	return nil, errWorkspaceNotReady
+}
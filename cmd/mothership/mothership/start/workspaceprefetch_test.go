@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/logger"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkspacePrefetcher(t *testing.T) {
+	prefetcher := newWorkspacePrefetcher(newValidationFactory(t.TempDir()), logger.NewLogger(true))
+
+	t.Run("Downloaded workspace", func(t *testing.T) {
+		ws, err := prefetcher.workspace("1.0.0")
+		require.NoError(t, err)
+		require.NotNil(t, ws)
+	})
+
+	t.Run("Workspace is downloaded in the background", func(t *testing.T) {
+		_, err := prefetcher.workspace("2.0.0")
+		require.True(t, errors.Is(err, errWorkspaceNotReady))
+		require.Eventually(t, func() bool {
+			ws, err := prefetcher.workspace("2.0.0")
+			return err == nil && ws != nil
+		}, 5*time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("Failed download is reported", func(t *testing.T) {
+		_, err := prefetcher.workspace("3.0.0")
+		require.True(t, errors.Is(err, errWorkspaceNotReady))
+		require.Eventually(t, func() bool {
+			_, err := prefetcher.workspace("3.0.0")
+			return err != nil && !errors.Is(err, errWorkspaceNotReady)
+		}, 5*time.Second, 10*time.Millisecond)
+	})
+}
@@ -0,0 +1,22 @@
+package cmd
+
+import (
+	"github.com/kyma-incubator/reconciler/internal/cli"
+	"github.com/kyma-incubator/reconciler/internal/cli/mothership"
+	"github.com/spf13/cobra"
+)
+
+func NewCmd(o *cli.Options) *cobra.Command {
+	clientOpts := mothership.NewClientOptions(o)
+	cmd := &cobra.Command{
+		Use:     "operation",
+		Aliases: []string{"operations", "op"},
+		Short:   "Manage operations of a running mothership reconciler",
+		Long:    "Day-2 operations for reconciliation operations using the REST API of a running mothership reconciler",
+	}
+	clientOpts.AddFlags(cmd.PersistentFlags())
+
+	cmd.AddCommand(newStopCmd(clientOpts))
+
+	return cmd
+}
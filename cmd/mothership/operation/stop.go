@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kyma-incubator/reconciler/internal/cli/mothership"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/spf13/cobra"
+)
+
+type stopOptions struct {
+	*mothership.ClientOptions
+	Reason string
+}
+
+func (o *stopOptions) Validate() error {
+	if o.Reason == "" {
+		return fmt.Errorf("a reason for stopping the operation has to be provided")
+	}
+	return o.ClientOptions.Validate()
+}
+
+func newStopCmd(clientOpts *mothership.ClientOptions) *cobra.Command {
+	o := &stopOptions{ClientOptions: clientOpts}
+	cmd := &cobra.Command{
+		Use:   "stop SCHEDULING_ID CORRELATION_ID",
+		Short: "Stop an operation.",
+		Long:  `Stop an operation which wasn't picked up by a component reconciler yet.`,
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.Validate(); err != nil {
+				return err
+			}
+			return runStop(o, args[0], args[1])
+		},
+	}
+	cmd.Flags().StringVar(&o.Reason, "reason", "", "Reason for stopping the operation")
+	return cmd
+}
+
+func runStop(o *stopOptions, schedulingID, correlationID string) error {
+	client, err := o.Client()
+	if err != nil {
+		return err
+	}
+	resp, err := client.PostOperationsSchedulingIDCorrelationIDStopWithResponse(context.Background(),
+		schedulingID, correlationID, keb.PostOperationsSchedulingIDCorrelationIDStopJSONRequestBody{Reason: o.Reason})
+	if err != nil {
+		return err
+	}
+	if err := mothership.CheckResponse(resp.HTTPResponse, resp.Body, 200); err != nil {
+		return err
+	}
+	fmt.Printf("Operation '%s' of reconciliation '%s' stopped\n", correlationID, schedulingID)
+	return nil
+}
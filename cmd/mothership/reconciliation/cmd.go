@@ -0,0 +1,23 @@
+package cmd
+
+import (
+	"github.com/kyma-incubator/reconciler/internal/cli"
+	"github.com/kyma-incubator/reconciler/internal/cli/mothership"
+	"github.com/spf13/cobra"
+)
+
+func NewCmd(o *cli.Options) *cobra.Command {
+	clientOpts := mothership.NewClientOptions(o)
+	cmd := &cobra.Command{
+		Use:     "reconciliation",
+		Aliases: []string{"reconciliations", "rc"},
+		Short:   "Inspect reconciliations of a running mothership reconciler",
+		Long:    "Day-2 operations for reconciliations using the REST API of a running mothership reconciler",
+	}
+	clientOpts.AddFlags(cmd.PersistentFlags())
+
+	cmd.AddCommand(newListCmd(clientOpts))
+	cmd.AddCommand(newInfoCmd(clientOpts))
+
+	return cmd
+}
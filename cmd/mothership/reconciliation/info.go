@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/internal/cli"
+	"github.com/kyma-incubator/reconciler/internal/cli/mothership"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/spf13/cobra"
+)
+
+func newInfoCmd(o *mothership.ClientOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "info SCHEDULING_ID",
+		Short: "Show details of a reconciliation.",
+		Long:  `Show the operations of a reconciliation.`,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.Validate(); err != nil {
+				return err
+			}
+			return runInfo(o, args[0])
+		},
+	}
+	return cmd
+}
+
+func runInfo(o *mothership.ClientOptions, schedulingID string) error {
+	client, err := o.Client()
+	if err != nil {
+		return err
+	}
+	resp, err := client.GetReconciliationsSchedulingIDInfoWithResponse(context.Background(), schedulingID)
+	if err != nil {
+		return err
+	}
+	if err := mothership.CheckResponse(resp.HTTPResponse, resp.Body, 200); err != nil {
+		return err
+	}
+	if resp.JSON200 == nil {
+		return mothership.MissingPayloadError(resp.HTTPResponse, resp.Body)
+	}
+	return renderOperations(o.Options, resp.JSON200)
+}
+
+func renderOperations(o *cli.Options, info *keb.HTTPReconciliationInfo) error {
+	formatter, err := cli.NewOutputFormatter(o.OutputFormat)
+	if err != nil {
+		return err
+	}
+
+	if err := formatter.Header("Runtime ID", "Config Version", "Component", "Priority", "Correlation ID",
+		"State", "Reason", "Updated at (UTC)"); err != nil {
+		return err
+	}
+	for _, operation := range info.Operations {
+		if err := formatter.AddRow(info.RuntimeID, info.ConfigVersion, operation.Component, operation.Priority,
+			operation.CorrelationID, operation.State, operation.Reason,
+			operation.Updated.UTC().Format(time.RFC822Z)); err != nil {
+			return err
+		}
+	}
+	return formatter.Output(os.Stdout)
+}
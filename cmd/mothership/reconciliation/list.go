@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/internal/cli"
+	"github.com/kyma-incubator/reconciler/internal/cli/mothership"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/spf13/cobra"
+)
+
+type listOptions struct {
+	*mothership.ClientOptions
+	RuntimeIDs []string
+	Statuses   []string
+	Before     string
+	After      string
+	Last       int
+}
+
+func (o *listOptions) Validate() error {
+	for _, status := range o.Statuses {
+		if _, err := keb.ToStatus(status); err != nil {
+			return err
+		}
+	}
+	for _, timestamp := range []string{o.Before, o.After} {
+		if timestamp == "" {
+			continue
+		}
+		if _, err := time.Parse(time.RFC3339, timestamp); err != nil {
+			return fmt.Errorf("timestamp '%s' is not RFC3339 formatted: %s", timestamp, err)
+		}
+	}
+	if o.Last < 0 {
+		return fmt.Errorf("last has to be >= 0 (was %d)", o.Last)
+	}
+	return o.ClientOptions.Validate()
+}
+
+func newListCmd(clientOpts *mothership.ClientOptions) *cobra.Command {
+	o := &listOptions{ClientOptions: clientOpts}
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List reconciliations.",
+		Long:    `List reconciliations filtered by runtime IDs, statuses or creation date.`,
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.Validate(); err != nil {
+				return err
+			}
+			return runList(o)
+		},
+	}
+	cmd.Flags().StringSliceVar(&o.RuntimeIDs, "runtime-id", nil, "Show only reconciliations of the given runtime IDs")
+	cmd.Flags().StringSliceVar(&o.Statuses, "status", nil, "Show only reconciliations with one of the given statuses")
+	cmd.Flags().StringVar(&o.Before, "before", "", "Show only reconciliations created before this RFC3339 timestamp")
+	cmd.Flags().StringVar(&o.After, "after", "", "Show only reconciliations created after this RFC3339 timestamp")
+	cmd.Flags().IntVar(&o.Last, "last", 0, "Show only the last N reconciliations")
+	return cmd
+}
+
+func runList(o *listOptions) error {
+	client, err := o.Client()
+	if err != nil {
+		return err
+	}
+	resp, err := client.GetReconciliationsWithResponse(context.Background(), o.params())
+	if err != nil {
+		return err
+	}
+	if err := mothership.CheckResponse(resp.HTTPResponse, resp.Body, 200); err != nil {
+		return err
+	}
+	if resp.JSON200 == nil {
+		return mothership.MissingPayloadError(resp.HTTPResponse, resp.Body)
+	}
+	return renderReconciliations(o.Options, *resp.JSON200)
+}
+
+func (o *listOptions) params() *keb.GetReconciliationsParams {
+	params := &keb.GetReconciliationsParams{}
+	if len(o.RuntimeIDs) > 0 {
+		params.RuntimeID = &o.RuntimeIDs
+	}
+	if len(o.Statuses) > 0 {
+		var statuses []keb.Status
+		for _, status := range o.Statuses {
+			statuses = append(statuses, keb.Status(status))
+		}
+		params.Status = &statuses
+	}
+	if o.Before != "" {
+		before, _ := time.Parse(time.RFC3339, o.Before) //validated before
+		params.Before = &before
+	}
+	if o.After != "" {
+		after, _ := time.Parse(time.RFC3339, o.After) //validated before
+		params.After = &after
+	}
+	if o.Last > 0 {
+		params.Last = &o.Last
+	}
+	return params
+}
+
+func renderReconciliations(o *cli.Options, reconciliations []keb.Reconciliation) error {
+	formatter, err := cli.NewOutputFormatter(o.OutputFormat)
+	if err != nil {
+		return err
+	}
+
+	if err := formatter.Header("Scheduling ID", "Runtime ID", "Status", "Created at (UTC)",
+		"Updated at (UTC)", "Lock"); err != nil {
+		return err
+	}
+	for _, reconciliation := range reconciliations {
+		if err := formatter.AddRow(reconciliation.SchedulingID, reconciliation.RuntimeID, reconciliation.Status,
+			reconciliation.Created.UTC().Format(time.RFC822Z), reconciliation.Updated.UTC().Format(time.RFC822Z),
+			reconciliation.Lock); err != nil {
+			return err
+		}
+	}
+	return formatter.Output(os.Stdout)
+}
@@ -0,0 +1,22 @@
+package cmd
+
+import (
+	"github.com/kyma-incubator/reconciler/internal/cli"
+	"github.com/spf13/cobra"
+)
+
+const defaultWorkspaceDir = ".workspace" //workspace directory used by 'mothership local'
+
+func NewCmd(o *cli.Options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "workspace",
+		Aliases: []string{"workspaces", "ws"},
+		Short:   "Manage workspace bundles",
+		Long:    "Export and import signed bundles of Kyma workspaces for landscapes without access to the Kyma sources",
+	}
+
+	cmd.AddCommand(newExportCmd(o))
+	cmd.AddCommand(newImportCmd(o))
+
+	return cmd
+}
@@ -0,0 +1,197 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/kyma-incubator/reconciler/internal/cli"
+	"github.com/kyma-incubator/reconciler/internal/components"
+	"github.com/kyma-incubator/reconciler/pkg/logger"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/chart"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/git"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+type exportOptions struct {
+	*cli.Options
+	version            string
+	components         []string
+	componentsFile     string
+	workspace          string
+	output             string
+	signingKeyFile     string
+	gitCredentialsFile string
+	keysFile           string
+}
+
+func (o *exportOptions) Validate() error {
+	if o.version == "" {
+		return fmt.Errorf("no Kyma version defined")
+	}
+	if o.version == chart.VersionLocal {
+		return fmt.Errorf("workspace of version '%s' cannot be exported", chart.VersionLocal)
+	}
+	if o.signingKeyFile == "" {
+		return fmt.Errorf("signing key is required to sign the workspace bundle")
+	}
+	if o.output == "" {
+		o.output = fmt.Sprintf("kyma-%s-workspace.tgz", o.version)
+	}
+	return nil
+}
+
+func newExportCmd(cliOpts *cli.Options) *cobra.Command {
+	o := &exportOptions{Options: cliOpts}
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export a signed workspace bundle.",
+		Long: `Prepare the workspace of a Kyma version including the sources of its external components and export it as signed tarball.
+The signature is stored next to the bundle in the file '<bundle>.sig'.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.Validate(); err != nil {
+				return err
+			}
+			return runExport(o)
+		},
+	}
+	cmd.Flags().StringVar(&o.version, "version", "", "Kyma version")
+	cmd.Flags().StringSliceVar(&o.components, "components", []string{},
+		"Comma separated list of external components to include (default are all components with a URL defined in the components file)")
+	cmd.Flags().StringVar(&o.componentsFile, "components-file", "",
+		`Path to the components file (default "<workspace>/installation/resources/components.yaml")`)
+	cmd.Flags().StringVar(&o.workspace, "workspace", defaultWorkspaceDir, "Workspace directory used to prepare the bundle")
+	cmd.Flags().StringVarP(&o.output, "output", "o", "", `Path of the bundle (default "kyma-<version>-workspace.tgz")`)
+	cmd.Flags().StringVar(&o.signingKeyFile, "signing-key", "", "PEM encoded private key (ECDSA, RSA or Ed25519) used to sign the bundle")
+	cmd.Flags().StringVar(&o.gitCredentialsFile, "git-credentials-file", "", "Path to the file which maps GIT repositories to credentials")
+	cmd.Flags().StringVar(&o.keysFile, "verification-keys-file", "", "Path to the file which maps key names to public keys trusted to verify signatures of component sources")
+	return cmd
+}
+
+func runExport(o *exportOptions) error {
+	l := logger.NewLogger(o.Verbose)
+
+	signingKey, err := ioutil.ReadFile(o.signingKeyFile)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read signing key file '%s'", o.signingKeyFile)
+	}
+
+	wsFact, err := chart.NewFactory(nil, o.workspace, l)
+	if err != nil {
+		return err
+	}
+	if o.gitCredentialsFile != "" {
+		gitCredentials, err := git.LoadCredentialsMapping(o.gitCredentialsFile)
+		if err != nil {
+			return err
+		}
+		wsFact.WithGitCredentials(gitCredentials)
+	}
+	if o.keysFile != "" {
+		keys, err := chart.LoadVerificationKeys(o.keysFile)
+		if err != nil {
+			return err
+		}
+		wsFact.WithVerificationKeys(keys)
+	}
+
+	ws, err := wsFact.Get(o.version)
+	if err != nil {
+		return err
+	}
+	componentsFile := o.componentsFile
+	if componentsFile == "" {
+		componentsFile = filepath.Join(ws.InstallationResourceDir, "components.yaml")
+	}
+	externalComps, err := externalComponents(componentsFile, o.version, o.components)
+	if err != nil {
+		return err
+	}
+
+	manifest, err := exportSignedBundle(wsFact, o, externalComps, string(signingKey))
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Workspace bundle of Kyma version '%s' with %d external components exported to '%s'\n",
+		manifest.Version, len(manifest.Components), o.output)
+	return nil
+}
+
+//exportSignedBundle streams the bundle into a temporary file next to the output, signs it and moves it to the
+//output path (the bundle is never kept in memory and a failed export leaves no partial bundle behind)
+func exportSignedBundle(wsFact *chart.DefaultFactory, o *exportOptions, comps []*chart.Component, signingKey string) (*chart.BundleManifest, error) {
+	tmpFile, err := ioutil.TempFile(filepath.Dir(o.output), "."+filepath.Base(o.output)+"-")
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		//no-op if the bundle was moved to the output path
+		_ = tmpFile.Close()
+		_ = os.Remove(tmpFile.Name())
+	}()
+
+	manifest, err := wsFact.ExportBundle(tmpFile, o.version, comps)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := tmpFile.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	signature, err := chart.SignBundle(tmpFile, signingKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to sign workspace bundle")
+	}
+	if err := tmpFile.Close(); err != nil {
+		return nil, err
+	}
+	if err := ioutil.WriteFile(chart.BundleSignatureFile(o.output), signature, 0600); err != nil {
+		return nil, err
+	}
+	if err := os.Rename(tmpFile.Name(), o.output); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+//externalComponents returns the components of the components file which are not part of the Kyma sources
+func externalComponents(componentsFile, version string, names []string) ([]*chart.Component, error) {
+	compList, err := components.NewComponentList(componentsFile)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read components file '%s'", componentsFile)
+	}
+
+	external := map[string]components.Component{}
+	var order []string
+	for _, c := range append(compList.Prerequisites, compList.Components...) {
+		if c.URL == "" {
+			continue
+		}
+		external[c.Name] = c
+		order = append(order, c.Name)
+	}
+	if len(names) > 0 {
+		order = names
+	}
+
+	var result []*chart.Component
+	for _, name := range order {
+		c, ok := external[name]
+		if !ok {
+			return nil, fmt.Errorf("component '%s' is not defined as external component in components file '%s'",
+				name, componentsFile)
+		}
+		compVersion := c.Version
+		if compVersion == "" {
+			compVersion = version
+		}
+		result = append(result, chart.NewComponentBuilder(compVersion, c.Name).
+			WithURL(c.URL).
+			WithConfiguration(c.Configuration).
+			Build())
+	}
+	return result, nil
+}
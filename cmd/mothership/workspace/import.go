@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/kyma-incubator/reconciler/internal/cli"
+	"github.com/kyma-incubator/reconciler/pkg/logger"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/chart"
+	"github.com/spf13/cobra"
+)
+
+type importOptions struct {
+	*cli.Options
+	workspace          string
+	publicKeyFile      string
+	insecureSkipVerify bool
+}
+
+func (o *importOptions) Validate() error {
+	if o.publicKeyFile == "" && !o.insecureSkipVerify {
+		return fmt.Errorf("public key is required to verify the signature of the workspace bundle " +
+			"(use 'insecure-skip-verify' to import unverified bundles)")
+	}
+	if o.publicKeyFile != "" && o.insecureSkipVerify {
+		return fmt.Errorf("use one of 'public-key' or 'insecure-skip-verify' flag")
+	}
+	return nil
+}
+
+func newImportCmd(cliOpts *cli.Options) *cobra.Command {
+	o := &importOptions{Options: cliOpts}
+	cmd := &cobra.Command{
+		Use:   "import BUNDLE",
+		Short: "Import a signed workspace bundle.",
+		Long: `Verify the signature of a workspace bundle and import its workspaces into the workspace directory of a component reconciler or of 'mothership local'.
+The signature is expected in the file '<bundle>.sig'.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.Validate(); err != nil {
+				return err
+			}
+			return runImport(o, args[0])
+		},
+	}
+	cmd.Flags().StringVar(&o.workspace, "workspace", defaultWorkspaceDir, "Workspace directory the bundle is imported into")
+	cmd.Flags().StringVar(&o.publicKeyFile, "public-key", "", "PEM encoded public key used to verify the signature of the bundle")
+	cmd.Flags().BoolVar(&o.insecureSkipVerify, "insecure-skip-verify", false, "Import the bundle without verifying its signature (only for bundles of trusted sources)")
+	return cmd
+}
+
+func runImport(o *importOptions, bundleFile string) error {
+	wsFact, err := chart.NewFactory(nil, o.workspace, logger.NewLogger(o.Verbose))
+	if err != nil {
+		return err
+	}
+	var manifest *chart.BundleManifest
+	if o.insecureSkipVerify {
+		manifest, err = wsFact.ImportUnverifiedBundleFile(bundleFile)
+	} else {
+		manifest, err = wsFact.ImportBundleFile(bundleFile, o.publicKeyFile)
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Workspace bundle of Kyma version '%s' with %d external components imported into '%s'\n",
+		manifest.Version, len(manifest.Components), o.workspace)
+	return nil
+}
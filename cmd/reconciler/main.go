@@ -41,6 +41,10 @@ func newCmd(o *cli.Options) *cobra.Command {
 		"Number of in parallel running reconciliation workers")
 	cmd.PersistentFlags().DurationVar(&reconcilerOpts.WorkerConfig.Timeout, "worker-timeout", defaultTimeout,
 		"Maximal time a worker will run before a reconciliation will be stopped")
+	cmd.PersistentFlags().DurationVar(&reconcilerOpts.ClientCacheConfig.TTL, "client-cache-ttl", 10*time.Minute,
+		"Time a Kubernetes client is shared between reconciliations of the same cluster before it gets re-created")
+	cmd.PersistentFlags().IntVar(&reconcilerOpts.ClientCacheConfig.Size, "client-cache-size", 50,
+		"Max number of cached Kubernetes clients (least recently used clients are closed first)")
 
 	//REST API configuration
 	cmd.PersistentFlags().IntVar(&reconcilerOpts.ServerConfig.Port, "server-port", 8080,
@@ -49,6 +53,18 @@ func newCmd(o *cli.Options) *cobra.Command {
 		"Path to SSL certificate file used for secure REST API communication")
 	cmd.PersistentFlags().StringVar(&reconcilerOpts.ServerConfig.SSLKeyFile, "server-key", "",
 		"Path to SSL key file used for secure REST API communication")
+	cmd.PersistentFlags().StringVar(&reconcilerOpts.ServerConfig.ClientCAFile, "server-client-ca", "",
+		"Path to CA file used to verify client certificates (enables mutual TLS for the REST API)")
+
+	//security configuration of the communication with the mothership reconciler
+	cmd.PersistentFlags().StringVar(&reconcilerOpts.SecurityConfig.ClientCrtFile, "client-crt", "",
+		"Path to client certificate file used for mutual TLS when sending callbacks to the mothership reconciler")
+	cmd.PersistentFlags().StringVar(&reconcilerOpts.SecurityConfig.ClientKeyFile, "client-key", "",
+		"Path to client key file used for mutual TLS when sending callbacks to the mothership reconciler")
+	cmd.PersistentFlags().StringVar(&reconcilerOpts.SecurityConfig.ClientCAFile, "client-ca", "",
+		"Path to CA file used to verify the certificate of the mothership reconciler")
+	cmd.PersistentFlags().StringVar(&reconcilerOpts.SecurityConfig.SignatureKeyFile, "signature-key-file", "",
+		"Path to the shared secret used to verify signed tasks and to sign callbacks")
 
 	//retry configuration
 	cmd.PersistentFlags().IntVar(&reconcilerOpts.RetryConfig.MaxRetries, "retries-max", 5,
@@ -65,10 +81,53 @@ func newCmd(o *cli.Options) *cobra.Command {
 	cmd.PersistentFlags().DurationVar(&reconcilerOpts.ProgressTrackerConfig.Interval, "progress-interval", 15*time.Second,
 		"Interval to verify the installation progress of a deployed Kubernetes resource")
 	reconcilerOpts.ProgressTrackerConfig.Timeout = reconcilerOpts.WorkerConfig.Timeout //coupled to reconcile-timeout
+	cmd.PersistentFlags().StringVar(&reconcilerOpts.ReadinessConfig.ChecksFile, "readiness-checks-file", "",
+		"File which defines the readiness checks of custom resources (a list of checks with 'apiVersion', 'kind' and a 'conditionType' or 'jsonPath'), only custom resources of these kinds are awaited")
+
+	//apply configuration
+	cmd.PersistentFlags().StringVar(&reconcilerOpts.ApplyConfig.Mode, "apply-mode", "client-side",
+		"Mode used to apply Kubernetes resources ('client-side' or 'server-side')")
+	cmd.PersistentFlags().StringToStringVar(&reconcilerOpts.ApplyConfig.ConflictPolicies, "apply-conflict-policy",
+		map[string]string{"*": "fail"},
+		"Server-side apply conflict policy ('fail' or 'force') per kind, e.g. '*=fail,horizontalpodautoscaler=force'")
+	cmd.PersistentFlags().IntVar(&reconcilerOpts.ApplyConfig.Workers, "apply-workers", 10,
+		"Max number of independent Kubernetes resources which are applied in parallel")
+
+	//deletion configuration
+	cmd.PersistentFlags().StringVar(&reconcilerOpts.DeletionConfig.Mode, "deletion-mode", "default",
+		"Mode used to delete Kubernetes resources ('default' or 'finalizer-aware')")
+	cmd.PersistentFlags().DurationVar(&reconcilerOpts.DeletionConfig.FinalizerTimeout, "deletion-finalizer-timeout", 2*time.Minute,
+		"Time until a deleted resource is considered as stuck on its finalizers (finalizer-aware deletion mode only)")
+	cmd.PersistentFlags().BoolVar(&reconcilerOpts.DeletionConfig.StripFinalizers, "deletion-strip-finalizers", false,
+		"Remove the finalizers of resources which are stuck in deletion (finalizer-aware deletion mode only)")
+
+	//Helm release configuration
+	cmd.PersistentFlags().StringVar(&reconcilerOpts.ReleaseConfig.Mode, "helm-release-mode", "none",
+		"Record component deployments as Helm releases ('none', 'record' or 'adopt' to take over existing Helm releases)")
 
 	//file cache for Kyma sources
 	cmd.PersistentFlags().StringVar(&reconcilerOpts.Workspace, "workspace", ".",
 		"Workspace directory used to cache Kyma sources")
+	cmd.PersistentFlags().StringVar(&reconcilerOpts.WorkspaceGCConfig.Quota, "workspace-quota", "",
+		"Max disk usage of cached workspaces including GIT mirrors, e.g. '10Gi' (least recently used workspaces are deleted first, empty means unlimited)")
+	cmd.PersistentFlags().DurationVar(&reconcilerOpts.WorkspaceGCConfig.MaxAge, "workspace-max-age", 0,
+		"Time until an unused workspace gets deleted (0 means unlimited)")
+	cmd.PersistentFlags().DurationVar(&reconcilerOpts.WorkspaceGCConfig.Interval, "workspace-gc-interval", 10*time.Minute,
+		"Interval of the workspace garbage collection")
+	cmd.PersistentFlags().BoolVar(&reconcilerOpts.WorkspaceBundleConfig.Offline, "workspace-offline", false,
+		"Refuse the download of Kyma sources: workspaces have to be imported from a workspace bundle")
+	cmd.PersistentFlags().StringVar(&reconcilerOpts.WorkspaceBundleConfig.Bundle, "workspace-bundle", "",
+		"Workspace bundle (created with 'mothership workspace export') which is imported into the workspace directory at startup")
+	cmd.PersistentFlags().StringVar(&reconcilerOpts.WorkspaceBundleConfig.PublicKeyFile, "workspace-bundle-public-key", "",
+		"Public key used to verify the signature of the workspace bundle")
+	cmd.PersistentFlags().StringVar(&reconcilerOpts.RenderCacheConfig.MemorySize, "render-cache-memory", "128Mi",
+		"Max size of rendered charts cached in memory, e.g. '256Mi' (empty disables the memory cache)")
+	cmd.PersistentFlags().StringVar(&reconcilerOpts.RenderCacheConfig.DiskSize, "render-cache-disk", "",
+		"Max size of rendered charts cached encrypted in the workspace directory, e.g. '2Gi' (empty disables the disk cache)")
+	cmd.PersistentFlags().StringVar(&reconcilerOpts.GitConfig.CredentialsFile, "git-credentials-file", "",
+		"File which maps GIT repositories to credentials (tokens, SSH deploy keys, GitHub Apps or K8s secrets containing them)")
+	cmd.PersistentFlags().StringVar(&reconcilerOpts.VerificationConfig.KeysFile, "verification-keys-file", "",
+		"File which maps key names to public keys trusted to verify signatures of component sources (selected by the component configuration 'repo.verify.key')")
 
 	cmd.PersistentFlags().BoolVarP(&reconcilerOpts.Verbose, "verbose", "v", false, "Show detailed information about the executed command actions")
 	cmd.PersistentFlags().BoolVar(&reconcilerOpts.NonInteractive, "non-interactive", false, "Enables the non-interactive shell mode")
@@ -5,6 +5,9 @@ import (
 
 	"github.com/kyma-incubator/reconciler/internal/cli"
 	reconCli "github.com/kyma-incubator/reconciler/internal/cli/reconciler"
+	"github.com/kyma-incubator/reconciler/pkg/metrics"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/service"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/spf13/cobra"
 )
 
@@ -26,6 +29,8 @@ func NewCmd(o *reconCli.Options, reconcilerName string) *cobra.Command {
 
 func Run(o *reconCli.Options, reconcilerName string) error {
 	ctx := cli.NewContext()
+	prometheus.MustRegister(metrics.NewWorkspaceCollector(service.WorkspaceStats))
+	prometheus.MustRegister(metrics.NewRenderCacheCollector(service.RenderCacheStats))
 	workerPool, err := StartComponentReconciler(ctx, o, reconcilerName)
 	if err != nil {
 		return err
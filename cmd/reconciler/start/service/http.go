@@ -14,7 +14,9 @@ import (
 	"github.com/kyma-incubator/reconciler/pkg/reconciler"
 	"github.com/kyma-incubator/reconciler/pkg/reconciler/service"
 	"github.com/kyma-incubator/reconciler/pkg/server"
+	"github.com/kyma-incubator/reconciler/pkg/signature"
 	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
 const (
@@ -22,22 +24,27 @@ const (
 )
 
 func StartWebserver(ctx context.Context, o *reconCli.Options, workerPool *service.WorkerPool) error {
+	signer, err := signature.NewSignerFromFile(o.SecurityConfig.SignatureKeyFile)
+	if err != nil {
+		return err
+	}
 	srv := server.Webserver{
-		Logger:     o.Logger(),
-		Port:       o.ServerConfig.Port,
-		SSLCrtFile: o.ServerConfig.SSLCrtFile,
-		SSLKeyFile: o.ServerConfig.SSLKeyFile,
-		Router:     newRouter(ctx, o, workerPool),
+		Logger:       o.Logger(),
+		Port:         o.ServerConfig.Port,
+		SSLCrtFile:   o.ServerConfig.SSLCrtFile,
+		SSLKeyFile:   o.ServerConfig.SSLKeyFile,
+		ClientCAFile: o.ServerConfig.ClientCAFile,
+		Router:       newRouter(ctx, o, workerPool, signer),
 	}
 	return srv.Start(ctx) //blocking until ctx gets closed
 }
 
-func newRouter(ctx context.Context, o *reconCli.Options, workerPool *service.WorkerPool) *mux.Router {
+func newRouter(ctx context.Context, o *reconCli.Options, workerPool *service.WorkerPool, signer *signature.Signer) *mux.Router {
 	router := mux.NewRouter()
 	router.HandleFunc(
 		fmt.Sprintf("/v{%s}/run", paramContractVersion),
 		func(w http.ResponseWriter, r *http.Request) { //just an adapter for the reconcile-fct call
-			reconcile(ctx, w, r, o, workerPool)
+			reconcile(ctx, w, r, o, workerPool, signer)
 		},
 	).Methods("PUT", "POST")
 
@@ -45,6 +52,9 @@ func newRouter(ctx context.Context, o *reconCli.Options, workerPool *service.Wor
 	router.HandleFunc("/health/live", live)
 	router.HandleFunc("/health/ready", ready(workerPool))
 
+	//metrics endpoint
+	router.Handle("/metrics", promhttp.Handler())
+
 	return router
 }
 
@@ -62,7 +72,7 @@ func ready(workerPool *service.WorkerPool) http.HandlerFunc {
 	}
 }
 
-func newModel(req *http.Request) (*reconciler.Task, error) {
+func newModel(req *http.Request, signer *signature.Signer) (*reconciler.Task, error) {
 	params := server.NewParams(req)
 	contractVersion, err := params.String(paramContractVersion)
 	if err != nil {
@@ -83,6 +93,11 @@ func newModel(req *http.Request) (*reconciler.Task, error) {
 		return nil, err
 	}
 
+	//the signature is bound to the operation: verify it before acting on the task
+	if err := signer.Verify(req, model.CorrelationID, b); err != nil {
+		return nil, &signatureError{err}
+	}
+
 	if model.Configuration == nil {
 		model.Configuration = map[string]interface{}{}
 	}
@@ -90,6 +105,10 @@ func newModel(req *http.Request) (*reconciler.Task, error) {
 	return model, err
 }
 
+type signatureError struct {
+	error
+}
+
 func modelForVersion(contractVersion string) (*reconciler.Task, error) {
 	if contractVersion == "" {
 		return nil, fmt.Errorf("contract version cannot be empty")
@@ -97,24 +116,36 @@ func modelForVersion(contractVersion string) (*reconciler.Task, error) {
 	return &reconciler.Task{}, nil //change this function if multiple contract versions have to be supported
 }
 
-func reconcile(ctx context.Context, w http.ResponseWriter, req *http.Request, o *reconCli.Options, workerPool *service.WorkerPool) {
-	dump, err := httputil.DumpRequest(req, true)
+func reconcile(ctx context.Context, w http.ResponseWriter, req *http.Request, o *reconCli.Options, workerPool *service.WorkerPool, signer *signature.Signer) {
+	//dump only the header: the body contains secrets and will be logged masked after unmarshalling
+	dump, err := httputil.DumpRequest(req, false)
 	if err == nil {
-		o.Logger().Debug("Start processing reconciliation request: %s", string(dump))
+		o.Logger().Debugf("Start processing reconciliation request: %s", string(dump))
 	} else {
 		o.Logger().Warnf("REST endpoint failed to dump http request for debugging purposes: %s", err)
 	}
 
 	//marshal model
-	model, err := newModel(req)
+	model, err := newModel(req, signer)
 	if err != nil {
+		if _, ok := err.(*signatureError); ok {
+			o.Logger().Warnf("Rejecting reconciliation request: %s", err)
+			server.SendHTTPError(w, http.StatusUnauthorized, &reconciler.HTTPErrorResponse{
+				Error: err.Error(),
+			})
+			return
+		}
 		o.Logger().Warnf("Unmarshalling of model failed: %s", err)
 		server.SendHTTPError(w, http.StatusInternalServerError, &reconciler.HTTPErrorResponse{
 			Error: err.Error(),
 		})
 		return
 	}
-	o.Logger().Debugf("Reconciliation model unmarshalled: %s", model)
+	if maskedModel, err := json.Marshal(model.Masked()); err == nil {
+		o.Logger().Debugf("Reconciliation model unmarshalled: %s", string(maskedModel))
+	} else {
+		o.Logger().Debugf("Reconciliation model unmarshalled: %s", model)
+	}
 
 	//validate model
 	if err := model.Validate(); err != nil {
@@ -0,0 +1 @@
+ALTER TABLE inventory_cluster_config_statuses DROP COLUMN "deletion_forced";
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE inventory_cluster_config_statuses ADD COLUMN "deletion_forced" boolean DEFAULT FALSE;
\ No newline at end of file
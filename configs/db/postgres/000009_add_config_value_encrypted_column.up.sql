@@ -0,0 +1 @@
+ALTER TABLE config_values ADD COLUMN "encrypted" boolean DEFAULT FALSE;
\ No newline at end of file
@@ -21,6 +21,7 @@ CREATE TABLE IF NOT EXISTS config_values (
 	"bucket" text NOT NULL,
 	"data_type" varchar(255) NOT NULL,
 	"value" text NULL,
+	"encrypted" boolean DEFAULT FALSE,
 	"username" varchar(255) NOT NULL,
 	"created" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
 	CONSTRAINT config_values_pk UNIQUE ("bucket", "key", "version"),
@@ -88,6 +89,7 @@ CREATE TABLE IF NOT EXISTS inventory_cluster_config_statuses (
 	"config_version" int NOT NULL,
 	"status" text NOT NULL,
 	"deleted" boolean DEFAULT FALSE,
+	"deletion_forced" boolean DEFAULT FALSE,
 	"created" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
 	FOREIGN KEY("runtime_id", "cluster_version", "config_version") REFERENCES inventory_cluster_configs("runtime_id", "cluster_version", "version") ON UPDATE CASCADE ON DELETE CASCADE
 );
@@ -0,0 +1,150 @@
+package mothership
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/internal/cli"
+	file "github.com/kyma-incubator/reconciler/pkg/files"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/ssl"
+	"github.com/pkg/errors"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+const (
+	envVarToken    = "RECONCILER_API_TOKEN"
+	defaultTimeout = 30 * time.Second
+)
+
+// ClientOptions are the options of commands which are interacting with the REST API of a running mothership reconciler
+type ClientOptions struct {
+	*cli.Options
+	URL       string //base URL of the API including the contract version (e.g. http://localhost:8080/v1)
+	Token     string //bearer token used to authenticate the API requests
+	TokenFile string
+	ClientCrt string //client certificate used for mutual TLS
+	ClientKey string
+	CAFile    string //CA bundle used to verify the certificate of the mothership reconciler
+	Timeout   time.Duration
+}
+
+func NewClientOptions(o *cli.Options) *ClientOptions {
+	return &ClientOptions{Options: o}
+}
+
+func (o *ClientOptions) AddFlags(flags *pflag.FlagSet) {
+	flags.StringVar(&o.URL, "url", "",
+		"URL of the mothership API (default is built from the mothership configuration, e.g. 'http://localhost:8080/v1')")
+	flags.StringVar(&o.Token, "token", "",
+		fmt.Sprintf("Bearer token used to authenticate at the mothership API (default is env var $%s)", envVarToken))
+	flags.StringVar(&o.TokenFile, "token-file", "", "File which contains the bearer token")
+	flags.StringVar(&o.ClientCrt, "client-crt", "", "Path to the client certificate used for mutual TLS")
+	flags.StringVar(&o.ClientKey, "client-key", "", "Path to the key of the client certificate")
+	flags.StringVar(&o.CAFile, "ca", "", "Path to the CA bundle used to verify the certificate of the mothership API")
+	flags.DurationVar(&o.Timeout, "timeout", defaultTimeout, "Timeout of API requests")
+	flags.StringVarP(&o.OutputFormat, "output-format", "o", "table",
+		fmt.Sprintf("Define output formatting. Supported options are '%s'.", strings.Join(cli.SupportedOutputFormats, "', '")))
+}
+
+func (o *ClientOptions) Validate() error {
+	if o.Token != "" && o.TokenFile != "" {
+		return fmt.Errorf("either a token or a token file can be defined but not both")
+	}
+	if o.TokenFile != "" && !file.Exists(o.TokenFile) {
+		return fmt.Errorf("token file '%s' not found", o.TokenFile)
+	}
+	if o.Timeout <= 0 {
+		return fmt.Errorf("timeout has to be > 0 (was %.1f secs)", o.Timeout.Seconds())
+	}
+	return o.clientConfig().Validate()
+}
+
+func (o *ClientOptions) clientConfig() *ssl.ClientConfig {
+	return &ssl.ClientConfig{
+		CrtFile: o.ClientCrt,
+		KeyFile: o.ClientKey,
+		CAFile:  o.CAFile,
+	}
+}
+
+// Client returns a client for the external API of the mothership reconciler
+func (o *ClientOptions) Client() (*keb.ClientWithResponses, error) {
+	httpClient, err := ssl.NewHTTPClient(o.clientConfig(), o.Timeout)
+	if err != nil {
+		return nil, err
+	}
+	token, err := o.token()
+	if err != nil {
+		return nil, err
+	}
+	return keb.NewClientWithResponses(o.apiURL(),
+		keb.WithHTTPClient(httpClient),
+		keb.WithRequestEditorFn(func(ctx context.Context, req *http.Request) error {
+			if token != "" {
+				req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+			}
+			return nil
+		}))
+}
+
+func (o *ClientOptions) apiURL() string {
+	if o.URL != "" {
+		return o.URL
+	}
+	scheme := viper.GetString("mothership.scheme")
+	if scheme == "" {
+		scheme = "http"
+	}
+	host := viper.GetString("mothership.host")
+	if host == "" {
+		host = "localhost"
+	}
+	port := viper.GetInt("mothership.port")
+	if port == 0 {
+		port = 8080
+	}
+	return fmt.Sprintf("%s://%s:%d/v1", scheme, host, port)
+}
+
+func (o *ClientOptions) token() (string, error) {
+	if o.TokenFile != "" {
+		token, err := ioutil.ReadFile(o.TokenFile)
+		if err != nil {
+			return "", errors.Wrap(err, fmt.Sprintf("failed to read token file '%s'", o.TokenFile))
+		}
+		return strings.TrimSpace(string(token)), nil
+	}
+	if o.Token != "" {
+		return o.Token, nil
+	}
+	return os.Getenv(envVarToken), nil
+}
+
+// CheckResponse returns an error if the mothership reconciler didn't respond with one of the expected HTTP codes
+func CheckResponse(httpResp *http.Response, body []byte, expectedCodes ...int) error {
+	for _, expectedCode := range expectedCodes {
+		if httpResp.StatusCode == expectedCode {
+			return nil
+		}
+	}
+	errResp := &keb.HTTPErrorResponse{}
+	if err := json.Unmarshal(body, errResp); err != nil || errResp.Error == "" {
+		errResp.Error = strings.TrimSpace(string(body))
+	}
+	return fmt.Errorf("mothership reconciler responded with HTTP code %d: %s", httpResp.StatusCode, errResp.Error)
+}
+
+// MissingPayloadError returns the error reported when the mothership reconciler responded with an expected HTTP code
+// but its response body couldn't be decoded into the expected payload
+func MissingPayloadError(httpResp *http.Response, body []byte) error {
+	return fmt.Errorf("mothership reconciler responded with HTTP code %d but without a valid payload: %s",
+		httpResp.StatusCode, strings.TrimSpace(string(body)))
+}
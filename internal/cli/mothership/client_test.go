@@ -0,0 +1,83 @@
+package mothership
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/kyma-incubator/reconciler/internal/cli"
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer abc" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_ = json.NewEncoder(w).Encode(&keb.HTTPErrorResponse{Error: "Authentication required"})
+			return
+		}
+		require.Equal(t, "/v1/clusters/runtime1/status", r.URL.Path)
+		w.Header().Set("content-type", "application/json")
+		_ = json.NewEncoder(w).Encode(&keb.HTTPClusterResponse{
+			Cluster:              "runtime1",
+			ClusterVersion:       1,
+			ConfigurationVersion: 2,
+			Status:               keb.StatusReady,
+		})
+	}))
+	defer srv.Close()
+
+	t.Run("Authenticated request", func(t *testing.T) {
+		tokenFile := filepath.Join(t.TempDir(), "token")
+		require.NoError(t, ioutil.WriteFile(tokenFile, []byte("abc\n"), 0600))
+
+		o := NewClientOptions(&cli.Options{})
+		o.URL = srv.URL + "/v1"
+		o.TokenFile = tokenFile
+		o.Timeout = defaultTimeout
+		require.NoError(t, o.Validate())
+
+		client, err := o.Client()
+		require.NoError(t, err)
+		resp, err := client.GetClustersRuntimeIDStatusWithResponse(context.Background(), "runtime1")
+		require.NoError(t, err)
+		require.NoError(t, CheckResponse(resp.HTTPResponse, resp.Body, http.StatusOK))
+		require.Equal(t, int64(2), resp.JSON200.ConfigurationVersion)
+		require.Equal(t, keb.StatusReady, resp.JSON200.Status)
+	})
+
+	t.Run("Unauthenticated request", func(t *testing.T) {
+		o := NewClientOptions(&cli.Options{})
+		o.URL = srv.URL + "/v1"
+		o.Timeout = defaultTimeout
+
+		client, err := o.Client()
+		require.NoError(t, err)
+		resp, err := client.GetClustersRuntimeIDStatusWithResponse(context.Background(), "runtime1")
+		require.NoError(t, err)
+		err = CheckResponse(resp.HTTPResponse, resp.Body, http.StatusOK)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "HTTP code 401: Authentication required")
+	})
+
+	t.Run("Missing payload", func(t *testing.T) {
+		httpResp := &http.Response{StatusCode: http.StatusOK}
+		require.NoError(t, CheckResponse(httpResp, []byte("null"), http.StatusOK))
+		err := MissingPayloadError(httpResp, []byte("null"))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "HTTP code 200 but without a valid payload: null")
+	})
+
+	t.Run("Invalid options", func(t *testing.T) {
+		o := NewClientOptions(&cli.Options{})
+		o.Token = "abc"
+		o.TokenFile = "abc"
+		o.Timeout = defaultTimeout
+		require.Error(t, o.Validate())
+	})
+}
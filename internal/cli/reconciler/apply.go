@@ -0,0 +1,25 @@
+package reconciler
+
+import (
+	"fmt"
+
+	k8s "github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes"
+)
+
+type ApplyConfig struct {
+	Mode             string            //'client-side' or 'server-side'
+	ConflictPolicies map[string]string //server-side apply conflict policy per kind ('*' defines the default)
+	Workers          int               //max. number of resources which are applied in parallel (0 means default)
+}
+
+func (c *ApplyConfig) ApplyConfig() (*k8s.ApplyConfig, error) {
+	return k8s.NewApplyConfig(c.Mode, c.ConflictPolicies)
+}
+
+func (c *ApplyConfig) validate() error {
+	if c.Workers < 0 {
+		return fmt.Errorf("apply workers cannot be < 0 (got %d)", c.Workers)
+	}
+	_, err := c.ApplyConfig()
+	return err
+}
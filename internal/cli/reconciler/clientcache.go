@@ -0,0 +1,21 @@
+package reconciler
+
+import (
+	"fmt"
+	"time"
+)
+
+type ClientCacheConfig struct {
+	TTL  time.Duration //how long a Kubernetes client is shared between tasks of the same cluster
+	Size int           //max number of cached Kubernetes clients
+}
+
+func (c *ClientCacheConfig) validate() error {
+	if c.TTL < 0 {
+		return fmt.Errorf("TTL of Kubernetes client cache cannot be < 0 (got %.1f secs)", c.TTL.Seconds())
+	}
+	if c.Size < 0 {
+		return fmt.Errorf("size of Kubernetes client cache cannot be < 0 (got %d)", c.Size)
+	}
+	return nil
+}
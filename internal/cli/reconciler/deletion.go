@@ -0,0 +1,22 @@
+package reconciler
+
+import (
+	"time"
+
+	k8s "github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes"
+)
+
+type DeletionConfig struct {
+	Mode             string        //'default' or 'finalizer-aware'
+	FinalizerTimeout time.Duration //time until a deleted resource is considered as stuck on its finalizers
+	StripFinalizers  bool          //remove the finalizers of stuck resources
+}
+
+func (c *DeletionConfig) DeletionConfig() (*k8s.DeletionConfig, error) {
+	return k8s.NewDeletionConfig(c.Mode, c.FinalizerTimeout, c.StripFinalizers)
+}
+
+func (c *DeletionConfig) validate() error {
+	_, err := c.DeletionConfig()
+	return err
+}
@@ -0,0 +1,21 @@
+package reconciler
+
+import (
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/git"
+)
+
+type GitConfig struct {
+	CredentialsFile string //file which maps GIT repositories to their credentials (empty means token secrets named after the repository host)
+}
+
+func (c *GitConfig) CredentialsMapping() (*git.CredentialsMapping, error) {
+	if c.CredentialsFile == "" {
+		return nil, nil
+	}
+	return git.LoadCredentialsMapping(c.CredentialsFile)
+}
+
+func (c *GitConfig) validate() error {
+	_, err := c.CredentialsMapping()
+	return err
+}
@@ -7,22 +7,44 @@ import (
 type Options struct {
 	*cli.Options
 	Workspace             string
+	WorkspaceGCConfig     *WorkspaceGCConfig
+	WorkspaceBundleConfig *WorkspaceBundleConfig
+	RenderCacheConfig     *RenderCacheConfig
+	GitConfig             *GitConfig
+	VerificationConfig    *VerificationConfig
 	ServerConfig          *ServerConfig
 	WorkerConfig          *WorkerConfig
+	ClientCacheConfig     *ClientCacheConfig
 	RetryConfig           *RetryConfig
 	HeartbeatSenderConfig *RecurringTaskConfig
 	ProgressTrackerConfig *RecurringTaskConfig
+	SecurityConfig        *SecurityConfig
+	ApplyConfig           *ApplyConfig
+	DeletionConfig        *DeletionConfig
+	ReleaseConfig         *ReleaseConfig
+	ReadinessConfig       *ReadinessConfig
 }
 
 func NewOptions(o *cli.Options) *Options {
 	return &Options{
 		o,
 		".",
+		&WorkspaceGCConfig{},
+		&WorkspaceBundleConfig{},
+		&RenderCacheConfig{},
+		&GitConfig{},
+		&VerificationConfig{},
 		&ServerConfig{},
 		&WorkerConfig{},
+		&ClientCacheConfig{},
 		&RetryConfig{},
 		&RecurringTaskConfig{},
 		&RecurringTaskConfig{},
+		&SecurityConfig{},
+		&ApplyConfig{},
+		&DeletionConfig{},
+		&ReleaseConfig{},
+		&ReadinessConfig{},
 	}
 }
 
@@ -30,12 +52,30 @@ func (o *Options) Validate() error {
 	if o.Workspace == "" {
 		o.Workspace = "."
 	}
+	if err := o.WorkspaceGCConfig.validate(); err != nil {
+		return err
+	}
+	if err := o.WorkspaceBundleConfig.validate(); err != nil {
+		return err
+	}
+	if err := o.RenderCacheConfig.validate(); err != nil {
+		return err
+	}
+	if err := o.GitConfig.validate(); err != nil {
+		return err
+	}
+	if err := o.VerificationConfig.validate(); err != nil {
+		return err
+	}
 	if err := o.ServerConfig.validate(); err != nil {
 		return err
 	}
 	if err := o.WorkerConfig.validate(); err != nil {
 		return err
 	}
+	if err := o.ClientCacheConfig.validate(); err != nil {
+		return err
+	}
 	if err := o.RetryConfig.validate(); err != nil {
 		return err
 	}
@@ -45,5 +85,20 @@ func (o *Options) Validate() error {
 	if err := o.ProgressTrackerConfig.validate(); err != nil {
 		return err
 	}
+	if err := o.SecurityConfig.validate(); err != nil {
+		return err
+	}
+	if err := o.ApplyConfig.validate(); err != nil {
+		return err
+	}
+	if err := o.DeletionConfig.validate(); err != nil {
+		return err
+	}
+	if err := o.ReleaseConfig.validate(); err != nil {
+		return err
+	}
+	if err := o.ReadinessConfig.validate(); err != nil {
+		return err
+	}
 	return nil
 }
@@ -0,0 +1,21 @@
+package reconciler
+
+import (
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes/progress"
+)
+
+type ReadinessConfig struct {
+	ChecksFile string //file which defines how the readiness of custom resources is verified (empty means no checks)
+}
+
+func (c *ReadinessConfig) ReadinessChecks() ([]*progress.ConditionCheck, error) {
+	if c.ChecksFile == "" {
+		return nil, nil
+	}
+	return progress.LoadConditionChecks(c.ChecksFile)
+}
+
+func (c *ReadinessConfig) validate() error {
+	_, err := c.ReadinessChecks()
+	return err
+}
@@ -0,0 +1,18 @@
+package reconciler
+
+import (
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/chart"
+)
+
+type ReleaseConfig struct {
+	Mode string //'none', 'record' or 'adopt'
+}
+
+func (c *ReleaseConfig) ReleaseMode() (chart.ReleaseMode, error) {
+	return chart.NewReleaseMode(c.Mode)
+}
+
+func (c *ReleaseConfig) validate() error {
+	_, err := c.ReleaseMode()
+	return err
+}
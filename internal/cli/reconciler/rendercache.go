@@ -0,0 +1,19 @@
+package reconciler
+
+import (
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/chart"
+)
+
+type RenderCacheConfig struct {
+	MemorySize string //max size of rendered charts cached in memory, e.g. '256Mi' (empty disables the memory tier)
+	DiskSize   string //max size of rendered charts cached on disk, e.g. '2Gi' (empty disables the disk tier)
+}
+
+func (c *RenderCacheConfig) RenderCacheConfig(workspace string) (*chart.RenderCacheConfig, error) {
+	return chart.NewRenderCacheConfig(c.MemorySize, c.DiskSize, workspace)
+}
+
+func (c *RenderCacheConfig) validate() error {
+	_, err := c.RenderCacheConfig("")
+	return err
+}
@@ -0,0 +1,30 @@
+package reconciler
+
+import (
+	"fmt"
+
+	file "github.com/kyma-incubator/reconciler/pkg/files"
+	"github.com/kyma-incubator/reconciler/pkg/ssl"
+)
+
+type SecurityConfig struct {
+	ClientCrtFile    string //client certificate used for mutual TLS when sending callbacks
+	ClientKeyFile    string
+	ClientCAFile     string //CA bundle used to verify the certificate of the mothership reconciler
+	SignatureKeyFile string //shared secret used to verify tasks and to sign callbacks
+}
+
+func (c *SecurityConfig) ClientConfig() *ssl.ClientConfig {
+	return &ssl.ClientConfig{
+		CrtFile: c.ClientCrtFile,
+		KeyFile: c.ClientKeyFile,
+		CAFile:  c.ClientCAFile,
+	}
+}
+
+func (c *SecurityConfig) validate() error {
+	if c.SignatureKeyFile != "" && !file.Exists(c.SignatureKeyFile) {
+		return fmt.Errorf("signature key file '%s' not found", c.SignatureKeyFile)
+	}
+	return c.ClientConfig().Validate()
+}
@@ -6,14 +6,18 @@ import (
 )
 
 type ServerConfig struct {
-	Port       int
-	SSLCrtFile string
-	SSLKeyFile string
+	Port         int
+	SSLCrtFile   string
+	SSLKeyFile   string
+	ClientCAFile string
 }
 
 func (c *ServerConfig) validate() error {
 	if c.Port <= 0 || c.Port > 65535 {
 		return fmt.Errorf("port %d is out of range 1-65535", c.Port)
 	}
+	if c.ClientCAFile != "" && (c.SSLCrtFile == "" || c.SSLKeyFile == "") {
+		return fmt.Errorf("client CA requires an SSL certificate and key (mutual TLS)")
+	}
 	return ssl.VerifyKeyPair(c.SSLCrtFile, c.SSLKeyFile)
 }
@@ -14,14 +14,80 @@ func NewComponentReconciler(o *Options, reconcilerName string) (*service.Compone
 		recon.Debug()
 	}
 
+	applyConfig, err := o.ApplyConfig.ApplyConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	deletionConfig, err := o.DeletionConfig.DeletionConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	releaseMode, err := o.ReleaseConfig.ReleaseMode()
+	if err != nil {
+		return nil, err
+	}
+
+	readinessChecks, err := o.ReadinessConfig.ReadinessChecks()
+	if err != nil {
+		return nil, err
+	}
+
+	workspaceGCConfig, err := o.WorkspaceGCConfig.WorkspaceGCConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	renderCacheConfig, err := o.RenderCacheConfig.RenderCacheConfig(o.Workspace)
+	if err != nil {
+		return nil, err
+	}
+
+	gitCredentials, err := o.GitConfig.CredentialsMapping()
+	if err != nil {
+		return nil, err
+	}
+
+	verificationKeys, err := o.VerificationConfig.VerificationKeys()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := o.WorkspaceBundleConfig.importBundle(o.Workspace, o.Logger()); err != nil {
+		return nil, err
+	}
+
 	recon.WithWorkspace(o.Workspace).
+		//configure deletion of unused workspaces
+		WithWorkspaceGC(workspaceGCConfig).
+		//configure caching of rendered charts
+		WithRenderCache(renderCacheConfig).
+		//configure whether Kyma sources can be downloaded or have to be imported from a workspace bundle
+		WithOfflineWorkspace(o.WorkspaceBundleConfig.Offline).
+		//configure credentials used to access GIT repositories
+		WithGitCredentials(gitCredentials).
+		//configure public keys trusted to verify signatures of component sources
+		WithVerificationKeys(verificationKeys).
 		//configure reconciliation worker pool + retry-behaviour
 		WithWorkers(o.WorkerConfig.Workers, o.WorkerConfig.Timeout).
 		WithRetry(o.RetryConfig.MaxRetries, o.RetryConfig.RetryDelay).
+		//configure sharing of Kubernetes clients between tasks targeting the same cluster
+		WithClientCacheConfig(o.ClientCacheConfig.TTL, o.ClientCacheConfig.Size).
 		//configure status updates send to mothership reconciler
 		WithHeartbeatSenderConfig(o.HeartbeatSenderConfig.Interval, o.HeartbeatSenderConfig.Timeout).
 		//configure reconciliation progress-checks applied on target K8s cluster
-		WithProgressTrackerConfig(o.ProgressTrackerConfig.Interval, o.ProgressTrackerConfig.Timeout)
+		WithProgressTrackerConfig(o.ProgressTrackerConfig.Interval, o.ProgressTrackerConfig.Timeout).
+		WithReadinessChecks(readinessChecks...).
+		//configure mutual TLS and signing of callbacks send to mothership reconciler
+		WithCallbackSecurity(o.SecurityConfig.ClientConfig(), o.SecurityConfig.SignatureKeyFile).
+		//configure how resources are applied on target K8s cluster
+		WithApplyConfig(applyConfig).
+		WithApplyWorkers(o.ApplyConfig.Workers).
+		//configure how resources are deleted on target K8s cluster
+		WithDeletionConfig(deletionConfig).
+		//configure whether deployments are recorded as Helm releases on target K8s cluster
+		WithReleaseMode(releaseMode)
 
 	return recon, nil
 }
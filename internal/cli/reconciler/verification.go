@@ -0,0 +1,21 @@
+package reconciler
+
+import (
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/chart"
+)
+
+type VerificationConfig struct {
+	KeysFile string //file which defines the public keys trusted to verify signatures of component sources (empty means no trusted keys)
+}
+
+func (c *VerificationConfig) VerificationKeys() (chart.VerificationKeys, error) {
+	if c.KeysFile == "" {
+		return nil, nil
+	}
+	return chart.LoadVerificationKeys(c.KeysFile)
+}
+
+func (c *VerificationConfig) validate() error {
+	_, err := c.VerificationKeys()
+	return err
+}
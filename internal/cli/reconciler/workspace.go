@@ -0,0 +1,61 @@
+package reconciler
+
+import (
+	"fmt"
+	"time"
+
+	file "github.com/kyma-incubator/reconciler/pkg/files"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/chart"
+	"go.uber.org/zap"
+)
+
+type WorkspaceGCConfig struct {
+	Quota    string        //max disk usage of all workspaces, e.g. '10Gi' (empty means unlimited)
+	MaxAge   time.Duration //max time a workspace is kept after its last usage (0 means unlimited)
+	Interval time.Duration //interval of the garbage collection runs
+}
+
+func (c *WorkspaceGCConfig) WorkspaceGCConfig() (*chart.WorkspaceGCConfig, error) {
+	return chart.NewWorkspaceGCConfig(c.Quota, c.MaxAge, c.Interval)
+}
+
+func (c *WorkspaceGCConfig) validate() error {
+	_, err := c.WorkspaceGCConfig()
+	return err
+}
+
+type WorkspaceBundleConfig struct {
+	Offline       bool   //refuse the download of Kyma sources
+	Bundle        string //workspace bundle imported at startup (empty means no import)
+	PublicKeyFile string //public key used to verify the signature of the workspace bundle
+}
+
+func (c *WorkspaceBundleConfig) validate() error {
+	if c.Bundle == "" {
+		return nil
+	}
+	if !file.Exists(c.Bundle) {
+		return fmt.Errorf("workspace bundle '%s' not found", c.Bundle)
+	}
+	if c.PublicKeyFile == "" {
+		return fmt.Errorf("public key file is required to verify the signature of workspace bundle '%s'", c.Bundle)
+	}
+	return nil
+}
+
+func (c *WorkspaceBundleConfig) importBundle(workspace string, logger *zap.SugaredLogger) error {
+	if c.Bundle == "" {
+		return nil
+	}
+	wsFactory, err := chart.NewFactory(nil, workspace, logger)
+	if err != nil {
+		return err
+	}
+	manifest, err := wsFactory.ImportBundleFile(c.Bundle, c.PublicKeyFile)
+	if err != nil {
+		return err
+	}
+	logger.Infof("Workspace bundle '%s' of Kyma version '%s' imported into workspace '%s'",
+		c.Bundle, manifest.Version, workspace)
+	return nil
+}
@@ -0,0 +1,166 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	jose "github.com/square/go-jose/v3"
+	"github.com/square/go-jose/v3/jwt"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testIssuer = "https://issuer.kyma.local"
+	testKeyID  = "test-key"
+)
+
+func newTestJWKS(t *testing.T) (*rsa.PrivateKey, string) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	jwks := jose.JSONWebKeySet{
+		Keys: []jose.JSONWebKey{
+			{Key: key.Public(), KeyID: testKeyID, Algorithm: string(jose.RS256), Use: "sig"},
+		},
+	}
+	data, err := json.Marshal(jwks)
+	require.NoError(t, err)
+	jwksFile := filepath.Join(t.TempDir(), "jwks.json")
+	require.NoError(t, ioutil.WriteFile(jwksFile, data, 0600))
+	return key, jwksFile
+}
+
+func newTestJWT(t *testing.T, key *rsa.PrivateKey, issuer string, expiry time.Time, roles interface{}) string {
+	signer, err := jose.NewSigner(
+		jose.SigningKey{Algorithm: jose.RS256, Key: key},
+		(&jose.SignerOptions{}).WithType("JWT").WithHeader("kid", testKeyID))
+	require.NoError(t, err)
+	token, err := jwt.Signed(signer).
+		Claims(jwt.Claims{
+			Subject: "jane.doe@kyma.local",
+			Issuer:  issuer,
+			Expiry:  jwt.NewNumericDate(expiry),
+		}).
+		Claims(map[string]interface{}{"roles": roles}).
+		CompactSerialize()
+	require.NoError(t, err)
+	return token
+}
+
+func newRequest(token string) *http.Request {
+	req := httptest.NewRequest(http.MethodGet, "http://localhost/v1/clusters", nil)
+	if token != "" {
+		req.Header.Set(authorizationHeader, bearerPrefix+token)
+	}
+	return req
+}
+
+func TestJWTAuthenticator(t *testing.T) {
+	key, jwksFile := newTestJWKS(t)
+	authenticator, err := NewJWTAuthenticator(&JWTConfig{JWKSFile: jwksFile, Issuer: testIssuer})
+	require.NoError(t, err)
+
+	t.Run("Valid token", func(t *testing.T) {
+		token := newTestJWT(t, key, testIssuer, time.Now().Add(time.Hour), []string{"operator", "unknown"})
+		identity, err := authenticator.Authenticate(newRequest(token))
+		require.NoError(t, err)
+		require.Equal(t, "jane.doe@kyma.local", identity.Subject)
+		require.Equal(t, []Role{RoleOperator}, identity.Roles)
+	})
+
+	t.Run("Role as string claim", func(t *testing.T) {
+		token := newTestJWT(t, key, testIssuer, time.Now().Add(time.Hour), "read-only")
+		identity, err := authenticator.Authenticate(newRequest(token))
+		require.NoError(t, err)
+		require.Equal(t, []Role{RoleReadOnly}, identity.Roles)
+	})
+
+	t.Run("Expired token", func(t *testing.T) {
+		token := newTestJWT(t, key, testIssuer, time.Now().Add(-time.Hour), []string{"operator"})
+		_, err := authenticator.Authenticate(newRequest(token))
+		require.Error(t, err)
+	})
+
+	t.Run("Wrong issuer", func(t *testing.T) {
+		token := newTestJWT(t, key, "https://evil.local", time.Now().Add(time.Hour), []string{"operator"})
+		_, err := authenticator.Authenticate(newRequest(token))
+		require.Error(t, err)
+	})
+
+	t.Run("Foreign signing key", func(t *testing.T) {
+		foreignKey, _ := newTestJWKS(t)
+		token := newTestJWT(t, foreignKey, testIssuer, time.Now().Add(time.Hour), []string{"operator"})
+		_, err := authenticator.Authenticate(newRequest(token))
+		require.Error(t, err)
+	})
+
+	t.Run("No credentials", func(t *testing.T) {
+		_, err := authenticator.Authenticate(newRequest(""))
+		require.ErrorIs(t, err, ErrNoCredentials)
+	})
+}
+
+func TestTokenAuthenticator(t *testing.T) {
+	tokenFile := filepath.Join(t.TempDir(), "tokens.yaml")
+	require.NoError(t, ioutil.WriteFile(tokenFile, []byte(`tokens:
+  - name: keb
+    token: s3cr3t
+    roles: [provisioner]
+`), 0600))
+
+	authenticator, err := NewTokenAuthenticator(tokenFile)
+	require.NoError(t, err)
+
+	identity, err := authenticator.Authenticate(newRequest("s3cr3t"))
+	require.NoError(t, err)
+	require.Equal(t, "keb", identity.Subject)
+	require.True(t, identity.HasAnyRole(RoleProvisioner))
+	require.False(t, identity.HasAnyRole(RoleOperator))
+
+	_, err = authenticator.Authenticate(newRequest("wrong"))
+	require.Error(t, err)
+}
+
+func TestMiddleware(t *testing.T) {
+	tokenFile := filepath.Join(t.TempDir(), "tokens.yaml")
+	require.NoError(t, ioutil.WriteFile(tokenFile, []byte(`tokens:
+  - name: keb
+    token: keb-token
+    roles: [provisioner]
+  - name: viewer
+    token: viewer-token
+    roles: [read-only]
+`), 0600))
+	tokenAuth, err := NewTokenAuthenticator(tokenFile)
+	require.NoError(t, err)
+
+	handler := NewMiddleware(Chain{tokenAuth})(Authorize(func(w http.ResponseWriter, r *http.Request) {
+		require.NotNil(t, IdentityFromContext(r.Context()))
+		w.WriteHeader(http.StatusOK)
+	}, RoleProvisioner))
+
+	testCases := []struct {
+		name         string
+		token        string
+		expectedCode int
+	}{
+		{name: "Authorized caller", token: "keb-token", expectedCode: http.StatusOK},
+		{name: "Caller without required role", token: "viewer-token", expectedCode: http.StatusForbidden},
+		{name: "Invalid token", token: "invalid", expectedCode: http.StatusUnauthorized},
+		{name: "Anonymous caller", token: "", expectedCode: http.StatusUnauthorized},
+	}
+	for _, testCase := range testCases {
+		tc := testCase
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, newRequest(tc.token))
+			require.Equal(t, tc.expectedCode, w.Result().StatusCode)
+		})
+	}
+}
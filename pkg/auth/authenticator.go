@@ -0,0 +1,57 @@
+package auth
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	authorizationHeader = "Authorization"
+	bearerPrefix        = "Bearer "
+)
+
+var (
+	ErrNoCredentials = errors.New("no credentials provided")
+	errUnknownToken  = errors.New("token is unknown")
+)
+
+// Authenticator verifies the credentials of an HTTP request and returns the identity of the caller.
+// ErrNoCredentials is returned if the request doesn't contain any credentials.
+type Authenticator interface {
+	Authenticate(r *http.Request) (*Identity, error)
+}
+
+// Chain tries each authenticator in the given order and returns the first successfully verified identity
+type Chain []Authenticator
+
+func (c Chain) Authenticate(r *http.Request) (*Identity, error) {
+	if _, err := bearerToken(r); err != nil {
+		return nil, err
+	}
+	var errs []string
+	for _, authenticator := range c {
+		identity, err := authenticator.Authenticate(r)
+		if err == nil {
+			return identity, nil
+		}
+		errs = append(errs, err.Error())
+	}
+	return nil, errors.Errorf("authentication failed: %s", strings.Join(errs, ", "))
+}
+
+func bearerToken(r *http.Request) (string, error) {
+	header := r.Header.Get(authorizationHeader)
+	if header == "" {
+		return "", ErrNoCredentials
+	}
+	if !strings.HasPrefix(header, bearerPrefix) {
+		return "", errors.Errorf("unsupported authorization scheme in header '%s'", authorizationHeader)
+	}
+	token := strings.TrimSpace(strings.TrimPrefix(header, bearerPrefix))
+	if token == "" {
+		return "", ErrNoCredentials
+	}
+	return token, nil
+}
@@ -0,0 +1,63 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+type Role string
+
+const (
+	RoleReadOnly    Role = "read-only"
+	RoleOperator    Role = "operator"
+	RoleProvisioner Role = "provisioner"
+)
+
+var SupportedRoles = []Role{RoleReadOnly, RoleOperator, RoleProvisioner}
+
+func ToRole(in string) (Role, error) {
+	for _, role := range SupportedRoles {
+		if strings.EqualFold(in, string(role)) {
+			return role, nil
+		}
+	}
+	return Role(""), fmt.Errorf("given string is not a role: %s", in)
+}
+
+// Identity is the verified caller of an API request
+type Identity struct {
+	Subject string
+	Roles   []Role
+	Method  string //authentication method used to verify the identity (e.g. "jwt" or "token")
+}
+
+func (i *Identity) HasAnyRole(roles ...Role) bool {
+	for _, required := range roles {
+		for _, role := range i.Roles {
+			if role == required {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (i *Identity) String() string {
+	return fmt.Sprintf("Identity [Subject=%s,Roles=%v,Method=%s]", i.Subject, i.Roles, i.Method)
+}
+
+type identityCtxKey struct{}
+
+func WithIdentity(ctx context.Context, identity *Identity) context.Context {
+	return context.WithValue(ctx, identityCtxKey{}, identity)
+}
+
+// IdentityFromContext returns the identity of the authenticated caller or nil if the request is anonymous
+func IdentityFromContext(ctx context.Context) *Identity {
+	identity, ok := ctx.Value(identityCtxKey{}).(*Identity)
+	if !ok {
+		return nil
+	}
+	return identity
+}
@@ -0,0 +1,140 @@
+package auth
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+	jose "github.com/square/go-jose/v3"
+	"github.com/square/go-jose/v3/jwt"
+)
+
+const (
+	defaultRolesClaim = "roles"
+	jwtLeeway         = 1 * time.Minute
+)
+
+type JWTConfig struct {
+	JWKSFile   string
+	Issuer     string
+	Audience   string
+	RolesClaim string //name of the claim which contains the roles of the subject (string or list of strings)
+}
+
+// JWTAuthenticator verifies bearer tokens which are signed by a key of the configured JSON Web Key Set
+type JWTAuthenticator struct {
+	jwks       *jose.JSONWebKeySet
+	expected   jwt.Expected
+	rolesClaim string
+}
+
+func NewJWTAuthenticator(cfg *JWTConfig) (*JWTAuthenticator, error) {
+	if cfg.Issuer == "" {
+		return nil, errors.New("JWT issuer is undefined")
+	}
+	data, err := ioutil.ReadFile(cfg.JWKSFile)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read JWKS file '%s'", cfg.JWKSFile)
+	}
+	jwks := &jose.JSONWebKeySet{}
+	if err := json.Unmarshal(data, jwks); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal JWKS file '%s'", cfg.JWKSFile)
+	}
+	if len(jwks.Keys) == 0 {
+		return nil, errors.Errorf("JWKS file '%s' doesn't contain any key", cfg.JWKSFile)
+	}
+
+	expected := jwt.Expected{Issuer: cfg.Issuer}
+	if cfg.Audience != "" {
+		expected.Audience = jwt.Audience{cfg.Audience}
+	}
+	rolesClaim := cfg.RolesClaim
+	if rolesClaim == "" {
+		rolesClaim = defaultRolesClaim
+	}
+
+	return &JWTAuthenticator{
+		jwks:       jwks,
+		expected:   expected,
+		rolesClaim: rolesClaim,
+	}, nil
+}
+
+func (a *JWTAuthenticator) Authenticate(r *http.Request) (*Identity, error) {
+	rawToken, err := bearerToken(r)
+	if err != nil {
+		return nil, err
+	}
+	token, err := jwt.ParseSigned(rawToken)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse JWT")
+	}
+	key, err := a.key(token)
+	if err != nil {
+		return nil, err
+	}
+
+	claims := jwt.Claims{}
+	customClaims := map[string]interface{}{}
+	if err := token.Claims(key, &claims, &customClaims); err != nil {
+		return nil, errors.Wrap(err, "JWT signature verification failed")
+	}
+	if err := claims.ValidateWithLeeway(a.expected.WithTime(time.Now()), jwtLeeway); err != nil {
+		return nil, errors.Wrap(err, "JWT validation failed")
+	}
+	if claims.Subject == "" {
+		return nil, errors.New("JWT doesn't contain a subject")
+	}
+
+	return &Identity{
+		Subject: claims.Subject,
+		Roles:   a.roles(customClaims),
+		Method:  "jwt",
+	}, nil
+}
+
+func (a *JWTAuthenticator) key(token *jwt.JSONWebToken) (interface{}, error) {
+	var kid string
+	for _, header := range token.Headers {
+		if header.KeyID != "" {
+			kid = header.KeyID
+			break
+		}
+	}
+	if kid == "" {
+		//tokens without key ID are only accepted if the key is unambiguous
+		if len(a.jwks.Keys) == 1 {
+			return a.jwks.Keys[0].Key, nil
+		}
+		return nil, errors.New("JWT doesn't define a key ID")
+	}
+	keys := a.jwks.Key(kid)
+	if len(keys) == 0 {
+		return nil, errors.Errorf("JWT key ID '%s' is unknown", kid)
+	}
+	return keys[0].Key, nil
+}
+
+func (a *JWTAuthenticator) roles(customClaims map[string]interface{}) []Role {
+	var values []string
+	switch claim := customClaims[a.rolesClaim].(type) {
+	case string:
+		values = append(values, claim)
+	case []interface{}:
+		for _, value := range claim {
+			if strValue, ok := value.(string); ok {
+				values = append(values, strValue)
+			}
+		}
+	}
+
+	var roles []Role
+	for _, value := range values {
+		if role, err := ToRole(value); err == nil { //ignore roles which aren't relevant for the reconciler
+			roles = append(roles, role)
+		}
+	}
+	return roles
+}
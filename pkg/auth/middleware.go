@@ -0,0 +1,54 @@
+package auth
+
+import (
+	"net/http"
+
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/server"
+	"github.com/pkg/errors"
+)
+
+// NewMiddleware returns a HTTP middleware which authenticates requests and stores the verified identity
+// in the request context. Requests without credentials are passed through as anonymous requests:
+// it's the responsibility of Authorize to reject them if the route requires a role.
+func NewMiddleware(authenticator Authenticator) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			identity, err := authenticator.Authenticate(r)
+			if err != nil {
+				if errors.Is(err, ErrNoCredentials) {
+					next.ServeHTTP(w, r)
+					return
+				}
+				w.Header().Set("WWW-Authenticate", "Bearer")
+				server.SendHTTPError(w, http.StatusUnauthorized, &keb.HTTPErrorResponse{
+					Error: err.Error(),
+				})
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(WithIdentity(r.Context(), identity)))
+		})
+	}
+}
+
+// Authorize wraps a handler and verifies that the authenticated caller owns at least one of the given roles
+func Authorize(handler http.HandlerFunc, roles ...Role) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		identity := IdentityFromContext(r.Context())
+		if identity == nil {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			server.SendHTTPError(w, http.StatusUnauthorized, &keb.HTTPErrorResponse{
+				Error: "Authentication required",
+			})
+			return
+		}
+		if !identity.HasAnyRole(roles...) {
+			server.SendHTTPError(w, http.StatusForbidden, &keb.HTTPErrorResponse{
+				Error: errors.Errorf("Subject '%s' is not authorized: one of the roles %v is required",
+					identity.Subject, roles).Error(),
+			})
+			return
+		}
+		handler(w, r)
+	}
+}
@@ -0,0 +1,84 @@
+package auth
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+type staticToken struct {
+	Name  string   `yaml:"name"`
+	Token string   `yaml:"token"`
+	Roles []string `yaml:"roles"`
+}
+
+type tokenFile struct {
+	Tokens []staticToken `yaml:"tokens"`
+}
+
+// TokenAuthenticator verifies static API tokens (e.g. used by KEB) which are defined in a token file:
+//
+//	tokens:
+//	  - name: keb
+//	    token: <secret>
+//	    roles: [provisioner]
+type TokenAuthenticator struct {
+	identities map[string]*Identity
+}
+
+func NewTokenAuthenticator(file string) (*TokenAuthenticator, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read token file '%s'", file)
+	}
+	tokens := &tokenFile{}
+	if err := yaml.Unmarshal(data, tokens); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal token file '%s'", file)
+	}
+
+	identities := make(map[string]*Identity, len(tokens.Tokens))
+	for idx, token := range tokens.Tokens {
+		if token.Name == "" || token.Token == "" {
+			return nil, fmt.Errorf("token #%d in token file '%s' has no name or token value", idx, file)
+		}
+		if _, exists := identities[token.Token]; exists {
+			return nil, fmt.Errorf("token '%s' in token file '%s' is not unique", token.Name, file)
+		}
+		identity := &Identity{
+			Subject: token.Name,
+			Method:  "token",
+		}
+		for _, roleName := range token.Roles {
+			role, err := ToRole(roleName)
+			if err != nil {
+				return nil, errors.Wrapf(err, "token '%s' in token file '%s' is invalid", token.Name, file)
+			}
+			identity.Roles = append(identity.Roles, role)
+		}
+		identities[token.Token] = identity
+	}
+
+	return &TokenAuthenticator{identities: identities}, nil
+}
+
+func (a *TokenAuthenticator) Authenticate(r *http.Request) (*Identity, error) {
+	token, err := bearerToken(r)
+	if err != nil {
+		return nil, err
+	}
+	//compare all tokens in constant time to avoid leaking timing information
+	var result *Identity
+	for knownToken, identity := range a.identities {
+		if subtle.ConstantTimeCompare([]byte(knownToken), []byte(token)) == 1 {
+			result = identity
+		}
+	}
+	if result == nil {
+		return nil, errUnknownToken
+	}
+	return result, nil
+}
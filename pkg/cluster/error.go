@@ -0,0 +1,29 @@
+package cluster
+
+import (
+	"fmt"
+
+	"github.com/kyma-incubator/reconciler/pkg/model"
+)
+
+type NotRestorableError struct {
+	runtimeID string
+	status    model.Status
+}
+
+func (err *NotRestorableError) Error() string {
+	return fmt.Sprintf("deletion of cluster '%s' cannot be revoked in status '%s' (only possible in status '%s' or '%s')",
+		err.runtimeID, err.status, model.ClusterStatusDeleteScheduled, model.ClusterStatusDeleteBlocked)
+}
+
+func newNotRestorableError(runtimeID string, status model.Status) error {
+	return &NotRestorableError{
+		runtimeID: runtimeID,
+		status:    status,
+	}
+}
+
+func IsNotRestorableError(err error) bool {
+	_, ok := err.(*NotRestorableError)
+	return ok
+}
@@ -16,13 +16,15 @@ import (
 type Inventory interface {
 	CreateOrUpdate(contractVersion int64, cluster *keb.Cluster) (*State, error)
 	UpdateStatus(State *State, status model.Status) (*State, error)
-	MarkForDeletion(runtimeID string) (*State, error)
+	MarkForDeletion(runtimeID string, force bool) (*State, error)
+	Restore(runtimeID string) (*State, error)
 	Delete(runtimeID string) error
 	Get(runtimeID string, configVersion int64) (*State, error)
 	GetLatest(runtimeID string) (*State, error)
 	StatusChanges(runtimeID string, offset time.Duration) ([]*StatusChange, error)
-	ClustersToReconcile(reconcileInterval time.Duration) ([]*State, error)
+	ClustersToReconcile(reconcileInterval, deletionGracePeriod time.Duration) ([]*State, error)
 	ClustersNotReady() ([]*State, error)
+	ClusterStatuses(statuses []model.Status, limit, offset int) ([]*model.ClusterStatusEntity, error)
 	CountRetries(runtimeID string, configVersion int64, maxRetries int, errorStatus ...model.Status) (int, error)
 }
 
@@ -203,18 +205,20 @@ func (i *DefaultInventory) createConfiguration(contractVersion int64, cluster *k
 }
 
 func (i *DefaultInventory) createStatus(configEntity *model.ClusterConfigurationEntity, status model.Status) (*model.ClusterStatusEntity, error) {
-	newStatusEntity := &model.ClusterStatusEntity{
+	return i.createStatusEntity(&model.ClusterStatusEntity{
 		RuntimeID:      configEntity.RuntimeID,
 		ClusterVersion: configEntity.ClusterVersion,
 		ConfigVersion:  configEntity.Version,
 		Status:         status,
-	}
+	})
+}
 
+func (i *DefaultInventory) createStatusEntity(newStatusEntity *model.ClusterStatusEntity) (*model.ClusterStatusEntity, error) {
 	//check if a new version is required
-	oldStatusEntity, err := i.latestStatus(configEntity.Version)
+	oldStatusEntity, err := i.latestStatus(newStatusEntity.ConfigVersion)
 	if err == nil {
 		if oldStatusEntity.Equal(newStatusEntity) { //reuse existing status entity
-			i.Logger.Debugf("No differences found for status of cluster '%s': not creating new database entity", configEntity.RuntimeID)
+			i.Logger.Debugf("No differences found for status of cluster '%s': not creating new database entity", newStatusEntity.RuntimeID)
 			return oldStatusEntity, nil
 		}
 		if oldStatusEntity.Status.IsDisabled() {
@@ -251,12 +255,83 @@ func (i *DefaultInventory) UpdateStatus(state *State, status model.Status) (*Sta
 	return state, nil
 }
 
-func (i *DefaultInventory) MarkForDeletion(runtimeID string) (*State, error) {
+// MarkForDeletion schedules the deletion of a cluster: the deletion starts after the deletion grace period
+// expired (see ClustersToReconcile) and can be revoked until then. If force is true, the preflight checks of the
+// components are skipped.
+func (i *DefaultInventory) MarkForDeletion(runtimeID string, force bool) (*State, error) {
 	clusterState, err := i.GetLatest(runtimeID)
 	if err != nil {
 		return nil, err
 	}
-	return i.UpdateStatus(clusterState, model.ClusterStatusDeletePending)
+
+	status := clusterState.Status.Status
+	switch {
+	case status == model.ClusterStatusDeletePreflight || status.IsDeletion():
+		//deletion is already running
+		return clusterState, nil
+	case status.IsDeleteScheduled():
+		//deletion is already scheduled: its grace period continues and a forced deletion stays forced
+		if !force || clusterState.Status.DeletionForced {
+			return clusterState, nil
+		}
+		return i.forceScheduledDeletion(clusterState)
+	case status == model.ClusterStatusDeleteError || status == model.ClusterStatusDeleteErrorRetryable ||
+		(status == model.ClusterStatusDeleteBlocked && force):
+		//preflight checks were already passed or are skipped: retry deletion immediately
+		return i.UpdateStatus(clusterState, model.ClusterStatusDeletePending)
+	}
+
+	newStatus, err := i.createStatusEntity(&model.ClusterStatusEntity{
+		RuntimeID:      clusterState.Configuration.RuntimeID,
+		ClusterVersion: clusterState.Configuration.ClusterVersion,
+		ConfigVersion:  clusterState.Configuration.Version,
+		Status:         model.ClusterStatusDeleteScheduled,
+		DeletionForced: force,
+	})
+	if err != nil {
+		return clusterState, err
+	}
+	clusterState.Status = newStatus
+	return clusterState, i.metricsCollector.OnClusterStateUpdate(clusterState)
+}
+
+//forceScheduledDeletion skips the preflight checks of a scheduled deletion. The status entity is updated in place
+//because its creation timestamp is the start of the deletion grace period.
+func (i *DefaultInventory) forceScheduledDeletion(clusterState *State) (*State, error) {
+	statusEntity := *clusterState.Status
+	statusEntity.DeletionForced = true
+	q, err := db.NewQuery(i.Conn, &statusEntity, i.Logger)
+	if err != nil {
+		return clusterState, err
+	}
+	cnt, err := q.Update().
+		Where(map[string]interface{}{
+			"ID":     statusEntity.ID,
+			"Status": model.ClusterStatusDeleteScheduled,
+		}).
+		ExecCount()
+	if err != nil {
+		return clusterState, err
+	}
+	if cnt == 0 {
+		return clusterState, fmt.Errorf("failed to force scheduled deletion of cluster '%s' "+
+			"(maybe updated by parallel running process)", statusEntity.RuntimeID)
+	}
+	clusterState.Status = &statusEntity
+	return clusterState, nil
+}
+
+// Restore revokes the deletion of a cluster which is in its deletion grace period or
+// whose deletion was blocked by a preflight check. The cluster will be reconciled again.
+func (i *DefaultInventory) Restore(runtimeID string) (*State, error) {
+	clusterState, err := i.GetLatest(runtimeID)
+	if err != nil {
+		return nil, err
+	}
+	if !clusterState.Status.Status.IsRestorable() {
+		return clusterState, newNotRestorableError(runtimeID, clusterState.Status.Status)
+	}
+	return i.UpdateStatus(clusterState, model.ClusterStatusReconcilePending)
 }
 
 func (i *DefaultInventory) Delete(runtimeID string) error {
@@ -467,7 +542,9 @@ func (i *DefaultInventory) latestCluster(runtimeID string) (*model.ClusterEntity
 	return clusterEntity.(*model.ClusterEntity), nil
 }
 
-func (i *DefaultInventory) ClustersToReconcile(reconcileInterval time.Duration) ([]*State, error) {
+// ClustersToReconcile returns all clusters which have to be reconciled or deleted. Clusters which are
+// scheduled for deletion are only returned after the deletion grace period expired.
+func (i *DefaultInventory) ClustersToReconcile(reconcileInterval, deletionGracePeriod time.Duration) ([]*State, error) {
 	var filters []statusSQLFilter
 	if reconcileInterval > 0 {
 		filters = append(filters, &reconcileIntervalFilter{
@@ -477,6 +554,9 @@ func (i *DefaultInventory) ClustersToReconcile(reconcileInterval time.Duration)
 	filters = append(filters, &statusFilter{
 		allowedStatuses: []model.Status{model.ClusterStatusReconcilePending, model.ClusterStatusDeletePending},
 	})
+	filters = append(filters, &deletionGracePeriodFilter{
+		gracePeriod: deletionGracePeriod,
+	})
 	return i.filterClusters(filters...)
 }
 
@@ -489,7 +569,47 @@ func (i *DefaultInventory) ClustersNotReady() ([]*State, error) {
 	return i.filterClusters(statusFilter)
 }
 
+//ClusterStatuses returns the latest status of all clusters which aren't deleted (ordered by runtime ID).
+//The result can be filtered by statuses (empty means all statuses) and paginated (limit <= 0 means unlimited).
+func (i *DefaultInventory) ClusterStatuses(statuses []model.Status, limit, offset int) ([]*model.ClusterStatusEntity, error) {
+	var filters []statusSQLFilter
+	if len(statuses) > 0 {
+		filters = append(filters, &statusFilter{allowedStatuses: statuses})
+	}
+	return i.latestStatuses(filters, func(q *db.Select) *db.Select {
+		q.OrderBy(map[string]string{"RuntimeID": "ASC"})
+		if limit > 0 {
+			q.Limit(limit)
+		}
+		if offset > 0 {
+			q.Offset(offset)
+		}
+		return q
+	})
+}
+
 func (i *DefaultInventory) filterClusters(filters ...statusSQLFilter) ([]*State, error) {
+	clusterStatuses, err := i.latestStatuses(filters, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	//retrieve clusters which require a reconciliation
+	var result []*State
+	for _, clusterStatus := range clusterStatuses {
+		state, err := i.Get(clusterStatus.RuntimeID, clusterStatus.ConfigVersion)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, state)
+	}
+
+	return result, nil
+}
+
+//latestStatuses returns the latest status entities of all clusters which aren't deleted and match the filters
+//(the query can be extended, e.g. by ordering and pagination)
+func (i *DefaultInventory) latestStatuses(filters []statusSQLFilter, extend func(*db.Select) *db.Select) ([]*model.ClusterStatusEntity, error) {
 	//get DDL for sub-query
 	clusterStatusEntity := &model.ClusterStatusEntity{}
 
@@ -543,26 +663,22 @@ func (i *DefaultInventory) filterClusters(filters ...statusSQLFilter) ([]*State,
 		return nil, err
 	}
 
-	clusterStatuses, err := q.Select().
+	selectQ := q.Select().
 		WhereIn("ID", statusIdsSQL, statusIdsArgs...). //query latest cluster states (= max(configVersion) within max(clusterVersion))
 		WhereRaw(statusFilterSQL).                     //filter these states also by provided criteria (by statuses, reconcile-interval etc.)
-		Where(map[string]interface{}{"Deleted": false}).
-		GetMany()
+		Where(map[string]interface{}{"Deleted": false})
+	if extend != nil {
+		selectQ = extend(selectQ)
+	}
+	clusterStatuses, err := selectQ.GetMany()
 	if err != nil {
 		return nil, err
 	}
 
-	//retrieve clusters which require a reconciliation
-	var result []*State
+	var result []*model.ClusterStatusEntity
 	for _, clusterStatus := range clusterStatuses {
-		clStateEntity := clusterStatus.(*model.ClusterStatusEntity)
-		state, err := i.Get(clStateEntity.RuntimeID, clStateEntity.ConfigVersion)
-		if err != nil {
-			return nil, err
-		}
-		result = append(result, state)
+		result = append(result, clusterStatus.(*model.ClusterStatusEntity))
 	}
-
 	return result, nil
 }
 
@@ -129,7 +129,7 @@ func TestInventory(t *testing.T) {
 		}()
 
 		//check clusters to reconcile
-		statesReconcile, err := inventory.ClustersToReconcile(0)
+		statesReconcile, err := inventory.ClustersToReconcile(0, 0)
 		require.NoError(t, err)
 		require.Len(t, statesReconcile, 2)
 		require.ElementsMatch(t,
@@ -143,6 +143,25 @@ func TestInventory(t *testing.T) {
 		require.ElementsMatch(t,
 			listStatuses(statesNotReady),
 			[]model.Status{model.ClusterStatusReconciling, model.ClusterStatusReconcileError, model.ClusterStatusDeleting, model.ClusterStatusDeleteError})
+
+		//check all clusters
+		statusesAll, err := inventory.ClusterStatuses(nil, 0, 0)
+		require.NoError(t, err)
+		require.Len(t, statusesAll, len(clusterStatuses))
+		require.ElementsMatch(t, listClusterStatuses(statusesAll), clusterStatuses)
+
+		//check clusters filtered by status
+		statusesReady, err := inventory.ClusterStatuses([]model.Status{model.ClusterStatusReady}, 0, 0)
+		require.NoError(t, err)
+		require.Len(t, statusesReady, 1)
+		require.Equal(t, model.ClusterStatusReady, statusesReady[0].Status)
+
+		//check paginated clusters
+		statusesPage, err := inventory.ClusterStatuses(nil, 2, 1)
+		require.NoError(t, err)
+		require.Len(t, statusesPage, 2)
+		require.Equal(t, statusesAll[1].RuntimeID, statusesPage[0].RuntimeID)
+		require.Equal(t, statusesAll[2].RuntimeID, statusesPage[1].RuntimeID)
 	})
 
 	t.Run("Get clusters to reconcile", func(t *testing.T) {
@@ -178,10 +197,10 @@ func TestInventory(t *testing.T) {
 		require.NoError(t, err)
 		require.Equal(t, model.ClusterStatusReconcileError, clusterState2v1v2b.Status.Status)
 
-		//delete cluster2, status: DeletePending -> Deleting
-		cluster2State2a, err := inventory.MarkForDeletion(cluster2v1v2.RuntimeID)
+		//delete cluster2, status: DeleteScheduled -> Deleting
+		cluster2State2a, err := inventory.MarkForDeletion(cluster2v1v2.RuntimeID, false)
 		require.NoError(t, err)
-		require.Equal(t, model.ClusterStatusDeletePending, cluster2State2a.Status.Status)
+		require.Equal(t, model.ClusterStatusDeleteScheduled, cluster2State2a.Status.Status)
 		expectedCluster2State2b, err := inventory.UpdateStatus(cluster2State2a, model.ClusterStatusDeleting) //<- EXPECTED STATE
 		require.NoError(t, err)
 		require.Equal(t, model.ClusterStatusDeleting, expectedCluster2State2b.Status.Status)
@@ -235,7 +254,7 @@ func TestInventory(t *testing.T) {
 		time.Sleep(2 * time.Second) //wait 2 sec to ensure cluster 4 exceeds the reconciliation timeout
 
 		//get clusters to reconcile
-		statesReconcile, err := inventory.ClustersToReconcile(1 * time.Second)
+		statesReconcile, err := inventory.ClustersToReconcile(1*time.Second, 0)
 		require.NoError(t, err)
 		require.Len(t, statesReconcile, 2)
 		require.ElementsMatch(t, []*State{expectedClusterState1v2v2, expectedClusterState4v2v2b}, statesReconcile)
@@ -277,6 +296,90 @@ func TestInventory(t *testing.T) {
 	})
 }
 
+func TestDeletionGracePeriod(t *testing.T) {
+	inventory := newInventory(t)
+
+	t.Run("Scheduled deletion starts after grace period", func(t *testing.T) {
+		cluster := newCluster(t, 1, 1, false)
+		clusterState, err := inventory.CreateOrUpdate(1, cluster)
+		require.NoError(t, err)
+		defer func() {
+			require.NoError(t, inventory.Delete(cluster.RuntimeID))
+		}()
+		_, err = inventory.UpdateStatus(clusterState, model.ClusterStatusReady)
+		require.NoError(t, err)
+
+		clusterState, err = inventory.MarkForDeletion(cluster.RuntimeID, false)
+		require.NoError(t, err)
+		require.Equal(t, model.ClusterStatusDeleteScheduled, clusterState.Status.Status)
+		require.False(t, clusterState.Status.DeletionForced)
+
+		//forcing a scheduled deletion doesn't reset the grace period
+		clusterStateForced, err := inventory.MarkForDeletion(cluster.RuntimeID, true)
+		require.NoError(t, err)
+		require.Equal(t, clusterState.Status.ID, clusterStateForced.Status.ID)
+		require.True(t, clusterStateForced.Status.DeletionForced)
+		clusterStateLatest, err := inventory.GetLatest(cluster.RuntimeID)
+		require.NoError(t, err)
+		require.Equal(t, clusterState.Status.ID, clusterStateLatest.Status.ID)
+		require.Equal(t, clusterState.Status.Created, clusterStateLatest.Status.Created)
+		require.True(t, clusterStateLatest.Status.DeletionForced)
+
+		//marking a scheduled cluster again doesn't reset the grace period or revoke the forced deletion
+		clusterStateAgain, err := inventory.MarkForDeletion(cluster.RuntimeID, false)
+		require.NoError(t, err)
+		require.Equal(t, clusterState.Status.ID, clusterStateAgain.Status.ID)
+		require.True(t, clusterStateAgain.Status.DeletionForced)
+
+		states, err := inventory.ClustersToReconcile(0, time.Hour)
+		require.NoError(t, err)
+		require.Empty(t, states)
+
+		time.Sleep(2 * time.Second) //wait 2 sec to ensure the grace period expires
+
+		states, err = inventory.ClustersToReconcile(0, 1*time.Second)
+		require.NoError(t, err)
+		require.Len(t, states, 1)
+		require.Equal(t, model.ClusterStatusDeleteScheduled, states[0].Status.Status)
+		require.True(t, states[0].Status.DeletionForced)
+	})
+
+	t.Run("Restore cluster", func(t *testing.T) {
+		cluster := newCluster(t, 1, 1, false)
+		clusterState, err := inventory.CreateOrUpdate(1, cluster)
+		require.NoError(t, err)
+		defer func() {
+			require.NoError(t, inventory.Delete(cluster.RuntimeID))
+		}()
+		_, err = inventory.UpdateStatus(clusterState, model.ClusterStatusReady)
+		require.NoError(t, err)
+
+		//not restorable if no deletion was scheduled
+		_, err = inventory.Restore(cluster.RuntimeID)
+		require.True(t, IsNotRestorableError(err))
+
+		_, err = inventory.MarkForDeletion(cluster.RuntimeID, false)
+		require.NoError(t, err)
+		clusterState, err = inventory.Restore(cluster.RuntimeID)
+		require.NoError(t, err)
+		require.Equal(t, model.ClusterStatusReconcilePending, clusterState.Status.Status)
+		require.False(t, clusterState.Status.DeletionForced)
+
+		//blocked deletions can be restored
+		clusterState, err = inventory.UpdateStatus(clusterState, model.ClusterStatusDeleteBlocked)
+		require.NoError(t, err)
+		clusterState, err = inventory.Restore(cluster.RuntimeID)
+		require.NoError(t, err)
+		require.Equal(t, model.ClusterStatusReconcilePending, clusterState.Status.Status)
+
+		//running deletions can't be restored
+		_, err = inventory.UpdateStatus(clusterState, model.ClusterStatusDeleting)
+		require.NoError(t, err)
+		_, err = inventory.Restore(cluster.RuntimeID)
+		require.True(t, IsNotRestorableError(err))
+	})
+}
+
 func TestCountRetries(t *testing.T) {
 	inventory := newInventory(t)
 
@@ -330,6 +433,14 @@ func listStatuses(states []*State) []model.Status {
 	return result
 }
 
+func listClusterStatuses(statuses []*model.ClusterStatusEntity) []model.Status {
+	var result []model.Status
+	for _, status := range statuses {
+		result = append(result, status.Status)
+	}
+	return result
+}
+
 func listStatusesForStatusChanges(states []*StatusChange) []model.Status {
 	var result []model.Status
 	for _, state := range states {
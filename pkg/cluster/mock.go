@@ -15,10 +15,12 @@ const envVarKubeconfig = "KUBECONFIG"
 type MockInventory struct {
 	ClustersToReconcileResult []*State
 	ClustersNotReadyResult    []*State
+	ClusterStatusesResult     []*model.ClusterStatusEntity
 	GetResult                 *State
 	GetLatestResult           *State
 	CreateOrUpdateResult      *State
 	MarkForDeletionResult     *State
+	RestoreResult             *State
 	DeleteResult              error
 	UpdateStatusResult        *State
 	ChangesResult             []*StatusChange
@@ -33,10 +35,14 @@ func (i *MockInventory) UpdateStatus(State *State, status model.Status) (*State,
 	return i.UpdateStatusResult, nil
 }
 
-func (i *MockInventory) MarkForDeletion(runtimeID string) (*State, error) {
+func (i *MockInventory) MarkForDeletion(runtimeID string, force bool) (*State, error) {
 	return i.MarkForDeletionResult, nil
 }
 
+func (i *MockInventory) Restore(runtimeID string) (*State, error) {
+	return i.RestoreResult, nil
+}
+
 func (i *MockInventory) Delete(runtimeID string) error {
 	return i.DeleteResult
 }
@@ -49,7 +55,7 @@ func (i *MockInventory) GetLatest(runtimeID string) (*State, error) {
 	return i.GetLatestResult, nil
 }
 
-func (i *MockInventory) ClustersToReconcile(reconcileInterval time.Duration) ([]*State, error) {
+func (i *MockInventory) ClustersToReconcile(reconcileInterval, deletionGracePeriod time.Duration) ([]*State, error) {
 	return i.ClustersToReconcileResult, nil
 }
 
@@ -57,6 +63,10 @@ func (i *MockInventory) ClustersNotReady() ([]*State, error) {
 	return i.ClustersNotReadyResult, nil
 }
 
+func (i *MockInventory) ClusterStatuses(statuses []model.Status, limit, offset int) ([]*model.ClusterStatusEntity, error) {
+	return i.ClusterStatusesResult, nil
+}
+
 func (i *MockInventory) StatusChanges(runtimeID string, offset time.Duration) ([]*StatusChange, error) {
 	return i.ChangesResult, nil
 }
@@ -57,3 +57,29 @@ func (rif *reconcileIntervalFilter) Filter(dbType db.Type, statusColHdr *db.Colu
 		return "", fmt.Errorf("database type '%s' is not supported by this filter", dbType)
 	}
 }
+
+//deletionGracePeriodFilter matches clusters which are scheduled for deletion and whose deletion grace period expired
+type deletionGracePeriodFilter struct {
+	gracePeriod time.Duration
+}
+
+func (dgf *deletionGracePeriodFilter) Filter(dbType db.Type, statusColHdr *db.ColumnHandler) (string, error) {
+	statusColName, err := statusColHdr.ColumnName("Status")
+	if err != nil {
+		return "", err
+	}
+	createdColName, err := statusColHdr.ColumnName("Created")
+	if err != nil {
+		return "", err
+	}
+	switch dbType {
+	case db.Postgres:
+		return fmt.Sprintf(`%s = '%s' AND %s <= NOW() - INTERVAL '%.0f SECOND'`,
+			statusColName, model.ClusterStatusDeleteScheduled, createdColName, dgf.gracePeriod.Seconds()), nil
+	case db.SQLite:
+		return fmt.Sprintf(`%s = '%s' AND %s <= DATETIME('now', '-%.0f SECONDS')`,
+			statusColName, model.ClusterStatusDeleteScheduled, createdColName, dgf.gracePeriod.Seconds()), nil
+	default:
+		return "", fmt.Errorf("database type '%s' is not supported by this filter", dbType)
+	}
+}
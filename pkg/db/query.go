@@ -205,6 +205,11 @@ func (s *Select) Limit(limit int) *Select {
 	return s
 }
 
+func (s *Select) Offset(offset int) *Select {
+	s.buffer.WriteString(fmt.Sprintf(" OFFSET %d", offset))
+	return s
+}
+
 func (s *Select) GetOne() (DatabaseEntity, error) {
 	if s.err != nil {
 		return nil, s.err
@@ -5,9 +5,12 @@ import "fmt"
 func ToStatus(in string) (Status, error) {
 
 	for _, status := range []Status{
+		StatusDeleteBlocked,
 		StatusDeleteError,
 		StatusDeleteErrorRetryable,
 		StatusDeletePending,
+		StatusDeletePreflight,
+		StatusDeleteScheduled,
 		StatusDeleted,
 		StatusDeleting,
 		StatusError,
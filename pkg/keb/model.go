@@ -1,5 +1,7 @@
 package keb
 
+import "strings"
+
 //ConfigurationAsMap flattens the list of configuration entities to a map.
 //Component struct is generated from OpenAPI.
 func (c Component) ConfigurationAsMap() map[string]interface{} {
@@ -9,3 +11,15 @@ func (c Component) ConfigurationAsMap() map[string]interface{} {
 	}
 	return result
 }
+
+//ResolveVersion returns the version used to reconcile the component: components from external GIT repositories
+//and components with an explicit version don't use the Kyma version.
+func (c Component) ResolveVersion(kymaVersion string) string {
+	if c.URL != "" && strings.HasSuffix(c.URL, ".git") {
+		return c.Version // ok even if it was empty: the workspace factory resolves the default branch
+	}
+	if c.Version != "" {
+		return c.Version
+	}
+	return kymaVersion
+}
@@ -9,12 +9,18 @@ import (
 
 // Defines values for Status.
 const (
+	StatusDeleteBlocked Status = "delete_blocked"
+
 	StatusDeleteError Status = "delete_error"
 
 	StatusDeleteErrorRetryable Status = "delete_error_retryable"
 
 	StatusDeletePending Status = "delete_pending"
 
+	StatusDeletePreflight Status = "delete_preflight"
+
+	StatusDeleteScheduled Status = "delete_scheduled"
+
 	StatusDeleted Status = "deleted"
 
 	StatusDeleting Status = "deleting"
@@ -35,14 +41,19 @@ const (
 // HTTPClusterConfig defines model for HTTPClusterConfig.
 type HTTPClusterConfig KymaConfig
 
+// HTTPClusterListResponse defines model for HTTPClusterListResponse.
+type HTTPClusterListResponse []HTTPClusterResponse
+
 // HTTPClusterResponse defines model for HTTPClusterResponse.
 type HTTPClusterResponse struct {
 	Cluster              string     `json:"cluster"`
 	ClusterVersion       int64      `json:"clusterVersion"`
 	ConfigurationVersion int64      `json:"configurationVersion"`
 	Failures             *[]Failure `json:"failures,omitempty"`
-	Status               Status     `json:"status"`
-	StatusURL            string     `json:"statusURL"`
+
+	// Status of a cluster. A deleted cluster is in status 'delete_scheduled' until the deletion grace period of the mothership reconciler expired (it can be restored in the meantime, a grace period of 0 starts the deletion immediately). Afterwards the preflight checks of the components run ('delete_preflight'): the deletion is either blocked ('delete_blocked') or starts ('delete_pending', 'deleting').
+	Status    Status `json:"status"`
+	StatusURL string `json:"statusURL"`
 }
 
 // HTTPClusterStatusResponse defines model for HTTPClusterStatusResponse.
@@ -66,8 +77,16 @@ type HTTPReconciliationInfo struct {
 	Operations    []Operation `json:"operations"`
 	RuntimeID     string      `json:"runtimeID"`
 	SchedulingID  string      `json:"schedulingID"`
-	Status        Status      `json:"status"`
-	Updated       time.Time   `json:"updated"`
+
+	// Status of a cluster. A deleted cluster is in status 'delete_scheduled' until the deletion grace period of the mothership reconciler expired (it can be restored in the meantime, a grace period of 0 starts the deletion immediately). Afterwards the preflight checks of the components run ('delete_preflight'): the deletion is either blocked ('delete_blocked') or starts ('delete_pending', 'deleting').
+	Status  Status    `json:"status"`
+	Updated time.Time `json:"updated"`
+}
+
+// HTTPValuesValidationErrorResponse defines model for HTTPValuesValidationErrorResponse.
+type HTTPValuesValidationErrorResponse struct {
+	Components *[]ComponentValuesErrors `json:"components,omitempty"`
+	Error      string                   `json:"error"`
 }
 
 // Cluster defines model for cluster.
@@ -89,6 +108,12 @@ type Component struct {
 	Version       string          `json:"version"`
 }
 
+// ComponentValuesErrors defines model for componentValuesErrors.
+type ComponentValuesErrors struct {
+	Component string        `json:"component"`
+	Errors    []ValuesError `json:"errors"`
+}
+
 // Configuration defines model for configuration.
 type Configuration struct {
 	Key    string      `json:"key"`
@@ -153,8 +178,10 @@ type Reconciliation struct {
 	Lock         string    `json:"lock"`
 	RuntimeID    string    `json:"runtimeID"`
 	SchedulingID string    `json:"schedulingID"`
-	Status       Status    `json:"status"`
-	Updated      time.Time `json:"updated"`
+
+	// Status of a cluster. A deleted cluster is in status 'delete_scheduled' until the deletion grace period of the mothership reconciler expired (it can be restored in the meantime, a grace period of 0 starts the deletion immediately). Afterwards the preflight checks of the components run ('delete_preflight'): the deletion is either blocked ('delete_blocked') or starts ('delete_pending', 'deleting').
+	Status  Status    `json:"status"`
+	Updated time.Time `json:"updated"`
 }
 
 // RuntimeInput defines model for runtimeInput.
@@ -163,24 +190,39 @@ type RuntimeInput struct {
 	Name        string `json:"name"`
 }
 
-// Status defines model for status.
+// Status of a cluster. A deleted cluster is in status 'delete_scheduled' until the deletion grace period of the mothership reconciler expired (it can be restored in the meantime, a grace period of 0 starts the deletion immediately). Afterwards the preflight checks of the components run ('delete_preflight'): the deletion is either blocked ('delete_blocked') or starts ('delete_pending', 'deleting').
 type Status string
 
 // StatusChange defines model for statusChange.
 type StatusChange struct {
 	Duration int64     `json:"duration"`
 	Started  time.Time `json:"started"`
-	Status   Status    `json:"status"`
+
+	// Status of a cluster. A deleted cluster is in status 'delete_scheduled' until the deletion grace period of the mothership reconciler expired (it can be restored in the meantime, a grace period of 0 starts the deletion immediately). Afterwards the preflight checks of the components run ('delete_preflight'): the deletion is either blocked ('delete_blocked') or starts ('delete_pending', 'deleting').
+	Status Status `json:"status"`
 }
 
 // StatusUpdate defines model for statusUpdate.
 type StatusUpdate struct {
+	// Status of a cluster. A deleted cluster is in status 'delete_scheduled' until the deletion grace period of the mothership reconciler expired (it can be restored in the meantime, a grace period of 0 starts the deletion immediately). Afterwards the preflight checks of the components run ('delete_preflight'): the deletion is either blocked ('delete_blocked') or starts ('delete_pending', 'deleting').
 	Status Status `json:"status"`
 }
 
+// ValuesError defines model for valuesError.
+type ValuesError struct {
+	Key     string `json:"key"`
+	Message string `json:"message"`
+}
+
 // BadRequest defines model for BadRequest.
 type BadRequest HTTPErrorResponse
 
+// ClusterBadRequest defines model for ClusterBadRequest.
+type ClusterBadRequest HTTPValuesValidationErrorResponse
+
+// ClusterListOKResponse defines model for ClusterListOKResponse.
+type ClusterListOKResponse HTTPClusterListResponse
+
 // InternalError defines model for InternalError.
 type InternalError HTTPErrorResponse
 
@@ -196,18 +238,40 @@ type ReconcilationsOKResponse HTTPReconcilerStatus
 // ReconciliationInfoOKResponse defines model for ReconciliationInfoOKResponse.
 type ReconciliationInfoOKResponse HTTPReconciliationInfo
 
+// ServiceUnavailable defines model for ServiceUnavailable.
+type ServiceUnavailable HTTPErrorResponse
+
 // ConfigurationOkResponse defines model for configurationOkResponse.
 type ConfigurationOkResponse HTTPClusterConfig
 
+// GetClustersParams defines parameters for GetClusters.
+type GetClustersParams struct {
+	Status *[]Status `json:"status,omitempty"`
+	Limit  *int      `json:"limit,omitempty"`
+	Offset *int      `json:"offset,omitempty"`
+}
+
 // PostClustersJSONBody defines parameters for PostClusters.
 type PostClustersJSONBody Cluster
 
 // PutClustersJSONBody defines parameters for PutClusters.
 type PutClustersJSONBody Cluster
 
+// DeleteClustersRuntimeIDParams defines parameters for DeleteClustersRuntimeID.
+type DeleteClustersRuntimeIDParams struct {
+	// Skip the preflight checks of the components (e.g. checks for customer workloads or volumes)
+	Force *bool `json:"force,omitempty"`
+}
+
 // PutClustersRuntimeIDStatusJSONBody defines parameters for PutClustersRuntimeIDStatus.
 type PutClustersRuntimeIDStatusJSONBody StatusUpdate
 
+// GetClustersRuntimeIDStatusChangesParams defines parameters for GetClustersRuntimeIDStatusChanges.
+type GetClustersRuntimeIDStatusChangesParams struct {
+	// Only return status changes which happened within this duration (e.g. '24h')
+	Offset *string `json:"offset,omitempty"`
+}
+
 // PostOperationsSchedulingIDCorrelationIDStopJSONBody defines parameters for PostOperationsSchedulingIDCorrelationIDStop.
 type PostOperationsSchedulingIDCorrelationIDStopJSONBody OperationStop
 
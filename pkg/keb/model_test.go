@@ -25,4 +25,11 @@ func TestContract(t *testing.T) {
 			"test2": "value2",
 		}, comp.ConfigurationAsMap())
 	})
+	t.Run("Resolve version", func(t *testing.T) {
+		require.Equal(t, "1.2.3", Component{}.ResolveVersion("1.2.3"))
+		require.Equal(t, "2.0.0", Component{Version: "2.0.0"}.ResolveVersion("1.2.3"))
+		require.Equal(t, "2.0.0", Component{URL: "https://example.com/comp.tgz", Version: "2.0.0"}.ResolveVersion("1.2.3"))
+		require.Equal(t, "", Component{URL: "https://github.com/kyma-project/kyma.git"}.ResolveVersion("1.2.3"))
+		require.Equal(t, "main", Component{URL: "https://github.com/kyma-project/kyma.git", Version: "main"}.ResolveVersion("1.2.3"))
+	})
 }
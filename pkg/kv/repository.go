@@ -6,6 +6,7 @@ import (
 	"github.com/kyma-incubator/reconciler/pkg/db"
 	"github.com/kyma-incubator/reconciler/pkg/model"
 	"github.com/kyma-incubator/reconciler/pkg/repository"
+	"github.com/pkg/errors"
 )
 
 type Repository struct {
@@ -217,7 +218,7 @@ func (cer *Repository) ValuesByBucket(bucket string) ([]*model.ValueEntity, erro
 	for _, entity := range entities {
 		result = append(result, entity.(*model.ValueEntity))
 	}
-	return result, nil
+	return result, cer.decryptValues(result...)
 }
 
 func (cer *Repository) ValuesByKey(key *model.KeyEntity) ([]*model.ValueEntity, error) {
@@ -264,7 +265,7 @@ func (cer *Repository) ValuesByKey(key *model.KeyEntity) ([]*model.ValueEntity,
 	for _, entity := range entities {
 		result = append(result, entity.(*model.ValueEntity))
 	}
-	return result, nil
+	return result, cer.decryptValues(result...)
 }
 
 func (cer *Repository) ValueHistory(bucket, key string) ([]*model.ValueEntity, error) {
@@ -285,7 +286,7 @@ func (cer *Repository) ValueHistory(bucket, key string) ([]*model.ValueEntity, e
 	for _, entity := range entities {
 		result = append(result, entity.(*model.ValueEntity))
 	}
-	return result, nil
+	return result, cer.decryptValues(result...)
 }
 
 func (cer *Repository) LatestValue(bucket, key string) (*model.ValueEntity, error) {
@@ -302,7 +303,8 @@ func (cer *Repository) LatestValue(bucket, key string) (*model.ValueEntity, erro
 	if err != nil {
 		return nil, cer.NewNotFoundError(err, &model.ValueEntity{}, whereCond)
 	}
-	return entity.(*model.ValueEntity), nil
+	value := entity.(*model.ValueEntity)
+	return value, cer.decryptValues(value)
 }
 
 func (cer *Repository) Value(bucket, key string, version int64) (*model.ValueEntity, error) {
@@ -317,7 +319,8 @@ func (cer *Repository) Value(bucket, key string, version int64) (*model.ValueEnt
 	if err != nil {
 		return nil, cer.NewNotFoundError(err, &model.ValueEntity{}, whereCond)
 	}
-	return entity.(*model.ValueEntity), nil
+	value := entity.(*model.ValueEntity)
+	return value, cer.decryptValues(value)
 }
 
 func (cer *Repository) CreateValue(value *model.ValueEntity) (*model.ValueEntity, error) {
@@ -351,12 +354,26 @@ func (cer *Repository) CreateValue(value *model.ValueEntity) (*model.ValueEntity
 
 	//insert operation
 	dbOps := func() (interface{}, error) {
+		//values of encrypted keys are only stored encrypted
+		plainValue := value.Value
+		value.Encrypted = key.Encrypted
+		if key.Encrypted {
+			encValue, err := cer.Conn.Encryptor().Encrypt(value.Value)
+			if err != nil {
+				return nil, errors.Wrap(err, fmt.Sprintf("failed to encrypt value of key '%s'", key.Key))
+			}
+			value.Value = encValue
+		}
+
 		//add value entity
 		q, err := db.NewQuery(cer.Conn, value, cer.Logger)
 		if err != nil {
+			value.Value = plainValue
 			return nil, err
 		}
-		valueEntity, err := value, q.Insert().Exec()
+		err = q.Insert().Exec()
+		value.Value = plainValue //return the plain value to the caller
+		valueEntity := value
 		if err != nil {
 			return valueEntity, err
 		}
@@ -379,6 +396,103 @@ func (cer *Repository) CreateValue(value *model.ValueEntity) (*model.ValueEntity
 	return valueEntity, err
 }
 
+// EncryptValues encrypts all values of encrypted keys which are still stored in plaintext
+// (e.g. because they were created before the encryption of values was supported).
+// It returns the number of encrypted values. If dryRun is true, the values are only counted.
+func (cer *Repository) EncryptValues(dryRun bool) (int, error) {
+	var cnt int
+	dbOps := func() error {
+		q, err := db.NewQuery(cer.Conn, &model.KeyEntity{}, cer.Logger)
+		if err != nil {
+			return err
+		}
+		keyEntities, err := q.Select().
+			OrderBy(map[string]string{"Version": "ASC"}).
+			GetMany()
+		if err != nil {
+			return err
+		}
+
+		for _, keyEntity := range keyEntities {
+			key := keyEntity.(*model.KeyEntity)
+			if !key.Encrypted {
+				continue
+			}
+			qValues, err := db.NewQuery(cer.Conn, &model.ValueEntity{}, cer.Logger)
+			if err != nil {
+				return err
+			}
+			valueEntities, err := qValues.Select().
+				Where(map[string]interface{}{"Key": key.Key, "KeyVersion": key.Version}).
+				OrderBy(map[string]string{"Version": "ASC"}).
+				GetMany()
+			if err != nil {
+				return err
+			}
+
+			for _, valueEntity := range valueEntities {
+				value := valueEntity.(*model.ValueEntity)
+				if value.Encrypted {
+					continue
+				}
+				cnt++
+				if dryRun {
+					cer.Logger.Infof("Value of key '%s' (key version %d) in bucket '%s' (version %d) is not encrypted",
+						value.Key, value.KeyVersion, value.Bucket, value.Version)
+					continue
+				}
+				if err := cer.encryptValue(value); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+	return cnt, cer.Transactional(dbOps)
+}
+
+func (cer *Repository) encryptValue(value *model.ValueEntity) error {
+	encValue, err := cer.Conn.Encryptor().Encrypt(value.Value)
+	if err != nil {
+		return errors.Wrap(err, fmt.Sprintf("failed to encrypt value of key '%s'", value.Key))
+	}
+	value.Value = encValue
+	value.Encrypted = true
+
+	q, err := db.NewQuery(cer.Conn, value, cer.Logger)
+	if err != nil {
+		return err
+	}
+	cnt, err := q.Update().
+		Where(map[string]interface{}{"Version": value.Version}).
+		ExecCount()
+	if err != nil {
+		return err
+	}
+	if cnt != 1 {
+		return fmt.Errorf("failed to encrypt value of key '%s' in bucket '%s' (version %d): %d rows were updated",
+			value.Key, value.Bucket, value.Version, cnt)
+	}
+	return nil
+}
+
+// decryptValues decrypts the values which are stored encrypted. A value which can't be decrypted (e.g. because
+// the encryption key was changed) fails the read.
+func (cer *Repository) decryptValues(values ...*model.ValueEntity) error {
+	for _, value := range values {
+		if !value.Encrypted {
+			continue
+		}
+		decValue, err := cer.Conn.Encryptor().Decrypt(value.Value)
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("failed to decrypt value of key '%s' in bucket '%s' (version %d)",
+				value.Key, value.Bucket, value.Version))
+		}
+		value.Value = decValue
+	}
+	return nil
+}
+
 func (cer *Repository) DeleteValue(key, bucket string) error {
 	//bundle DB operations
 	dbOps := func() error {
@@ -336,6 +336,121 @@ func TestRepositoryValues(t *testing.T) {
 	})
 }
 
+func TestRepositoryEncryptedValues(t *testing.T) {
+	ceRepo := newKeyValueRepo(t)
+
+	//create encrypted test key
+	keyEntity, err := ceRepo.CreateKey(&model.KeyEntity{
+		Key:       fmt.Sprintf("testEncKey%d", time.Now().UnixNano()),
+		DataType:  model.String,
+		Encrypted: true,
+		Username:  "testUsername",
+	})
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, ceRepo.DeleteKey(keyEntity.Key))
+	}()
+
+	t.Run("Value is stored encrypted", func(t *testing.T) {
+		valueEntity, err := ceRepo.CreateValue(&model.ValueEntity{
+			Key:        keyEntity.Key,
+			KeyVersion: keyEntity.Version,
+			Bucket:     "test-enc-bucket1",
+			DataType:   model.String,
+			Username:   "testUsername",
+			Value:      "secret-value",
+		})
+		require.NoError(t, err)
+		require.Equal(t, "secret-value", valueEntity.Value)
+
+		storedValue := rawValue(t, ceRepo, valueEntity.Version)
+		require.NotEqual(t, "secret-value", storedValue.Value)
+		require.True(t, storedValue.Encrypted)
+
+		latestValue, err := ceRepo.LatestValue("test-enc-bucket1", keyEntity.Key)
+		require.NoError(t, err)
+		require.Equal(t, "secret-value", latestValue.Value)
+
+		//creating the same value again doesn't create a new version
+		sameValue, err := ceRepo.CreateValue(latestValue)
+		require.NoError(t, err)
+		require.Equal(t, latestValue.Version, sameValue.Version)
+	})
+
+	t.Run("Encrypt plaintext values", func(t *testing.T) {
+		//store a plaintext value by bypassing the repository
+		plainValue := &model.ValueEntity{
+			Key:        keyEntity.Key,
+			KeyVersion: keyEntity.Version,
+			Bucket:     "test-enc-bucket2",
+			DataType:   model.String,
+			Username:   "testUsername",
+			Value:      "plain-value",
+		}
+		q, err := db.NewQuery(ceRepo.Conn, plainValue, ceRepo.Logger)
+		require.NoError(t, err)
+		require.NoError(t, q.Insert().Exec())
+
+		//plaintext values are returned as they are
+		valueEntity, err := ceRepo.LatestValue("test-enc-bucket2", keyEntity.Key)
+		require.NoError(t, err)
+		require.Equal(t, "plain-value", valueEntity.Value)
+
+		//dry run doesn't change anything
+		cnt, err := ceRepo.EncryptValues(true)
+		require.NoError(t, err)
+		require.Equal(t, 1, cnt)
+		require.Equal(t, "plain-value", rawValue(t, ceRepo, plainValue.Version).Value)
+
+		cnt, err = ceRepo.EncryptValues(false)
+		require.NoError(t, err)
+		require.Equal(t, 1, cnt)
+		storedValue := rawValue(t, ceRepo, plainValue.Version)
+		require.NotEqual(t, "plain-value", storedValue.Value)
+		require.True(t, storedValue.Encrypted)
+
+		valueEntity, err = ceRepo.LatestValue("test-enc-bucket2", keyEntity.Key)
+		require.NoError(t, err)
+		require.Equal(t, "plain-value", valueEntity.Value)
+
+		//nothing left to encrypt
+		cnt, err = ceRepo.EncryptValues(false)
+		require.NoError(t, err)
+		require.Equal(t, 0, cnt)
+	})
+
+	t.Run("Fail on values which can't be decrypted", func(t *testing.T) {
+		//store a value which is marked as encrypted but isn't decryptable by bypassing the repository
+		brokenValue := &model.ValueEntity{
+			Key:        keyEntity.Key,
+			KeyVersion: keyEntity.Version,
+			Bucket:     "test-enc-bucket3",
+			DataType:   model.String,
+			Username:   "testUsername",
+			Value:      "not-encrypted",
+			Encrypted:  true,
+		}
+		q, err := db.NewQuery(ceRepo.Conn, brokenValue, ceRepo.Logger)
+		require.NoError(t, err)
+		require.NoError(t, q.Insert().Exec())
+
+		_, err = ceRepo.LatestValue("test-enc-bucket3", keyEntity.Key)
+		require.Error(t, err)
+		_, err = ceRepo.ValuesByBucket("test-enc-bucket3")
+		require.Error(t, err)
+	})
+}
+
+func rawValue(t *testing.T, ceRepo *Repository, version int64) *model.ValueEntity {
+	q, err := db.NewQuery(ceRepo.Conn, &model.ValueEntity{}, ceRepo.Logger)
+	require.NoError(t, err)
+	entity, err := q.Select().
+		Where(map[string]interface{}{"Version": version}).
+		GetOne()
+	require.NoError(t, err)
+	return entity.(*model.ValueEntity)
+}
+
 func newKeyValueRepo(t *testing.T) *Repository {
 	ceRepo, err := NewRepository(db.NewTestConnection(t), true)
 	require.NoError(t, err)
@@ -37,7 +37,7 @@ func (c *ReconciliationWaitingCollector) Collect(ch chan<- prometheus.Metric) {
 		return
 	}
 
-	clusters, err := c.inventory.ClustersToReconcile(0)
+	clusters, err := c.inventory.ClustersToReconcile(0, 0)
 	if err != nil {
 		c.logger.Error(err.Error())
 		return
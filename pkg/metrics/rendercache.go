@@ -0,0 +1,77 @@
+package metrics
+
+import (
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/chart"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	tierMemory = "memory"
+	tierDisk   = "disk"
+)
+
+// RenderCacheCollector provides the statistics of the render cache of the component reconciler:
+// - reconciler_render_cache_hits_total - renderings served from the cache (per tier)
+// - reconciler_render_cache_misses_total - renderings which weren't cached
+// - reconciler_render_cache_uncacheable_total - renderings of charts using non-deterministic template functions
+// - reconciler_render_cache_entries - number of cached renderings (per tier)
+// - reconciler_render_cache_size_bytes - size of the cached renderings (per tier)
+type RenderCacheCollector struct {
+	stats func() *chart.RenderCacheStats
+
+	hitsDesc        *prometheus.Desc
+	missesDesc      *prometheus.Desc
+	uncacheableDesc *prometheus.Desc
+	entriesDesc     *prometheus.Desc
+	sizeDesc        *prometheus.Desc
+}
+
+func NewRenderCacheCollector(stats func() *chart.RenderCacheStats) *RenderCacheCollector {
+	return &RenderCacheCollector{
+		stats: stats,
+		hitsDesc: prometheus.NewDesc(prometheus.BuildFQName("", prometheusSubsystem, "render_cache_hits_total"),
+			"Number of chart renderings served from the render cache",
+			[]string{"tier"},
+			nil),
+		missesDesc: prometheus.NewDesc(prometheus.BuildFQName("", prometheusSubsystem, "render_cache_misses_total"),
+			"Number of chart renderings which weren't found in the render cache",
+			[]string{},
+			nil),
+		uncacheableDesc: prometheus.NewDesc(prometheus.BuildFQName("", prometheusSubsystem, "render_cache_uncacheable_total"),
+			"Number of chart renderings which weren't cached because the chart uses non-deterministic template functions",
+			[]string{},
+			nil),
+		entriesDesc: prometheus.NewDesc(prometheus.BuildFQName("", prometheusSubsystem, "render_cache_entries"),
+			"Number of chart renderings stored in the render cache",
+			[]string{"tier"},
+			nil),
+		sizeDesc: prometheus.NewDesc(prometheus.BuildFQName("", prometheusSubsystem, "render_cache_size_bytes"),
+			"Size of the chart renderings stored in the render cache",
+			[]string{"tier"},
+			nil),
+	}
+}
+
+func (c *RenderCacheCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.hitsDesc
+	ch <- c.missesDesc
+	ch <- c.uncacheableDesc
+	ch <- c.entriesDesc
+	ch <- c.sizeDesc
+}
+
+// Collect implements the prometheus.Collector interface.
+func (c *RenderCacheCollector) Collect(ch chan<- prometheus.Metric) {
+	stats := c.stats()
+	if stats == nil { //render cache is disabled
+		return
+	}
+	ch <- prometheus.MustNewConstMetric(c.hitsDesc, prometheus.CounterValue, float64(stats.MemoryHits), tierMemory)
+	ch <- prometheus.MustNewConstMetric(c.hitsDesc, prometheus.CounterValue, float64(stats.DiskHits), tierDisk)
+	ch <- prometheus.MustNewConstMetric(c.missesDesc, prometheus.CounterValue, float64(stats.Misses))
+	ch <- prometheus.MustNewConstMetric(c.uncacheableDesc, prometheus.CounterValue, float64(stats.Uncacheable))
+	ch <- prometheus.MustNewConstMetric(c.entriesDesc, prometheus.GaugeValue, float64(stats.MemoryEntries), tierMemory)
+	ch <- prometheus.MustNewConstMetric(c.entriesDesc, prometheus.GaugeValue, float64(stats.DiskEntries), tierDisk)
+	ch <- prometheus.MustNewConstMetric(c.sizeDesc, prometheus.GaugeValue, float64(stats.MemorySize), tierMemory)
+	ch <- prometheus.MustNewConstMetric(c.sizeDesc, prometheus.GaugeValue, float64(stats.DiskSize), tierDisk)
+}
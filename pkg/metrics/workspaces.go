@@ -0,0 +1,53 @@
+package metrics
+
+import (
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/chart"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// WorkspaceCollector provides the workspace statistics of the last garbage collection run:
+// - reconciler_workspaces_total - number of workspaces cached by the component reconciler
+// - reconciler_workspaces_size_bytes - disk usage of all cached workspaces
+// - reconciler_workspaces_in_use_total - number of workspaces used by running reconciliations
+type WorkspaceCollector struct {
+	stats func() *chart.WorkspaceStats
+
+	countDesc *prometheus.Desc
+	sizeDesc  *prometheus.Desc
+	inUseDesc *prometheus.Desc
+}
+
+func NewWorkspaceCollector(stats func() *chart.WorkspaceStats) *WorkspaceCollector {
+	return &WorkspaceCollector{
+		stats: stats,
+		countDesc: prometheus.NewDesc(prometheus.BuildFQName("", prometheusSubsystem, "workspaces_total"),
+			"Number of workspaces cached by the component reconciler",
+			[]string{},
+			nil),
+		sizeDesc: prometheus.NewDesc(prometheus.BuildFQName("", prometheusSubsystem, "workspaces_size_bytes"),
+			"Disk usage of all workspaces cached by the component reconciler",
+			[]string{},
+			nil),
+		inUseDesc: prometheus.NewDesc(prometheus.BuildFQName("", prometheusSubsystem, "workspaces_in_use_total"),
+			"Number of workspaces used by running reconciliations",
+			[]string{},
+			nil),
+	}
+}
+
+func (c *WorkspaceCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.countDesc
+	ch <- c.sizeDesc
+	ch <- c.inUseDesc
+}
+
+// Collect implements the prometheus.Collector interface.
+func (c *WorkspaceCollector) Collect(ch chan<- prometheus.Metric) {
+	stats := c.stats()
+	if stats == nil { //garbage collection didn't run yet
+		return
+	}
+	ch <- prometheus.MustNewConstMetric(c.countDesc, prometheus.GaugeValue, float64(stats.Count))
+	ch <- prometheus.MustNewConstMetric(c.sizeDesc, prometheus.GaugeValue, float64(stats.Size))
+	ch <- prometheus.MustNewConstMetric(c.inUseDesc, prometheus.GaugeValue, float64(stats.InUse))
+}
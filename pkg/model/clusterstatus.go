@@ -7,6 +7,9 @@ import (
 type Status string
 
 const (
+	ClusterStatusDeleteScheduled         Status = "delete_scheduled"
+	ClusterStatusDeletePreflight         Status = "delete_preflight"
+	ClusterStatusDeleteBlocked           Status = "delete_blocked"
 	ClusterStatusDeletePending           Status = "delete_pending"
 	ClusterStatusDeleting                Status = "deleting"
 	ClusterStatusDeleteError             Status = "delete_error"
@@ -28,16 +31,37 @@ func (s Status) IsDeleteCandidate() bool {
 	return s == ClusterStatusDeletePending || s == ClusterStatusDeleteErrorRetryable
 }
 
+//IsDeleteScheduled indicates that the deletion of the cluster is in its grace period
+func (s Status) IsDeleteScheduled() bool {
+	return s == ClusterStatusDeleteScheduled
+}
+
+//IsRestorable indicates that the deletion of the cluster can be revoked (Kyma wasn't touched so far)
+func (s Status) IsRestorable() bool {
+	return s == ClusterStatusDeleteScheduled || s == ClusterStatusDeleteBlocked
+}
+
 func (s Status) IsReconcileCandidate() bool {
 	return s == ClusterStatusReconcilePending || s == ClusterStatusReady || s == ClusterStatusReconcileErrorRetryable
 }
 
 func (s Status) IsFinal() bool {
-	return s == ClusterStatusReady || s == ClusterStatusReconcileError || s == ClusterStatusDeleted || s == ClusterStatusDeleteError || s == ClusterStatusReconcileErrorRetryable || s == ClusterStatusDeleteErrorRetryable
+	return s == ClusterStatusReady || s == ClusterStatusReconcileError || s == ClusterStatusDeleted || s == ClusterStatusDeleteError || s == ClusterStatusReconcileErrorRetryable || s == ClusterStatusDeleteErrorRetryable || s == ClusterStatusDeleteBlocked
 }
 
 func (s Status) IsInProgress() bool {
-	return s == ClusterStatusDeleting || s == ClusterStatusReconciling
+	return s == ClusterStatusDeleting || s == ClusterStatusReconciling || s == ClusterStatusDeletePreflight
+}
+
+//OperationType returns the type of the operations which are processed for a cluster in this status
+func (s Status) OperationType() OperationType {
+	if s == ClusterStatusDeletePreflight {
+		return OperationTypePreflight
+	}
+	if s.IsDeletion() {
+		return OperationTypeDelete
+	}
+	return OperationTypeReconcile
 }
 
 func (s Status) IsDisabled() bool {
@@ -78,6 +102,12 @@ func NewClusterStatus(status Status) (*ClusterStatus, error) {
 		clusterStatus.ID = 9
 	case ClusterStatusDeleteErrorRetryable:
 		clusterStatus.ID = 10
+	case ClusterStatusDeleteScheduled:
+		clusterStatus.ID = 11
+	case ClusterStatusDeletePreflight:
+		clusterStatus.ID = 12
+	case ClusterStatusDeleteBlocked:
+		clusterStatus.ID = 13
 	default:
 		return clusterStatus, fmt.Errorf("ClusterStatus '%s' is unknown", status)
 	}
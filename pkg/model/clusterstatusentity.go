@@ -17,6 +17,7 @@ type ClusterStatusEntity struct {
 	ConfigVersion  int64     `db:"notNull"` // Cluster config entity primary key
 	Status         Status    `db:"notNull"`
 	Deleted        bool      `db:"notNull"`
+	DeletionForced bool      `db:"notNull"` //deletion of the cluster skips the preflight checks of the components
 	Created        time.Time `db:"readOnly"`
 }
 
@@ -52,7 +53,8 @@ func (c *ClusterStatusEntity) Equal(other db.DatabaseEntity) bool {
 	}
 	otherClProp, ok := other.(*ClusterStatusEntity)
 	if ok {
-		return c.ConfigVersion == otherClProp.ConfigVersion && c.Status == otherClProp.Status
+		return c.ConfigVersion == otherClProp.ConfigVersion && c.Status == otherClProp.Status &&
+			c.DeletionForced == otherClProp.DeletionForced
 	}
 	return false
 }
@@ -75,6 +77,15 @@ func (c *ClusterStatusEntity) GetKEBClusterStatus() (keb.Status, error) {
 
 	case ClusterStatusReconcileError:
 		kebStatus = keb.StatusError
+	case ClusterStatusDeleteScheduled:
+		kebStatus = keb.StatusDeleteScheduled
+
+	case ClusterStatusDeletePreflight:
+		kebStatus = keb.StatusDeletePreflight
+
+	case ClusterStatusDeleteBlocked:
+		kebStatus = keb.StatusDeleteBlocked
+
 	case ClusterStatusDeletePending:
 		kebStatus = keb.StatusDeletePending
 
@@ -10,6 +10,8 @@ type OperationType string
 const (
 	OperationTypeReconcile OperationType = "reconcile"
 	OperationTypeDelete    OperationType = "delete"
+	//OperationTypePreflight verifies that a component can be deleted without losing customer data or workloads
+	OperationTypePreflight OperationType = "preflight"
 )
 
 func NewOperationType(state string) (OperationType, error) {
@@ -19,6 +21,8 @@ func NewOperationType(state string) (OperationType, error) {
 		result = OperationTypeReconcile
 	case string(OperationTypeDelete):
 		result = OperationTypeDelete
+	case string(OperationTypePreflight):
+		result = OperationTypePreflight
 	default:
 		return "", fmt.Errorf("operation state '%s' does not exist", state)
 	}
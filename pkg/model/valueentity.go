@@ -15,6 +15,7 @@ type ValueEntity struct {
 	Version    int64     `db:"readOnly"`
 	Bucket     string    `db:"notNull"`
 	Value      string    `db:"notNull"`
+	Encrypted  bool      `db:"notNull"` //value is stored encrypted (values are decrypted when they are read)
 	DataType   DataType  `db:"notNull"`
 	Created    time.Time `db:"readOnly"`
 	Username   string    `db:"notNull"`
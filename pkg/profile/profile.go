@@ -0,0 +1,170 @@
+package profile
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/imdario/mergo"
+	"github.com/pkg/errors"
+)
+
+var namePattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// Profile is a custom Kyma profile defined outside the charts. Its values are layered on top of the values of
+// its base profile, which is either a profile shipped with the charts (e.g. 'evaluation') or another custom profile.
+type Profile struct {
+	Name       string                            `json:"-"`
+	Base       string                            `json:"base"`                 //chart profile or custom profile the values are applied to
+	Values     map[string]interface{}            `json:"values,omitempty"`     //values applied to all components (keys in dot-notation)
+	Components map[string]map[string]interface{} `json:"components,omitempty"` //values applied to individual components
+}
+
+func (p *Profile) validate() error {
+	if !namePattern.MatchString(p.Name) {
+		return fmt.Errorf("name of custom profile '%s' is invalid: it has to match the pattern '%s'", p.Name, namePattern)
+	}
+	if strings.TrimSpace(p.Base) == "" {
+		return fmt.Errorf("custom profile '%s' has no base profile", p.Name)
+	}
+	return nil
+}
+
+// Source provides custom profiles. It returns nil if the source doesn't define a profile with the given name.
+type Source interface {
+	Get(name string) (*Profile, error)
+}
+
+// Resolver looks up custom profiles in its sources (the first source defining a profile wins)
+type Resolver struct {
+	sources []Source
+}
+
+func NewResolver(sources ...Source) *Resolver {
+	return &Resolver{sources: sources}
+}
+
+// Resolve follows the chain of base profiles of the given profile until a profile is reached which isn't a custom
+// profile (or which is its own base): this is the profile which is looked up in the charts.
+// A nil resolver treats all profiles as chart profiles.
+func (r *Resolver) Resolve(name string) (*Resolved, error) {
+	resolved := &Resolved{Name: name, ChartProfile: name}
+	if r == nil || name == "" {
+		return resolved, nil
+	}
+
+	visited := make(map[string]bool)
+	for {
+		profile, err := r.lookup(resolved.ChartProfile)
+		if err != nil {
+			return nil, err
+		}
+		if profile == nil {
+			return resolved, nil
+		}
+		if visited[profile.Name] {
+			return nil, fmt.Errorf("custom profile '%s' has a cyclic chain of base profiles", name)
+		}
+		visited[profile.Name] = true
+		//layers are ordered from the base profile to the requested profile
+		resolved.layers = append([]*Profile{profile}, resolved.layers...)
+		resolved.ChartProfile = profile.Base
+		if profile.Base == profile.Name {
+			return resolved, nil //custom profile overrides the chart profile with the same name
+		}
+	}
+}
+
+func (r *Resolver) lookup(name string) (*Profile, error) {
+	for _, source := range r.sources {
+		profile, err := source.Get(name)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to retrieve custom profile '%s'", name)
+		}
+		if profile != nil {
+			profile.Name = name
+			if err := profile.validate(); err != nil {
+				return nil, err
+			}
+			return profile, nil
+		}
+	}
+	return nil, nil
+}
+
+// Resolved is a profile whose chain of custom profiles was resolved
+type Resolved struct {
+	Name         string //name of the requested profile
+	ChartProfile string //profile which is looked up in the charts
+	layers       []*Profile
+}
+
+// IsCustom returns true if the requested profile is a custom profile
+func (r *Resolved) IsCustom() bool {
+	return len(r.layers) > 0
+}
+
+// Values returns the values of the custom profiles for the given component as nested map. Values of a profile
+// override the values of its base profile and values of a component section override the values for all components.
+func (r *Resolved) Values(component string) (map[string]interface{}, error) {
+	if !r.IsCustom() {
+		return nil, nil
+	}
+	result := make(map[string]interface{})
+	for _, layer := range r.layers {
+		for _, values := range []map[string]interface{}{layer.Values, layer.Components[component]} {
+			if err := mergeValues(result, values); err != nil {
+				return nil, errors.Wrapf(err, "failed to merge values of custom profile '%s' for component '%s'",
+					layer.Name, component)
+			}
+		}
+	}
+	return result, nil
+}
+
+//mergeValues merges values with keys in dot-notation into the nested map (keys are merged in sorted order
+//to ensure that 'a.b' overrides the value 'b' of 'a' deterministically)
+func mergeValues(dst map[string]interface{}, values map[string]interface{}) error {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if err := mergo.Merge(&dst, toNestedMap(key, copyValue(values[key])), mergo.WithOverride); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//copyValue returns a deep copy of nested maps and lists (merging must not modify the profile definitions)
+func copyValue(value interface{}) interface{} {
+	switch typedValue := value.(type) {
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(typedValue))
+		for key, nestedValue := range typedValue {
+			result[key] = copyValue(nestedValue)
+		}
+		return result
+	case []interface{}:
+		result := make([]interface{}, len(typedValue))
+		for idx, nestedValue := range typedValue {
+			result[idx] = copyValue(nestedValue)
+		}
+		return result
+	default:
+		return value
+	}
+}
+
+//toNestedMap converts a key with dot-notation into a nested map (e.g. a.b.c=value become [a:[b:[c:value]]])
+func toNestedMap(key string, value interface{}) map[string]interface{} {
+	tokens := strings.Split(key, ".")
+	result := map[string]interface{}{tokens[len(tokens)-1]: value}
+	for idx := len(tokens) - 2; idx >= 0; idx-- {
+		result = map[string]interface{}{tokens[idx]: result}
+	}
+	return result
+}
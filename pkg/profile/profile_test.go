@@ -0,0 +1,161 @@
+package profile
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+//bucketRepository is a KV store with buckets of string values
+type bucketRepository map[string]map[string]string
+
+func (r bucketRepository) ValuesByBucket(bucket string) ([]*model.ValueEntity, error) {
+	if bucket == KVBucketPrefix+"broken" {
+		return nil, errors.New("database not reachable")
+	}
+	var result []*model.ValueEntity
+	for key, value := range r[bucket] {
+		result = append(result, &model.ValueEntity{Bucket: bucket, Key: key, Value: value, DataType: model.String})
+	}
+	return result, nil
+}
+
+func TestResolver(t *testing.T) {
+	profilesFile := filepath.Join(t.TempDir(), "profiles.yaml")
+	require.NoError(t, ioutil.WriteFile(profilesFile, []byte(`
+trial:
+  base: evaluation
+  values:
+    global.domainName: trial.example.com
+    global.replicas: 2
+  components:
+    istio:
+      pilot:
+        resources: small
+trial-small:
+  base: trial
+  values:
+    global.replicas: 1
+  components:
+    istio:
+      pilot.resources: tiny
+production:
+  base: production
+  values:
+    global.replicas: 5
+cycle-a:
+  base: cycle-b
+cycle-b:
+  base: cycle-a
+`), 0600))
+	fileSource, err := NewFileSource(profilesFile)
+	require.NoError(t, err)
+
+	kvSource := NewKVSource(bucketRepository{
+		KVBucketPrefix + "trial-small": { //overrides the profile of the file
+			"base":                          "trial",
+			"values.global.replicas":        "3",
+			"components.istio.pilot.domain": "istio.example.com",
+		},
+		KVBucketPrefix + "invalid": {
+			"unknown": "value",
+		},
+	})
+
+	resolver := NewResolver(kvSource, fileSource)
+
+	t.Run("Chart profiles are passed as they are", func(t *testing.T) {
+		resolved, err := resolver.Resolve("evaluation")
+		require.NoError(t, err)
+		require.False(t, resolved.IsCustom())
+		require.Equal(t, "evaluation", resolved.ChartProfile)
+		values, err := resolved.Values("istio")
+		require.NoError(t, err)
+		require.Nil(t, values)
+
+		var nilResolver *Resolver
+		resolved, err = nilResolver.Resolve("trial")
+		require.NoError(t, err)
+		require.Equal(t, "trial", resolved.ChartProfile)
+	})
+
+	t.Run("Layer custom profiles on top of the chart profile", func(t *testing.T) {
+		resolved, err := NewResolver(fileSource).Resolve("trial-small")
+		require.NoError(t, err)
+		require.True(t, resolved.IsCustom())
+		require.Equal(t, "evaluation", resolved.ChartProfile)
+
+		values, err := resolved.Values("istio")
+		require.NoError(t, err)
+		require.Equal(t, map[string]interface{}{
+			"global": map[string]interface{}{"domainName": "trial.example.com", "replicas": float64(1)},
+			"pilot":  map[string]interface{}{"resources": "tiny"},
+		}, values)
+
+		values, err = resolved.Values("ory")
+		require.NoError(t, err)
+		require.Equal(t, map[string]interface{}{
+			"global": map[string]interface{}{"domainName": "trial.example.com", "replicas": float64(1)},
+		}, values)
+
+		//profile definitions are not modified
+		trial, err := NewResolver(fileSource).Resolve("trial")
+		require.NoError(t, err)
+		trialValues, err := trial.Values("istio")
+		require.NoError(t, err)
+		require.Equal(t, map[string]interface{}{"resources": "small"}, trialValues["pilot"])
+	})
+
+	t.Run("Override chart profile with the same name", func(t *testing.T) {
+		resolved, err := resolver.Resolve("production")
+		require.NoError(t, err)
+		require.True(t, resolved.IsCustom())
+		require.Equal(t, "production", resolved.ChartProfile)
+		values, err := resolved.Values("istio")
+		require.NoError(t, err)
+		require.Equal(t, map[string]interface{}{"global": map[string]interface{}{"replicas": float64(5)}}, values)
+	})
+
+	t.Run("KV store takes precedence", func(t *testing.T) {
+		resolved, err := resolver.Resolve("trial-small")
+		require.NoError(t, err)
+		require.Equal(t, "evaluation", resolved.ChartProfile)
+
+		values, err := resolved.Values("istio")
+		require.NoError(t, err)
+		require.Equal(t, map[string]interface{}{
+			"global": map[string]interface{}{"domainName": "trial.example.com", "replicas": "3"},
+			"pilot":  map[string]interface{}{"resources": "small", "domain": "istio.example.com"},
+		}, values)
+	})
+
+	t.Run("Invalid profiles", func(t *testing.T) {
+		_, err := resolver.Resolve("cycle-a")
+		require.Error(t, err)
+		_, err = resolver.Resolve("invalid")
+		require.Error(t, err)
+		_, err = resolver.Resolve("broken")
+		require.Error(t, err)
+	})
+}
+
+func TestNewFileSource(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"nobase.yaml":  "trial:\n  values:\n    key: value\n",
+		"invalid.yaml": "Trial_Small:\n  base: evaluation\n",
+		"unknown.yaml": "trial:\n  base: evaluation\n  unknown: true\n",
+	} {
+		file := filepath.Join(dir, name)
+		require.NoError(t, ioutil.WriteFile(file, []byte(content), 0600))
+		_, err := NewFileSource(file)
+		require.Error(t, err, name)
+	}
+
+	_, err := NewFileSource(filepath.Join(dir, "missing.yaml"))
+	require.Error(t, err)
+}
@@ -0,0 +1,112 @@
+package profile
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	//KVBucketPrefix is the prefix of the KV store buckets which define custom profiles ('profile-<name>')
+	KVBucketPrefix = "profile-"
+
+	//keys of the values in a profile bucket
+	kvKeyBase             = "base"
+	kvKeyValuesPrefix     = "values."
+	kvKeyComponentsPrefix = "components."
+)
+
+// FileSource provides the custom profiles defined in a YAML or JSON file (a map of profile names to profiles)
+type FileSource struct {
+	profiles map[string]*Profile
+}
+
+// NewFileSource reads the custom profiles from the file
+func NewFileSource(file string) (*FileSource, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read custom profiles file '%s'", file)
+	}
+	profiles := make(map[string]*Profile)
+	if err := yaml.UnmarshalStrict(data, &profiles); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse custom profiles file '%s'", file)
+	}
+	for name, profile := range profiles {
+		if profile == nil {
+			return nil, fmt.Errorf("custom profile '%s' in file '%s' is empty", name, file)
+		}
+		profile.Name = name
+		if err := profile.validate(); err != nil {
+			return nil, err
+		}
+	}
+	return &FileSource{profiles: profiles}, nil
+}
+
+func (s *FileSource) Get(name string) (*Profile, error) {
+	return s.profiles[name], nil
+}
+
+// BucketRepository returns the latest values of a KV store bucket
+type BucketRepository interface {
+	ValuesByBucket(bucket string) ([]*model.ValueEntity, error)
+}
+
+// KVSource provides the custom profiles stored in the KV store. Each profile is a bucket 'profile-<name>' with the
+// keys 'base', 'values.<key>' (values for all components) and 'components.<component>.<key>'.
+type KVSource struct {
+	repo BucketRepository
+}
+
+func NewKVSource(repo BucketRepository) *KVSource {
+	return &KVSource{repo: repo}
+}
+
+func (s *KVSource) Get(name string) (*Profile, error) {
+	if !namePattern.MatchString(name) {
+		return nil, nil //not a valid bucket name: profile can't be stored in the KV store
+	}
+	values, err := s.repo.ValuesByBucket(KVBucketPrefix + name)
+	if err != nil {
+		return nil, err
+	}
+	if len(values) == 0 {
+		return nil, nil
+	}
+
+	profile := &Profile{
+		Name:       name,
+		Values:     make(map[string]interface{}),
+		Components: make(map[string]map[string]interface{}),
+	}
+	for _, value := range values {
+		typedValue, err := value.Get()
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get typed value of key '%s' in bucket '%s'", value.Key, value.Bucket)
+		}
+		switch {
+		case value.Key == kvKeyBase:
+			profile.Base = fmt.Sprint(typedValue)
+		case strings.HasPrefix(value.Key, kvKeyValuesPrefix):
+			profile.Values[strings.TrimPrefix(value.Key, kvKeyValuesPrefix)] = typedValue
+		case strings.HasPrefix(value.Key, kvKeyComponentsPrefix):
+			componentAndKey := strings.SplitN(strings.TrimPrefix(value.Key, kvKeyComponentsPrefix), ".", 2)
+			if len(componentAndKey) != 2 || componentAndKey[0] == "" || componentAndKey[1] == "" {
+				return nil, fmt.Errorf("key '%s' in bucket '%s' is invalid: expected format '%s<component>.<key>'",
+					value.Key, value.Bucket, kvKeyComponentsPrefix)
+			}
+			if _, ok := profile.Components[componentAndKey[0]]; !ok {
+				profile.Components[componentAndKey[0]] = make(map[string]interface{})
+			}
+			profile.Components[componentAndKey[0]][componentAndKey[1]] = typedValue
+		default:
+			return nil, fmt.Errorf("key '%s' in bucket '%s' is not supported: expected '%s', '%s<key>' or '%s<component>.<key>'",
+				value.Key, value.Bucket, kvKeyBase, kvKeyValuesPrefix, kvKeyComponentsPrefix)
+		}
+	}
+	return profile, nil
+}
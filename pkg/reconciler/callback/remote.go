@@ -9,15 +9,19 @@ import (
 	"net/url"
 
 	"github.com/kyma-incubator/reconciler/pkg/reconciler"
+	"github.com/kyma-incubator/reconciler/pkg/signature"
 	"go.uber.org/zap"
 )
 
 type RemoteCallbackHandler struct {
-	logger      *zap.SugaredLogger
-	callbackURL string
+	logger        *zap.SugaredLogger
+	callbackURL   string
+	httpClient    *http.Client
+	signer        *signature.Signer
+	correlationID string
 }
 
-func NewRemoteCallbackHandler(callbackURL string, logger *zap.SugaredLogger) (Handler, error) {
+func NewRemoteCallbackHandler(callbackURL string, logger *zap.SugaredLogger) (*RemoteCallbackHandler, error) {
 	//validate URL
 	if callbackURL != "" { //empty URLs are allowed (used in some test cases)
 		if _, err := url.ParseRequestURI(callbackURL); err != nil {
@@ -29,9 +33,25 @@ func NewRemoteCallbackHandler(callbackURL string, logger *zap.SugaredLogger) (Ha
 	return &RemoteCallbackHandler{
 		logger:      logger,
 		callbackURL: callbackURL,
+		httpClient:  http.DefaultClient,
 	}, nil
 }
 
+// WithHTTPClient defines the HTTP client used to send callbacks (e.g. configured for mutual TLS)
+func (cb *RemoteCallbackHandler) WithHTTPClient(httpClient *http.Client) *RemoteCallbackHandler {
+	if httpClient != nil {
+		cb.httpClient = httpClient
+	}
+	return cb
+}
+
+// WithSigner enables signing of callbacks for the operation with the given correlation ID
+func (cb *RemoteCallbackHandler) WithSigner(signer *signature.Signer, correlationID string) *RemoteCallbackHandler {
+	cb.signer = signer
+	cb.correlationID = correlationID
+	return cb
+}
+
 func (cb *RemoteCallbackHandler) Callback(msg *reconciler.CallbackMessage) error {
 	if cb.callbackURL == "" { //test cases often don't provide a callback URL
 		cb.logger.Warn("Remote callback handler got an empty callback-URL provided: remote callback not executed")
@@ -43,7 +63,17 @@ func (cb *RemoteCallbackHandler) Callback(msg *reconciler.CallbackMessage) error
 		return err
 	}
 
-	resp, err := http.Post(cb.callbackURL, "application/json", bytes.NewBuffer(requestBody))
+	req, err := http.NewRequest(http.MethodPost, cb.callbackURL, bytes.NewBuffer(requestBody))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if err := cb.signer.Sign(req, cb.correlationID, requestBody); err != nil {
+		cb.logger.Errorf("Remote callback handler failed to sign HTTP request: %s", err)
+		return err
+	}
+
+	resp, err := cb.httpClient.Do(req)
 	if err != nil {
 		cb.logger.Errorf("Remote callback handler failed to send HTTP request: %s", err)
 		return err
@@ -0,0 +1,417 @@
+package chart
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
+)
+
+const bundleManifestFile = "bundle.yaml"
+
+// BundleManifest describes the content of a workspace bundle
+type BundleManifest struct {
+	Version    string             `json:"version"`              //Kyma version
+	Created    time.Time          `json:"created"`              //creation time of the bundle
+	Components []*BundleComponent `json:"components,omitempty"` //external components included in the bundle
+	Workspaces []string           `json:"workspaces"`           //workspace directories (relative to the storage directory)
+}
+
+// BundleComponent is an external component included in a workspace bundle
+type BundleComponent struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	URL     string `json:"url"`
+}
+
+// ExportBundle prepares the Kyma workspace of the given version and the workspaces of the external components
+// and writes them as gzipped tarball to the writer. The bundle can be imported into the storage directory of a
+// workspace factory which has no access to the sources (e.g. in air-gapped landscapes).
+func (f *DefaultFactory) ExportBundle(w io.Writer, version string, components []*Component) (*BundleManifest, error) {
+	if version == VersionLocal {
+		return nil, fmt.Errorf("workspace of version '%s' cannot be exported", VersionLocal)
+	}
+	manifest := &BundleManifest{
+		Version: version,
+		Created: time.Now().UTC(),
+	}
+
+	//protect the exported workspaces against garbage collection until the bundle is written
+	lease := f.Lease()
+	defer lease.Release()
+
+	kymaWs, err := lease.Get(version)
+	if err != nil {
+		return nil, err
+	}
+	wsDirs := []string{kymaWs.WorkspaceDir}
+
+	for _, component := range components {
+		ws, err := lease.GetExternalComponent(component)
+		if err != nil {
+			return nil, err
+		}
+		if strings.HasSuffix(component.url, ".git") {
+			//the GIT clone is required to resolve the revision of the component
+			wsDirs = append(wsDirs, f.componentBaseDir(component))
+		}
+		wsDirs = append(wsDirs, ws.WorkspaceDir)
+		manifest.Components = append(manifest.Components, &BundleComponent{
+			Name:    component.name,
+			Version: component.version,
+			URL:     component.url,
+		})
+	}
+
+	for _, wsDir := range wsDirs {
+		relDir, err := filepath.Rel(f.storageDir, wsDir)
+		if err != nil {
+			return nil, err
+		}
+		manifest.Workspaces = append(manifest.Workspaces, filepath.ToSlash(relDir))
+	}
+
+	f.logger.Infof("Exporting workspaces %v into bundle", manifest.Workspaces)
+	return manifest, f.writeBundle(w, manifest)
+}
+
+func (f *DefaultFactory) writeBundle(w io.Writer, manifest *BundleManifest) error {
+	gzipWriter := gzip.NewWriter(w)
+	tarWriter := tar.NewWriter(gzipWriter)
+
+	manifestData, err := yaml.Marshal(manifest)
+	if err != nil {
+		return err
+	}
+	if err := tarWriter.WriteHeader(&tar.Header{
+		Name:    bundleManifestFile,
+		Mode:    0600,
+		Size:    int64(len(manifestData)),
+		ModTime: manifest.Created,
+	}); err != nil {
+		return err
+	}
+	if _, err := tarWriter.Write(manifestData); err != nil {
+		return err
+	}
+
+	for _, wsDir := range manifest.Workspaces {
+		if err := addDirToTar(tarWriter, f.storageDir, wsDir); err != nil {
+			return errors.Wrapf(err, "failed to add workspace '%s' to bundle", wsDir)
+		}
+	}
+
+	if err := tarWriter.Close(); err != nil {
+		return err
+	}
+	return gzipWriter.Close()
+}
+
+func addDirToTar(tarWriter *tar.Writer, baseDir, dir string) error {
+	return filepath.Walk(filepath.Join(baseDir, dir), func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		relPath, err := filepath.Rel(baseDir, path)
+		if err != nil {
+			return err
+		}
+		var link string
+		if info.Mode()&os.ModeSymlink != 0 {
+			if link, err = os.Readlink(path); err != nil {
+				return err
+			}
+		}
+		header, err := tar.FileInfoHeader(info, link)
+		if err != nil {
+			return err
+		}
+		header.Name = filepath.ToSlash(relPath)
+		if err := tarWriter.WriteHeader(header); err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		file, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			_ = file.Close()
+		}()
+		_, err = io.Copy(tarWriter, file)
+		return err
+	})
+}
+
+// ImportBundle extracts the workspaces of a bundle into the storage directory. Workspaces which already exist are
+// kept untouched.
+func (f *DefaultFactory) ImportBundle(r io.Reader) (*BundleManifest, error) {
+	//block the creation and deletion of workspaces while the bundle gets imported
+	f.gcMutex.Lock()
+	defer f.gcMutex.Unlock()
+	f.mutexGetComponent.Lock()
+	defer f.mutexGetComponent.Unlock()
+
+	if err := f.validate(); err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(f.storageDir, 0700); err != nil {
+		return nil, err
+	}
+	tmpDir, err := ioutil.TempDir(f.storageDir, ".import-")
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := os.RemoveAll(tmpDir); err != nil {
+			f.logger.Warnf("Failed to delete temporary import directory '%s': %s", tmpDir, err)
+		}
+	}()
+
+	if err := extractBundle(r, tmpDir); err != nil {
+		return nil, errors.Wrap(err, "failed to extract workspace bundle")
+	}
+	manifest, err := readBundleManifest(tmpDir)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, wsDir := range manifest.Workspaces {
+		srcDir := filepath.Join(tmpDir, filepath.FromSlash(wsDir))
+		if !f.readyMarkerExists(srcDir) {
+			return nil, fmt.Errorf("workspace '%s' of bundle is incomplete: ready marker is missing", wsDir)
+		}
+		dstDir := filepath.Join(f.storageDir, filepath.FromSlash(wsDir))
+		if f.readyMarkerExists(dstDir) {
+			f.logger.Debugf("Workspace '%s' already exists and will not be imported", dstDir)
+			continue
+		}
+		if err := f.cleanFailedWorkspace(dstDir); err != nil {
+			return nil, err
+		}
+		if err := os.MkdirAll(filepath.Dir(dstDir), 0700); err != nil {
+			return nil, err
+		}
+		if err := os.Rename(srcDir, dstDir); err != nil {
+			return nil, errors.Wrapf(err, "failed to import workspace '%s'", wsDir)
+		}
+		f.logger.Infof("Workspace '%s' imported", dstDir)
+	}
+	return manifest, nil
+}
+
+// ImportBundleFile verifies the signature of the bundle file (stored in '<bundleFile>.sig') with the public key
+// and imports the bundle. A public key is required: use ImportUnverifiedBundleFile to skip the verification.
+func (f *DefaultFactory) ImportBundleFile(bundleFile, publicKeyFile string) (*BundleManifest, error) {
+	if publicKeyFile == "" {
+		return nil, fmt.Errorf("public key is required to verify the signature of workspace bundle '%s'", bundleFile)
+	}
+	data, err := ioutil.ReadFile(bundleFile)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read workspace bundle '%s'", bundleFile)
+	}
+	publicKey, err := ioutil.ReadFile(publicKeyFile)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read public key file '%s'", publicKeyFile)
+	}
+	signature, err := ioutil.ReadFile(BundleSignatureFile(bundleFile))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read signature of workspace bundle '%s'", bundleFile)
+	}
+	if err := VerifyBundle(data, signature, string(publicKey)); err != nil {
+		return nil, err
+	}
+	return f.ImportBundle(bytes.NewReader(data))
+}
+
+// ImportUnverifiedBundleFile imports the bundle file without verifying its signature. It's only intended for
+// bundles of trusted sources (e.g. during development) and has to be explicitly requested by the operator.
+func (f *DefaultFactory) ImportUnverifiedBundleFile(bundleFile string) (*BundleManifest, error) {
+	bundle, err := os.Open(bundleFile)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read workspace bundle '%s'", bundleFile)
+	}
+	defer func() {
+		_ = bundle.Close()
+	}()
+	f.logger.Warnf("Signature of workspace bundle '%s' is not verified", bundleFile)
+	return f.ImportBundle(bundle)
+}
+
+// BundleSignatureFile returns the path of the file which contains the signature of the bundle
+func BundleSignatureFile(bundleFile string) string {
+	return bundleFile + ".sig"
+}
+
+func readBundleManifest(dir string) (*BundleManifest, error) {
+	data, err := ioutil.ReadFile(filepath.Join(dir, bundleManifestFile))
+	if err != nil {
+		return nil, errors.Wrap(err, "workspace bundle contains no manifest")
+	}
+	manifest := &BundleManifest{}
+	if err := yaml.UnmarshalStrict(data, manifest); err != nil {
+		return nil, errors.Wrap(err, "failed to parse manifest of workspace bundle")
+	}
+	if manifest.Version == "" || len(manifest.Workspaces) == 0 {
+		return nil, errors.New("manifest of workspace bundle defines no version or workspaces")
+	}
+	for _, wsDir := range manifest.Workspaces {
+		if !isLocalPath(wsDir) || filepath.Clean(wsDir) == "." || wsDir == bundleManifestFile {
+			return nil, fmt.Errorf("workspace '%s' of bundle is not a relative path", wsDir)
+		}
+	}
+	return manifest, nil
+}
+
+func extractBundle(r io.Reader, dstDir string) error {
+	gzipReader, err := gzip.NewReader(r)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = gzipReader.Close()
+	}()
+
+	tarReader := tar.NewReader(gzipReader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if !isLocalPath(header.Name) {
+			return fmt.Errorf("entry '%s' is located outside of the bundle", header.Name)
+		}
+		target := filepath.Join(dstDir, filepath.FromSlash(header.Name))
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			err = os.MkdirAll(target, 0700)
+		case tar.TypeReg:
+			err = extractFile(tarReader, target, os.FileMode(header.Mode).Perm())
+		case tar.TypeSymlink:
+			linkTarget := filepath.Join(filepath.Dir(header.Name), header.Linkname)
+			if filepath.IsAbs(header.Linkname) || !isLocalPath(filepath.ToSlash(linkTarget)) {
+				return fmt.Errorf("symlink '%s' points outside of the bundle", header.Name)
+			}
+			if err = os.MkdirAll(filepath.Dir(target), 0700); err == nil {
+				err = os.Symlink(header.Linkname, target)
+			}
+		default:
+			return fmt.Errorf("entry '%s' has unsupported type '%c'", header.Name, header.Typeflag)
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func extractFile(r io.Reader, target string, perm os.FileMode) error {
+	if err := os.MkdirAll(filepath.Dir(target), 0700); err != nil {
+		return err
+	}
+	out, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, perm|0600)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, r); err != nil { //nolint:gosec //bundles are signed and created by operators
+		_ = out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+//isLocalPath returns true if the slash separated path is relative and doesn't leave its root directory
+func isLocalPath(path string) bool {
+	cleaned := filepath.Clean(filepath.FromSlash(path))
+	return path != "" && !filepath.IsAbs(cleaned) && cleaned != ".." &&
+		!strings.HasPrefix(cleaned, ".."+string(filepath.Separator))
+}
+
+// SignBundle creates a base64 encoded signature of the bundle (compatible with 'cosign sign-blob') using a
+// PEM encoded ECDSA, RSA or Ed25519 private key. The bundle is streamed from the reader: only Ed25519 signatures
+// require the whole bundle in memory.
+func SignBundle(r io.Reader, privateKeyPEM string) ([]byte, error) {
+	block, _ := pem.Decode([]byte(privateKeyPEM))
+	if block == nil {
+		return nil, errors.New("private key is not PEM encoded")
+	}
+	var key interface{}
+	var err error
+	switch block.Type {
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	default:
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse private key")
+	}
+
+	var signature []byte
+	switch privKey := key.(type) {
+	case *ecdsa.PrivateKey:
+		var digest []byte
+		if digest, err = bundleDigest(r); err == nil {
+			signature, err = ecdsa.SignASN1(rand.Reader, privKey, digest)
+		}
+	case *rsa.PrivateKey:
+		var digest []byte
+		if digest, err = bundleDigest(r); err == nil {
+			signature, err = rsa.SignPKCS1v15(rand.Reader, privKey, crypto.SHA256, digest)
+		}
+	case ed25519.PrivateKey:
+		//Ed25519 signs the message itself and not its digest
+		var data []byte
+		if data, err = ioutil.ReadAll(r); err == nil {
+			signature = ed25519.Sign(privKey, data)
+		}
+	default:
+		return nil, fmt.Errorf("private key type '%T' is not supported", key)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return []byte(base64.StdEncoding.EncodeToString(signature)), nil
+}
+
+//bundleDigest returns the SHA-256 digest of the bundle read from the reader
+func bundleDigest(r io.Reader) ([]byte, error) {
+	hash := sha256.New()
+	if _, err := io.Copy(hash, r); err != nil {
+		return nil, errors.Wrap(err, "failed to read workspace bundle")
+	}
+	return hash.Sum(nil), nil
+}
+
+// VerifyBundle verifies the signature of a bundle using the PEM encoded public key
+func VerifyBundle(data, signature []byte, publicKeyPEM string) error {
+	if err := verifyCosignSignature(data, signature, publicKeyPEM); err != nil {
+		return errors.Wrap(err, "signature of workspace bundle is invalid")
+	}
+	return nil
+}
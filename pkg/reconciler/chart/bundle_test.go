@@ -0,0 +1,143 @@
+package chart
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"io/ioutil"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	file "github.com/kyma-incubator/reconciler/pkg/files"
+	log "github.com/kyma-incubator/reconciler/pkg/logger"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkspaceBundle(t *testing.T) {
+	repoDir, _ := newTestKymaRepository(t)
+
+	rscdir, err := filepath.Abs("test/unittest-kyma/resources/archives")
+	require.NoError(t, err)
+	server := httptest.NewServer(handlerFuncArchive(t, rscdir))
+	defer server.Close()
+	component := NewComponentBuilder("1.0.0", "testmeplz").WithURL(server.URL + "/testmeplz.tar.gz").Build()
+
+	//signing keys
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	privKeyDer, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	privKey := string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: privKeyDer}))
+	pubKeyDer, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	require.NoError(t, err)
+	pubKeyFile := filepath.Join(t.TempDir(), "key.pub")
+	require.NoError(t, ioutil.WriteFile(pubKeyFile, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubKeyDer}), 0600))
+
+	//export bundle
+	srcFactory, err := NewFactory(&reconciler.Repository{URL: repoDir}, t.TempDir(), log.NewLogger(true))
+	require.NoError(t, err)
+	var bundle bytes.Buffer
+	manifest, err := srcFactory.ExportBundle(&bundle, "master", []*Component{component})
+	require.NoError(t, err)
+	require.Equal(t, "master", manifest.Version)
+	require.Len(t, manifest.Components, 1)
+	require.Equal(t, []string{"master", "1.0.0-testmeplz"}, manifest.Workspaces)
+
+	signature, err := SignBundle(bytes.NewReader(bundle.Bytes()), privKey)
+	require.NoError(t, err)
+	bundleFile := filepath.Join(t.TempDir(), "bundle.tgz")
+	require.NoError(t, ioutil.WriteFile(bundleFile, bundle.Bytes(), 0600))
+	require.NoError(t, ioutil.WriteFile(BundleSignatureFile(bundleFile), signature, 0600))
+
+	//offline factory can't reach the sources
+	offlineFactory, err := NewFactory(&reconciler.Repository{URL: repoDir}, t.TempDir(), log.NewLogger(true))
+	require.NoError(t, err)
+	offlineFactory.WithOffline(true)
+
+	t.Run("Offline factory refuses downloads", func(t *testing.T) {
+		_, err := offlineFactory.Get("master")
+		require.Error(t, err)
+		_, err = offlineFactory.GetExternalComponent(component)
+		require.Error(t, err)
+	})
+
+	t.Run("Reject tampered bundle", func(t *testing.T) {
+		tamperedFile := filepath.Join(t.TempDir(), "tampered.tgz")
+		require.NoError(t, ioutil.WriteFile(tamperedFile, append(bundle.Bytes(), 0), 0600))
+		require.NoError(t, ioutil.WriteFile(BundleSignatureFile(tamperedFile), signature, 0600))
+		_, err := offlineFactory.ImportBundleFile(tamperedFile, pubKeyFile)
+		require.Error(t, err)
+	})
+
+	t.Run("Reject unsigned bundle", func(t *testing.T) {
+		unsignedFile := filepath.Join(t.TempDir(), "unsigned.tgz")
+		require.NoError(t, ioutil.WriteFile(unsignedFile, bundle.Bytes(), 0600))
+		_, err := offlineFactory.ImportBundleFile(unsignedFile, pubKeyFile)
+		require.Error(t, err)
+		//a public key is required to import a bundle
+		_, err = offlineFactory.ImportBundleFile(bundleFile, "")
+		require.Error(t, err)
+		require.False(t, file.DirExists(filepath.Join(offlineFactory.storageDir, "master")))
+	})
+
+	t.Run("Import unverified bundle", func(t *testing.T) {
+		unsignedFile := filepath.Join(t.TempDir(), "unsigned.tgz")
+		require.NoError(t, ioutil.WriteFile(unsignedFile, bundle.Bytes(), 0600))
+		insecureFactory, err := NewFactory(nil, t.TempDir(), log.NewLogger(true))
+		require.NoError(t, err)
+		imported, err := insecureFactory.ImportUnverifiedBundleFile(unsignedFile)
+		require.NoError(t, err)
+		require.Equal(t, manifest.Workspaces, imported.Workspaces)
+	})
+
+	t.Run("Import bundle and use workspaces offline", func(t *testing.T) {
+		imported, err := offlineFactory.ImportBundleFile(bundleFile, pubKeyFile)
+		require.NoError(t, err)
+		require.Equal(t, manifest.Workspaces, imported.Workspaces)
+
+		ws, err := offlineFactory.Get("master")
+		require.NoError(t, err)
+		require.True(t, file.Exists(filepath.Join(ws.ResourceDir, "next", "Chart.yaml")))
+
+		compWs, err := offlineFactory.GetExternalComponent(component)
+		require.NoError(t, err)
+		require.True(t, file.DirExists(compWs.WorkspaceDir))
+
+		_, err = offlineFactory.Get("1.0.0")
+		require.Error(t, err)
+
+		//importing again keeps the existing workspaces
+		_, err = offlineFactory.ImportBundleFile(bundleFile, pubKeyFile)
+		require.NoError(t, err)
+	})
+}
+
+func TestExtractBundle(t *testing.T) {
+	newBundle := func(header *tar.Header) *bytes.Buffer {
+		var buf bytes.Buffer
+		gzipWriter := gzip.NewWriter(&buf)
+		tarWriter := tar.NewWriter(gzipWriter)
+		require.NoError(t, tarWriter.WriteHeader(header))
+		require.NoError(t, tarWriter.Close())
+		require.NoError(t, gzipWriter.Close())
+		return &buf
+	}
+
+	for _, header := range []*tar.Header{
+		{Name: "../escape", Typeflag: tar.TypeReg},
+		{Name: "/absolute", Typeflag: tar.TypeReg},
+		{Name: "ws/link", Typeflag: tar.TypeSymlink, Linkname: "../../escape"},
+		{Name: "ws/link", Typeflag: tar.TypeSymlink, Linkname: "/etc/passwd"},
+		{Name: "ws/device", Typeflag: tar.TypeChar},
+	} {
+		require.Error(t, extractBundle(newBundle(header), t.TempDir()), header.Name)
+	}
+	require.NoError(t, extractBundle(newBundle(&tar.Header{Name: "ws/link", Typeflag: tar.TypeSymlink, Linkname: "../ws"}), t.TempDir()))
+}
@@ -11,6 +11,7 @@ type Component struct {
 	version       string
 	name          string
 	profile       string
+	profileValues map[string]interface{} //values of a custom profile (applied on top of the chart profile)
 	namespace     string
 	configuration map[string]interface{}
 }
@@ -61,6 +62,12 @@ func (cb *ComponentBuilder) WithProfile(profile string) *ComponentBuilder {
 	return cb
 }
 
+//WithProfileValues sets the values of a custom profile as nested map
+func (cb *ComponentBuilder) WithProfileValues(values map[string]interface{}) *ComponentBuilder {
+	cb.component.profileValues = values
+	return cb
+}
+
 func (cb *ComponentBuilder) WithNamespace(namespace string) *ComponentBuilder {
 	cb.component.namespace = namespace
 	return cb
@@ -0,0 +1,60 @@
+package chart
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	//RepoTokenNamespaceConfigKey is the component configuration key used to define the namespace of the secrets
+	//with repository credentials
+	RepoTokenNamespaceConfigKey = "repo.token.namespace"
+	defaultTokenNamespace       = "default"
+)
+
+//repositoryCredentials are used to authenticate against chart repositories and OCI registries
+type repositoryCredentials struct {
+	username string
+	password string
+}
+
+//lookupCredentials reads the credentials of a chart repository or OCI registry from the secret named like the
+//host of the repository. Equal to the GIT token lookup, the secret is expected in the namespace defined by the
+//component configuration 'repo.token.namespace' (default namespace if undefined). The secret has to contain either
+//a 'username' and 'password' or a 'token'. Nil is returned if no secret exists or is accessible.
+func lookupCredentials(clientSet kubernetes.Interface, component *Component, host string) (*repositoryCredentials, error) {
+	if clientSet == nil {
+		return nil, nil
+	}
+
+	namespace := defaultTokenNamespace
+	if tokenNamespace, ok := component.configuration[RepoTokenNamespaceConfigKey]; ok && tokenNamespace != nil {
+		namespace = fmt.Sprintf("%s", tokenNamespace)
+	}
+	secretName := strings.TrimPrefix(strings.Split(host, ":")[0], "www.")
+
+	secret, err := clientSet.CoreV1().Secrets(namespace).Get(context.Background(), secretName, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) || apierrors.IsForbidden(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "failed to retrieve credentials secret '%s:%s'", namespace, secretName)
+	}
+
+	if token, ok := secret.Data["token"]; ok {
+		return &repositoryCredentials{
+			username: "xxx", //anything but an empty string
+			password: strings.Trim(string(token), "\n"),
+		}, nil
+	}
+	return &repositoryCredentials{
+		username: strings.Trim(string(secret.Data["username"]), "\n"),
+		password: strings.Trim(string(secret.Data["password"]), "\n"),
+	}, nil
+}
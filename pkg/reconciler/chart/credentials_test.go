@@ -0,0 +1,42 @@
+package chart
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestLookupCredentials(t *testing.T) {
+	clientSet := fake.NewSimpleClientset(
+		&v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "registry.example.com", Namespace: "default"},
+			Data:       map[string][]byte{"username": []byte("user"), "password": []byte("secret\n")},
+		},
+		&v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "charts.example.com", Namespace: "kyma-system"},
+			Data:       map[string][]byte{"token": []byte("abc")},
+		},
+	)
+
+	credentials, err := lookupCredentials(clientSet, NewComponentBuilder("1.0.0", "app").Build(), "registry.example.com:443")
+	require.NoError(t, err)
+	require.Equal(t, &repositoryCredentials{username: "user", password: "secret"}, credentials)
+
+	component := NewComponentBuilder("1.0.0", "app").
+		WithConfiguration(map[string]interface{}{RepoTokenNamespaceConfigKey: "kyma-system"}).
+		Build()
+	credentials, err = lookupCredentials(clientSet, component, "charts.example.com")
+	require.NoError(t, err)
+	require.Equal(t, &repositoryCredentials{username: "xxx", password: "abc"}, credentials)
+
+	credentials, err = lookupCredentials(clientSet, component, "unknown.example.com")
+	require.NoError(t, err)
+	require.Nil(t, credentials)
+
+	credentials, err = lookupCredentials(nil, component, "charts.example.com")
+	require.NoError(t, err)
+	require.Nil(t, credentials)
+}
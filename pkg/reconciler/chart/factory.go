@@ -1,10 +1,13 @@
 package chart
 
 import (
+	"bytes"
 	"crypto/sha1" //nolint
 	"fmt"
 	"io"
+	"io/ioutil"
 	"net/http"
+	"net/url"
 	"path"
 	"strings"
 
@@ -16,6 +19,7 @@ import (
 	reconcilerK8s "github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes"
 	"github.com/mholt/archiver/v3"
 	"github.com/otiai10/copy"
+	"helm.sh/helm/v3/pkg/chartutil"
 
 	"path/filepath"
 	"sync"
@@ -50,9 +54,18 @@ type Factory interface {
 type DefaultFactory struct {
 	storageDir        string
 	logger            *zap.SugaredLogger
-	mutexGet          sync.Mutex
+	workspaceLocks    keyedMutex   //serializes the creation of a Kyma workspace per version
+	mirrorLocks       keyedMutex   //serializes the usage of a GIT mirror per repository
+	gcMutex           sync.RWMutex //blocks the creation of Kyma workspaces during garbage collection
 	mutexGetComponent sync.Mutex
 	kymaRepository    *reconciler.Repository
+	gitCredentials    *git.CredentialsMapping
+	offline           bool //refuses any download of sources (workspaces have to be imported from bundles)
+	verificationKeys  VerificationKeys
+	usage             workspaceUsage
+	pinned            WorkspaceLease //protects workspaces which were provided without a lease
+	statsMu           sync.Mutex
+	stats             *WorkspaceStats
 }
 
 func NewFactory(repo *reconciler.Repository, storageDir string, logger *zap.SugaredLogger) (*DefaultFactory, error) {
@@ -64,6 +77,25 @@ func NewFactory(repo *reconciler.Repository, storageDir string, logger *zap.Suga
 	return factory, factory.validate()
 }
 
+// WithGitCredentials configures the credentials used to access GIT repositories
+func (f *DefaultFactory) WithGitCredentials(credentials *git.CredentialsMapping) *DefaultFactory {
+	f.gitCredentials = credentials
+	return f
+}
+
+// WithOffline enables the offline mode which refuses any network access: only workspaces which exist in the storage
+// directory (e.g. imported from a workspace bundle) can be used
+func (f *DefaultFactory) WithOffline(offline bool) *DefaultFactory {
+	f.offline = offline
+	return f
+}
+
+// WithVerificationKeys configures the public keys which are trusted to verify signatures of component sources
+func (f *DefaultFactory) WithVerificationKeys(keys VerificationKeys) *DefaultFactory {
+	f.verificationKeys = keys
+	return f
+}
+
 func (f *DefaultFactory) String() string {
 	return fmt.Sprintf("WorkspaceFactory [storageDir=%s]", f.storageDir)
 }
@@ -99,10 +131,52 @@ func (f *DefaultFactory) defaultStorageDir() string {
 	return filepath.Join(baseDir, ".kyma", "reconciler", "workspaces")
 }
 
+// Lease returns a factory which protects the provided workspaces against garbage collection until it gets released
+func (f *DefaultFactory) Lease() *WorkspaceLease {
+	return &WorkspaceLease{factory: f}
+}
+
+// Get returns the workspace of the given Kyma version. Workspaces provided without a lease (see Lease) are
+// never garbage collected while the factory exists.
 func (f *DefaultFactory) Get(version string) (*KymaWorkspace, error) {
-	f.mutexGet.Lock()
-	defer f.mutexGet.Unlock()
+	return f.getKymaWorkspace(version, f.pinnedLease())
+}
 
+// Lookup returns the workspace of the given Kyma version only if it was already downloaded (nil otherwise).
+// In contrast to Get it never starts a download.
+func (f *DefaultFactory) Lookup(version string) (*KymaWorkspace, error) {
+	if err := f.validate(); err != nil {
+		return nil, err
+	}
+	if version == VersionLocal {
+		return newKymaWorkspace(f.storageDir)
+	}
+
+	f.gcMutex.RLock()
+	defer f.gcMutex.RUnlock()
+
+	wsDir := f.workspaceDir(version)
+	if !file.Exists(filepath.Join(wsDir, wsReadyIndicatorFile)) {
+		return nil, nil
+	}
+	f.markUsed(wsDir, f.pinnedLease())
+	return newKymaWorkspace(wsDir)
+}
+
+func (f *DefaultFactory) getKymaWorkspace(version string, lease *WorkspaceLease) (*KymaWorkspace, error) {
+	f.gcMutex.RLock()
+	defer f.gcMutex.RUnlock()
+	unlock := f.workspaceLocks.lock(version)
+	defer unlock()
+
+	ws, err := f.kymaWorkspace(version)
+	if err == nil && version != VersionLocal {
+		f.markUsed(ws.WorkspaceDir, lease)
+	}
+	return ws, err
+}
+
+func (f *DefaultFactory) kymaWorkspace(version string) (*KymaWorkspace, error) {
 	if err := f.validate(); err != nil {
 		return nil, err
 	}
@@ -127,14 +201,30 @@ func (f *DefaultFactory) Get(version string) (*KymaWorkspace, error) {
 		}
 	}
 
-	if err := f.clone(version, wsDir, wsDir, f.kymaRepository); err != nil {
+	if f.offline {
+		return nil, f.offlineError(fmt.Sprintf("Kyma version '%s'", version))
+	}
+	if err := f.checkout(version, wsDir, f.kymaRepository); err != nil {
 		return nil, err
 	}
 
 	return newKymaWorkspace(wsDir)
 }
 
+// GetExternalComponent returns the workspace of an external component. Like Get, it protects the workspace
+// against garbage collection as long as the factory exists.
 func (f *DefaultFactory) GetExternalComponent(component *Component) (*Workspace, error) {
+	return f.getExternalComponent(component, f.pinnedLease())
+}
+
+func (f *DefaultFactory) pinnedLease() *WorkspaceLease {
+	f.pinned.mu.Lock()
+	defer f.pinned.mu.Unlock()
+	f.pinned.factory = f
+	return &f.pinned
+}
+
+func (f *DefaultFactory) getExternalComponent(component *Component, lease *WorkspaceLease) (*Workspace, error) {
 	f.mutexGetComponent.Lock()
 	defer f.mutexGetComponent.Unlock()
 
@@ -143,40 +233,183 @@ func (f *DefaultFactory) GetExternalComponent(component *Component) (*Workspace,
 	}
 
 	if strings.HasSuffix(component.url, ".git") {
-		return f.getExternalGitComponent(component)
+		ws, err := f.getExternalGitComponent(component)
+		if err == nil {
+			f.markUsed(f.componentBaseDir(component), lease)
+			f.markUsed(ws.WorkspaceDir, lease)
+		}
+		return ws, err
 	}
 
-	return f.getExternalArchiveComponent(component)
+	var ws *Workspace
+	var err error
+	switch {
+	case strings.HasPrefix(component.url, ociScheme):
+		ws, err = f.getExternalChartComponent(component, f.pullOCIChart)
+	case isHelmRepository(component.url):
+		ws, err = f.getExternalChartComponent(component, f.pullRepositoryChart)
+	default:
+		ws, err = f.getExternalArchiveComponent(component)
+	}
+	if err == nil {
+		f.markUsed(ws.WorkspaceDir, lease)
+	}
+	return ws, err
 }
 
 func (f *DefaultFactory) getExternalArchiveComponent(component *Component) (*Workspace, error) {
-	version := fmt.Sprintf("%s-%s", component.version, component.name)
+	verification, err := newVerification(component, f.verificationKeys)
+	if err != nil {
+		return nil, err
+	}
+	version := fmt.Sprintf("%s-%s%s", component.version, component.name, workspaceSuffix(verification))
 	wsDir := f.workspaceDir(version)
 
 	if f.readyMarkerExists(wsDir) {
 		return newComponentWorkspace(wsDir, component.name)
 	}
 
+	if f.offline {
+		return nil, f.offlineError(fmt.Sprintf("component '%s' with version '%s'", component.name, component.version))
+	}
 	if err := f.cleanFailedWorkspace(wsDir); err != nil {
 		return nil, err
 	}
 	f.logger.Infof("Downloading component '%s' with version '%s' from source '%s' into workspace '%s'",
 		component.name, component.version, component.url, wsDir)
-	if err := f.downloadComponent(component, wsDir); err != nil {
+	if err := f.downloadComponent(component, verification, wsDir); err != nil {
 		return nil, err
 	}
 
 	return newComponentWorkspace(wsDir, component.name)
 }
 
+//getExternalChartComponent stores the chart archive returned by the pull function in the workspace of the component
+func (f *DefaultFactory) getExternalChartComponent(component *Component, pull func(*Component) ([]byte, error)) (*Workspace, error) {
+	verification, err := newVerification(component, f.verificationKeys)
+	if err != nil {
+		return nil, err
+	}
+	wsDir := f.workspaceDir(fmt.Sprintf("%s-%s-%.8x%s", component.version, component.name,
+		sha1.Sum([]byte(component.url)), workspaceSuffix(verification))) //nolint
+
+	if f.readyMarkerExists(wsDir) {
+		return newComponentWorkspace(wsDir, component.name)
+	}
+
+	if f.offline {
+		return nil, f.offlineError(fmt.Sprintf("component '%s' with version '%s'", component.name, component.version))
+	}
+	if err := f.cleanFailedWorkspace(wsDir); err != nil {
+		return nil, err
+	}
+	f.logger.Infof("Pulling chart of component '%s' with version '%s' from source '%s' into workspace '%s'",
+		component.name, component.version, component.url, wsDir)
+	data, err := pull(component)
+	if err != nil {
+		return nil, err
+	}
+	if verification != nil {
+		if err := verification.verifyArchive(data, component.url); err != nil {
+			return nil, err
+		}
+	}
+	if err := f.expandChart(data, wsDir, component.name); err != nil {
+		if removeErr := os.RemoveAll(wsDir); removeErr != nil {
+			f.logger.Warnf("Failed to delete incomplete workspace '%s': %s", wsDir, removeErr)
+		}
+		return nil, err
+	}
+
+	//create a marker file to flag success
+	if err := f.createReadyMarker(wsDir); err != nil {
+		return nil, err
+	}
+	return newComponentWorkspace(wsDir, component.name)
+}
+
+//expandChart extracts the chart archive into a sub-directory named like the component
+func (f *DefaultFactory) expandChart(data []byte, wsDir, componentName string) error {
+	if err := os.MkdirAll(f.storageDir, 0700); err != nil {
+		return err
+	}
+	tmpDir, err := ioutil.TempDir(f.storageDir, "chart_*")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := os.RemoveAll(tmpDir); err != nil {
+			f.logger.Warnf("Failed to delete temporary directory '%s': %s", tmpDir, err)
+		}
+	}()
+
+	if err := chartutil.Expand(tmpDir, bytes.NewReader(data)); err != nil {
+		return errors.Wrap(err, "failed to extract chart archive")
+	}
+	//the chart is extracted into a directory named like the chart
+	entries, err := ioutil.ReadDir(tmpDir)
+	if err != nil {
+		return err
+	}
+	if len(entries) != 1 || !entries[0].IsDir() {
+		return fmt.Errorf("chart archive is expected to contain exactly one chart")
+	}
+
+	if err := os.MkdirAll(wsDir, 0700); err != nil {
+		return err
+	}
+	return os.Rename(filepath.Join(tmpDir, entries[0].Name()), filepath.Join(wsDir, componentName))
+}
+
+func (f *DefaultFactory) pullOCIChart(component *Component) ([]byte, error) {
+	ref, err := parseOCIReference(component.url, component.version)
+	if err != nil {
+		return nil, err
+	}
+	credentials, err := f.credentials(component, ref.registry)
+	if err != nil {
+		return nil, err
+	}
+	return newOCIClient(credentials).pull(ref)
+}
+
+func (f *DefaultFactory) pullRepositoryChart(component *Component) ([]byte, error) {
+	parsed, err := url.Parse(component.url)
+	if err != nil {
+		return nil, errors.Wrapf(err, "URL '%s' of Helm repository is invalid", component.url)
+	}
+	credentials, err := f.credentials(component, parsed.Host)
+	if err != nil {
+		return nil, err
+	}
+	chartName := component.name
+	if name, ok := component.configuration[RepoChartNameConfigKey]; ok && fmt.Sprint(name) != "" {
+		chartName = fmt.Sprint(name)
+	}
+	return pullRepositoryChart(component.url, chartName, component.version, credentials)
+}
+
+func (f *DefaultFactory) credentials(component *Component, host string) (*repositoryCredentials, error) {
+	clientSet, err := reconcilerK8s.NewInClusterClientSet(f.logger)
+	if err != nil {
+		return nil, err
+	}
+	return lookupCredentials(clientSet, component, host)
+}
+
 func (f *DefaultFactory) getExternalGitComponent(component *Component) (*Workspace, error) {
 	baseDir := f.componentBaseDir(component)
 
 	if f.readyMarkerExists(baseDir) { // already cloned, just fetch
-		if err := f.fetchComponent(component, baseDir); err != nil {
+		if f.offline {
+			f.logger.Debugf("Skipping fetch of component '%s' in offline mode", component.name)
+		} else if err := f.fetchComponent(component, baseDir); err != nil {
 			return nil, err
 		}
 	} else {
+		if f.offline {
+			return nil, f.offlineError(fmt.Sprintf("component '%s' with version '%s'", component.name, component.version))
+		}
 		if err := f.cleanFailedWorkspace(baseDir); err != nil {
 			return nil, err
 		}
@@ -208,7 +441,7 @@ func (f *DefaultFactory) cloneComponent(component *Component, dstDir string) err
 	return f.clone(component.version, dstPath, dstDir, repo)
 }
 
-func (f *DefaultFactory) downloadComponent(component *Component, dstDir string) error {
+func (f *DefaultFactory) downloadComponent(component *Component, verification *verification, dstDir string) error {
 	// create dst dir
 	if err := os.MkdirAll(dstDir, 0700); err != nil {
 		f.logger.Warnf("Unable to create destination directory: %q", dstDir)
@@ -225,6 +458,10 @@ func (f *DefaultFactory) downloadComponent(component *Component, dstDir string)
 		}
 	}()
 
+	if err := f.verifyArchive(component, verification, tmpFile); err != nil {
+		return err
+	}
+
 	err = archiver.Unarchive(tmpFile, dstDir)
 	if err != nil {
 		return err
@@ -295,6 +532,11 @@ func extension(mimeType string) (string, error) {
 	}
 }
 
+func (f *DefaultFactory) offlineError(source string) error {
+	return fmt.Errorf("workspace of %s is not available in storage directory '%s' and cannot be downloaded "+
+		"in offline mode: import a workspace bundle which contains it", source, f.storageDir)
+}
+
 func (f *DefaultFactory) readyFile(dstDir string) string {
 	return filepath.Join(dstDir, wsReadyIndicatorFile)
 }
@@ -309,6 +551,7 @@ func (f *DefaultFactory) clone(version string, dstDir string, markerDir string,
 	}
 
 	cloner, _ := git.NewCloner(&git.Client{}, repo, true, clientSet, f.logger)
+	cloner.WithCredentials(f.gitCredentials)
 	if err := cloner.CloneAndCheckout(dstDir, version); err != nil {
 		f.logger.Warnf("Deleting workspace '%s' because GIT clone of repository-URL '%s' with revision '%s' failed",
 			dstDir, repo.URL, version)
@@ -372,6 +615,7 @@ func (f *DefaultFactory) fetchComponent(component *Component, dstDir string) err
 		return err
 	}
 	cloner, _ := git.NewCloner(&git.Client{}, repo, true, clientSet, f.logger)
+	cloner.WithCredentials(f.gitCredentials)
 	return cloner.FetchAndCheckout(dstPath, component.version)
 }
 
@@ -397,7 +641,11 @@ func (f *DefaultFactory) copyComponentLatestRev(component *Component, baseDir st
 	if err != nil {
 		return "", err
 	}
-	wsDir := f.workspaceDir(fmt.Sprintf("%s-%s", rev[0:8], component.name))
+	verification, err := newVerification(component, f.verificationKeys)
+	if err != nil {
+		return "", err
+	}
+	wsDir := f.workspaceDir(fmt.Sprintf("%s-%s%s", rev[0:8], component.name, workspaceSuffix(verification)))
 
 	if f.readyMarkerExists(wsDir) {
 		return wsDir, nil
@@ -420,6 +668,12 @@ func (f *DefaultFactory) copyComponentLatestRev(component *Component, baseDir st
 	}); err != nil {
 		return "", err
 	}
+	if err := f.verifyCheckout(component, verification, destWsDir, rev); err != nil {
+		if removeErr := os.RemoveAll(wsDir); removeErr != nil {
+			f.logger.Warnf("Failed to delete unverified workspace '%s': %s", wsDir, removeErr)
+		}
+		return "", err
+	}
 	if err := f.createReadyMarker(wsDir); err != nil {
 		return "", err
 	}
@@ -427,6 +681,34 @@ func (f *DefaultFactory) copyComponentLatestRev(component *Component, baseDir st
 	return wsDir, nil
 }
 
+//verifyArchive verifies the integrity of a downloaded archive if the component configuration requires it
+func (f *DefaultFactory) verifyArchive(component *Component, verification *verification, archiveFile string) error {
+	if verification == nil {
+		return nil
+	}
+	data, err := ioutil.ReadFile(archiveFile)
+	if err != nil {
+		return err
+	}
+	if err := verification.verifyArchive(data, component.url); err != nil {
+		return err
+	}
+	f.logger.Debugf("Integrity of archive '%s' of component '%s' verified", component.url, component.name)
+	return nil
+}
+
+//verifyCheckout verifies the signature of a GIT checkout if the component configuration requires it
+func (f *DefaultFactory) verifyCheckout(component *Component, verification *verification, repoPath, revision string) error {
+	if verification == nil {
+		return nil
+	}
+	if err := verification.verifyCheckout(repoPath, component.version, revision); err != nil {
+		return err
+	}
+	f.logger.Debugf("Signature of revision '%s' of component '%s' verified", revision, component.name)
+	return nil
+}
+
 func (f *DefaultFactory) createReadyMarker(wsDir string) error {
 	fileHandler, err := os.Create(f.readyFile(wsDir))
 	if err != nil {
@@ -80,6 +80,24 @@ func TestWorkspaceFactory(t *testing.T) {
 		require.Equal(t, defaultRepositoryURL, wsf1.kymaRepository.URL)
 	})
 
+	t.Run("Lookup existing workspaces only", func(t *testing.T) {
+		wsf, err := NewFactory(nil, t.TempDir(), logger)
+		require.NoError(t, err)
+
+		ws, err := wsf.Lookup(version)
+		require.NoError(t, err)
+		require.Nil(t, ws)
+		require.False(t, file.DirExists(wsf.workspaceDir(version)))
+
+		for _, dir := range []string{resDir, instResDir, instResCrdDir} {
+			require.NoError(t, os.MkdirAll(filepath.Join(wsf.workspaceDir(version), dir), 0700))
+		}
+		require.NoError(t, ioutil.WriteFile(filepath.Join(wsf.workspaceDir(version), wsReadyIndicatorFile), nil, 0600))
+		ws, err = wsf.Lookup(version)
+		require.NoError(t, err)
+		require.Equal(t, filepath.Join(wsf.workspaceDir(version), resDir), ws.ResourceDir)
+	})
+
 	t.Run("Clone and delete workspace", func(t *testing.T) {
 		test.IntegrationTest(t)
 
@@ -138,13 +156,8 @@ func TestWorkspaceFactory(t *testing.T) {
 	defer server.Close()
 
 	t.Run("Create external component from archive", func(t *testing.T) {
-		factory := &DefaultFactory{logger: logger, storageDir: storageDir}
-
-		_, err := ioutil.TempDir(factory.storageDir, "test_*")
-		if err != nil {
-			t.Error(err)
-			return
-		}
+		//workspaces and GIT mirrors are written to a temporary directory which is removed after the test
+		factory := &DefaultFactory{logger: logger, storageDir: t.TempDir()}
 
 		fis := assertFileInfos(t, rscdir)
 		vds := make([]string, len(fis))
@@ -162,13 +175,8 @@ func TestWorkspaceFactory(t *testing.T) {
 	})
 
 	t.Run("race-condition", func(t *testing.T) {
-		factory := &DefaultFactory{logger: logger, storageDir: storageDir}
-
-		_, err := ioutil.TempDir(factory.storageDir, "test_*")
-		if err != nil {
-			t.Error(err)
-			return
-		}
+		//workspaces and GIT mirrors are written to a temporary directory which is removed after the test
+		factory := &DefaultFactory{logger: logger, storageDir: t.TempDir()}
 
 		fis := assertFileInfos(t, rscdir)
 
@@ -246,12 +254,7 @@ func Test_ExternalGitComponent(t *testing.T) {
 	test.IntegrationTest(t)
 
 	logger := log.NewLogger(true)
-	factory := &DefaultFactory{logger: logger, storageDir: storageDir}
-	_, err := ioutil.TempDir(factory.storageDir, "test_*")
-	if err != nil {
-		t.Error(err)
-		return
-	}
+	factory := &DefaultFactory{logger: logger, storageDir: t.TempDir()}
 
 	c := &Component{
 		version: "master",
@@ -13,6 +13,7 @@ import (
 	"helm.sh/helm/v3/pkg/chart"
 	"helm.sh/helm/v3/pkg/chart/loader"
 	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/release"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 )
 
@@ -31,28 +32,33 @@ func NewHelmClient(chartDir string, logger *zap.SugaredLogger) (*HelmClient, err
 	}, nil
 }
 
-func (c *HelmClient) Render(component *Component) (string, error) {
+// RenderRelease renders the component as Helm release (including chart, values and hooks)
+func (c *HelmClient) RenderRelease(component *Component) (*release.Release, error) {
+	return c.render(component)
+}
+
+func (c *HelmClient) render(component *Component) (*release.Release, error) {
 	helmChart, err := loader.Load(filepath.Join(c.chartDir, component.name))
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	config, err := c.mergeChartConfiguration(helmChart, component, false)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	tplAction, err := c.newTemplatingAction(component)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	helmRelease, err := tplAction.Run(helmChart, config)
 	if err != nil || helmRelease == nil {
-		return "", errors.Wrap(err, fmt.Sprintf("Failed to render HELM template for component '%s'", component.name))
+		return nil, errors.Wrap(err, fmt.Sprintf("Failed to render HELM template for component '%s'", component.name))
 	}
 
-	return helmRelease.Manifest, nil
+	return helmRelease, nil
 }
 
 func (c *HelmClient) newTemplatingAction(component *Component) (*action.Install, error) {
@@ -99,6 +105,13 @@ func (c *HelmClient) mergeChartConfiguration(chart *chart.Chart, component *Comp
 		return nil, err
 	}
 
+	if len(component.profileValues) > 0 {
+		if err := mergo.Merge(&result, component.profileValues, mergo.WithOverride); err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("failed to merge profile configuration with custom profile "+
+				"values for component '%s'", component.name))
+		}
+	}
+
 	componentConfig, err := component.Configuration()
 	if err != nil {
 		return nil, err
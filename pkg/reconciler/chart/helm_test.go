@@ -154,6 +154,39 @@ func TestHelm(t *testing.T) {
 		require.Equal(t, expected, got)
 	})
 
+	t.Run("Merge chart configuration with custom profile values", func(t *testing.T) {
+		component := NewComponentBuilder("main", componentName).
+			WithNamespace("testNamespace").
+			WithProfile(profileName).
+			WithProfileValues(map[string]interface{}{
+				"config": map[string]interface{}{
+					"key1": "value1 from custom profile",
+					"key2": "value2 from custom profile",
+				},
+			}).
+			WithConfiguration(map[string]interface{}{
+				"config.key2": "value2 from component",
+			}).
+			Build()
+
+		helm, err := NewHelmClient(chartDir, logger)
+		require.NoError(t, err)
+
+		got, err := helm.mergeChartConfiguration(loadHelmChart(t, component), component, false)
+		require.NoError(t, err)
+
+		var expected map[string]interface{}
+		err = json.Unmarshal([]byte(`{
+			"config": {
+				"key1": "value1 from custom profile",
+				"key2": "value2 from component"
+			},
+			"profile": true
+		}`), &expected)
+		require.NoError(t, err)
+		require.Equal(t, expected, got)
+	})
+
 	t.Run("Render template", func(t *testing.T) {
 		component := NewComponentBuilder("main", componentName).
 			WithNamespace("testNamespace").
@@ -167,10 +200,10 @@ func TestHelm(t *testing.T) {
 		helm, err := NewHelmClient(chartDir, logger)
 		require.NoError(t, err)
 
-		got, err := helm.Render(component)
+		got, err := helm.RenderRelease(component)
 		require.NoError(t, err)
 		gotAsMap := make(map[string]interface{})
-		require.NoError(t, yaml.Unmarshal([]byte(got), &gotAsMap)) //use for equality check (avoids whitespace diffs)
+		require.NoError(t, yaml.Unmarshal([]byte(got.Manifest), &gotAsMap)) //use for equality check (avoids whitespace diffs)
 
 		expected, err := ioutil.ReadFile(filepath.Join(chartDir, componentName, "configmap-expected.yaml"))
 		require.NoError(t, err)
@@ -179,6 +212,38 @@ func TestHelm(t *testing.T) {
 
 		require.Equal(t, expectedAsMap, gotAsMap)
 	})
+
+	t.Run("Render template with hooks", func(t *testing.T) {
+		component := NewComponentBuilder("main", componentName).
+			WithNamespace("testNamespace").
+			WithProfile(profileName).
+			Build()
+
+		helm, err := NewHelmClient(chartDir, logger)
+		require.NoError(t, err)
+
+		rel, err := helm.RenderRelease(component)
+		require.NoError(t, err)
+		hooks := newHooks(rel.Hooks)
+		require.NotContains(t, rel.Manifest, "component-1-migration") //hooks are not part of the manifest
+		require.Len(t, hooks, 3)
+
+		preInstall := hooks.Filter(PreInstallHook)
+		require.Len(t, preInstall, 2)
+		require.Equal(t, "component-1-prepare", preInstall[0].Name) //ordered by weight
+		require.Equal(t, -5, preInstall[0].Weight)
+		require.True(t, preInstall[0].HasDeletePolicy(BeforeHookCreationPolicy))
+		require.Equal(t, "component-1-migration", preInstall[1].Name)
+		require.Equal(t, "ConfigMap", preInstall[1].Kind)
+		require.Contains(t, preInstall[1].Manifest, "value1 from profile.yaml")
+		require.True(t, preInstall[1].HasDeletePolicy(HookSucceededPolicy))
+		require.True(t, preInstall[1].HasDeletePolicy(HookFailedPolicy))
+		require.False(t, preInstall[1].HasDeletePolicy(BeforeHookCreationPolicy))
+
+		require.Len(t, hooks.Filter(PreUpgradeHook), 1)
+		require.Len(t, hooks.Filter(PostDeleteHook), 1)
+		require.Empty(t, hooks.Filter(PostInstallHook))
+	})
 }
 
 func loadHelmChart(t *testing.T, component *Component) *chart.Chart {
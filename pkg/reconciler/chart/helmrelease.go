@@ -0,0 +1,193 @@
+package chart
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"helm.sh/helm/v3/pkg/release"
+	"helm.sh/helm/v3/pkg/storage"
+	"helm.sh/helm/v3/pkg/storage/driver"
+	helmtime "helm.sh/helm/v3/pkg/time"
+	"k8s.io/client-go/kubernetes"
+)
+
+type ReleaseMode string
+
+const (
+	//NoReleaseMode doesn't write any Helm release records (default)
+	NoReleaseMode ReleaseMode = "none"
+	//RecordReleaseMode records each deployment of a component as Helm release. Releases which
+	//were not created by the reconciler are left untouched.
+	RecordReleaseMode ReleaseMode = "record"
+	//AdoptReleaseMode records deployments as Helm releases and continues the history of
+	//existing releases which were created by Helm (or the Kyma installer)
+	AdoptReleaseMode ReleaseMode = "adopt"
+
+	//ReleaseDescription identifies Helm releases which were created by the reconciler
+	ReleaseDescription = "Deployed by Kyma reconciler"
+	maxReleaseHistory  = 10
+)
+
+func NewReleaseMode(mode string) (ReleaseMode, error) {
+	switch strings.ToLower(mode) {
+	case "", string(NoReleaseMode):
+		return NoReleaseMode, nil
+	case string(RecordReleaseMode):
+		return RecordReleaseMode, nil
+	case string(AdoptReleaseMode):
+		return AdoptReleaseMode, nil
+	default:
+		return "", fmt.Errorf("Helm release mode '%s' is not supported (supported are '%s', '%s' and '%s')",
+			mode, NoReleaseMode, RecordReleaseMode, AdoptReleaseMode)
+	}
+}
+
+func (m ReleaseMode) Enabled() bool {
+	return m == RecordReleaseMode || m == AdoptReleaseMode
+}
+
+// ReleaseRecorder writes Helm-compatible release records (stored as secrets in the release namespace)
+// which allow the inspection and rollback of components by the Helm CLI
+type ReleaseRecorder struct {
+	storage *storage.Storage
+	mode    ReleaseMode
+	logger  *zap.SugaredLogger
+	now     func() time.Time
+}
+
+func NewReleaseRecorder(clientset kubernetes.Interface, namespace string, mode ReleaseMode, logger *zap.SugaredLogger) *ReleaseRecorder {
+	secretsDriver := driver.NewSecrets(clientset.CoreV1().Secrets(namespace))
+	secretsDriver.Log = logger.Debugf
+	releaseStorage := storage.Init(secretsDriver)
+	releaseStorage.MaxHistory = maxReleaseHistory
+	return &ReleaseRecorder{
+		storage: releaseStorage,
+		mode:    mode,
+		logger:  logger,
+		now:     time.Now,
+	}
+}
+
+// Record stores the deployed manifest as new revision of the component's Helm release. Previously
+// deployed revisions are marked as superseded.
+func (r *ReleaseRecorder) Record(manifest *Manifest) error {
+	if !r.mode.Enabled() || manifest.Release == nil {
+		return nil
+	}
+	rendered := manifest.Release
+
+	history, err := r.history(rendered.Name)
+	if err != nil {
+		return err
+	}
+
+	latest := latestRelease(history)
+	if latest != nil && !IsReconcilerRelease(latest) {
+		if r.mode != AdoptReleaseMode {
+			r.logger.Warnf("Helm release '%s' (revision %d) wasn't created by the reconciler: "+
+				"skipping release record (use Helm release mode '%s' to adopt the release)",
+				latest.Name, latest.Version, AdoptReleaseMode)
+			return nil
+		}
+		r.logger.Infof("Adopting Helm release '%s' (revision %d, status '%s')",
+			latest.Name, latest.Version, latest.Info.Status)
+	}
+
+	now := helmtime.Time{Time: r.now()}
+	newRelease := &release.Release{
+		Name:      rendered.Name,
+		Namespace: rendered.Namespace,
+		Chart:     rendered.Chart,
+		Config:    rendered.Config,
+		Manifest:  rendered.Manifest,
+		Hooks:     rendered.Hooks,
+		Version:   1,
+		Info: &release.Info{
+			FirstDeployed: now,
+			LastDeployed:  now,
+			Status:        release.StatusDeployed,
+			Description:   ReleaseDescription,
+		},
+	}
+	if latest != nil {
+		newRelease.Version = latest.Version + 1
+		if latest.Info != nil {
+			newRelease.Info.FirstDeployed = latest.Info.FirstDeployed
+		}
+	}
+
+	for _, rel := range history {
+		if rel.Info == nil || rel.Info.Status != release.StatusDeployed {
+			continue
+		}
+		rel.Info.Status = release.StatusSuperseded
+		if err := r.storage.Update(rel); err != nil {
+			return errors.Wrapf(err, "failed to supersede revision %d of Helm release '%s'", rel.Version, rel.Name)
+		}
+	}
+
+	if err := r.storage.Create(newRelease); err != nil {
+		return errors.Wrapf(err, "failed to record revision %d of Helm release '%s'", newRelease.Version, newRelease.Name)
+	}
+	r.logger.Debugf("Recorded revision %d of Helm release '%s'", newRelease.Version, newRelease.Name)
+	return nil
+}
+
+// Exists returns true if a revision of the Helm release is recorded on the cluster
+func (r *ReleaseRecorder) Exists(name string) (bool, error) {
+	history, err := r.history(name)
+	if err != nil {
+		return false, err
+	}
+	return len(history) > 0, nil
+}
+
+// Remove deletes all revisions of a Helm release. Releases which were not created by the
+// reconciler are only deleted in adopt mode.
+func (r *ReleaseRecorder) Remove(name string) error {
+	if !r.mode.Enabled() {
+		return nil
+	}
+	history, err := r.history(name)
+	if err != nil {
+		return err
+	}
+	if latest := latestRelease(history); latest != nil && !IsReconcilerRelease(latest) && r.mode != AdoptReleaseMode {
+		r.logger.Warnf("Helm release '%s' (revision %d) wasn't created by the reconciler: "+
+			"skipping deletion of release (use Helm release mode '%s' to adopt the release)",
+			latest.Name, latest.Version, AdoptReleaseMode)
+		return nil
+	}
+	for _, rel := range history {
+		if _, err := r.storage.Delete(rel.Name, rel.Version); err != nil && err != driver.ErrReleaseNotFound {
+			return errors.Wrapf(err, "failed to delete revision %d of Helm release '%s'", rel.Version, rel.Name)
+		}
+	}
+	return nil
+}
+
+func (r *ReleaseRecorder) history(name string) ([]*release.Release, error) {
+	history, err := r.storage.History(name)
+	if err != nil && err != driver.ErrReleaseNotFound {
+		return nil, errors.Wrapf(err, "failed to retrieve history of Helm release '%s'", name)
+	}
+	return history, nil
+}
+
+func latestRelease(history []*release.Release) *release.Release {
+	var latest *release.Release
+	for _, rel := range history {
+		if latest == nil || rel.Version > latest.Version {
+			latest = rel
+		}
+	}
+	return latest
+}
+
+// IsReconcilerRelease returns true if the release was recorded by the reconciler
+func IsReconcilerRelease(rel *release.Release) bool {
+	return rel.Info != nil && rel.Info.Description == ReleaseDescription
+}
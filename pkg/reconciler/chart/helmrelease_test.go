@@ -0,0 +1,127 @@
+package chart
+
+import (
+	"testing"
+	"time"
+
+	log "github.com/kyma-incubator/reconciler/pkg/logger"
+	"github.com/stretchr/testify/require"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/release"
+	"helm.sh/helm/v3/pkg/storage"
+	"helm.sh/helm/v3/pkg/storage/driver"
+	helmtime "helm.sh/helm/v3/pkg/time"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestReleaseMode(t *testing.T) {
+	for _, mode := range []string{"", "none", "record", "ADOPT"} {
+		_, err := NewReleaseMode(mode)
+		require.NoError(t, err)
+	}
+	_, err := NewReleaseMode("overwrite")
+	require.Error(t, err)
+
+	require.False(t, NoReleaseMode.Enabled())
+	require.True(t, RecordReleaseMode.Enabled())
+	require.True(t, AdoptReleaseMode.Enabled())
+}
+
+func TestReleaseRecorder(t *testing.T) {
+	logger := log.NewLogger(true)
+
+	newManifest := func() *Manifest {
+		return &Manifest{
+			Type: HelmChart,
+			Name: "component-1",
+			Release: &release.Release{
+				Name:      "component-1",
+				Namespace: "kyma-system",
+				Chart:     &chart.Chart{Metadata: &chart.Metadata{Name: "component-1", Version: "1.0.0"}},
+				Config:    map[string]interface{}{"key": "value"},
+				Manifest:  "kind: ConfigMap",
+			},
+		}
+	}
+
+	newStorage := func(clientset *fake.Clientset) *storage.Storage {
+		return storage.Init(driver.NewSecrets(clientset.CoreV1().Secrets("kyma-system")))
+	}
+
+	t.Run("Record releases", func(t *testing.T) {
+		clientset := fake.NewSimpleClientset()
+		recorder := NewReleaseRecorder(clientset, "kyma-system", RecordReleaseMode, logger)
+
+		exists, err := recorder.Exists("component-1")
+		require.NoError(t, err)
+		require.False(t, exists)
+
+		require.NoError(t, recorder.Record(newManifest()))
+		require.NoError(t, recorder.Record(newManifest()))
+
+		exists, err = recorder.Exists("component-1")
+		require.NoError(t, err)
+		require.True(t, exists)
+
+		history, err := newStorage(clientset).History("component-1")
+		require.NoError(t, err)
+		require.Len(t, history, 2)
+		for _, rel := range history {
+			require.True(t, IsReconcilerRelease(rel))
+			if rel.Version == 1 {
+				require.Equal(t, release.StatusSuperseded, rel.Info.Status)
+			} else {
+				require.Equal(t, 2, rel.Version)
+				require.Equal(t, release.StatusDeployed, rel.Info.Status)
+				require.Equal(t, "kind: ConfigMap", rel.Manifest)
+			}
+		}
+
+		require.NoError(t, recorder.Remove("component-1"))
+		_, err = newStorage(clientset).History("component-1")
+		require.Equal(t, driver.ErrReleaseNotFound, err)
+	})
+
+	t.Run("Skip or adopt foreign releases", func(t *testing.T) {
+		clientset := fake.NewSimpleClientset()
+		firstDeployed := helmtime.Time{Time: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)}
+		foreign := newManifest().Release
+		foreign.Version = 3
+		foreign.Info = &release.Info{
+			FirstDeployed: firstDeployed,
+			LastDeployed:  firstDeployed,
+			Status:        release.StatusDeployed,
+			Description:   "Install complete",
+		}
+		require.NoError(t, newStorage(clientset).Create(foreign))
+
+		//record mode doesn't touch foreign releases
+		recorder := NewReleaseRecorder(clientset, "kyma-system", RecordReleaseMode, logger)
+		require.NoError(t, recorder.Record(newManifest()))
+		history, err := newStorage(clientset).History("component-1")
+		require.NoError(t, err)
+		require.Len(t, history, 1)
+		require.NoError(t, recorder.Remove("component-1"))
+		history, err = newStorage(clientset).History("component-1")
+		require.NoError(t, err)
+		require.Len(t, history, 1)
+
+		//adopt mode continues the history
+		recorder = NewReleaseRecorder(clientset, "kyma-system", AdoptReleaseMode, logger)
+		require.NoError(t, recorder.Record(newManifest()))
+		latest, err := newStorage(clientset).Last("component-1")
+		require.NoError(t, err)
+		require.Equal(t, 4, latest.Version)
+		require.True(t, IsReconcilerRelease(latest))
+		require.True(t, firstDeployed.Equal(latest.Info.FirstDeployed))
+
+		previous, err := newStorage(clientset).Get("component-1", 3)
+		require.NoError(t, err)
+		require.Equal(t, release.StatusSuperseded, previous.Info.Status)
+
+		//adopted releases are removed in adopt mode
+		require.NoError(t, recorder.Remove("component-1"))
+		_, err = newStorage(clientset).History("component-1")
+		require.Equal(t, driver.ErrReleaseNotFound, err)
+	})
+}
@@ -0,0 +1,105 @@
+package chart
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+	"helm.sh/helm/v3/pkg/getter"
+	"helm.sh/helm/v3/pkg/repo"
+)
+
+const (
+	helmRepoIndexFile = "index.yaml"
+	//RepoChartNameConfigKey is the component configuration key used to define the name of the chart in a Helm
+	//repository (the component name is used if undefined)
+	RepoChartNameConfigKey = "repo.chart.name"
+)
+
+var helmGetters = getter.Providers{
+	{
+		Schemes: []string{"http", "https"},
+		New:     getter.NewHTTPGetter,
+	},
+}
+
+//isHelmRepository returns true if the URL points to the index file of a Helm repository
+//(e.g. 'https://charts.example.com/index.yaml')
+func isHelmRepository(componentURL string) bool {
+	parsed, err := url.Parse(componentURL)
+	if err != nil {
+		return false
+	}
+	return strings.HasSuffix(parsed.Path, "/"+helmRepoIndexFile)
+}
+
+//pullRepositoryChart downloads the chart archive of a component from a Helm repository. The chart is resolved by its
+//name and the component version (the latest chart version is used if the component has no version).
+func pullRepositoryChart(indexURL, chartName, version string, credentials *repositoryCredentials) ([]byte, error) {
+	repoURL := strings.TrimSuffix(strings.TrimSuffix(indexURL, helmRepoIndexFile), "/")
+
+	cacheDir, err := ioutil.TempDir("", "helm-repository-*")
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = os.RemoveAll(cacheDir)
+	}()
+
+	entry := &repo.Entry{
+		Name: "component",
+		URL:  repoURL,
+	}
+	var getterOpts []getter.Option
+	if credentials != nil {
+		entry.Username = credentials.username
+		entry.Password = credentials.password
+		getterOpts = append(getterOpts, getter.WithBasicAuth(credentials.username, credentials.password))
+	}
+	chartRepo, err := repo.NewChartRepository(entry, helmGetters)
+	if err != nil {
+		return nil, err
+	}
+	chartRepo.CachePath = cacheDir
+
+	indexFile, err := chartRepo.DownloadIndexFile()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to download index of Helm repository '%s'", repoURL)
+	}
+	index, err := repo.LoadIndexFile(indexFile)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to load index of Helm repository '%s'", repoURL)
+	}
+	chartVersion, err := index.Get(chartName, version)
+	if err != nil {
+		return nil, errors.Wrapf(err, "chart '%s' with version '%s' not found in Helm repository '%s'",
+			chartName, version, repoURL)
+	}
+	if len(chartVersion.URLs) == 0 {
+		return nil, fmt.Errorf("chart '%s' with version '%s' in Helm repository '%s' has no download URL",
+			chartName, chartVersion.Version, repoURL)
+	}
+
+	chartURL, err := repo.ResolveReferenceURL(repoURL, chartVersion.URLs[0])
+	if err != nil {
+		return nil, err
+	}
+	chartGetter, err := helmGetters.ByScheme(strings.SplitN(chartURL, ":", 2)[0])
+	if err != nil {
+		return nil, err
+	}
+	//send credentials only to the host of the repository
+	if parsedChartURL, err := url.Parse(chartURL); err == nil {
+		if parsedRepoURL, err := url.Parse(repoURL); err == nil && parsedChartURL.Host != parsedRepoURL.Host {
+			getterOpts = nil
+		}
+	}
+	data, err := chartGetter.Get(chartURL, getterOpts...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to download chart '%s'", chartURL)
+	}
+	return data.Bytes(), nil
+}
@@ -0,0 +1,99 @@
+package chart
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	log "github.com/kyma-incubator/reconciler/pkg/logger"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHelmRepository(t *testing.T) {
+	chartData := packageTestChart(t)
+
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		switch r.URL.Path {
+		case "/charts/index.yaml":
+			_, _ = w.Write([]byte(fmt.Sprintf(`apiVersion: v1
+entries:
+  my-chart:
+  - name: my-chart
+    version: 1.0.0
+    urls:
+    - my-chart-1.0.0.tgz
+  - name: my-chart
+    version: 1.1.0
+    urls:
+    - %s/charts/my-chart-1.1.0.tgz
+`, "http://"+r.Host)))
+		case "/charts/my-chart-1.0.0.tgz", "/charts/my-chart-1.1.0.tgz":
+			_, _ = w.Write(chartData)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	require.True(t, isHelmRepository(server.URL+"/charts/index.yaml"))
+	require.False(t, isHelmRepository(server.URL+"/charts/my-chart-1.0.0.tgz"))
+
+	t.Run("Pull chart", func(t *testing.T) {
+		for _, version := range []string{"1.0.0", "1.1.0", ""} {
+			data, err := pullRepositoryChart(server.URL+"/charts/index.yaml", "my-chart", version, nil)
+			require.NoError(t, err)
+			require.Equal(t, chartData, data)
+		}
+		_, err := pullRepositoryChart(server.URL+"/charts/index.yaml", "my-chart", "2.0.0", nil)
+		require.Error(t, err)
+	})
+
+	t.Run("Get external component from Helm repository", func(t *testing.T) {
+		storageDir, err := ioutil.TempDir("", "helmrepository_*")
+		require.NoError(t, err)
+		defer func() {
+			require.NoError(t, os.RemoveAll(storageDir))
+		}()
+		factory, err := NewFactory(nil, storageDir, log.NewLogger(true))
+		require.NoError(t, err)
+
+		component := NewComponentBuilder("1.0.0", "my-component").
+			WithURL(server.URL + "/charts/index.yaml").
+			WithConfiguration(map[string]interface{}{RepoChartNameConfigKey: "my-chart"}).
+			Build()
+		ws, err := factory.GetExternalComponent(component)
+		require.NoError(t, err)
+		require.FileExists(t, filepath.Join(ws.WorkspaceDir, "my-component", "Chart.yaml"))
+		require.FileExists(t, filepath.Join(ws.WorkspaceDir, wsReadyIndicatorFile))
+
+		//cached workspace is used
+		requestsBefore := requests
+		ws2, err := factory.GetExternalComponent(component)
+		require.NoError(t, err)
+		require.Equal(t, ws.WorkspaceDir, ws2.WorkspaceDir)
+		require.Equal(t, requestsBefore, requests)
+
+		//digest mismatch fails and doesn't create a workspace
+		component = NewComponentBuilder("1.1.0", "my-component").
+			WithURL(server.URL + "/charts/index.yaml").
+			WithConfiguration(map[string]interface{}{
+				RepoChartNameConfigKey: "my-chart",
+				VerifyDigestConfigKey:  fmt.Sprintf("sha256:%x", sha256.Sum256([]byte("other"))),
+			}).
+			Build()
+		_, err = factory.GetExternalComponent(component)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "integrity verification of component 'my-component' failed")
+
+		component.configuration[VerifyDigestConfigKey] = fmt.Sprintf("sha256:%x", sha256.Sum256(chartData))
+		_, err = factory.GetExternalComponent(component)
+		require.NoError(t, err)
+	})
+}
@@ -0,0 +1,100 @@
+package chart
+
+import (
+	"sort"
+
+	"helm.sh/helm/v3/pkg/release"
+)
+
+type HookEvent string
+
+const (
+	PreInstallHook  HookEvent = "pre-install"
+	PostInstallHook HookEvent = "post-install"
+	PreUpgradeHook  HookEvent = "pre-upgrade"
+	PostUpgradeHook HookEvent = "post-upgrade"
+	PreDeleteHook   HookEvent = "pre-delete"
+	PostDeleteHook  HookEvent = "post-delete"
+)
+
+type HookDeletePolicy string
+
+const (
+	//BeforeHookCreationPolicy deletes a previous hook resource before a new one is created (default policy)
+	BeforeHookCreationPolicy HookDeletePolicy = "before-hook-creation"
+	//HookSucceededPolicy deletes the hook resource after the hook was successfully executed
+	HookSucceededPolicy HookDeletePolicy = "hook-succeeded"
+	//HookFailedPolicy deletes the hook resource if the hook failed
+	HookFailedPolicy HookDeletePolicy = "hook-failed"
+)
+
+// Hook is a resource of a chart which is annotated with 'helm.sh/hook': it's not part of the
+// rendered manifest and has to be applied when one of its events occurs.
+type Hook struct {
+	Name           string
+	Kind           string
+	Manifest       string
+	Events         []HookEvent
+	Weight         int
+	DeletePolicies []HookDeletePolicy
+}
+
+func (h *Hook) HasEvent(event HookEvent) bool {
+	for _, hookEvent := range h.Events {
+		if hookEvent == event {
+			return true
+		}
+	}
+	return false
+}
+
+// HasDeletePolicy returns true if the policy is defined for the hook. Hooks without
+// any delete policy use the 'before-hook-creation' policy (same as in Helm).
+func (h *Hook) HasDeletePolicy(policy HookDeletePolicy) bool {
+	if len(h.DeletePolicies) == 0 {
+		return policy == BeforeHookCreationPolicy
+	}
+	for _, deletePolicy := range h.DeletePolicies {
+		if deletePolicy == policy {
+			return true
+		}
+	}
+	return false
+}
+
+type Hooks []*Hook
+
+// Filter returns the hooks of an event ordered by their weight (hooks with the same
+// weight keep the order in which they were rendered)
+func (h Hooks) Filter(event HookEvent) Hooks {
+	var result Hooks
+	for _, hook := range h {
+		if hook.HasEvent(event) {
+			result = append(result, hook)
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Weight < result[j].Weight
+	})
+	return result
+}
+
+func newHooks(releaseHooks []*release.Hook) Hooks {
+	var result Hooks
+	for _, releaseHook := range releaseHooks {
+		hook := &Hook{
+			Name:     releaseHook.Name,
+			Kind:     releaseHook.Kind,
+			Manifest: releaseHook.Manifest,
+			Weight:   releaseHook.Weight,
+		}
+		for _, event := range releaseHook.Events {
+			hook.Events = append(hook.Events, HookEvent(event))
+		}
+		for _, policy := range releaseHook.DeletePolicies {
+			hook.DeletePolicies = append(hook.DeletePolicies, HookDeletePolicy(policy))
+		}
+		result = append(result, hook)
+	}
+	return result
+}
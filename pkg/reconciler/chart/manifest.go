@@ -3,6 +3,9 @@ package chart
 import (
 	"bytes"
 	"fmt"
+	"strings"
+
+	"helm.sh/helm/v3/pkg/release"
 )
 
 type ManifestType string
@@ -16,6 +19,8 @@ type Manifest struct {
 	Type     ManifestType
 	Name     string
 	Manifest string
+	Hooks    Hooks            //hooks of Helm charts which are not included in the manifest
+	Release  *release.Release //rendered Helm release (only set for Helm charts)
 }
 
 func MergeManifests(manifests ...*Manifest) string {
@@ -28,3 +33,23 @@ func MergeManifests(manifests ...*Manifest) string {
 	}
 	return buffer.String()
 }
+
+//MergeManifestsWithHooks merges the manifests including their Helm hooks (e.g. to display the rendered resources)
+func MergeManifestsWithHooks(manifests ...*Manifest) string {
+	var buffer bytes.Buffer
+	buffer.WriteString(MergeManifests(manifests...))
+	for _, manifest := range manifests {
+		for _, hook := range manifest.Hooks {
+			events := make([]string, 0, len(hook.Events))
+			for _, event := range hook.Events {
+				events = append(events, string(event))
+			}
+			buffer.WriteString("---\n")
+			buffer.WriteString(fmt.Sprintf("# Hook '%s' of %s '%s' (events: %s, weight: %d)\n",
+				hook.Name, manifest.Type, manifest.Name, strings.Join(events, ","), hook.Weight))
+			buffer.WriteString(hook.Manifest)
+			buffer.WriteString("\n")
+		}
+	}
+	return buffer.String()
+}
@@ -0,0 +1,93 @@
+package chart
+
+import (
+	"crypto/sha1" //nolint
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/kyma-incubator/reconciler/pkg/reconciler"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/git"
+	reconcilerK8s "github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes"
+	"github.com/pkg/errors"
+)
+
+const gitMirrorsDir = "mirrors"
+
+//keyedMutex provides a mutex per key (e.g. per workspace or GIT mirror) so that unrelated keys don't block each other
+type keyedMutex struct {
+	mu    sync.Mutex
+	locks map[string]*refCountedMutex
+}
+
+type refCountedMutex struct {
+	sync.Mutex
+	refs int
+}
+
+//lock blocks until the mutex of the key is acquired and returns the function which releases it
+func (k *keyedMutex) lock(key string) func() {
+	k.mu.Lock()
+	if k.locks == nil {
+		k.locks = make(map[string]*refCountedMutex)
+	}
+	mutex, ok := k.locks[key]
+	if !ok {
+		mutex = &refCountedMutex{}
+		k.locks[key] = mutex
+	}
+	mutex.refs++
+	k.mu.Unlock()
+
+	mutex.Lock()
+	return func() {
+		mutex.Unlock()
+		k.mu.Lock()
+		defer k.mu.Unlock()
+		mutex.refs--
+		if mutex.refs == 0 {
+			delete(k.locks, key)
+		}
+	}
+}
+
+func (f *DefaultFactory) mirrorDir(repoURL string) string {
+	return filepath.Join(f.storageDir, gitMirrorsDir, fmt.Sprintf("%x", sha1.Sum([]byte(repoURL)))) //nolint
+}
+
+//checkout exports the given revision of the repository from its shared bare mirror into the workspace directory
+func (f *DefaultFactory) checkout(version string, dstDir string, repo *reconciler.Repository) error {
+	mirrorDir := f.mirrorDir(repo.URL)
+	unlock := f.mirrorLocks.lock(mirrorDir)
+	defer unlock()
+
+	f.logger.Infof("Checking out revision '%s' of GIT repository '%s' from mirror '%s' into workspace '%s'",
+		version, repo.URL, mirrorDir, dstDir)
+
+	clientSet, err := reconcilerK8s.NewInClusterClientSet(f.logger)
+	if err != nil {
+		return err
+	}
+	cloner, _ := git.NewCloner(&git.Client{}, repo, false, clientSet, f.logger)
+	cloner.WithCredentials(f.gitCredentials)
+
+	hash, err := cloner.ExportFromMirror(mirrorDir, dstDir, version)
+	if err != nil {
+		f.logger.Warnf("Deleting workspace '%s' because checkout of revision '%s' of GIT repository '%s' failed",
+			dstDir, version, repo.URL)
+		if removeErr := os.RemoveAll(dstDir); removeErr != nil {
+			err = errors.Wrap(err, removeErr.Error())
+		}
+		return err
+	}
+	f.logger.Debugf("Revision '%s' of GIT repository '%s' resolved to commit '%s'", version, repo.URL, hash)
+
+	//the marker of the mirror tracks its last usage: mirrors are garbage collected like workspaces
+	if err := f.createReadyMarker(mirrorDir); err != nil {
+		return err
+	}
+
+	//create a marker file to flag success
+	return f.createReadyMarker(dstDir)
+}
@@ -0,0 +1,129 @@
+package chart
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	file "github.com/kyma-incubator/reconciler/pkg/files"
+	log "github.com/kyma-incubator/reconciler/pkg/logger"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKymaWorkspaceFromMirror(t *testing.T) {
+	repoDir, commit := newTestKymaRepository(t)
+
+	storageDir := t.TempDir()
+	wsf, err := NewFactory(&reconciler.Repository{URL: repoDir}, storageDir, log.NewLogger(true))
+	require.NoError(t, err)
+
+	t.Run("Export tag and branch from the same mirror", func(t *testing.T) {
+		ws, err := wsf.Get("1.0.0")
+		require.NoError(t, err)
+		require.True(t, file.Exists(filepath.Join(ws.ResourceDir, "component", "Chart.yaml")))
+		require.False(t, file.Exists(filepath.Join(ws.ResourceDir, "next", "Chart.yaml")))
+		require.False(t, file.DirExists(filepath.Join(ws.WorkspaceDir, ".git")))
+
+		ws, err = wsf.Get("master")
+		require.NoError(t, err)
+		require.True(t, file.Exists(filepath.Join(ws.ResourceDir, "next", "Chart.yaml")))
+
+		mirrors, err := ioutil.ReadDir(filepath.Join(storageDir, gitMirrorsDir))
+		require.NoError(t, err)
+		require.Len(t, mirrors, 1)
+	})
+
+	t.Run("Fetch new commits incrementally", func(t *testing.T) {
+		head := commit(filepath.Join(resDir, "other", "Chart.yaml"))
+
+		ws, err := wsf.Get(head.String())
+		require.NoError(t, err)
+		require.True(t, file.Exists(filepath.Join(ws.ResourceDir, "other", "Chart.yaml")))
+	})
+
+	t.Run("Prepare versions concurrently", func(t *testing.T) {
+		head := commit(filepath.Join(resDir, "concurrent", "Chart.yaml"))
+
+		var wg sync.WaitGroup
+		for _, version := range []string{"1.0.0", "master", head.String(), head.String()[0:8], "master"} {
+			wg.Add(1)
+			go func(version string) {
+				defer wg.Done()
+				_, err := wsf.Get(version)
+				require.NoError(t, err)
+			}(version)
+		}
+		wg.Wait()
+	})
+
+	t.Run("Unknown revision", func(t *testing.T) {
+		_, err := wsf.Get("2.0.0")
+		require.Error(t, err)
+		require.False(t, file.DirExists(wsf.workspaceDir("2.0.0")))
+	})
+}
+
+func TestKeyedMutex(t *testing.T) {
+	var locks keyedMutex
+	unlockA := locks.lock("a")
+
+	//other keys are not blocked
+	unlockB := locks.lock("b")
+	unlockB()
+
+	locked := make(chan struct{})
+	go func() {
+		unlock := locks.lock("a")
+		close(locked)
+		unlock()
+	}()
+	select {
+	case <-locked:
+		t.Fatal("key 'a' was locked twice")
+	case <-time.After(100 * time.Millisecond):
+	}
+	unlockA()
+	<-locked
+
+	locks.mu.Lock()
+	defer locks.mu.Unlock()
+	require.Empty(t, locks.locks)
+}
+
+//newTestKymaRepository creates a GIT repository which contains a Kyma workspace: the revision '1.0.0' is tagged,
+//branch 'master' contains an additional component. The returned function commits further files.
+func newTestKymaRepository(t *testing.T) (string, func(fileName string) plumbing.Hash) {
+	repoDir := t.TempDir()
+	repo, err := gogit.PlainInit(repoDir, false)
+	require.NoError(t, err)
+	worktree, err := repo.Worktree()
+	require.NoError(t, err)
+
+	commit := func(fileName string) plumbing.Hash {
+		filePath := filepath.Join(repoDir, fileName)
+		require.NoError(t, os.MkdirAll(filepath.Dir(filePath), 0700))
+		require.NoError(t, ioutil.WriteFile(filePath, []byte(fileName), 0600))
+		_, err := worktree.Add(fileName)
+		require.NoError(t, err)
+		hash, err := worktree.Commit(fileName, &gogit.CommitOptions{
+			Author: &object.Signature{Name: "test", Email: "test@example.com", When: time.Now()},
+		})
+		require.NoError(t, err)
+		return hash
+	}
+
+	commit(filepath.Join(resDir, "component", "Chart.yaml"))
+	release := commit(filepath.Join(instResCrdDir, "crd.yaml"))
+	_, err = repo.CreateTag("1.0.0", release, nil)
+	require.NoError(t, err)
+	commit(filepath.Join(resDir, "next", "Chart.yaml"))
+
+	return repoDir, commit
+}
@@ -0,0 +1,251 @@
+package chart
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	ociScheme                = "oci://"
+	ociManifestMediaType     = "application/vnd.oci.image.manifest.v1+json"
+	helmChartLayerType       = "application/vnd.cncf.helm.chart.content.v1.tar+gzip"
+	helmLegacyChartLayerType = "application/tar+gzip"
+)
+
+//ociHTTPClient is used for all registry requests: the timeout covers the download of the chart layer
+var ociHTTPClient = &http.Client{Timeout: 5 * time.Minute}
+
+// ociReference is a chart reference like 'oci://registry.example.com/charts/mychart:1.0.0'
+type ociReference struct {
+	registry   string
+	repository string
+	tag        string
+}
+
+// parseOCIReference parses an OCI chart reference. If the reference contains no tag, the version is used as tag
+// ('+' is replaced by '_' as Helm does when pushing charts).
+func parseOCIReference(ref, version string) (*ociReference, error) {
+	if !strings.HasPrefix(ref, ociScheme) {
+		return nil, fmt.Errorf("OCI reference '%s' has to start with '%s'", ref, ociScheme)
+	}
+	parts := strings.SplitN(strings.TrimPrefix(ref, ociScheme), "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("OCI reference '%s' is invalid: expected format is 'oci://<registry>/<repository>'", ref)
+	}
+	result := &ociReference{
+		registry:   parts[0],
+		repository: parts[1],
+		tag:        strings.ReplaceAll(version, "+", "_"),
+	}
+	if idx := strings.LastIndex(result.repository, ":"); idx > strings.LastIndex(result.repository, "/") {
+		result.tag = result.repository[idx+1:]
+		result.repository = result.repository[:idx]
+	}
+	if result.tag == "" {
+		return nil, fmt.Errorf("OCI reference '%s' is invalid: neither a tag nor a version is defined", ref)
+	}
+	return result, nil
+}
+
+func (r *ociReference) String() string {
+	return fmt.Sprintf("%s%s/%s:%s", ociScheme, r.registry, r.repository, r.tag)
+}
+
+type ociDescriptor struct {
+	MediaType string `json:"mediaType"`
+	Digest    string `json:"digest"`
+	Size      int64  `json:"size"`
+}
+
+type ociManifest struct {
+	Layers []ociDescriptor `json:"layers"`
+}
+
+// ociClient pulls Helm charts from OCI registries by using the registry HTTP API (Helm's registry client is
+// not part of Helm's public API in the used Helm version)
+type ociClient struct {
+	httpClient  *http.Client
+	scheme      string
+	credentials *repositoryCredentials
+	token       string
+}
+
+func newOCIClient(credentials *repositoryCredentials) *ociClient {
+	return &ociClient{
+		httpClient:  ociHTTPClient,
+		scheme:      "https",
+		credentials: credentials,
+	}
+}
+
+// pull returns the chart archive referenced by the OCI reference
+func (c *ociClient) pull(ref *ociReference) ([]byte, error) {
+	manifestData, err := c.get(ref, fmt.Sprintf("manifests/%s", ref.tag), ociManifestMediaType)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to retrieve manifest of '%s'", ref)
+	}
+	manifest := &ociManifest{}
+	if err := json.Unmarshal(manifestData, manifest); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse manifest of '%s'", ref)
+	}
+
+	var chartLayer *ociDescriptor
+	for i, layer := range manifest.Layers {
+		if layer.MediaType == helmChartLayerType || layer.MediaType == helmLegacyChartLayerType {
+			chartLayer = &manifest.Layers[i]
+			break
+		}
+	}
+	if chartLayer == nil {
+		return nil, fmt.Errorf("'%s' is not a Helm chart: manifest contains no layer of type '%s'", ref, helmChartLayerType)
+	}
+
+	chartData, err := c.get(ref, fmt.Sprintf("blobs/%s", chartLayer.Digest), "")
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to retrieve chart of '%s'", ref)
+	}
+	if digest := fmt.Sprintf("sha256:%x", sha256.Sum256(chartData)); digest != chartLayer.Digest {
+		return nil, fmt.Errorf("digest of chart '%s' is '%s' but expected was '%s'", ref, digest, chartLayer.Digest)
+	}
+	return chartData, nil
+}
+
+func (c *ociClient) get(ref *ociReference, path, accept string) ([]byte, error) {
+	reqURL := fmt.Sprintf("%s://%s/v2/%s/%s", c.scheme, ref.registry, ref.repository, path)
+	resp, err := c.do(reqURL, accept)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusUnauthorized {
+		challenge := resp.Header.Get("WWW-Authenticate")
+		_ = resp.Body.Close()
+		if err := c.authenticate(challenge); err != nil {
+			return nil, err
+		}
+		if resp, err = c.do(reqURL, accept); err != nil {
+			return nil, err
+		}
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("request '%s' failed with status %d", reqURL, resp.StatusCode)
+	}
+	return ioutil.ReadAll(resp.Body)
+}
+
+func (c *ociClient) do(reqURL, accept string) (*http.Response, error) {
+	req, err := http.NewRequest(http.MethodGet, reqURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	} else if c.credentials != nil {
+		req.SetBasicAuth(c.credentials.username, c.credentials.password)
+	}
+	return c.httpClient.Do(req)
+}
+
+// authenticate retrieves a bearer token from the authorization service announced in the challenge
+func (c *ociClient) authenticate(challenge string) error {
+	scheme, params := parseChallenge(challenge)
+	if !strings.EqualFold(scheme, "bearer") || params["realm"] == "" {
+		return fmt.Errorf("registry rejected request: unsupported authentication challenge '%s'", challenge)
+	}
+
+	tokenURL, err := url.Parse(params["realm"])
+	if err != nil {
+		return errors.Wrapf(err, "registry announced invalid token realm '%s'", params["realm"])
+	}
+	query := tokenURL.Query()
+	for _, key := range []string{"service", "scope"} {
+		if params[key] != "" {
+			query.Set(key, params[key])
+		}
+	}
+	tokenURL.RawQuery = query.Encode()
+
+	req, err := http.NewRequest(http.MethodGet, tokenURL.String(), nil)
+	if err != nil {
+		return err
+	}
+	if c.credentials != nil {
+		req.SetBasicAuth(c.credentials.username, c.credentials.password)
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("token request '%s' failed with status %d", tokenURL, resp.StatusCode)
+	}
+
+	tokenResp := &struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}{}
+	if err := json.NewDecoder(resp.Body).Decode(tokenResp); err != nil {
+		return errors.Wrap(err, "failed to parse token response")
+	}
+	c.token = tokenResp.Token
+	if c.token == "" {
+		c.token = tokenResp.AccessToken
+	}
+	if c.token == "" {
+		return fmt.Errorf("token response of '%s' contains no token", tokenURL)
+	}
+	return nil
+}
+
+// parseChallenge parses a WWW-Authenticate header like 'Bearer realm="https://auth",service="registry"'
+func parseChallenge(challenge string) (string, map[string]string) {
+	params := make(map[string]string)
+	parts := strings.SplitN(strings.TrimSpace(challenge), " ", 2)
+	if len(parts) < 2 {
+		return parts[0], params
+	}
+	for _, param := range splitChallengeParams(parts[1]) {
+		keyAndValue := strings.SplitN(param, "=", 2)
+		if len(keyAndValue) != 2 {
+			continue
+		}
+		params[strings.ToLower(strings.TrimSpace(keyAndValue[0]))] = strings.Trim(strings.TrimSpace(keyAndValue[1]), `"`)
+	}
+	return parts[0], params
+}
+
+// splitChallengeParams splits the parameters of a challenge by commas which are not quoted
+// (scopes can contain commas, e.g. 'repository:charts/app:pull,push')
+func splitChallengeParams(params string) []string {
+	var result []string
+	var quoted bool
+	start := 0
+	for i, char := range params {
+		switch char {
+		case '"':
+			quoted = !quoted
+		case ',':
+			if !quoted {
+				result = append(result, params[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(result, params[start:])
+}
@@ -0,0 +1,112 @@
+package chart
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/chartutil"
+)
+
+//packageTestChart returns the archive of the test chart 'component-1'
+func packageTestChart(t *testing.T) []byte {
+	ch, err := loader.LoadDir(filepath.Join(chartDir, componentName))
+	require.NoError(t, err)
+	archive, err := chartutil.Save(ch, t.TempDir())
+	require.NoError(t, err)
+	data, err := ioutil.ReadFile(archive)
+	require.NoError(t, err)
+	return data
+}
+
+func TestParseOCIReference(t *testing.T) {
+	ref, err := parseOCIReference("oci://registry.example.com/charts/app", "1.0.0+build")
+	require.NoError(t, err)
+	require.Equal(t, &ociReference{registry: "registry.example.com", repository: "charts/app", tag: "1.0.0_build"}, ref)
+
+	ref, err = parseOCIReference("oci://localhost:5000/app:2.0.0", "1.0.0")
+	require.NoError(t, err)
+	require.Equal(t, &ociReference{registry: "localhost:5000", repository: "app", tag: "2.0.0"}, ref)
+
+	_, err = parseOCIReference("oci://registry.example.com", "1.0.0")
+	require.Error(t, err)
+	_, err = parseOCIReference("oci://localhost:5000/app", "")
+	require.Error(t, err)
+}
+
+func TestOCIClient(t *testing.T) {
+	chartData := packageTestChart(t)
+	digest := fmt.Sprintf("sha256:%x", sha256.Sum256(chartData))
+
+	var server *httptest.Server
+	server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/token":
+			username, password, ok := r.BasicAuth()
+			if !ok || username != "user" || password != "secret" || r.URL.Query().Get("scope") != "repository:charts/app:pull" {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			_, _ = w.Write([]byte(`{"token":"abc"}`))
+		case r.Header.Get("Authorization") != "Bearer abc":
+			w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="registry",scope="repository:charts/app:pull"`, server.URL))
+			w.WriteHeader(http.StatusUnauthorized)
+		case r.URL.Path == "/v2/charts/app/manifests/1.0.0":
+			require.Equal(t, ociManifestMediaType, r.Header.Get("Accept"))
+			manifest, err := json.Marshal(&ociManifest{Layers: []ociDescriptor{
+				{MediaType: helmChartLayerType, Digest: digest, Size: int64(len(chartData))},
+			}})
+			require.NoError(t, err)
+			_, _ = w.Write(manifest)
+		case r.URL.Path == "/v2/charts/app/blobs/"+digest:
+			_, _ = w.Write(chartData)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	newClient := func(credentials *repositoryCredentials) *ociClient {
+		client := newOCIClient(credentials)
+		client.httpClient = server.Client()
+		return client
+	}
+	registry := strings.TrimPrefix(server.URL, "https://")
+
+	t.Run("Pull chart", func(t *testing.T) {
+		data, err := newClient(&repositoryCredentials{username: "user", password: "secret"}).
+			pull(&ociReference{registry: registry, repository: "charts/app", tag: "1.0.0"})
+		require.NoError(t, err)
+		require.Equal(t, chartData, data)
+	})
+
+	t.Run("Pull chart with invalid credentials", func(t *testing.T) {
+		_, err := newClient(&repositoryCredentials{username: "user", password: "wrong"}).
+			pull(&ociReference{registry: registry, repository: "charts/app", tag: "1.0.0"})
+		require.Error(t, err)
+	})
+
+	t.Run("Pull missing chart", func(t *testing.T) {
+		_, err := newClient(&repositoryCredentials{username: "user", password: "secret"}).
+			pull(&ociReference{registry: registry, repository: "charts/app", tag: "2.0.0"})
+		require.Error(t, err)
+	})
+}
+
+func TestParseChallenge(t *testing.T) {
+	scheme, params := parseChallenge(`Bearer realm="https://auth.example.com/token",service="registry",scope="repository:app:pull,push"`)
+	require.Equal(t, "Bearer", scheme)
+	require.Equal(t, map[string]string{
+		"realm":   "https://auth.example.com/token",
+		"service": "registry",
+		"scope":   "repository:app:pull,push",
+	}, params)
+}
@@ -9,6 +9,7 @@ import (
 	reconcilerK8s "github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes"
 
 	"go.uber.org/zap"
+	"helm.sh/helm/v3/pkg/release"
 )
 
 const kindCRD = "CustomResourceDefinition"
@@ -28,8 +29,9 @@ type Provider interface {
 
 // DefaultProvider provides a default implementation of Provider.
 type DefaultProvider struct {
-	wsFactory Factory
-	logger    *zap.SugaredLogger
+	wsFactory   Factory
+	renderCache *RenderCache
+	logger      *zap.SugaredLogger
 }
 
 // NewDefaultProvider returns a new instance of DefaultProvider.
@@ -43,6 +45,12 @@ func NewDefaultProvider(wsFactory Factory, logger *zap.SugaredLogger) (*DefaultP
 	}, nil
 }
 
+// WithRenderCache configures the cache of rendered releases (nil disables caching)
+func (p *DefaultProvider) WithRenderCache(renderCache *RenderCache) *DefaultProvider {
+	p.renderCache = renderCache
+	return p
+}
+
 func (p *DefaultProvider) RenderCRD(version string) ([]*Manifest, error) {
 	ws, err := p.wsFactory.Get(version)
 	if err != nil {
@@ -106,21 +114,40 @@ func (p *DefaultProvider) RenderManifest(component *Component) (*Manifest, error
 		return nil, err
 	}
 
+	var cacheKey string
+	if p.renderCache != nil {
+		if cacheKey, err = p.renderCache.key(wsDir, component); err != nil {
+			p.logger.Warnf("Bypassing render cache for component '%s': %s", component.name, err)
+		} else if helmRelease := p.renderCache.get(cacheKey); helmRelease != nil {
+			p.logger.Debugf("Using cached rendering of component '%s'", component.name)
+			return newHelmManifest(component, helmRelease), nil
+		}
+	}
+
 	helmClient, err := NewHelmClient(wsDir, p.logger)
 	if err != nil {
 		return nil, err
 	}
 
-	manifest, err := helmClient.Render(component)
+	helmRelease, err := helmClient.RenderRelease(component)
 	if err != nil {
 		return nil, err
 	}
 
+	if cacheKey != "" {
+		p.renderCache.put(cacheKey, helmRelease)
+	}
+	return newHelmManifest(component, helmRelease), nil
+}
+
+func newHelmManifest(component *Component, helmRelease *release.Release) *Manifest {
 	return &Manifest{
 		Type:     HelmChart,
 		Name:     component.name,
-		Manifest: manifest,
-	}, nil
+		Manifest: helmRelease.Manifest,
+		Hooks:    newHooks(helmRelease.Hooks),
+		Release:  helmRelease,
+	}
 }
 
 func (p *DefaultProvider) Configuration(component *Component) (map[string]interface{}, error) {
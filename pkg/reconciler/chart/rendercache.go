@@ -0,0 +1,422 @@
+package chart
+
+import (
+	"bytes"
+	"compress/gzip"
+	"container/list"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	helmchart "helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/release"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+const renderCacheDir = ".render-cache"
+
+var (
+	//template actions of a chart
+	templateActions = regexp.MustCompile(`(?s){{.*?}}`)
+	//template functions which return a different result for each rendering (e.g. generated passwords or certificates):
+	//rendered releases of charts using them aren't cached to avoid that clusters share generated secrets
+	nonDeterministicFuncs = regexp.MustCompile(`\b(rand[A-Z]\w*|uuidv4|gen[A-Z]\w*|now|date\w*|htmlDate\w*|unixEpoch)\b`)
+)
+
+// RenderCacheConfig defines the size limits of the tiers of the render cache
+type RenderCacheConfig struct {
+	MemorySize int64  //max size of the releases kept in memory in bytes (0 disables the memory tier)
+	DiskSize   int64  //max size of the releases stored on disk in bytes (0 disables the disk tier)
+	Dir        string //directory of the disk tier
+}
+
+// NewRenderCacheConfig creates a render cache configuration. The sizes are quantities like '256Mi'
+// (an empty value disables the tier).
+func NewRenderCacheConfig(memorySize, diskSize, workspace string) (*RenderCacheConfig, error) {
+	config := &RenderCacheConfig{
+		Dir: filepath.Join(workspace, renderCacheDir),
+	}
+	for _, limit := range []struct {
+		name     string
+		quantity string
+		target   *int64
+	}{
+		{"memory", memorySize, &config.MemorySize},
+		{"disk", diskSize, &config.DiskSize},
+	} {
+		if limit.quantity == "" {
+			continue
+		}
+		quantity, err := resource.ParseQuantity(limit.quantity)
+		if err != nil {
+			return nil, errors.Wrapf(err, "%s size of render cache '%s' is invalid", limit.name, limit.quantity)
+		}
+		if quantity.Value() < 0 {
+			return nil, fmt.Errorf("%s size of render cache cannot be negative but was '%s'", limit.name, limit.quantity)
+		}
+		*limit.target = quantity.Value()
+	}
+	return config, nil
+}
+
+// Enabled returns true if at least one tier of the cache has a size limit
+func (c *RenderCacheConfig) Enabled() bool {
+	return c != nil && (c.MemorySize > 0 || c.DiskSize > 0)
+}
+
+// RenderCacheStats contains the counters and the current size of the render cache
+type RenderCacheStats struct {
+	MemoryHits    int64 //renderings served from the memory tier
+	DiskHits      int64 //renderings served from the disk tier
+	Misses        int64 //renderings which weren't cached
+	Uncacheable   int64 //renderings of charts using non-deterministic template functions
+	MemoryEntries int
+	MemorySize    int64
+	DiskEntries   int
+	DiskSize      int64
+}
+
+// RenderCache caches rendered Helm releases in memory and on disk. The entries are addressed by the revision
+// of the chart directory, the component, its namespace, the profile and the configuration values.
+// Rendered manifests can contain secret configuration values: entries on disk are encrypted with a key which
+// exists only in memory (the disk tier is wiped when the cache gets created).
+type RenderCache struct {
+	config *RenderCacheConfig
+	logger *zap.SugaredLogger
+	aead   cipher.AEAD
+
+	mu     sync.Mutex
+	memory *lruTier
+	disk   *lruTier
+	stats  RenderCacheStats
+}
+
+func NewRenderCache(config *RenderCacheConfig, logger *zap.SugaredLogger) (*RenderCache, error) {
+	if !config.Enabled() {
+		return nil, fmt.Errorf("render cache requires a memory or a disk size")
+	}
+
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	if config.DiskSize > 0 {
+		//entries of previous processes were encrypted with another key
+		if err := os.RemoveAll(config.Dir); err != nil {
+			return nil, errors.Wrapf(err, "failed to clean render cache directory '%s'", config.Dir)
+		}
+		if err := os.MkdirAll(config.Dir, 0700); err != nil {
+			return nil, errors.Wrapf(err, "failed to create render cache directory '%s'", config.Dir)
+		}
+	}
+
+	return &RenderCache{
+		config: config,
+		logger: logger,
+		aead:   aead,
+		memory: newLRUTier(config.MemorySize),
+		disk:   newLRUTier(config.DiskSize),
+	}, nil
+}
+
+// Stats returns the counters and the current size of the cache
+func (c *RenderCache) Stats() *RenderCacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	stats := c.stats
+	stats.MemoryEntries, stats.MemorySize = c.memory.entries.Len(), c.memory.size
+	stats.DiskEntries, stats.DiskSize = c.disk.entries.Len(), c.disk.size
+	return &stats
+}
+
+//key calculates the address of the component's rendered release
+func (c *RenderCache) key(chartDir string, component *Component) (string, error) {
+	revision, err := chartRevision(filepath.Join(chartDir, component.name))
+	if err != nil {
+		return "", err
+	}
+	config, err := component.Configuration()
+	if err != nil {
+		return "", err
+	}
+	//map keys are sorted: equal configurations result in equal JSON
+	configData, err := json.Marshal([]map[string]interface{}{component.profileValues, config})
+	if err != nil {
+		return "", err
+	}
+	configHash := sha256.Sum256(configData)
+
+	hash := sha256.New()
+	fmt.Fprintf(hash, "%s\x00%s\x00%s\x00%s\x00%x", revision, component.name, component.namespace,
+		strings.ToLower(component.profile), configHash)
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+//get returns the cached release or nil if the release isn't cached
+func (c *RenderCache) get(key string) *release.Release {
+	c.mu.Lock()
+	if entry, ok := c.memory.get(key); ok {
+		c.stats.MemoryHits++
+		c.mu.Unlock()
+		return c.decode(key, entry.data)
+	}
+	_, onDisk := c.disk.get(key)
+	c.mu.Unlock()
+
+	if onDisk {
+		if data, err := c.readEntry(key); err == nil {
+			if rel := c.decode(key, data); rel != nil {
+				c.mu.Lock()
+				c.stats.DiskHits++
+				c.memory.add(key, data)
+				c.mu.Unlock()
+				return rel
+			}
+		} else {
+			c.logger.Warnf("Failed to read rendered release '%s' from render cache: %s", key, err)
+		}
+		c.mu.Lock()
+		c.disk.remove(key)
+		c.mu.Unlock()
+	}
+
+	c.mu.Lock()
+	c.stats.Misses++
+	c.mu.Unlock()
+	return nil
+}
+
+//put adds the release to the cache unless its chart uses non-deterministic template functions
+func (c *RenderCache) put(key string, rel *release.Release) {
+	if rel.Chart != nil && !isDeterministic(rel.Chart) {
+		c.mu.Lock()
+		c.stats.Uncacheable++
+		c.mu.Unlock()
+		return
+	}
+
+	data, err := encodeRelease(rel)
+	if err != nil {
+		c.logger.Warnf("Failed to encode rendered release '%s' for render cache: %s", rel.Name, err)
+		return
+	}
+
+	diskWritten := false
+	if c.config.DiskSize >= int64(len(data)) {
+		if err := c.writeEntry(key, data); err == nil {
+			diskWritten = true
+		} else {
+			c.logger.Warnf("Failed to write rendered release '%s' to render cache: %s", rel.Name, err)
+		}
+	}
+
+	c.mu.Lock()
+	c.memory.add(key, data)
+	var evicted []string
+	if diskWritten {
+		evicted = c.disk.add(key, nil, int64(len(data)))
+	}
+	c.mu.Unlock()
+
+	for _, evictedKey := range evicted {
+		if err := os.Remove(c.entryFile(evictedKey)); err != nil && !os.IsNotExist(err) {
+			c.logger.Warnf("Failed to delete evicted entry '%s' of render cache: %s", evictedKey, err)
+		}
+	}
+}
+
+func (c *RenderCache) decode(key string, data []byte) *release.Release {
+	rel, err := decodeRelease(data)
+	if err != nil {
+		c.logger.Warnf("Failed to decode rendered release '%s' of render cache: %s", key, err)
+		return nil
+	}
+	return rel
+}
+
+func (c *RenderCache) entryFile(key string) string {
+	return filepath.Join(c.config.Dir, key)
+}
+
+func (c *RenderCache) writeEntry(key string, data []byte) error {
+	nonce := make([]byte, c.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+	tmpFile, err := ioutil.TempFile(c.config.Dir, ".tmp-")
+	if err != nil {
+		return err
+	}
+	if _, err := tmpFile.Write(c.aead.Seal(nonce, nonce, data, []byte(key))); err != nil {
+		_ = tmpFile.Close()
+		_ = os.Remove(tmpFile.Name())
+		return err
+	}
+	if err := tmpFile.Close(); err != nil {
+		_ = os.Remove(tmpFile.Name())
+		return err
+	}
+	return os.Rename(tmpFile.Name(), c.entryFile(key))
+}
+
+func (c *RenderCache) readEntry(key string) ([]byte, error) {
+	encrypted, err := ioutil.ReadFile(c.entryFile(key))
+	if err != nil {
+		return nil, err
+	}
+	if len(encrypted) < c.aead.NonceSize() {
+		return nil, fmt.Errorf("entry is truncated")
+	}
+	nonceSize := c.aead.NonceSize()
+	return c.aead.Open(nil, encrypted[:nonceSize], encrypted[nonceSize:], []byte(key))
+}
+
+//encodeRelease serializes the release (as Helm does for its release storage) and compresses it
+func encodeRelease(rel *release.Release) ([]byte, error) {
+	var buffer bytes.Buffer
+	writer := gzip.NewWriter(&buffer)
+	if err := json.NewEncoder(writer).Encode(rel); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+//decodeRelease returns a new copy of the release on each call: callers are free to modify it
+func decodeRelease(data []byte) (*release.Release, error) {
+	reader, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	rel := &release.Release{}
+	if err := json.NewDecoder(reader).Decode(rel); err != nil && err != io.EOF {
+		return nil, err
+	}
+	return rel, nil
+}
+
+//chartRevision fingerprints the files of a chart directory (paths, sizes, modes and modification times):
+//checkouts of a new revision rewrite the changed files and result in a new fingerprint
+func chartRevision(dir string) (string, error) {
+	hash := sha256.New()
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		relPath, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(hash, "%s\x00%d\x00%s\x00%d\n", relPath, info.Size(), info.Mode(), info.ModTime().UnixNano())
+		return nil
+	})
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to fingerprint chart directory '%s'", dir)
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+//isDeterministic returns false if a template of the chart or of its sub-charts uses non-deterministic functions
+func isDeterministic(ch *helmchart.Chart) bool {
+	for _, tpl := range ch.Templates {
+		for _, action := range templateActions.FindAll(tpl.Data, -1) {
+			if nonDeterministicFuncs.Match(action) {
+				return false
+			}
+		}
+	}
+	for _, dependency := range ch.Dependencies() {
+		if !isDeterministic(dependency) {
+			return false
+		}
+	}
+	return true
+}
+
+//lruTier keeps the entries of a cache tier within its size limit by evicting the least recently used entries
+type lruTier struct {
+	limit   int64
+	size    int64
+	entries *list.List
+	index   map[string]*list.Element
+}
+
+type lruEntry struct {
+	key  string
+	data []byte //nil if the data is stored outside of the tier (e.g. on disk)
+	size int64
+}
+
+func newLRUTier(limit int64) *lruTier {
+	return &lruTier{
+		limit:   limit,
+		entries: list.New(),
+		index:   make(map[string]*list.Element),
+	}
+}
+
+func (t *lruTier) get(key string) (*lruEntry, bool) {
+	elem, ok := t.index[key]
+	if !ok {
+		return nil, false
+	}
+	t.entries.MoveToFront(elem)
+	return elem.Value.(*lruEntry), true
+}
+
+//add stores the entry (the size defaults to the size of the data) and returns the keys of the evicted entries
+func (t *lruTier) add(key string, data []byte, size ...int64) []string {
+	entry := &lruEntry{key: key, data: data, size: int64(len(data))}
+	if len(size) > 0 {
+		entry.size = size[0]
+	}
+	if entry.size > t.limit {
+		return nil //entry doesn't fit into the tier
+	}
+	t.remove(key)
+	t.index[key] = t.entries.PushFront(entry)
+	t.size += entry.size
+
+	var evicted []string
+	for t.size > t.limit {
+		oldest := t.entries.Back()
+		evictedKey := oldest.Value.(*lruEntry).key
+		t.remove(evictedKey)
+		evicted = append(evicted, evictedKey)
+	}
+	return evicted
+}
+
+func (t *lruTier) remove(key string) {
+	elem, ok := t.index[key]
+	if !ok {
+		return
+	}
+	t.entries.Remove(elem)
+	delete(t.index, key)
+	t.size -= elem.Value.(*lruEntry).size
+}
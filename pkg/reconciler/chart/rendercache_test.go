@@ -0,0 +1,200 @@
+package chart
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/logger"
+	"github.com/stretchr/testify/require"
+	helmchart "helm.sh/helm/v3/pkg/chart"
+)
+
+//renderCacheFactory provides a Kyma workspace whose resource directory contains the test charts
+type renderCacheFactory struct {
+	resourceDir string
+}
+
+func (f *renderCacheFactory) Get(version string) (*KymaWorkspace, error) {
+	return &KymaWorkspace{ResourceDir: f.resourceDir}, nil
+}
+
+func (f *renderCacheFactory) Delete(version string) error {
+	return nil
+}
+
+func (f *renderCacheFactory) GetExternalComponent(component *Component) (*Workspace, error) {
+	return &Workspace{WorkspaceDir: f.resourceDir}, nil
+}
+
+func TestRenderCache(t *testing.T) {
+	log := logger.NewLogger(true)
+
+	resourceDir := t.TempDir()
+	writeTestChart(t, resourceDir, "cached", `{{ .Values.key }}`)
+	writeTestChart(t, resourceDir, "random", `{{ randAlphaNum 16 }}`)
+
+	newComponent := func(name, value string) *Component {
+		return NewComponentBuilder("1.0.0", name).
+			WithNamespace("kyma-system").
+			WithConfiguration(map[string]interface{}{"key": value}).
+			Build()
+	}
+
+	newProvider := func(t *testing.T, config *RenderCacheConfig) (*DefaultProvider, *RenderCache) {
+		cache, err := NewRenderCache(config, log)
+		require.NoError(t, err)
+		provider, err := NewDefaultProvider(&renderCacheFactory{resourceDir: resourceDir}, log)
+		require.NoError(t, err)
+		return provider.WithRenderCache(cache), cache
+	}
+
+	t.Run("Serve renderings from memory", func(t *testing.T) {
+		provider, cache := newProvider(t, &RenderCacheConfig{MemorySize: 1024 * 1024})
+
+		uncached, err := provider.RenderManifest(newComponent("cached", "abc"))
+		require.NoError(t, err)
+		cached, err := provider.RenderManifest(newComponent("cached", "abc"))
+		require.NoError(t, err)
+		require.Equal(t, uncached.Manifest, cached.Manifest)
+		require.Contains(t, cached.Manifest, "value: abc")
+		require.Equal(t, uncached.Release.Name, cached.Release.Name)
+		require.NotSame(t, uncached.Release, cached.Release)
+
+		//other configuration values result in another cache entry
+		other, err := provider.RenderManifest(newComponent("cached", "xyz"))
+		require.NoError(t, err)
+		require.Contains(t, other.Manifest, "value: xyz")
+
+		stats := cache.Stats()
+		require.Equal(t, int64(1), stats.MemoryHits)
+		require.Equal(t, int64(2), stats.Misses)
+		require.Equal(t, 2, stats.MemoryEntries)
+	})
+
+	t.Run("Serve renderings from encrypted disk entries", func(t *testing.T) {
+		cacheDir := filepath.Join(t.TempDir(), renderCacheDir)
+		provider, cache := newProvider(t, &RenderCacheConfig{DiskSize: 1024 * 1024, Dir: cacheDir})
+
+		_, err := provider.RenderManifest(newComponent("cached", "secret-value"))
+		require.NoError(t, err)
+		cached, err := provider.RenderManifest(newComponent("cached", "secret-value"))
+		require.NoError(t, err)
+		require.Contains(t, cached.Manifest, "value: secret-value")
+
+		stats := cache.Stats()
+		require.Equal(t, int64(1), stats.DiskHits)
+		require.Equal(t, 1, stats.DiskEntries)
+
+		files, err := ioutil.ReadDir(cacheDir)
+		require.NoError(t, err)
+		require.Len(t, files, 1)
+		data, err := ioutil.ReadFile(filepath.Join(cacheDir, files[0].Name()))
+		require.NoError(t, err)
+		require.NotContains(t, string(data), "secret-value")
+
+		//entries of previous processes are dropped
+		_, err = NewRenderCache(&RenderCacheConfig{DiskSize: 1024 * 1024, Dir: cacheDir}, log)
+		require.NoError(t, err)
+		files, err = ioutil.ReadDir(cacheDir)
+		require.NoError(t, err)
+		require.Empty(t, files)
+	})
+
+	t.Run("Don't cache charts with non-deterministic functions", func(t *testing.T) {
+		provider, cache := newProvider(t, &RenderCacheConfig{MemorySize: 1024 * 1024})
+
+		first, err := provider.RenderManifest(newComponent("random", ""))
+		require.NoError(t, err)
+		second, err := provider.RenderManifest(newComponent("random", ""))
+		require.NoError(t, err)
+		require.NotEqual(t, first.Manifest, second.Manifest)
+
+		stats := cache.Stats()
+		require.Equal(t, int64(2), stats.Uncacheable)
+		require.Equal(t, 0, stats.MemoryEntries)
+	})
+
+	t.Run("Changed chart files invalidate the cache", func(t *testing.T) {
+		_, cache := newProvider(t, &RenderCacheConfig{MemorySize: 1024 * 1024})
+
+		key, err := cache.key(resourceDir, newComponent("cached", "abc"))
+		require.NoError(t, err)
+		otherNamespace, err := cache.key(resourceDir, NewComponentBuilder("1.0.0", "cached").
+			WithNamespace("default").
+			WithConfiguration(map[string]interface{}{"key": "abc"}).
+			Build())
+		require.NoError(t, err)
+		require.NotEqual(t, key, otherNamespace)
+
+		valuesFile := filepath.Join(resourceDir, "cached", "values.yaml")
+		modTime := time.Now().Add(time.Minute)
+		require.NoError(t, os.Chtimes(valuesFile, modTime, modTime))
+		changedKey, err := cache.key(resourceDir, newComponent("cached", "abc"))
+		require.NoError(t, err)
+		require.NotEqual(t, key, changedKey)
+	})
+}
+
+func TestRenderCacheConfig(t *testing.T) {
+	config, err := NewRenderCacheConfig("1Mi", "", "workspace")
+	require.NoError(t, err)
+	require.Equal(t, &RenderCacheConfig{MemorySize: 1024 * 1024, Dir: filepath.Join("workspace", renderCacheDir)}, config)
+	require.True(t, config.Enabled())
+
+	config, err = NewRenderCacheConfig("", "", "workspace")
+	require.NoError(t, err)
+	require.False(t, config.Enabled())
+
+	_, err = NewRenderCacheConfig("abc", "", "workspace")
+	require.Error(t, err)
+	_, err = NewRenderCacheConfig("", "-1Gi", "workspace")
+	require.Error(t, err)
+}
+
+func TestLRUTier(t *testing.T) {
+	tier := newLRUTier(10)
+	require.Empty(t, tier.add("a", []byte("1234")))
+	require.Empty(t, tier.add("b", []byte("1234")))
+	_, ok := tier.get("a") //'b' becomes the least recently used entry
+	require.True(t, ok)
+	require.Equal(t, []string{"b"}, tier.add("c", []byte("1234")))
+	require.Empty(t, tier.add("too-large", nil, 11))
+	require.Equal(t, int64(8), tier.size)
+
+	_, ok = tier.get("b")
+	require.False(t, ok)
+	tier.remove("a")
+	require.Equal(t, int64(4), tier.size)
+}
+
+func TestIsDeterministic(t *testing.T) {
+	newChart := func(template string) *helmchart.Chart {
+		return &helmchart.Chart{
+			Templates: []*helmchart.File{{Name: "templates/test.yaml", Data: []byte(template)}},
+		}
+	}
+	require.True(t, isDeterministic(newChart(`# generated now: {{ .Values.key | quote }}`)))
+	require.False(t, isDeterministic(newChart(`password: {{ randAlphaNum 10 | b64enc }}`)))
+	require.False(t, isDeterministic(newChart(`{{- $ca := genCA "ca" 365 }}`)))
+
+	parent := newChart(`{{ .Values.key }}`)
+	parent.AddDependency(newChart(`{{ now | date "2006" }}`))
+	require.False(t, isDeterministic(parent))
+}
+
+func writeTestChart(t *testing.T, dir, name, value string) {
+	files := map[string]string{
+		"Chart.yaml":           "apiVersion: v2\nname: " + name + "\nversion: 1.0.0\n",
+		"values.yaml":          "key: default\n",
+		"templates/cm.yaml":    "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: " + name + "\ndata:\n  value: " + value + "\n",
+		"templates/hooks.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: " + name + "-hook\n  annotations:\n    \"helm.sh/hook\": pre-install\n",
+	}
+	for file, content := range files {
+		path := filepath.Join(dir, name, file)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0700))
+		require.NoError(t, ioutil.WriteFile(path, []byte(content), 0600))
+	}
+}
@@ -0,0 +1,113 @@
+package chart
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/xeipuuv/gojsonschema"
+	helmchart "helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/chartutil"
+)
+
+const rootValuesKey = "(root)"
+
+// ValuesError is a violation of the values schema ('values.schema.json') of a chart
+type ValuesError struct {
+	Key     string //key of the invalid value in dot-notation (e.g. 'global.domainName')
+	Message string
+}
+
+func (e *ValuesError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Key, e.Message)
+}
+
+// ValidateValues validates the values of the component (values of the chart merged with the values of the profile
+// and the configuration of the component) against the values schemas of its chart and of its sub-charts.
+// Charts without values schema accept any values.
+func (c *HelmClient) ValidateValues(component *Component) ([]*ValuesError, error) {
+	helmChart, err := loader.Load(filepath.Join(c.chartDir, component.name))
+	if err != nil {
+		return nil, err
+	}
+
+	config, err := c.mergeChartConfiguration(helmChart, component, true)
+	if err != nil {
+		return nil, err
+	}
+	//values of sub-charts are defined in the values of their parent chart
+	values, err := chartutil.CoalesceValues(helmChart, config)
+	if err != nil {
+		return nil, err
+	}
+
+	return validateChartValues(helmChart, values, "")
+}
+
+func validateChartValues(ch *helmchart.Chart, values map[string]interface{}, keyPrefix string) ([]*ValuesError, error) {
+	var result []*ValuesError
+
+	if len(ch.Schema) > 0 {
+		valuesJSON, err := json.Marshal(values)
+		if err != nil {
+			return nil, err
+		}
+		validation, err := gojsonschema.Validate(gojsonschema.NewBytesLoader(ch.Schema), gojsonschema.NewBytesLoader(valuesJSON))
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to validate values against the values schema of chart '%s'", ch.Name())
+		}
+		for _, validationErr := range validation.Errors() {
+			result = append(result, &ValuesError{
+				Key:     valuesErrorKey(keyPrefix, validationErr),
+				Message: validationErr.Description(),
+			})
+		}
+	}
+
+	for _, subChart := range ch.Dependencies() {
+		subValues, ok := values[subChart.Name()].(map[string]interface{})
+		if !ok {
+			subValues = map[string]interface{}{}
+		}
+		subResult, err := validateChartValues(subChart, subValues, joinValuesKey(keyPrefix, subChart.Name()))
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, subResult...)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Key < result[j].Key
+	})
+	return result, nil
+}
+
+//valuesErrorKey returns the key of the invalid value (missing or unknown properties are reported for their parent)
+func valuesErrorKey(keyPrefix string, validationErr gojsonschema.ResultError) string {
+	key := validationErr.Field()
+	if key == rootValuesKey {
+		key = ""
+	}
+	if property, ok := validationErr.Details()["property"].(string); ok && property != "" {
+		key = joinValuesKey(key, property)
+	}
+	key = joinValuesKey(keyPrefix, key)
+	if key == "" {
+		return rootValuesKey
+	}
+	return key
+}
+
+func joinValuesKey(prefix, key string) string {
+	switch {
+	case prefix == "":
+		return key
+	case key == "":
+		return prefix
+	default:
+		return prefix + "." + key
+	}
+}
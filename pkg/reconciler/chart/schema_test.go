@@ -0,0 +1,82 @@
+package chart
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/kyma-incubator/reconciler/pkg/logger"
+	"github.com/stretchr/testify/require"
+)
+
+const testValuesSchema = `{
+  "$schema": "http://json-schema.org/draft-07/schema#",
+  "type": "object",
+  "required": ["replicas"],
+  "properties": {
+    "replicas": {"type": "integer", "minimum": 1},
+    "config": {
+      "type": "object",
+      "additionalProperties": false,
+      "properties": {
+        "mode": {"type": "string", "enum": ["dev", "prod"]}
+      }
+    }
+  }
+}`
+
+func TestValidateValues(t *testing.T) {
+	chartDir := t.TempDir()
+	writeTestChart(t, chartDir, "schema", `{{ .Values.replicas }}`)
+	writeTestChart(t, filepath.Join(chartDir, "schema", "charts"), "sub", `{{ .Values.port }}`)
+	for file, content := range map[string]string{
+		"schema/values.yaml":                   "replicas: 1\nconfig:\n  mode: dev\nsub:\n  port: 8080\n",
+		"schema/values.schema.json":            testValuesSchema,
+		"schema/profile-evaluation.yaml":       "replicas: 0\n",
+		"schema/charts/sub/values.schema.json": `{"properties": {"port": {"type": "integer"}}}`,
+	} {
+		require.NoError(t, ioutil.WriteFile(filepath.Join(chartDir, file), []byte(content), 0600))
+	}
+
+	helmClient, err := NewHelmClient(chartDir, logger.NewLogger(true))
+	require.NoError(t, err)
+
+	validate := func(profile string, configuration map[string]interface{}) []*ValuesError {
+		valuesErrs, err := helmClient.ValidateValues(NewComponentBuilder("1.0.0", "schema").
+			WithNamespace("kyma-system").
+			WithProfile(profile).
+			WithConfiguration(configuration).
+			Build())
+		require.NoError(t, err)
+		return valuesErrs
+	}
+
+	t.Run("Valid configuration", func(t *testing.T) {
+		require.Empty(t, validate("", map[string]interface{}{"replicas": 3, "config.mode": "prod"}))
+	})
+
+	t.Run("Invalid configuration", func(t *testing.T) {
+		valuesErrs := validate("", map[string]interface{}{
+			"replicas":      "three",
+			"config.mode":   "test",
+			"config.debug":  true,
+			"sub.port":      "http",
+			"unknown.value": 1, //no schema restriction
+		})
+		require.Len(t, valuesErrs, 4)
+		require.Equal(t, "config.debug", valuesErrs[0].Key)
+		require.Equal(t, "config.mode", valuesErrs[1].Key)
+		require.Equal(t, "replicas", valuesErrs[2].Key)
+		require.Equal(t, "sub.port", valuesErrs[3].Key)
+		require.Contains(t, valuesErrs[2].Message, "Invalid type")
+	})
+
+	t.Run("Profile values are validated", func(t *testing.T) {
+		valuesErrs := validate("evaluation", nil)
+		require.Len(t, valuesErrs, 1)
+		require.Equal(t, "replicas", valuesErrs[0].Key)
+
+		//configuration overrides values of the profile
+		require.Empty(t, validate("evaluation", map[string]interface{}{"replicas": 2}))
+	})
+}
@@ -0,0 +1,282 @@
+package chart
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	//VerifyConfigKeyPrefix is the prefix of all component configuration keys which configure integrity checks
+	VerifyConfigKeyPrefix = "repo.verify."
+	//VerifyDigestConfigKey is the component configuration key used to define the expected SHA-256 digest of a
+	//downloaded archive or chart (e.g. 'sha256:9f86d08...')
+	VerifyDigestConfigKey = VerifyConfigKeyPrefix + "digest"
+	//VerifySignatureTypeConfigKey is the component configuration key used to define the type of the signature
+	//which has to be verified ('cosign' or 'gpg')
+	VerifySignatureTypeConfigKey = VerifyConfigKeyPrefix + "signatureType"
+	//VerifySignatureURLConfigKey is the component configuration key used to define the URL of the detached signature
+	//of an archive or chart (default for archives is the archive URL with suffix '.sig' (cosign) or '.asc' (gpg))
+	VerifySignatureURLConfigKey = VerifyConfigKeyPrefix + "signatureURL"
+	//VerifyKeyConfigKey is the component configuration key used to select the public key which is used to verify
+	//signatures: it's the name of a key which is trusted by the reconciler (see VerificationKeys)
+	VerifyKeyConfigKey = VerifyConfigKeyPrefix + "key"
+
+	CosignSignature = "cosign"
+	GPGSignature    = "gpg"
+
+	digestPrefix = "sha256:"
+)
+
+// VerificationKeys are the public keys trusted by the reconciler to verify signatures of component sources
+// (key name mapped to a PEM encoded key for cosign or an ASCII armored key ring for gpg). The component configuration
+// only selects one of these keys: keys provided by the cluster configuration are not accepted.
+type VerificationKeys map[string]string
+
+// LoadVerificationKeys reads the trusted public keys from a YAML or JSON file (a map of key names to keys)
+func LoadVerificationKeys(file string) (VerificationKeys, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read verification keys file '%s'", file)
+	}
+	keys := make(VerificationKeys)
+	if err := yaml.UnmarshalStrict(data, &keys); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse verification keys file '%s'", file)
+	}
+	for name, key := range keys {
+		if strings.TrimSpace(key) == "" {
+			return nil, fmt.Errorf("verification key '%s' in file '%s' is empty", name, file)
+		}
+	}
+	return keys, nil
+}
+
+//verification defines the integrity checks of a component source
+type verification struct {
+	component     string
+	digest        string
+	signatureType string
+	signatureURL  string
+	publicKey     string
+}
+
+//newVerification returns the integrity checks defined in the component configuration (nil if nothing is defined)
+func newVerification(component *Component, keys VerificationKeys) (*verification, error) {
+	v := &verification{
+		component:     component.name,
+		digest:        strings.ToLower(configString(component, VerifyDigestConfigKey)),
+		signatureType: strings.ToLower(configString(component, VerifySignatureTypeConfigKey)),
+		signatureURL:  configString(component, VerifySignatureURLConfigKey),
+	}
+	if v.digest == "" && v.signatureType == "" {
+		return nil, nil
+	}
+	if v.digest != "" {
+		if !strings.HasPrefix(v.digest, digestPrefix) {
+			v.digest = digestPrefix + v.digest
+		}
+		if len(v.digest) != len(digestPrefix)+2*sha256.Size {
+			return nil, fmt.Errorf("configuration '%s' of component '%s' is invalid: expected format is 'sha256:<hex>'",
+				VerifyDigestConfigKey, component.name)
+		}
+	}
+	switch v.signatureType {
+	case "":
+	case CosignSignature, GPGSignature:
+		keyName := configString(component, VerifyKeyConfigKey)
+		if keyName == "" {
+			return nil, fmt.Errorf("configuration '%s' of component '%s' is missing: required to verify %s signatures",
+				VerifyKeyConfigKey, component.name, v.signatureType)
+		}
+		publicKey, ok := keys[keyName]
+		if !ok {
+			return nil, fmt.Errorf("configuration '%s' of component '%s' is invalid: key '%s' is not trusted by the reconciler",
+				VerifyKeyConfigKey, component.name, keyName)
+		}
+		v.publicKey = publicKey
+	default:
+		return nil, fmt.Errorf("configuration '%s' of component '%s' is invalid: signature type '%s' is not supported "+
+			"(supported are '%s' and '%s')", VerifySignatureTypeConfigKey, component.name, v.signatureType,
+			CosignSignature, GPGSignature)
+	}
+	return v, nil
+}
+
+//workspaceSuffix returns a suffix for the workspace of a verified source: workspaces are only reused
+//if they were verified with the same checks (empty suffix if the source isn't verified)
+func workspaceSuffix(v *verification) string {
+	if v == nil {
+		return ""
+	}
+	checks := strings.Join([]string{v.digest, v.signatureType, v.signatureURL, v.publicKey}, "\n")
+	return fmt.Sprintf("-verified-%.8x", sha256.Sum256([]byte(checks)))
+}
+
+func configString(component *Component, key string) string {
+	value, ok := component.configuration[key]
+	if !ok || value == nil {
+		return ""
+	}
+	return strings.TrimSpace(fmt.Sprint(value))
+}
+
+//verifyArchive verifies the digest and signature of a downloaded archive or chart
+func (v *verification) verifyArchive(data []byte, sourceURL string) error {
+	if v.digest != "" {
+		if digest := fmt.Sprintf("%s%x", digestPrefix, sha256.Sum256(data)); digest != v.digest {
+			return v.error("digest of '%s' is '%s' but expected was '%s'", sourceURL, digest, v.digest)
+		}
+	}
+	if v.signatureType == "" {
+		return nil
+	}
+
+	signatureURL, err := v.archiveSignatureURL(sourceURL)
+	if err != nil {
+		return err
+	}
+	signature, err := downloadSignature(signatureURL)
+	if err != nil {
+		return v.error("signature of '%s' could not be retrieved: %s", sourceURL, err)
+	}
+	if v.signatureType == CosignSignature {
+		err = verifyCosignSignature(data, signature, v.publicKey)
+	} else {
+		err = verifyGPGSignature(data, signature, v.publicKey)
+	}
+	if err != nil {
+		return v.error("%s signature '%s' of '%s' is invalid: %s", v.signatureType, signatureURL, sourceURL, err)
+	}
+	return nil
+}
+
+func (v *verification) archiveSignatureURL(sourceURL string) (string, error) {
+	if v.signatureURL != "" {
+		return v.signatureURL, nil
+	}
+	if !strings.HasPrefix(sourceURL, "http://") && !strings.HasPrefix(sourceURL, "https://") {
+		return "", v.error("configuration '%s' is required to verify the signature of '%s'",
+			VerifySignatureURLConfigKey, sourceURL)
+	}
+	if v.signatureType == GPGSignature {
+		return sourceURL + ".asc", nil
+	}
+	return sourceURL + ".sig", nil
+}
+
+//verifyCheckout verifies that the checked out commit or the tag referenced by the version has a valid GPG signature
+func (v *verification) verifyCheckout(repoPath, version, revision string) error {
+	if v.digest != "" {
+		return v.error("configuration '%s' is not supported for GIT sources: use a commit hash as version instead",
+			VerifyDigestConfigKey)
+	}
+	if v.signatureType != GPGSignature {
+		return v.error("only '%s' signatures are supported for GIT sources", GPGSignature)
+	}
+
+	repo, err := gogit.PlainOpen(repoPath)
+	if err != nil {
+		return err
+	}
+	commit, err := repo.CommitObject(plumbing.NewHash(revision))
+	if err != nil {
+		return err
+	}
+	_, commitErr := commit.Verify(v.publicKey)
+	if commitErr == nil {
+		return nil
+	}
+
+	//the commit isn't signed: accept a signed annotated tag of the version
+	if tagRef, err := repo.Tag(version); err == nil {
+		if tag, err := repo.TagObject(tagRef.Hash()); err == nil && tag.Target == commit.Hash {
+			if _, err := tag.Verify(v.publicKey); err == nil {
+				return nil
+			}
+		}
+	}
+	return v.error("neither commit '%s' nor tag '%s' has a valid GPG signature: %s", revision, version, commitErr)
+}
+
+func (v *verification) error(format string, args ...interface{}) error {
+	return fmt.Errorf("integrity verification of component '%s' failed: %s", v.component, fmt.Sprintf(format, args...))
+}
+
+func downloadSignature(signatureURL string) ([]byte, error) {
+	resp, err := http.Get(signatureURL) // #nosec
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("request '%s' failed with status %d", signatureURL, resp.StatusCode)
+	}
+	return ioutil.ReadAll(resp.Body)
+}
+
+//verifyCosignSignature verifies a base64 encoded blob signature (as created by 'cosign sign-blob')
+func verifyCosignSignature(data, signature []byte, publicKey string) error {
+	block, _ := pem.Decode([]byte(publicKey))
+	if block == nil {
+		return errors.New("public key is not PEM encoded")
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse public key")
+	}
+	sig, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(signature)))
+	if err != nil {
+		return errors.Wrap(err, "signature is not base64 encoded")
+	}
+
+	digest := sha256.Sum256(data)
+	switch pubKey := key.(type) {
+	case *ecdsa.PublicKey:
+		if !ecdsa.VerifyASN1(pubKey, digest[:], sig) {
+			return errors.New("ECDSA signature does not match")
+		}
+	case *rsa.PublicKey:
+		if err := rsa.VerifyPKCS1v15(pubKey, crypto.SHA256, digest[:], sig); err != nil {
+			return errors.Wrap(err, "RSA signature does not match")
+		}
+	case ed25519.PublicKey:
+		if !ed25519.Verify(pubKey, data, sig) {
+			return errors.New("Ed25519 signature does not match")
+		}
+	default:
+		return fmt.Errorf("public key type '%T' is not supported", key)
+	}
+	return nil
+}
+
+//verifyGPGSignature verifies a detached (ASCII armored or binary) GPG signature
+func verifyGPGSignature(data, signature []byte, publicKey string) error {
+	keyRing, err := openpgp.ReadArmoredKeyRing(strings.NewReader(publicKey))
+	if err != nil {
+		return errors.Wrap(err, "failed to read public key ring")
+	}
+	if bytes.HasPrefix(bytes.TrimSpace(signature), []byte("-----BEGIN")) {
+		_, err = openpgp.CheckArmoredDetachedSignature(keyRing, bytes.NewReader(data), bytes.NewReader(signature), nil)
+	} else {
+		_, err = openpgp.CheckDetachedSignature(keyRing, bytes.NewReader(data), bytes.NewReader(signature), nil)
+	}
+	return err
+}
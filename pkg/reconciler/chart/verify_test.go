@@ -0,0 +1,204 @@
+package chart
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewVerification(t *testing.T) {
+	newComponent := func(config map[string]interface{}) *Component {
+		return NewComponentBuilder("1.0.0", "component").WithConfiguration(config).Build()
+	}
+
+	keys := VerificationKeys{"trusted": "trusted key"}
+
+	v, err := newVerification(newComponent(map[string]interface{}{"a": "b"}), keys)
+	require.NoError(t, err)
+	require.Nil(t, v)
+	require.Empty(t, workspaceSuffix(v))
+
+	v, err = newVerification(newComponent(map[string]interface{}{
+		VerifyDigestConfigKey: fmt.Sprintf("%X", sha256.Sum256([]byte("test"))),
+	}), keys)
+	require.NoError(t, err)
+	require.Equal(t, fmt.Sprintf("sha256:%x", sha256.Sum256([]byte("test"))), v.digest)
+	require.NotEmpty(t, workspaceSuffix(v))
+
+	v, err = newVerification(newComponent(map[string]interface{}{
+		VerifySignatureTypeConfigKey: "cosign",
+		VerifyKeyConfigKey:           "trusted",
+	}), keys)
+	require.NoError(t, err)
+	require.Equal(t, "trusted key", v.publicKey)
+
+	for _, config := range []map[string]interface{}{
+		{VerifyDigestConfigKey: "sha256:abc"},
+		{VerifySignatureTypeConfigKey: "cosign"},
+		{VerifySignatureTypeConfigKey: "cosign", VerifyKeyConfigKey: "untrusted"},
+		{VerifySignatureTypeConfigKey: "x509", VerifyKeyConfigKey: "trusted"},
+	} {
+		_, err := newVerification(newComponent(config), keys)
+		require.Error(t, err)
+	}
+}
+
+func TestWorkspaceSuffix(t *testing.T) {
+	v1 := &verification{digest: "sha256:abc"}
+	v2 := &verification{digest: "sha256:abc", signatureType: CosignSignature, publicKey: "key"}
+	v3 := &verification{digest: "sha256:abc", signatureType: CosignSignature, publicKey: "other key"}
+	require.NotEqual(t, workspaceSuffix(v1), workspaceSuffix(v2))
+	require.NotEqual(t, workspaceSuffix(v2), workspaceSuffix(v3))
+	require.Equal(t, workspaceSuffix(v2), workspaceSuffix(&verification{digest: "sha256:abc",
+		signatureType: CosignSignature, publicKey: "key"}))
+}
+
+func TestLoadVerificationKeys(t *testing.T) {
+	dir := t.TempDir()
+
+	keysFile := filepath.Join(dir, "keys.yaml")
+	require.NoError(t, ioutil.WriteFile(keysFile, []byte("release: |\n  -----BEGIN PUBLIC KEY-----\n"), 0600))
+	keys, err := LoadVerificationKeys(keysFile)
+	require.NoError(t, err)
+	require.Equal(t, VerificationKeys{"release": "-----BEGIN PUBLIC KEY-----\n"}, keys)
+
+	emptyFile := filepath.Join(dir, "empty.yaml")
+	require.NoError(t, ioutil.WriteFile(emptyFile, []byte("release: ''"), 0600))
+	_, err = LoadVerificationKeys(emptyFile)
+	require.Error(t, err)
+
+	_, err = LoadVerificationKeys(filepath.Join(dir, "missing.yaml"))
+	require.Error(t, err)
+}
+
+func TestVerifyArchive(t *testing.T) {
+	data := []byte("chart archive")
+	tampered := []byte("tampered chart archive")
+
+	//cosign key and signature
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	pubKeyDer, err := x509.MarshalPKIXPublicKey(&ecdsaKey.PublicKey)
+	require.NoError(t, err)
+	cosignPubKey := string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubKeyDer}))
+	digest := sha256.Sum256(data)
+	cosignSig, err := ecdsa.SignASN1(rand.Reader, ecdsaKey, digest[:])
+	require.NoError(t, err)
+
+	//gpg key and signature
+	entity, gpgPubKey := newGPGEntity(t)
+	gpgSig := &bytes.Buffer{}
+	require.NoError(t, openpgp.ArmoredDetachSign(gpgSig, entity, bytes.NewReader(data), nil))
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/archive.tgz.sig":
+			_, _ = w.Write([]byte(base64.StdEncoding.EncodeToString(cosignSig)))
+		case "/archive.tgz.asc", "/signatures/archive.asc":
+			_, _ = w.Write(gpgSig.Bytes())
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	archiveURL := server.URL + "/archive.tgz"
+
+	t.Run("Verify digest", func(t *testing.T) {
+		v := &verification{component: "component", digest: fmt.Sprintf("sha256:%x", digest)}
+		require.NoError(t, v.verifyArchive(data, "oci://registry/chart"))
+		err := v.verifyArchive(tampered, "oci://registry/chart")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "integrity verification of component 'component' failed")
+	})
+
+	t.Run("Verify cosign signature", func(t *testing.T) {
+		v := &verification{component: "component", signatureType: CosignSignature, publicKey: cosignPubKey}
+		require.NoError(t, v.verifyArchive(data, archiveURL))
+		require.Error(t, v.verifyArchive(tampered, archiveURL))
+		//signature URL has to be defined for non-HTTP sources
+		require.Error(t, v.verifyArchive(data, "oci://registry/chart"))
+	})
+
+	t.Run("Verify GPG signature", func(t *testing.T) {
+		v := &verification{component: "component", signatureType: GPGSignature, publicKey: gpgPubKey}
+		require.NoError(t, v.verifyArchive(data, archiveURL))
+		require.Error(t, v.verifyArchive(tampered, archiveURL))
+
+		v.signatureURL = server.URL + "/signatures/archive.asc"
+		require.NoError(t, v.verifyArchive(data, "oci://registry/chart"))
+	})
+
+	t.Run("Missing signature", func(t *testing.T) {
+		v := &verification{component: "component", signatureType: GPGSignature, publicKey: gpgPubKey}
+		require.Error(t, v.verifyArchive(data, server.URL+"/unsigned.tgz"))
+	})
+}
+
+func TestVerifyCheckout(t *testing.T) {
+	entity, gpgPubKey := newGPGEntity(t)
+	_, otherPubKey := newGPGEntity(t)
+
+	repoDir := t.TempDir()
+	repo, err := gogit.PlainInit(repoDir, false)
+	require.NoError(t, err)
+	worktree, err := repo.Worktree()
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(filepath.Join(repoDir, "Chart.yaml"), []byte("name: test"), 0600))
+	_, err = worktree.Add("Chart.yaml")
+	require.NoError(t, err)
+
+	signature := &object.Signature{Name: "test", Email: "test@example.com", When: time.Now()}
+	signedCommit, err := worktree.Commit("signed", &gogit.CommitOptions{Author: signature, SignKey: entity})
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(filepath.Join(repoDir, "values.yaml"), []byte("key: value"), 0600))
+	_, err = worktree.Add("values.yaml")
+	require.NoError(t, err)
+	unsignedCommit, err := worktree.Commit("unsigned", &gogit.CommitOptions{Author: signature})
+	require.NoError(t, err)
+	_, err = repo.CreateTag("1.0.0", unsignedCommit, &gogit.CreateTagOptions{
+		Tagger: signature, Message: "release", SignKey: entity,
+	})
+	require.NoError(t, err)
+
+	v := &verification{component: "component", signatureType: GPGSignature, publicKey: gpgPubKey}
+	require.NoError(t, v.verifyCheckout(repoDir, "main", signedCommit.String()))
+	require.Error(t, v.verifyCheckout(repoDir, "main", unsignedCommit.String()))
+	//unsigned commit is accepted if the tag is signed
+	require.NoError(t, v.verifyCheckout(repoDir, "1.0.0", unsignedCommit.String()))
+
+	v.publicKey = otherPubKey
+	require.Error(t, v.verifyCheckout(repoDir, "main", signedCommit.String()))
+
+	v = &verification{component: "component", signatureType: CosignSignature, publicKey: "key"}
+	require.Error(t, v.verifyCheckout(repoDir, "main", signedCommit.String()))
+}
+
+//newGPGEntity creates a GPG key pair and returns the entity including its ASCII armored public key
+func newGPGEntity(t *testing.T) (*openpgp.Entity, string) {
+	entity, err := openpgp.NewEntity("test", "", "test@example.com", nil)
+	require.NoError(t, err)
+	pubKey := &bytes.Buffer{}
+	writer, err := armor.Encode(pubKey, openpgp.PublicKeyType, nil)
+	require.NoError(t, err)
+	require.NoError(t, entity.Serialize(writer))
+	require.NoError(t, writer.Close())
+	return entity, pubKey.String()
+}
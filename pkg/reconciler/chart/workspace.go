@@ -1,10 +1,12 @@
 package chart
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path"
 	"path/filepath"
+	"strings"
 
 	file "github.com/kyma-incubator/reconciler/pkg/files"
 )
@@ -15,6 +17,8 @@ const (
 	instResCrdDir = "installation/resources/crds"
 )
 
+var errProfileFound = errors.New("profile found")
+
 type Workspace struct {
 	WorkspaceDir string
 }
@@ -88,3 +92,21 @@ func (w *KymaWorkspace) validate() error {
 	}
 	return nil
 }
+
+//HasProfile returns true if any chart of the workspace ships a file for the profile ('profile-<name>.yaml')
+func (w *KymaWorkspace) HasProfile(name string) (bool, error) {
+	profileFile := fmt.Sprintf("profile-%s.yaml", strings.ToLower(name))
+	err := filepath.Walk(w.ResourceDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() && info.Name() == profileFile {
+			return errProfileFound
+		}
+		return nil
+	})
+	if err == errProfileFound {
+		return true, nil
+	}
+	return false, err
+}
@@ -0,0 +1,29 @@
+package chart
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestKymaWorkspaceHasProfile(t *testing.T) {
+	resourceDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(resourceDir, "comp", "charts", "sub"), 0700))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(resourceDir, "comp", "profile-evaluation.yaml"), []byte{}, 0600))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(resourceDir, "comp", "charts", "sub", "profile-production.yaml"), []byte{}, 0600))
+	ws := &KymaWorkspace{ResourceDir: resourceDir}
+
+	for name, expected := range map[string]bool{
+		"evaluation":  true,
+		"Production":  true,
+		"trial-small": false,
+		"values":      false,
+	} {
+		exists, err := ws.HasProfile(name)
+		require.NoError(t, err)
+		require.Equal(t, expected, exists, name)
+	}
+}
@@ -0,0 +1,295 @@
+package chart
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+const defaultWorkspaceGCInterval = 10 * time.Minute
+
+// WorkspaceGCConfig defines when workspaces which are no longer used get deleted
+type WorkspaceGCConfig struct {
+	Quota    int64         //max disk usage of all workspaces in bytes (0 means unlimited)
+	MaxAge   time.Duration //max time a workspace is kept after it was used the last time (0 means unlimited)
+	Interval time.Duration //interval of the garbage collection runs
+}
+
+// NewWorkspaceGCConfig creates a garbage collection configuration. The quota is a quantity like '10Gi'
+// (an empty value means unlimited).
+func NewWorkspaceGCConfig(quota string, maxAge, interval time.Duration) (*WorkspaceGCConfig, error) {
+	config := &WorkspaceGCConfig{
+		MaxAge:   maxAge,
+		Interval: interval,
+	}
+	if quota != "" {
+		quantity, err := resource.ParseQuantity(quota)
+		if err != nil {
+			return nil, errors.Wrapf(err, "workspace quota '%s' is invalid", quota)
+		}
+		config.Quota = quantity.Value()
+	}
+	if config.Quota < 0 {
+		return nil, fmt.Errorf("workspace quota cannot be negative but was '%s'", quota)
+	}
+	if config.MaxAge < 0 {
+		return nil, fmt.Errorf("workspace max age cannot be negative but was '%s'", maxAge)
+	}
+	if config.Interval < 0 {
+		return nil, fmt.Errorf("workspace garbage collection interval cannot be negative but was '%s'", interval)
+	}
+	if config.Interval == 0 {
+		config.Interval = defaultWorkspaceGCInterval
+	}
+	return config, nil
+}
+
+// Enabled returns true if a quota or max age is defined
+func (c *WorkspaceGCConfig) Enabled() bool {
+	return c != nil && (c.Quota > 0 || c.MaxAge > 0)
+}
+
+// WorkspaceStats summarizes the workspaces which exist after a garbage collection run
+type WorkspaceStats struct {
+	Count   int   //number of workspaces
+	Size    int64 //disk usage of all workspaces in bytes
+	InUse   int   //number of workspaces used by running tasks
+	Deleted int   //number of workspaces deleted by the garbage collection run
+}
+
+//workspaceUsage counts the references of running tasks on workspace directories
+type workspaceUsage struct {
+	mu   sync.Mutex
+	refs map[string]int
+}
+
+func (u *workspaceUsage) acquire(dir string) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if u.refs == nil {
+		u.refs = make(map[string]int)
+	}
+	u.refs[dir]++
+}
+
+func (u *workspaceUsage) release(dir string) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if u.refs[dir] <= 1 {
+		delete(u.refs, dir)
+		return
+	}
+	u.refs[dir]--
+}
+
+func (u *workspaceUsage) inUse(dir string) bool {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	return u.refs[dir] > 0
+}
+
+// WorkspaceLease is a Factory which protects all workspaces it provided against garbage collection until
+// the lease gets released
+type WorkspaceLease struct {
+	factory *DefaultFactory
+	mu      sync.Mutex
+	dirs    map[string]bool
+}
+
+func (l *WorkspaceLease) Get(version string) (*KymaWorkspace, error) {
+	return l.factory.getKymaWorkspace(version, l)
+}
+
+func (l *WorkspaceLease) Delete(version string) error {
+	return l.factory.Delete(version)
+}
+
+func (l *WorkspaceLease) GetExternalComponent(component *Component) (*Workspace, error) {
+	return l.factory.getExternalComponent(component, l)
+}
+
+func (l *WorkspaceLease) acquire(dir string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.dirs == nil {
+		l.dirs = make(map[string]bool)
+	}
+	if l.dirs[dir] {
+		return
+	}
+	l.dirs[dir] = true
+	l.factory.usage.acquire(dir)
+}
+
+// Release allows the garbage collection of all workspaces provided by the lease
+func (l *WorkspaceLease) Release() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for dir := range l.dirs {
+		l.factory.usage.release(dir)
+	}
+	l.dirs = nil
+}
+
+// LeaseWorkspaces returns a factory which protects the workspaces it provides against garbage collection
+// until the returned release function is called. Factories without garbage collection support are returned as they are.
+func LeaseWorkspaces(factory Factory) (Factory, func()) {
+	defaultFactory, ok := factory.(*DefaultFactory)
+	if !ok {
+		return factory, func() {}
+	}
+	lease := defaultFactory.Lease()
+	return lease, lease.Release
+}
+
+type workspaceInfo struct {
+	dir      string
+	size     int64
+	lastUsed time.Time
+}
+
+// CollectGarbage deletes the least recently used workspaces until the disk usage is below the quota and
+// all workspaces which weren't used within the max age. Workspaces used by running tasks are never deleted.
+// GIT mirrors count toward the quota and are deleted like workspaces (they are re-created by the next checkout).
+func (f *DefaultFactory) CollectGarbage(config *WorkspaceGCConfig) (*WorkspaceStats, error) {
+	//block the creation of workspaces while the garbage collection is running
+	f.gcMutex.Lock()
+	defer f.gcMutex.Unlock()
+	f.mutexGetComponent.Lock()
+	defer f.mutexGetComponent.Unlock()
+
+	if err := f.validate(); err != nil {
+		return nil, err
+	}
+
+	workspaces, err := f.listWorkspaces()
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(workspaces, func(i, j int) bool {
+		return workspaces[i].lastUsed.Before(workspaces[j].lastUsed)
+	})
+
+	stats := &WorkspaceStats{}
+	for _, ws := range workspaces {
+		stats.Size += ws.size
+	}
+
+	now := time.Now()
+	for _, ws := range workspaces {
+		inUse := f.usage.inUse(ws.dir)
+		if inUse {
+			stats.InUse++
+		}
+		expired := config.MaxAge > 0 && now.Sub(ws.lastUsed) > config.MaxAge
+		overQuota := config.Quota > 0 && stats.Size > config.Quota
+		if inUse || (!expired && !overQuota) {
+			stats.Count++
+			continue
+		}
+		f.logger.Infof("Deleting workspace '%s' (size: %d bytes, last used: %s)", ws.dir, ws.size, ws.lastUsed)
+		if err := os.RemoveAll(ws.dir); err != nil {
+			f.logger.Warnf("Failed to delete workspace '%s': %s", ws.dir, err)
+			stats.Count++
+			continue
+		}
+		stats.Size -= ws.size
+		stats.Deleted++
+	}
+
+	if config.Quota > 0 && stats.Size > config.Quota {
+		f.logger.Warnf("Workspaces exceed the disk quota of %d bytes (current usage: %d bytes) "+
+			"because %d workspaces are in use", config.Quota, stats.Size, stats.InUse)
+	}
+
+	f.statsMu.Lock()
+	f.stats = stats
+	f.statsMu.Unlock()
+
+	return stats, nil
+}
+
+// WorkspaceStats returns the workspace statistics of the last garbage collection run
+// (nil if the garbage collection never ran)
+func (f *DefaultFactory) WorkspaceStats() *WorkspaceStats {
+	f.statsMu.Lock()
+	defer f.statsMu.Unlock()
+	if f.stats == nil {
+		return nil
+	}
+	stats := *f.stats
+	return &stats
+}
+
+//listWorkspaces returns all complete workspaces (including GIT mirrors) in the storage directory. Only directories
+//with a ready marker are considered as workspace to avoid the deletion of foreign files.
+func (f *DefaultFactory) listWorkspaces() ([]*workspaceInfo, error) {
+	var workspaces []*workspaceInfo
+	baseDirs := []string{
+		f.storageDir,
+		filepath.Join(f.storageDir, gitComponentsBaseDir),
+		filepath.Join(f.storageDir, gitMirrorsDir),
+	}
+	for _, baseDir := range baseDirs {
+		entries, err := ioutil.ReadDir(baseDir)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, errors.Wrapf(err, "failed to list workspaces in directory '%s'", baseDir)
+		}
+		for _, entry := range entries {
+			if !entry.IsDir() {
+				continue
+			}
+			dir := filepath.Join(baseDir, entry.Name())
+			marker, err := os.Stat(f.readyFile(dir))
+			if err != nil {
+				continue //not a workspace or workspace is incomplete
+			}
+			size, err := dirSize(dir)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to calculate size of workspace '%s'", dir)
+			}
+			workspaces = append(workspaces, &workspaceInfo{
+				dir:      dir,
+				size:     size,
+				lastUsed: marker.ModTime(),
+			})
+		}
+	}
+	return workspaces, nil
+}
+
+//markUsed updates the last usage time of a workspace (stored as modification time of the ready marker, to
+//survive restarts) and acquires the workspace for the lease
+func (f *DefaultFactory) markUsed(dir string, lease *WorkspaceLease) {
+	now := time.Now()
+	if err := os.Chtimes(f.readyFile(dir), now, now); err != nil && !os.IsNotExist(err) {
+		f.logger.Warnf("Failed to update last usage time of workspace '%s': %s", dir, err)
+	}
+	if lease != nil {
+		lease.acquire(dir)
+	}
+}
+
+func dirSize(dir string) (int64, error) {
+	var size int64
+	err := filepath.Walk(dir, func(_ string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.Mode().IsRegular() {
+			size += info.Size()
+		}
+		return nil
+	})
+	return size, err
+}
@@ -0,0 +1,149 @@
+package chart
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	file "github.com/kyma-incubator/reconciler/pkg/files"
+	log "github.com/kyma-incubator/reconciler/pkg/logger"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkspaceGCConfig(t *testing.T) {
+	config, err := NewWorkspaceGCConfig("1Ki", time.Hour, 0)
+	require.NoError(t, err)
+	require.Equal(t, int64(1024), config.Quota)
+	require.Equal(t, defaultWorkspaceGCInterval, config.Interval)
+	require.True(t, config.Enabled())
+
+	config, err = NewWorkspaceGCConfig("", 0, time.Minute)
+	require.NoError(t, err)
+	require.False(t, config.Enabled())
+
+	_, err = NewWorkspaceGCConfig("abc", 0, 0)
+	require.Error(t, err)
+	_, err = NewWorkspaceGCConfig("-1Gi", 0, 0)
+	require.Error(t, err)
+	_, err = NewWorkspaceGCConfig("", -time.Hour, 0)
+	require.Error(t, err)
+}
+
+func TestWorkspaceGC(t *testing.T) {
+	logger := log.NewLogger(true)
+
+	//creates a workspace with a 100 bytes file which was used the last time at the given time
+	newWorkspace := func(t *testing.T, dir string, lastUsed time.Time) {
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, instResCrdDir), 0700))
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, resDir), 0700))
+		require.NoError(t, ioutil.WriteFile(filepath.Join(dir, resDir, "file"), make([]byte, 100), 0600))
+		marker := filepath.Join(dir, wsReadyIndicatorFile)
+		require.NoError(t, ioutil.WriteFile(marker, nil, 0600))
+		require.NoError(t, os.Chtimes(marker, lastUsed, lastUsed))
+	}
+
+	newFactory := func(t *testing.T) *DefaultFactory {
+		storageDir, err := ioutil.TempDir("", "workspacegc_*")
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			require.NoError(t, os.RemoveAll(storageDir))
+		})
+		factory, err := NewFactory(nil, storageDir, logger)
+		require.NoError(t, err)
+
+		now := time.Now()
+		newWorkspace(t, factory.workspaceDir("1.0.0"), now.Add(-3*time.Hour))
+		newWorkspace(t, factory.workspaceDir("2.0.0"), now.Add(-2*time.Hour))
+		newWorkspace(t, factory.workspaceDir("3.0.0"), now.Add(-1*time.Minute))
+		newWorkspace(t, filepath.Join(storageDir, gitComponentsBaseDir, "abc-component"), now.Add(-4*time.Hour))
+		//directories without ready marker are never deleted
+		require.NoError(t, os.MkdirAll(filepath.Join(storageDir, "foreign"), 0700))
+		return factory
+	}
+
+	t.Run("Delete workspaces exceeding max age", func(t *testing.T) {
+		factory := newFactory(t)
+		require.Nil(t, factory.WorkspaceStats())
+
+		stats, err := factory.CollectGarbage(&WorkspaceGCConfig{MaxAge: 90 * time.Minute})
+		require.NoError(t, err)
+		require.Equal(t, 3, stats.Deleted)
+		require.Equal(t, 1, stats.Count)
+		require.Equal(t, stats, factory.WorkspaceStats())
+
+		require.True(t, file.DirExists(factory.workspaceDir("3.0.0")))
+		require.False(t, file.DirExists(factory.workspaceDir("1.0.0")))
+		require.False(t, file.DirExists(factory.workspaceDir("2.0.0")))
+		require.True(t, file.DirExists(factory.workspaceDir("foreign")))
+	})
+
+	t.Run("Delete least recently used workspaces exceeding quota", func(t *testing.T) {
+		factory := newFactory(t)
+
+		stats, err := factory.CollectGarbage(&WorkspaceGCConfig{Quota: 250})
+		require.NoError(t, err)
+		require.Equal(t, 2, stats.Deleted)
+		require.Equal(t, 2, stats.Count)
+		require.Equal(t, int64(200), stats.Size)
+
+		require.False(t, file.DirExists(filepath.Join(factory.storageDir, gitComponentsBaseDir, "abc-component")))
+		require.False(t, file.DirExists(factory.workspaceDir("1.0.0")))
+		require.True(t, file.DirExists(factory.workspaceDir("2.0.0")))
+		require.True(t, file.DirExists(factory.workspaceDir("3.0.0")))
+	})
+
+	t.Run("Keep workspaces in use", func(t *testing.T) {
+		factory := newFactory(t)
+
+		lease := factory.Lease()
+		ws, err := lease.Get("1.0.0")
+		require.NoError(t, err)
+
+		//get updates the last usage
+		marker, err := os.Stat(factory.readyFile(ws.WorkspaceDir))
+		require.NoError(t, err)
+		require.WithinDuration(t, time.Now(), marker.ModTime(), time.Minute)
+
+		stats, err := factory.CollectGarbage(&WorkspaceGCConfig{Quota: 1})
+		require.NoError(t, err)
+		require.Equal(t, 3, stats.Deleted)
+		require.Equal(t, 1, stats.InUse)
+		require.True(t, file.DirExists(factory.workspaceDir("1.0.0")))
+
+		lease.Release()
+		stats, err = factory.CollectGarbage(&WorkspaceGCConfig{Quota: 1})
+		require.NoError(t, err)
+		require.Equal(t, 1, stats.Deleted)
+		require.Zero(t, stats.Count)
+		require.False(t, file.DirExists(factory.workspaceDir("1.0.0")))
+	})
+
+	t.Run("Keep workspaces provided without lease", func(t *testing.T) {
+		factory := newFactory(t)
+
+		_, err := factory.Get("1.0.0")
+		require.NoError(t, err)
+
+		stats, err := factory.CollectGarbage(&WorkspaceGCConfig{Quota: 1})
+		require.NoError(t, err)
+		require.Equal(t, 3, stats.Deleted)
+		require.Equal(t, 1, stats.InUse)
+		require.True(t, file.DirExists(factory.workspaceDir("1.0.0")))
+	})
+
+	t.Run("GIT mirrors count toward the quota", func(t *testing.T) {
+		factory := newFactory(t)
+		mirrorDir := factory.mirrorDir("https://github.com/kyma-project/kyma")
+		newWorkspace(t, mirrorDir, time.Now().Add(-5*time.Hour))
+
+		stats, err := factory.CollectGarbage(&WorkspaceGCConfig{Quota: 350})
+		require.NoError(t, err)
+		require.Equal(t, 2, stats.Deleted)
+		require.Equal(t, 3, stats.Count)
+		require.False(t, file.DirExists(mirrorDir))
+		require.False(t, file.DirExists(filepath.Join(factory.storageDir, gitComponentsBaseDir, "abc-component")))
+		require.True(t, file.DirExists(factory.workspaceDir("1.0.0")))
+	})
+}
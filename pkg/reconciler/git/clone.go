@@ -25,6 +25,7 @@ type Cloner struct {
 
 	repoClient         RepoClient
 	inClusterClientSet k8s.Interface
+	credentials        *CredentialsMapping
 	logger             *zap.SugaredLogger
 }
 
@@ -48,6 +49,13 @@ func NewCloner(repoClient RepoClient, repo *reconciler.Repository, autoCheckout
 	}, nil
 }
 
+// WithCredentials configures the credentials mapping used to authenticate against the repository.
+// Repositories without a matching entry fall back to the token secret named after the repository host.
+func (r *Cloner) WithCredentials(credentials *CredentialsMapping) *Cloner {
+	r.credentials = credentials
+	return r
+}
+
 // Clone clones the repository from the given remote URL to the given `path` in the local filesystem.
 func (r *Cloner) Clone(path string) (*git.Repository, error) {
 	auth, err := r.buildAuth()
@@ -72,9 +80,14 @@ func (r *Cloner) Checkout(rev string, repo *git.Repository) error {
 		return errors.Wrap(err, "error getting the GIT worktree")
 	}
 
+	auth, err := r.buildAuth()
+	if err != nil {
+		return err
+	}
+
 	// hash, err := r.repoClient.ResolveRevision(gitp.Revision(rev))
-	var defaultLister refLister = remoteRefLister{}
-	var resolver = revisionResolver{url: r.repo.URL, repository: repo, refLister: defaultLister}
+	var defaultLister refLister = remoteRefLister{auth: auth}
+	var resolver = revisionResolver{url: r.repo.URL, repository: repo, refLister: defaultLister, auth: auth}
 
 	hash, err := resolver.resolveRevision(rev)
 	if err != nil {
@@ -112,6 +125,14 @@ func (r *Cloner) CloneAndCheckout(dstPath, rev string) error {
 }
 
 func (r *Cloner) buildAuth() (transport.AuthMethod, error) {
+	if creds := r.credentials.Lookup(r.repo.URL); creds != nil {
+		resolved, err := creds.resolve(r.inClusterClientSet)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to resolve credentials of GIT repository '%s'", r.repo.URL)
+		}
+		return resolved.authMethod()
+	}
+
 	tokenNamespace := "default"
 	if r.repo.TokenNamespace != "" {
 		tokenNamespace = r.repo.TokenNamespace
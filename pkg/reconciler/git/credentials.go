@@ -0,0 +1,339 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/go-git/go-git/v5/plumbing/transport/ssh"
+	"github.com/pkg/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8s "k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	defaultSSHUser = "git"
+
+	//keys of a K8s secret which contains repository credentials
+	secretKeyToken                = "token"
+	secretKeyUsername             = "username"
+	secretKeyPassword             = "password"
+	secretKeySSHPrivateKey        = "sshPrivateKey"
+	secretKeySSHPassphrase        = "sshPassphrase"
+	secretKeyKnownHosts           = "knownHosts"
+	secretKeyGitHubAppID          = "githubAppID"
+	secretKeyGitHubInstallationID = "githubInstallationID"
+	secretKeyGitHubPrivateKey     = "githubPrivateKey"
+	secretKeyGitHubAPIURL         = "githubAPIURL"
+)
+
+// CredentialsMapping assigns credentials to GIT repositories.
+type CredentialsMapping struct {
+	Repositories []*Credentials `json:"repositories"`
+}
+
+// Credentials used to access the GIT repositories with the given URL prefix.
+// Secrets can be provided inline, as local files or as K8s secret (the values of the K8s secret take precedence).
+type Credentials struct {
+	URL               string                `json:"url"`                         //URL prefix of the repositories
+	Secret            string                `json:"secret,omitempty"`            //K8s secret with the credentials ('<namespace>/<name>')
+	Username          string                `json:"username,omitempty"`          //basic auth user
+	Password          string                `json:"password,omitempty"`          //basic auth password
+	Token             string                `json:"token,omitempty"`             //access token
+	SSHUser           string                `json:"sshUser,omitempty"`           //SSH user (default is 'git')
+	SSHPrivateKey     string                `json:"sshPrivateKey,omitempty"`     //PEM encoded SSH deploy key
+	SSHPrivateKeyFile string                `json:"sshPrivateKeyFile,omitempty"` //file containing the SSH deploy key
+	SSHPassphrase     string                `json:"sshPassphrase,omitempty"`     //passphrase of the SSH deploy key
+	KnownHosts        string                `json:"knownHosts,omitempty"`        //known_hosts entries used to verify the SSH host key
+	KnownHostsFile    string                `json:"knownHostsFile,omitempty"`    //known_hosts file used to verify the SSH host key
+	GitHubApp         *GitHubAppCredentials `json:"githubApp,omitempty"`         //GitHub App used to mint installation tokens
+}
+
+// GitHubAppCredentials of a GitHub App installation.
+type GitHubAppCredentials struct {
+	AppID          int64  `json:"appID"`
+	InstallationID int64  `json:"installationID"`
+	PrivateKey     string `json:"privateKey,omitempty"`     //PEM encoded private key of the app
+	PrivateKeyFile string `json:"privateKeyFile,omitempty"` //file containing the private key of the app
+	APIURL         string `json:"apiURL,omitempty"`         //GitHub API URL (default is 'https://api.github.com')
+}
+
+// LoadCredentialsMapping reads the credentials mapping from a YAML or JSON file.
+func LoadCredentialsMapping(file string) (*CredentialsMapping, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read GIT credentials file '%s'", file)
+	}
+	mapping := &CredentialsMapping{}
+	if err := yaml.UnmarshalStrict(data, mapping); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse GIT credentials file '%s'", file)
+	}
+	return mapping, mapping.validate()
+}
+
+func (m *CredentialsMapping) validate() error {
+	for idx, creds := range m.Repositories {
+		if creds == nil || creds.URL == "" {
+			return fmt.Errorf("GIT credentials entry #%d has no repository URL", idx+1)
+		}
+		if _, err := parseRepoURL(creds.URL); err != nil {
+			return err
+		}
+		if creds.Secret != "" {
+			if _, _, err := splitSecretRef(creds.Secret); err != nil {
+				return err
+			}
+		}
+		if creds.GitHubApp != nil && (creds.GitHubApp.AppID <= 0 || creds.GitHubApp.InstallationID <= 0) {
+			return fmt.Errorf("GitHub App credentials of repository '%s' require an app ID and an installation ID", creds.URL)
+		}
+	}
+	return nil
+}
+
+// Lookup returns the credentials of the entry with the longest URL prefix matching the repository URL.
+// Entries match only repositories with the same scheme and host and the entry path has to end at a
+// path segment boundary of the repository path.
+func (m *CredentialsMapping) Lookup(repoURL string) *Credentials {
+	if m == nil {
+		return nil
+	}
+	repoLoc, err := parseRepoURL(repoURL)
+	if err != nil {
+		return nil
+	}
+	var result *Credentials
+	var resultLoc *repoLocation
+	for _, creds := range m.Repositories {
+		credsLoc, err := parseRepoURL(creds.URL)
+		if err != nil || !credsLoc.contains(repoLoc) {
+			continue
+		}
+		if result == nil || len(credsLoc.path) > len(resultLoc.path) {
+			result = creds
+			resultLoc = credsLoc
+		}
+	}
+	return result
+}
+
+// repoLocation is the normalized URL of a GIT repository (SCP-like URLs such as 'git@host:org/repo' use the scheme 'ssh')
+type repoLocation struct {
+	scheme string
+	host   string
+	path   string
+}
+
+func parseRepoURL(repoURL string) (*repoLocation, error) {
+	normURL := strings.TrimSuffix(strings.ToLower(repoURL), ".git")
+	if !strings.Contains(normURL, "://") { //SCP-like URL: [user@]host:path
+		sep := strings.Index(normURL, ":")
+		if sep < 0 {
+			return nil, fmt.Errorf("GIT repository URL '%s' is invalid", repoURL)
+		}
+		host := normURL[:sep]
+		if at := strings.LastIndex(host, "@"); at >= 0 {
+			host = host[at+1:]
+		}
+		if host == "" {
+			return nil, fmt.Errorf("GIT repository URL '%s' has no host", repoURL)
+		}
+		return &repoLocation{scheme: "ssh", host: host, path: strings.Trim(normURL[sep+1:], "/")}, nil
+	}
+	parsedURL, err := url.Parse(normURL)
+	if err != nil {
+		return nil, errors.Wrapf(err, "GIT repository URL '%s' is invalid", repoURL)
+	}
+	if parsedURL.Host == "" {
+		return nil, fmt.Errorf("GIT repository URL '%s' has no host", repoURL)
+	}
+	return &repoLocation{scheme: parsedURL.Scheme, host: parsedURL.Host, path: strings.Trim(parsedURL.Path, "/")}, nil
+}
+
+// contains returns true if the repository is located at or below this location
+func (l *repoLocation) contains(repo *repoLocation) bool {
+	if l.scheme != repo.scheme || l.host != repo.host {
+		return false
+	}
+	return l.path == "" || repo.path == l.path || strings.HasPrefix(repo.path, l.path+"/")
+}
+
+func splitSecretRef(ref string) (string, string, error) {
+	parts := strings.Split(ref, "/")
+	if len(parts) == 1 && parts[0] != "" {
+		return "default", parts[0], nil
+	}
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("invalid secret reference '%s': expected '<namespace>/<name>'", ref)
+	}
+	return parts[0], parts[1], nil
+}
+
+// resolve returns a copy of the credentials which includes the values of the referenced K8s secret and local files.
+func (c *Credentials) resolve(clientSet k8s.Interface) (*Credentials, error) {
+	result := *c
+	if c.GitHubApp != nil {
+		app := *c.GitHubApp
+		result.GitHubApp = &app
+	}
+
+	if c.Secret != "" {
+		if err := result.mergeSecret(clientSet); err != nil {
+			return nil, err
+		}
+	}
+
+	var err error
+	if result.SSHPrivateKeyFile != "" && result.SSHPrivateKey == "" {
+		if result.SSHPrivateKey, err = readFile(result.SSHPrivateKeyFile); err != nil {
+			return nil, err
+		}
+	}
+	if result.KnownHostsFile != "" && result.KnownHosts == "" {
+		if result.KnownHosts, err = readFile(result.KnownHostsFile); err != nil {
+			return nil, err
+		}
+	}
+	if result.GitHubApp != nil && result.GitHubApp.PrivateKeyFile != "" && result.GitHubApp.PrivateKey == "" {
+		if result.GitHubApp.PrivateKey, err = readFile(result.GitHubApp.PrivateKeyFile); err != nil {
+			return nil, err
+		}
+	}
+	return &result, nil
+}
+
+func (c *Credentials) mergeSecret(clientSet k8s.Interface) error {
+	if clientSet == nil {
+		return fmt.Errorf("GIT credentials of repository '%s' refer to secret '%s' "+
+			"but no K8s cluster is available", c.URL, c.Secret)
+	}
+	namespace, name, err := splitSecretRef(c.Secret)
+	if err != nil {
+		return err
+	}
+	secret, err := clientSet.CoreV1().Secrets(namespace).Get(context.Background(), name, v1.GetOptions{})
+	if err != nil {
+		return errors.Wrapf(err, "failed to read GIT credentials secret '%s'", c.Secret)
+	}
+
+	value := func(key string, target *string) {
+		if data, ok := secret.Data[key]; ok {
+			*target = strings.Trim(string(data), "\n")
+		}
+	}
+	value(secretKeyToken, &c.Token)
+	value(secretKeyUsername, &c.Username)
+	value(secretKeyPassword, &c.Password)
+	value(secretKeySSHPrivateKey, &c.SSHPrivateKey)
+	value(secretKeySSHPassphrase, &c.SSHPassphrase)
+	value(secretKeyKnownHosts, &c.KnownHosts)
+
+	if _, ok := secret.Data[secretKeyGitHubPrivateKey]; ok {
+		if c.GitHubApp == nil {
+			c.GitHubApp = &GitHubAppCredentials{}
+		}
+		value(secretKeyGitHubPrivateKey, &c.GitHubApp.PrivateKey)
+		value(secretKeyGitHubAPIURL, &c.GitHubApp.APIURL)
+		for key, target := range map[string]*int64{
+			secretKeyGitHubAppID:          &c.GitHubApp.AppID,
+			secretKeyGitHubInstallationID: &c.GitHubApp.InstallationID,
+		} {
+			if data, ok := secret.Data[key]; ok {
+				id, err := strconv.ParseInt(strings.TrimSpace(string(data)), 10, 64)
+				if err != nil {
+					return errors.Wrapf(err, "invalid value of key '%s' in GIT credentials secret '%s'", key, c.Secret)
+				}
+				*target = id
+			}
+		}
+	}
+	return nil
+}
+
+// authMethod converts the credentials into the GIT auth method (SSH keys are preferred over GitHub Apps and tokens).
+func (c *Credentials) authMethod() (transport.AuthMethod, error) {
+	switch {
+	case c.SSHPrivateKey != "":
+		return c.sshAuth()
+	case c.GitHubApp != nil && c.GitHubApp.PrivateKey != "":
+		token, err := mintInstallationToken(c.GitHubApp)
+		if err != nil {
+			return nil, err
+		}
+		return &http.BasicAuth{
+			Username: "x-access-token",
+			Password: token,
+		}, nil
+	case c.Token != "":
+		return &http.BasicAuth{
+			Username: "xxx", // anything but an empty string
+			Password: c.Token,
+		}, nil
+	case c.Username != "":
+		return &http.BasicAuth{
+			Username: c.Username,
+			Password: c.Password,
+		}, nil
+	default:
+		return nil, nil
+	}
+}
+
+func (c *Credentials) sshAuth() (transport.AuthMethod, error) {
+	user := c.SSHUser
+	if user == "" {
+		user = defaultSSHUser
+	}
+	auth, err := ssh.NewPublicKeys(user, []byte(c.SSHPrivateKey), c.SSHPassphrase)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to load SSH deploy key of repository '%s'", c.URL)
+	}
+	if c.KnownHosts == "" {
+		//go-git falls back to the known_hosts files of the user ($SSH_KNOWN_HOSTS or ~/.ssh/known_hosts)
+		return auth, nil
+	}
+
+	knownHostsFile, err := ioutil.TempFile("", "known_hosts-")
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = os.Remove(knownHostsFile.Name())
+	}()
+	if _, err := knownHostsFile.WriteString(c.KnownHosts + "\n"); err != nil {
+		_ = knownHostsFile.Close()
+		return nil, err
+	}
+	if err := knownHostsFile.Close(); err != nil {
+		return nil, err
+	}
+	//the known_hosts file is parsed immediately: it can be deleted afterwards
+	auth.HostKeyCallback, err = ssh.NewKnownHostsCallback(knownHostsFile.Name())
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse known_hosts of repository '%s'", c.URL)
+	}
+	return auth, nil
+}
+
+func readFile(file string) (string, error) {
+	if strings.HasPrefix(file, "~/") {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		file = filepath.Join(homeDir, file[2:])
+	}
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to read GIT credentials from file '%s'", file)
+	}
+	return string(data), nil
+}
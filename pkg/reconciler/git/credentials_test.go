@@ -0,0 +1,178 @@
+package git
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/go-git/go-git/v5/plumbing/transport/ssh"
+	"github.com/kyma-incubator/reconciler/pkg/logger"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+const testKnownHosts = "github.com ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIOMqqnkVzrm0SdG6UOoqKLsabgH5C9okWi0dh2l9GKJl"
+
+func TestCredentialsMapping(t *testing.T) {
+	t.Run("Load and lookup credentials", func(t *testing.T) {
+		credsFile := filepath.Join(t.TempDir(), "credentials.yaml")
+		require.NoError(t, ioutil.WriteFile(credsFile, []byte(`
+repositories:
+- url: https://github.com/kyma-project/
+  token: org-token
+- url: https://github.com/kyma-project/private.git
+  secret: kyma-system/private-repo
+- url: git@github.com:kyma-project/
+  sshPrivateKeyFile: ~/.ssh/id_rsa
+`), 0600))
+
+		mapping, err := LoadCredentialsMapping(credsFile)
+		require.NoError(t, err)
+		require.Len(t, mapping.Repositories, 3)
+
+		require.Equal(t, "org-token", mapping.Lookup("https://github.com/kyma-project/kyma").Token)
+		require.Equal(t, "kyma-system/private-repo", mapping.Lookup("https://github.com/kyma-project/private").Secret)
+		require.Equal(t, "~/.ssh/id_rsa", mapping.Lookup("git@github.com:kyma-project/kyma.git").SSHPrivateKeyFile)
+		require.Nil(t, mapping.Lookup("https://github.com/other/repo"))
+		require.Nil(t, mapping.Lookup("http://github.com/kyma-project/kyma"))
+
+		var nilMapping *CredentialsMapping
+		require.Nil(t, nilMapping.Lookup("https://github.com/kyma-project/kyma"))
+	})
+
+	t.Run("Lookup respects host and path boundaries", func(t *testing.T) {
+		mapping := &CredentialsMapping{Repositories: []*Credentials{
+			{URL: "https://git.example.com", Token: "host-token"},
+			{URL: "https://git.example.com/org/repo", Token: "repo-token"},
+			{URL: "git@git.example.com:org/", Token: "ssh-token"},
+		}}
+
+		require.Equal(t, "host-token", mapping.Lookup("https://git.example.com/other/repo").Token)
+		require.Equal(t, "repo-token", mapping.Lookup("https://git.example.com/org/repo.git").Token)
+		require.Equal(t, "repo-token", mapping.Lookup("https://git.example.com/org/repo/").Token)
+		require.Equal(t, "ssh-token", mapping.Lookup("ssh://git@git.example.com/org/repo").Token)
+
+		//sibling hosts and repositories don't match
+		require.Nil(t, mapping.Lookup("https://git.example.com.evil.org/x"))
+		require.Nil(t, mapping.Lookup("https://git.example.com:8443/org/repo"))
+		require.Equal(t, "host-token", mapping.Lookup("https://git.example.com/org/repo-other").Token)
+		require.Nil(t, mapping.Lookup("git@git.example.com:other/repo"))
+		require.Nil(t, mapping.Lookup("invalid-url"))
+	})
+
+	t.Run("Reject invalid entries", func(t *testing.T) {
+		for _, content := range []string{
+			"repositories:\n- token: abc",
+			"repositories:\n- url: https://github.com/\n  secret: a/b/c",
+			"repositories:\n- url: https://github.com/\n  githubApp:\n    appID: 1",
+			"repositories:\n- url: https://github.com/\n  unknown: field",
+			"repositories:\n- url: github.com\n  token: abc",
+		} {
+			credsFile := filepath.Join(t.TempDir(), "credentials.yaml")
+			require.NoError(t, ioutil.WriteFile(credsFile, []byte(content), 0600))
+			_, err := LoadCredentialsMapping(credsFile)
+			require.Error(t, err, content)
+		}
+	})
+}
+
+func TestCredentialsAuthMethod(t *testing.T) {
+	t.Run("Token and basic auth", func(t *testing.T) {
+		auth, err := (&Credentials{Token: "abc"}).authMethod()
+		require.NoError(t, err)
+		require.Equal(t, &http.BasicAuth{Username: "xxx", Password: "abc"}, auth)
+
+		auth, err = (&Credentials{Username: "user", Password: "pwd"}).authMethod()
+		require.NoError(t, err)
+		require.Equal(t, &http.BasicAuth{Username: "user", Password: "pwd"}, auth)
+
+		auth, err = (&Credentials{}).authMethod()
+		require.NoError(t, err)
+		require.Nil(t, auth)
+	})
+
+	t.Run("SSH deploy key with known hosts", func(t *testing.T) {
+		dir := t.TempDir()
+		keyFile := filepath.Join(dir, "id_rsa")
+		require.NoError(t, ioutil.WriteFile(keyFile, testRSAKey(t), 0600))
+		knownHostsFile := filepath.Join(dir, "known_hosts")
+		require.NoError(t, ioutil.WriteFile(knownHostsFile, []byte(testKnownHosts), 0600))
+
+		creds, err := (&Credentials{
+			URL:               "git@github.com:kyma-project/",
+			SSHPrivateKeyFile: keyFile,
+			KnownHostsFile:    knownHostsFile,
+		}).resolve(nil)
+		require.NoError(t, err)
+
+		auth, err := creds.authMethod()
+		require.NoError(t, err)
+		sshAuth, ok := auth.(*ssh.PublicKeys)
+		require.True(t, ok)
+		require.Equal(t, defaultSSHUser, sshAuth.User)
+		require.NotNil(t, sshAuth.HostKeyCallback)
+
+		_, err = (&Credentials{SSHPrivateKey: "invalid"}).authMethod()
+		require.Error(t, err)
+
+		creds.KnownHosts = "invalid known hosts entry"
+		_, err = creds.authMethod()
+		require.Error(t, err)
+	})
+
+	t.Run("Credentials from K8s secret", func(t *testing.T) {
+		clientSet := fake.NewSimpleClientset(&v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "repo-creds", Namespace: "kyma-system"},
+			Data: map[string][]byte{
+				"sshPrivateKey": testRSAKey(t),
+				"knownHosts":    []byte(testKnownHosts),
+			},
+		})
+
+		creds, err := (&Credentials{URL: "git@github.com:", Secret: "kyma-system/repo-creds"}).resolve(clientSet)
+		require.NoError(t, err)
+		auth, err := creds.authMethod()
+		require.NoError(t, err)
+		require.IsType(t, &ssh.PublicKeys{}, auth)
+
+		_, err = (&Credentials{URL: "git@github.com:", Secret: "kyma-system/missing"}).resolve(clientSet)
+		require.Error(t, err)
+
+		_, err = (&Credentials{URL: "git@github.com:", Secret: "kyma-system/repo-creds"}).resolve(nil)
+		require.Error(t, err)
+	})
+
+	t.Run("Cloner prefers credentials mapping over token secret", func(t *testing.T) {
+		clientSet := fake.NewSimpleClientset(&v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "github.com", Namespace: "default"},
+			Data:       map[string][]byte{"token": []byte("host-token")},
+		})
+		repo := &reconciler.Repository{URL: "https://github.com/kyma-project/kyma"}
+		cloner, _ := NewCloner(nil, repo, true, clientSet, logger.NewLogger(true))
+
+		auth, err := cloner.buildAuth()
+		require.NoError(t, err)
+		require.Equal(t, "host-token", auth.(*http.BasicAuth).Password)
+
+		cloner.WithCredentials(&CredentialsMapping{Repositories: []*Credentials{
+			{URL: "https://github.com/kyma-project", Token: "mapped-token"},
+		}})
+		auth, err = cloner.buildAuth()
+		require.NoError(t, err)
+		require.Equal(t, "mapped-token", auth.(*http.BasicAuth).Password)
+	})
+}
+
+func testRSAKey(t *testing.T) []byte {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+}
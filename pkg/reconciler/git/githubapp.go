@@ -0,0 +1,149 @@
+package git
+
+import (
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	jose "github.com/square/go-jose/v3"
+	"github.com/square/go-jose/v3/jwt"
+)
+
+const (
+	defaultGitHubAPIURL = "https://api.github.com"
+	appJWTLifetime      = 9 * time.Minute //GitHub accepts app JWTs with a lifetime of max. 10 minutes
+	appJWTClockDrift    = time.Minute
+	tokenRenewalBuffer  = 5 * time.Minute //renew installation tokens before they expire
+)
+
+var (
+	installationTokens   = map[string]*installationTokenEntry{} //cache of minted installation tokens
+	installationTokensMu sync.Mutex                             //guards only the cache map, not the token requests
+	gitHubHTTPClient     = &http.Client{Timeout: 30 * time.Second}
+)
+
+//installationTokenEntry serialises the token requests of a single GitHub App installation
+type installationTokenEntry struct {
+	mu    sync.Mutex
+	token *installationToken
+}
+
+type installationToken struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// mintInstallationToken returns a cached installation token of the GitHub App or mints a new one.
+func mintInstallationToken(app *GitHubAppCredentials) (string, error) {
+	apiURL := strings.TrimSuffix(app.APIURL, "/")
+	if apiURL == "" {
+		apiURL = defaultGitHubAPIURL
+	}
+	cacheKey := fmt.Sprintf("%s|%d|%d", apiURL, app.AppID, app.InstallationID)
+
+	entry := installationTokenCacheEntry(cacheKey)
+	entry.mu.Lock()
+	defer entry.mu.Unlock()
+
+	if entry.token != nil && time.Now().Add(tokenRenewalBuffer).Before(entry.token.ExpiresAt) {
+		return entry.token.Token, nil
+	}
+
+	jwt, err := appJWT(app.AppID, app.PrivateKey, time.Now())
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequest(http.MethodPost,
+		fmt.Sprintf("%s/app/installations/%d/access_tokens", apiURL, app.InstallationID), nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Authorization", "Bearer "+jwt)
+	req.Header.Set("Accept", "application/vnd.github.v3+json")
+
+	resp, err := gitHubHTTPClient.Do(req)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to request installation token of GitHub App %d", app.AppID)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode != http.StatusCreated {
+		return "", fmt.Errorf("failed to request installation token of GitHub App %d: "+
+			"GitHub API returned status %d: %s", app.AppID, resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	token := &installationToken{}
+	if err := json.Unmarshal(body, token); err != nil {
+		return "", errors.Wrapf(err, "failed to parse installation token of GitHub App %d", app.AppID)
+	}
+	if token.Token == "" {
+		return "", fmt.Errorf("GitHub API returned an empty installation token for GitHub App %d", app.AppID)
+	}
+	entry.token = token
+	return token.Token, nil
+}
+
+func installationTokenCacheEntry(cacheKey string) *installationTokenEntry {
+	installationTokensMu.Lock()
+	defer installationTokensMu.Unlock()
+	entry, ok := installationTokens[cacheKey]
+	if !ok {
+		entry = &installationTokenEntry{}
+		installationTokens[cacheKey] = entry
+	}
+	return entry
+}
+
+// appJWT creates the RS256 signed JWT used to authenticate as GitHub App.
+func appJWT(appID int64, privateKeyPEM string, now time.Time) (string, error) {
+	key, err := parseRSAPrivateKey(privateKeyPEM)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to parse private key of GitHub App %d", appID)
+	}
+
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.RS256, Key: key},
+		(&jose.SignerOptions{}).WithType("JWT"))
+	if err != nil {
+		return "", err
+	}
+	return jwt.Signed(signer).
+		Claims(jwt.Claims{
+			IssuedAt: jwt.NewNumericDate(now.Add(-appJWTClockDrift)),
+			Expiry:   jwt.NewNumericDate(now.Add(appJWTLifetime)),
+		}).
+		Claims(map[string]interface{}{"iss": appID}). //GitHub expects the app ID as numeric issuer
+		CompactSerialize()
+}
+
+func parseRSAPrivateKey(privateKeyPEM string) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode([]byte(privateKeyPEM))
+	if block == nil {
+		return nil, fmt.Errorf("no PEM data found")
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("private key is not a RSA key")
+	}
+	return rsaKey, nil
+}
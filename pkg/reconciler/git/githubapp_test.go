@@ -0,0 +1,126 @@
+package git
+
+import (
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	gitHttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/square/go-jose/v3/jwt"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGitHubAppInstallationToken(t *testing.T) {
+	privateKeyPEM := testRSAKey(t)
+	block, _ := pem.Decode(privateKeyPEM)
+	privateKey, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+	require.NoError(t, err)
+
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Method != http.MethodPost || r.URL.Path != "/app/installations/456/access_tokens" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if err := verifyTestJWT(&privateKey.PublicKey, strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")); err != nil {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(err.Error()))
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		_, _ = fmt.Fprintf(w, `{"token":"ghs_installation%d","expires_at":"%s"}`,
+			requests, time.Now().Add(time.Hour).Format(time.RFC3339))
+	}))
+	defer server.Close()
+
+	creds := &Credentials{GitHubApp: &GitHubAppCredentials{
+		AppID:          123,
+		InstallationID: 456,
+		PrivateKey:     string(privateKeyPEM),
+		APIURL:         server.URL,
+	}}
+
+	auth, err := creds.authMethod()
+	require.NoError(t, err)
+	require.Equal(t, &gitHttp.BasicAuth{Username: "x-access-token", Password: "ghs_installation1"}, auth)
+
+	//token is cached until it expires
+	auth, err = creds.authMethod()
+	require.NoError(t, err)
+	require.Equal(t, "ghs_installation1", auth.(*gitHttp.BasicAuth).Password)
+	require.Equal(t, 1, requests)
+
+	//unknown installation
+	_, err = mintInstallationToken(&GitHubAppCredentials{
+		AppID:          123,
+		InstallationID: 789,
+		PrivateKey:     string(privateKeyPEM),
+		APIURL:         server.URL,
+	})
+	require.Error(t, err)
+
+	//invalid private key
+	_, err = mintInstallationToken(&GitHubAppCredentials{AppID: 1, InstallationID: 2, PrivateKey: "invalid", APIURL: server.URL})
+	require.Error(t, err)
+}
+
+func TestGitHubAppInstallationTokenConcurrency(t *testing.T) {
+	privateKeyPEM := string(testRSAKey(t))
+
+	blocked := make(chan struct{})
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/app/installations/1/access_tokens" {
+			close(blocked)
+			<-release
+		}
+		w.WriteHeader(http.StatusCreated)
+		_, _ = fmt.Fprintf(w, `{"token":"ghs_%s","expires_at":"%s"}`,
+			strings.Split(r.URL.Path, "/")[3], time.Now().Add(time.Hour).Format(time.RFC3339))
+	}))
+	defer server.Close()
+	defer close(release)
+
+	slowDone := make(chan error, 1)
+	go func() {
+		_, err := mintInstallationToken(&GitHubAppCredentials{
+			AppID: 1, InstallationID: 1, PrivateKey: privateKeyPEM, APIURL: server.URL})
+		slowDone <- err
+	}()
+	<-blocked
+
+	//a pending token request of another installation doesn't block this one
+	token, err := mintInstallationToken(&GitHubAppCredentials{
+		AppID: 1, InstallationID: 2, PrivateKey: privateKeyPEM, APIURL: server.URL})
+	require.NoError(t, err)
+	require.Equal(t, "ghs_2", token)
+
+	release <- struct{}{}
+	require.NoError(t, <-slowDone)
+}
+
+func verifyTestJWT(publicKey *rsa.PublicKey, rawJWT string) error {
+	token, err := jwt.ParseSigned(rawJWT)
+	if err != nil {
+		return err
+	}
+	claims := struct {
+		Iss int64 `json:"iss"`
+		Iat int64 `json:"iat"`
+		Exp int64 `json:"exp"`
+	}{}
+	if err := token.Claims(publicKey, &claims); err != nil {
+		return err
+	}
+	if claims.Iss != 123 || claims.Exp-claims.Iat > int64((10*time.Minute).Seconds()) {
+		return fmt.Errorf("invalid claims")
+	}
+	return nil
+}
@@ -0,0 +1,187 @@
+package git
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	gitp "github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/pkg/errors"
+)
+
+var mirrorRefSpecs = []config.RefSpec{
+	"+refs/heads/*:refs/heads/*",
+	"+refs/tags/*:refs/tags/*",
+}
+
+// ExportFromMirror fetches the repository incrementally into the bare mirror located at `mirrorPath` (the mirror
+// is created if it does not exist) and exports the files of the given revision into `dstPath`.
+// The caller has to ensure that a mirror is not used concurrently.
+// Revision can be a branch name, a release version (e.g. 1.4.1), a commit hash (e.g. 34edf09a), a PR (e.g. PR-9486)
+// or empty for the HEAD of the default branch. The resolved commit hash is returned.
+func (r *Cloner) ExportFromMirror(mirrorPath, dstPath, rev string) (*gitp.Hash, error) {
+	mirror, err := r.openMirror(mirrorPath)
+	if err != nil {
+		return nil, err
+	}
+
+	hash, err := r.resolveInMirror(mirror, rev)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to resolve GIT revision '%s' using repository '%s'", rev, r.repo.URL)
+	}
+
+	if err := exportTree(mirror, *hash, dstPath); err != nil {
+		return nil, errors.Wrapf(err, "failed to export GIT revision '%s' of repository '%s'", rev, r.repo.URL)
+	}
+	return hash, nil
+}
+
+func (r *Cloner) openMirror(mirrorPath string) (*git.Repository, error) {
+	mirror, err := git.PlainOpen(mirrorPath)
+	if err == nil {
+		return mirror, nil
+	}
+	if err != git.ErrRepositoryNotExists {
+		return nil, errors.Wrapf(err, "failed to open GIT mirror '%s'", mirrorPath)
+	}
+
+	r.logger.Infof("Creating GIT mirror of repository '%s' in '%s'", r.repo.URL, mirrorPath)
+	mirror, err = git.PlainInit(mirrorPath, true)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create GIT mirror '%s'", mirrorPath)
+	}
+	_, err = mirror.CreateRemote(&config.RemoteConfig{
+		Name:  git.DefaultRemoteName,
+		URLs:  []string{r.repo.URL},
+		Fetch: mirrorRefSpecs,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to configure remote of GIT mirror '%s'", mirrorPath)
+	}
+	return mirror, nil
+}
+
+func (r *Cloner) resolveInMirror(mirror *git.Repository, rev string) (*gitp.Hash, error) {
+	//commits are immutable: fetching is not required if the commit is already known
+	if gitp.IsHash(rev) {
+		if _, err := mirror.CommitObject(gitp.NewHash(rev)); err == nil {
+			hash := gitp.NewHash(rev)
+			return &hash, nil
+		}
+	}
+
+	auth, err := r.buildAuth()
+	if err != nil {
+		return nil, err
+	}
+
+	refSpecs := mirrorRefSpecs
+	if strings.HasPrefix(rev, prPrefix) {
+		prRef := fmt.Sprintf("refs/pull/%s/head", strings.TrimPrefix(rev, prPrefix))
+		refSpecs = append([]config.RefSpec{config.RefSpec(fmt.Sprintf("+%s:%s", prRef, prRef))}, mirrorRefSpecs...)
+		rev = prRef
+	}
+
+	r.logger.Debugf("Fetching GIT repository '%s' into mirror", r.repo.URL)
+	err = mirror.Fetch(&git.FetchOptions{
+		RemoteName: git.DefaultRemoteName,
+		RefSpecs:   refSpecs,
+		Auth:       auth,
+		Tags:       git.NoTags,
+	})
+	if err != nil && err != git.NoErrAlreadyUpToDate {
+		return nil, errors.Wrap(err, "failed to fetch GIT mirror")
+	}
+
+	if rev == "" {
+		return r.remoteHead(mirror, auth)
+	}
+	return mirror.ResolveRevision(gitp.Revision(rev))
+}
+
+//remoteHead resolves the HEAD of the default branch of the remote repository
+func (r *Cloner) remoteHead(mirror *git.Repository, auth transport.AuthMethod) (*gitp.Hash, error) {
+	remote, err := mirror.Remote(git.DefaultRemoteName)
+	if err != nil {
+		return nil, err
+	}
+	refs, err := remote.List(&git.ListOptions{Auth: auth})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list remote references")
+	}
+	for _, ref := range refs {
+		if ref.Name() != gitp.HEAD {
+			continue
+		}
+		if ref.Type() == gitp.SymbolicReference {
+			return mirror.ResolveRevision(gitp.Revision(ref.Target()))
+		}
+		hash := ref.Hash()
+		return &hash, nil
+	}
+	return nil, errors.New("remote repository has no HEAD reference")
+}
+
+//exportTree writes the files of the given commit into the destination directory
+func exportTree(repo *git.Repository, hash gitp.Hash, dstPath string) error {
+	commit, err := repo.CommitObject(hash)
+	if err != nil {
+		return err
+	}
+	tree, err := commit.Tree()
+	if err != nil {
+		return err
+	}
+
+	dstPath = filepath.Clean(dstPath)
+	if err := os.MkdirAll(dstPath, 0700); err != nil {
+		return err
+	}
+	return tree.Files().ForEach(func(f *object.File) error {
+		target := filepath.Join(dstPath, filepath.FromSlash(f.Name))
+		if !strings.HasPrefix(target, dstPath+string(filepath.Separator)) {
+			return fmt.Errorf("file '%s' is located outside of the export directory", f.Name)
+		}
+		if err := os.MkdirAll(filepath.Dir(target), 0700); err != nil {
+			return err
+		}
+		if f.Mode == filemode.Symlink {
+			linkTarget, err := f.Contents()
+			if err != nil {
+				return err
+			}
+			return os.Symlink(linkTarget, target)
+		}
+		return exportFile(f, target)
+	})
+}
+
+func exportFile(f *object.File, target string) error {
+	perm := os.FileMode(0600)
+	if f.Mode == filemode.Executable {
+		perm = 0700
+	}
+	reader, err := f.Reader()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = reader.Close()
+	}()
+	out, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, perm)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, reader); err != nil {
+		_ = out.Close()
+		return err
+	}
+	return out.Close()
+}
@@ -0,0 +1,91 @@
+package git
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	gitp "github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/kyma-incubator/reconciler/pkg/logger"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportFromMirror(t *testing.T) {
+	repoDir := t.TempDir()
+	repo, err := git.PlainInit(repoDir, false)
+	require.NoError(t, err)
+	worktree, err := repo.Worktree()
+	require.NoError(t, err)
+
+	signature := &object.Signature{Name: "test", Email: "test@example.com", When: time.Now()}
+	require.NoError(t, ioutil.WriteFile(filepath.Join(repoDir, "README.md"), []byte("readme"), 0600))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(repoDir, "run.sh"), []byte("#!/bin/sh"), 0700))
+	require.NoError(t, os.Symlink("README.md", filepath.Join(repoDir, "link")))
+	_, err = worktree.Add(".")
+	require.NoError(t, err)
+	head, err := worktree.Commit("initial", &git.CommitOptions{Author: signature})
+	require.NoError(t, err)
+
+	//simulate a pull request which exists only as 'refs/pull/<id>/head'
+	require.NoError(t, ioutil.WriteFile(filepath.Join(repoDir, "README.md"), []byte("pull request"), 0600))
+	_, err = worktree.Add("README.md")
+	require.NoError(t, err)
+	prCommit, err := worktree.Commit("pull request", &git.CommitOptions{Author: signature})
+	require.NoError(t, err)
+	require.NoError(t, repo.Storer.SetReference(gitp.NewHashReference("refs/pull/12/head", prCommit)))
+	require.NoError(t, repo.Storer.SetReference(gitp.NewHashReference("refs/heads/master", head)))
+
+	cloner, err := NewCloner(&Client{}, &reconciler.Repository{URL: repoDir}, false, nil, logger.NewLogger(true))
+	require.NoError(t, err)
+	mirrorDir := filepath.Join(t.TempDir(), "mirror")
+
+	t.Run("Export HEAD of default branch", func(t *testing.T) {
+		dstDir := filepath.Join(t.TempDir(), "head")
+		hash, err := cloner.ExportFromMirror(mirrorDir, dstDir, "")
+		require.NoError(t, err)
+		require.Equal(t, head, *hash)
+
+		content, err := ioutil.ReadFile(filepath.Join(dstDir, "README.md"))
+		require.NoError(t, err)
+		require.Equal(t, "readme", string(content))
+
+		info, err := os.Stat(filepath.Join(dstDir, "run.sh"))
+		require.NoError(t, err)
+		require.NotZero(t, info.Mode()&0100)
+
+		linkTarget, err := os.Readlink(filepath.Join(dstDir, "link"))
+		require.NoError(t, err)
+		require.Equal(t, "README.md", linkTarget)
+
+		_, err = os.Stat(filepath.Join(dstDir, ".git"))
+		require.True(t, os.IsNotExist(err))
+	})
+
+	t.Run("Export pull request", func(t *testing.T) {
+		dstDir := filepath.Join(t.TempDir(), "pr")
+		hash, err := cloner.ExportFromMirror(mirrorDir, dstDir, "PR-12")
+		require.NoError(t, err)
+		require.Equal(t, prCommit, *hash)
+
+		content, err := ioutil.ReadFile(filepath.Join(dstDir, "README.md"))
+		require.NoError(t, err)
+		require.Equal(t, "pull request", string(content))
+	})
+
+	t.Run("Export known commit without remote access", func(t *testing.T) {
+		require.NoError(t, os.RemoveAll(repoDir))
+
+		hash, err := cloner.ExportFromMirror(mirrorDir, filepath.Join(t.TempDir(), "commit"), head.String())
+		require.NoError(t, err)
+		require.Equal(t, head, *hash)
+
+		//branches require a fetch
+		_, err = cloner.ExportFromMirror(mirrorDir, filepath.Join(t.TempDir(), "branch"), "master")
+		require.Error(t, err)
+	})
+}
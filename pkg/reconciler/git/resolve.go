@@ -8,6 +8,7 @@ import (
 
 	"github.com/go-git/go-git/v5/config"
 	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/transport"
 	"github.com/go-git/go-git/v5/storage/memory"
 	"github.com/pkg/errors"
 )
@@ -16,6 +17,7 @@ type revisionResolver struct {
 	repository *git.Repository
 	url        string
 	refLister  refLister
+	auth       transport.AuthMethod
 }
 
 const prPrefix string = "PR-"
@@ -25,6 +27,7 @@ type refLister interface {
 }
 
 type remoteRefLister struct {
+	auth transport.AuthMethod
 }
 
 func (rl remoteRefLister) List(repoURL string) ([]*plumbing.Reference, error) {
@@ -32,7 +35,7 @@ func (rl remoteRefLister) List(repoURL string) ([]*plumbing.Reference, error) {
 		Name: "origin",
 		URLs: []string{repoURL},
 	})
-	return remote.List(&git.ListOptions{})
+	return remote.List(&git.ListOptions{Auth: rl.auth})
 }
 
 // revision can be 'main', a branch name, a release version (e.g. 1.4.1), a commit hash (e.g. 34edf09a) or a PR (e.g. PR-9486).
@@ -65,10 +68,10 @@ func (r *revisionResolver) fetch(name string, kind string) error {
 	case "pr":
 		name = strings.TrimLeft(name, prPrefix)
 		refs := []config.RefSpec{config.RefSpec(fmt.Sprintf("+refs/pull/%s/head:refs/remote/origin/pr/%s", name, name))}
-		return r.repository.Fetch(&git.FetchOptions{RefSpecs: refs})
+		return r.repository.Fetch(&git.FetchOptions{RefSpecs: refs, Auth: r.auth})
 	case "branch":
 		refs := []config.RefSpec{config.RefSpec(fmt.Sprintf("+refs/heads/%s:refs/remote/origin/%s", name, name))}
-		return r.repository.Fetch(&git.FetchOptions{RefSpecs: refs})
+		return r.repository.Fetch(&git.FetchOptions{RefSpecs: refs, Auth: r.auth})
 	default:
 		return errors.Errorf("Unknown Type: %s", kind)
 	}
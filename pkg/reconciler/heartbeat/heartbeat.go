@@ -50,6 +50,7 @@ type Sender struct {
 	status          reconciler.Status //current status
 	callback        cb.Handler        //callback-handler which trigger the callback logic to inform reconciler-controller
 	restartInterval chan bool         //trigger for callback-handler to inform reconciler-controller
+	deletionReport  []reconciler.DeletedResource
 	m               sync.Mutex
 	logger          *zap.SugaredLogger
 }
@@ -85,7 +86,7 @@ func (su *Sender) sendUpdate(status reconciler.Status, reason error, onlyOnce bo
 	su.stopJob() //ensure previous interval-loop is stopped before starting a new loop
 
 	task := func(status reconciler.Status, rootCause error) error {
-		err := su.callback.Callback(&reconciler.CallbackMessage{
+		msg := &reconciler.CallbackMessage{
 			Status: status,
 			Error: func(err error) string {
 				if err != nil {
@@ -93,7 +94,11 @@ func (su *Sender) sendUpdate(status reconciler.Status, reason error, onlyOnce bo
 				}
 				return ""
 			}(rootCause),
-		})
+		}
+		if deletionReport := su.getDeletionReport(); len(deletionReport) > 0 {
+			msg.DeletionReport = &deletionReport
+		}
+		err := su.callback.Callback(msg)
 		if err == nil {
 			su.logger.Debugf("Heartbeat communicated status '%s' successfully to mothership-reconciler", status)
 		} else {
@@ -166,6 +171,20 @@ func (su *Sender) sendUpdate(status reconciler.Status, reason error, onlyOnce bo
 	su.status = status
 }
 
+// WithDeletionReport attaches the per-resource deletion report to all following status updates
+func (su *Sender) WithDeletionReport(report []reconciler.DeletedResource) *Sender {
+	su.m.Lock()
+	defer su.m.Unlock()
+	su.deletionReport = report
+	return su
+}
+
+func (su *Sender) getDeletionReport() []reconciler.DeletedResource {
+	su.m.Lock()
+	defer su.m.Unlock()
+	return su.deletionReport
+}
+
 func (su *Sender) CurrentStatus() reconciler.Status {
 	return su.status
 }
@@ -79,6 +79,7 @@ func (a *CommandActions) Remove(context *service.ActionContext) error {
 	component := chart.NewComponentBuilder(context.Task.Version, context.Task.Component).
 		WithNamespace(context.Task.Namespace).
 		WithProfile(context.Task.Profile).
+		WithProfileValues(context.Task.ProfileValues).
 		WithConfiguration(context.Task.Configuration).
 		WithURL(context.Task.URL).
 		Build()
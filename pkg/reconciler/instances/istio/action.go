@@ -48,6 +48,7 @@ func (a *UninstallAction) Run(context *service.ActionContext) error {
 		component := chart.NewComponentBuilder(context.Task.Version, istioChart).
 			WithNamespace(istioNamespace).
 			WithProfile(context.Task.Profile).
+			WithProfileValues(context.Task.ProfileValues).
 			WithConfiguration(context.Task.Configuration).Build()
 		manifest, err := context.ChartProvider.RenderManifest(component)
 		if err != nil {
@@ -73,6 +74,7 @@ func (a *ReconcileAction) Run(context *service.ActionContext) error {
 	component := chart.NewComponentBuilder(context.Task.Version, istioChart).
 		WithNamespace(istioNamespace).
 		WithProfile(context.Task.Profile).
+		WithProfileValues(context.Task.ProfileValues).
 		WithConfiguration(context.Task.Configuration).Build()
 	manifest, err := context.ChartProvider.RenderManifest(component)
 	if err != nil {
@@ -47,6 +47,7 @@ func (a *preReconcileAction) Run(context *service.ActionContext) error {
 	component := chart.NewComponentBuilder(context.Task.Version, oryChart).
 		WithNamespace(oryNamespace).
 		WithProfile(context.Task.Profile).
+		WithProfileValues(context.Task.ProfileValues).
 		WithConfiguration(context.Task.Configuration).Build()
 
 	values, err := context.ChartProvider.Configuration(component)
@@ -7,9 +7,12 @@ import (
 	"time"
 
 	"strings"
+	"sync"
 
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
 
 	"github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes/internal"
 	"github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes/progress"
@@ -18,13 +21,17 @@ import (
 	v1apps "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
 	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 )
 
 const (
-	defaultNamespace  = "default"
-	namespaceManifest = `
+	defaultNamespace    = "default"
+	defaultApplyWorkers = 10
+	defaultCRDTimeout   = 5 * time.Minute
+	crdCheckInterval    = 1 * time.Second
+	namespaceManifest   = `
 apiVersion: v1
 kind: Namespace
 metadata:
@@ -41,6 +48,13 @@ type kubeClientAdapter struct {
 type Config struct {
 	ProgressInterval time.Duration
 	ProgressTimeout  time.Duration
+	Apply            *ApplyConfig //client-side apply is used if undefined
+	ApplyWorkers     int          //max. number of resources which are applied in parallel
+	//ReadinessChecks are used to verify the readiness of custom resources (only resources of these kinds are awaited)
+	ReadinessChecks []*progress.ConditionCheck
+	Deletion        *DeletionConfig //default deletion mode is used if undefined
+	//DeletionReport collects the deletion status of all resources deleted by the client (optional)
+	DeletionReport *DeletionReport
 }
 
 func NewKubernetesClient(kubeconfig string, logger *zap.SugaredLogger, config *Config) (Client, error) {
@@ -94,7 +108,7 @@ func (g *kubeClientAdapter) Deploy(ctx context.Context, manifest, namespace stri
 		namespace = defaultNamespace
 	}
 
-	deployedResources, err := g.deployManifest(ctx, manifest, namespace, interceptors)
+	deployedResources, err := g.deployManifest(internal.WithWarningLogger(ctx, g.logger), manifest, namespace, interceptors)
 
 	//delete namespace if no resources was deployed into it
 	if len(deployedResources) == 0 {
@@ -125,6 +139,42 @@ func (g *kubeClientAdapter) deployManifest(ctx context.Context, manifest, namesp
 		return nil, err
 	}
 
+	for _, stage := range sortByInstallOrder(unstructs) {
+		stage, err = g.intercept(stage, namespace, interceptors)
+		if err != nil {
+			return deployedResources, err
+		}
+
+		resources, err := g.applyStage(stage, namespace)
+		deployedResources = append(deployedResources, resources...)
+		if err != nil {
+			return deployedResources, err
+		}
+
+		//custom resources can only be applied after their CRDs were established
+		if err := g.waitForEstablishedCRDs(ctx, resources); err != nil {
+			return deployedResources, err
+		}
+
+		//if resource is watchable, add it to progress tracker
+		for _, unstruct := range stage {
+			watchable, err := progress.NewWatchableResource(unstruct.GetKind())
+			if err == nil { //add only watchable resources to progress tracker
+				pt.AddResource(watchable, unstruct.GetNamespace(), unstruct.GetName())
+				continue
+			}
+			pt.AddCustomResource(unstruct.GroupVersionKind(), unstruct.GetNamespace(), unstruct.GetName())
+		}
+	}
+
+	g.logger.Debugf("Manifest processed: %d Kubernetes resources were successfully deployed",
+		len(deployedResources))
+	return deployedResources, pt.Watch(ctx, progress.ReadyState)
+}
+
+func (g *kubeClientAdapter) intercept(unstructs []*unstructured.Unstructured, namespace string, interceptors []ResourceInterceptor) ([]*unstructured.Unstructured, error) {
+	var intercepted []*unstructured.Unstructured
+
 LoopUnstructs:
 	for _, unstruct := range unstructs {
 		for _, interceptor := range interceptors {
@@ -140,7 +190,7 @@ LoopUnstructs:
 			}
 			switch result {
 			case ErrorInterceptionResult:
-				return deployedResources, err
+				return nil, err
 			case IgnoreResourceInterceptionResult:
 				g.logger.Debugf("Interceptor indicated to not apply Kuberentes resource '%s@%s' (kind '%s')",
 					unstruct.GetName(), unstruct.GetNamespace(), unstruct.GetKind())
@@ -149,29 +199,105 @@ LoopUnstructs:
 				//continue change: just do nothing and continue processing
 			}
 		}
-		metadata, err := g.kubeClient.ApplyWithNamespaceOverride(unstruct, namespace)
-		if err != nil {
-			g.logger.Errorf("Failed to apply Kubernetes unstructured entity: %s", err)
-			g.logger.Debugf("Used JSON data: %+v", unstruct)
-			return deployedResources, err
-		}
+		intercepted = append(intercepted, unstruct)
+	}
 
-		resource := toResource(metadata)
+	return intercepted, nil
+}
 
-		//add deploy resource to result
-		g.logger.Debugf("Kubernetes resource '%v' successfully deployed", resource)
-		deployedResources = append(deployedResources, resource)
+// applyStage applies independent resources concurrently and returns the successfully deployed resources
+// (in manifest order) and the first occurred error.
+func (g *kubeClientAdapter) applyStage(unstructs []*unstructured.Unstructured, namespace string) ([]*Resource, error) {
+	workers := g.config.ApplyWorkers
+	if workers <= 0 {
+		workers = defaultApplyWorkers
+	}
+
+	resources := make([]*Resource, len(unstructs))
+	errs := make([]error, len(unstructs))
+	semaphore := make(chan struct{}, workers)
+	var wg sync.WaitGroup
+	for idx, unstruct := range unstructs {
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func(idx int, unstruct *unstructured.Unstructured) {
+			defer func() {
+				<-semaphore
+				wg.Done()
+			}()
+			metadata, err := g.apply(unstruct, namespace)
+			if err != nil {
+				g.logger.Errorf("Failed to apply Kubernetes unstructured entity: %s", err)
+				g.logger.Debugf("Used JSON data: %+v", unstruct)
+				errs[idx] = err
+				return
+			}
+			resources[idx] = toResource(metadata)
+			g.logger.Debugf("Kubernetes resource '%v' successfully deployed", resources[idx])
+		}(idx, unstruct)
+	}
+	wg.Wait()
 
-		//if resource is watchable, add it to progress tracker
-		watchable, err := progress.NewWatchableResource(resource.Kind)
-		if err == nil { //add only watchable resources to progress tracker
-			pt.AddResource(watchable, resource.Namespace, resource.Name)
+	var deployedResources []*Resource
+	var firstErr error
+	for idx := range unstructs {
+		if errs[idx] != nil && firstErr == nil {
+			firstErr = errs[idx]
+		}
+		if resources[idx] != nil {
+			deployedResources = append(deployedResources, resources[idx])
 		}
 	}
+	return deployedResources, firstErr
+}
 
-	g.logger.Debugf("Manifest processed: %d Kubernetes resources were successfully deployed",
-		len(deployedResources))
-	return deployedResources, pt.Watch(ctx, progress.ReadyState)
+func (g *kubeClientAdapter) waitForEstablishedCRDs(ctx context.Context, resources []*Resource) error {
+	var crds []string
+	for _, resource := range resources {
+		if resource.Kind == crdKind {
+			crds = append(crds, resource.Name)
+		}
+	}
+	if len(crds) == 0 {
+		return nil
+	}
+
+	timeout := g.config.ProgressTimeout
+	if timeout <= 0 {
+		timeout = defaultCRDTimeout
+	}
+	g.logger.Debugf("Waiting for %d CRDs to become established", len(crds))
+	err := wait.PollImmediate(crdCheckInterval, timeout, func() (bool, error) {
+		if ctx.Err() != nil {
+			return false, ctx.Err()
+		}
+		for _, crd := range crds {
+			u, err := g.kubeClient.Get("customresourcedefinitions", crd, "")
+			if err != nil {
+				g.logger.Debugf("Failed to retrieve CRD '%s' (will retry): %s", crd, err)
+				return false, nil
+			}
+			if !isEstablished(u) {
+				return false, nil
+			}
+		}
+		return true, nil
+	})
+	if err != nil {
+		return errors.Wrapf(err, "CRDs '%s' were not established", strings.Join(crds, "', '"))
+	}
+
+	//API discovery information has to be refreshed to resolve the new custom resource kinds
+	g.kubeClient.ResetRESTMapper()
+	return nil
+}
+
+func (g *kubeClientAdapter) apply(unstruct *unstructured.Unstructured, namespace string) (*internal.Metadata, error) {
+	if !g.config.Apply.serverSide() {
+		return g.kubeClient.ApplyWithNamespaceOverride(unstruct, namespace)
+	}
+	force := g.config.Apply.ConflictPolicy(unstruct.GetKind()) == ForceConflictPolicy
+	return g.kubeClient.ServerSideApplyWithNamespaceOverride(unstruct, namespace, force)
 }
 
 func (g *kubeClientAdapter) addNamespaceUnstruct(unstructs []*unstructured.Unstructured, namespace string) ([]*unstructured.Unstructured, error) {
@@ -228,7 +354,8 @@ func (g *kubeClientAdapter) DeleteResource(kind, name, namespace string) (*Resou
 	return deletedResource, nil
 }
 
-func (g *kubeClientAdapter) Delete(ctx context.Context, manifest, namespace string) ([]*Resource, error) {
+func (g *kubeClientAdapter) Delete(ctx context.Context, manifest, namespace string, opts ...DeleteOption) ([]*Resource, error) {
+	ctx = internal.WithWarningLogger(ctx, g.logger)
 	if namespace == "" {
 		namespace = defaultNamespace
 	}
@@ -240,6 +367,12 @@ func (g *kubeClientAdapter) Delete(ctx context.Context, manifest, namespace stri
 		return nil, err
 	}
 
+	deleteOpts := newDeleteOptions(opts)
+
+	if g.config.Deletion.finalizerAware() {
+		return g.deleteFinalizerAware(ctx, unstructs, namespace, deleteOpts)
+	}
+
 	pt, err := g.newProgressTracker()
 	if err != nil {
 		return nil, err
@@ -258,6 +391,11 @@ func (g *kubeClientAdapter) Delete(ctx context.Context, manifest, namespace stri
 		if !g.resourceExists(unstruct.GetKind(), unstruct.GetName(), unstruct.GetNamespace()) {
 			g.logger.Debugf("Could not find resource for deletion: kind='%s', name='%s', namespace='%s'",
 				unstruct.GetKind(), unstruct.GetName(), unstruct.GetNamespace())
+			g.config.DeletionReport.add(&Resource{
+				Kind:      unstruct.GetKind(),
+				Name:      unstruct.GetName(),
+				Namespace: unstruct.GetNamespace(),
+			}, NotFoundStatus, nil, nil)
 			continue
 		}
 
@@ -269,10 +407,16 @@ func (g *kubeClientAdapter) Delete(ctx context.Context, manifest, namespace stri
 		if err != nil && !k8serr.IsNotFound(err) {
 			g.logger.Errorf("Failed to delete Kubernetes unstructured resource kind='%s', name='%s', namespace='%s': %s",
 				unstruct.GetKind(), unstruct.GetName(), unstruct.GetNamespace(), err)
+			g.config.DeletionReport.add(&Resource{
+				Kind:      unstruct.GetKind(),
+				Name:      unstruct.GetName(),
+				Namespace: unstruct.GetNamespace(),
+			}, FailedStatus, nil, err)
 			return deletedResources, err
 		}
 
 		resource := toResource(metadata)
+		g.config.DeletionReport.add(resource, DeletedStatus, nil, nil)
 
 		//add deleted resource to result set
 		deletedResources = append(deletedResources, resource)
@@ -289,6 +433,12 @@ func (g *kubeClientAdapter) Delete(ctx context.Context, manifest, namespace stri
 		g.logger.Warnf("Watching progress of deleted resources failed: %s", err)
 	}
 
+	if deleteOpts.BeforeNamespaceDeletion != nil {
+		if err := deleteOpts.BeforeNamespaceDeletion(ctx); err != nil {
+			return deletedResources, err
+		}
+	}
+
 	if err = g.kubeClient.DeleteNamespace(namespace); err != nil && !k8serr.IsNotFound(err) {
 		g.logger.Errorf("Failed to delete namespace name='%s': %s",
 			namespace, err)
@@ -297,6 +447,97 @@ func (g *kubeClientAdapter) Delete(ctx context.Context, manifest, namespace stri
 	return deletedResources, nil
 }
 
+// deleteFinalizerAware deletes the custom resources of all CRDs in the manifest before the manifest resources
+// are deleted in reverse order. Resources which still exist after the finalizer timeout are reported as stuck
+// or their finalizers are removed (if configured).
+func (g *kubeClientAdapter) deleteFinalizerAware(ctx context.Context, unstructs []*unstructured.Unstructured, namespace string, deleteOpts *DeleteOptions) ([]*Resource, error) {
+	deleter := &finalizerAwareDeleter{
+		dynamicClient: g.kubeClient.DynamicClient(),
+		config:        g.config.Deletion,
+		report:        g.config.DeletionReport,
+		logger:        g.logger,
+		interval:      deletionCheckInterval,
+	}
+	if g.config.ProgressInterval > 0 && g.config.ProgressInterval < deletionCheckInterval {
+		deleter.interval = g.config.ProgressInterval
+	}
+
+	//custom resources are deleted first: their controllers are part of the manifest and have to process the finalizers
+	var crds []*unstructured.Unstructured
+	for _, unstruct := range unstructs {
+		if unstruct.GetKind() == crdKind {
+			crds = append(crds, unstruct)
+		}
+	}
+	deleter.deleteCustomResources(ctx, crds)
+
+	var deletedResources []*Resource
+	var targets []*deletionTarget
+	for i := len(unstructs) - 1; i >= 0; i-- {
+		unstruct := unstructs[i]
+		if unstruct.GetNamespace() == "" {
+			unstruct.SetNamespace(namespace)
+		}
+		target, err := g.deletionTarget(unstruct)
+		if err != nil {
+			if meta.IsNoMatchError(err) { //kind doesn't exist anymore (e.g. because its CRD was deleted)
+				g.config.DeletionReport.add(target.resource, NotFoundStatus, nil, nil)
+				continue
+			}
+			deleter.fail(target.resource, err)
+			continue
+		}
+		if deleter.delete(ctx, target) {
+			targets = append(targets, target)
+			deletedResources = append(deletedResources, target.resource)
+		}
+	}
+	deleter.awaitRemoval(ctx, targets)
+
+	if deleteOpts.BeforeNamespaceDeletion != nil {
+		if err := deleteOpts.BeforeNamespaceDeletion(ctx); err != nil {
+			deleter.fail(&Resource{Kind: "Namespace", Name: namespace}, err)
+			return deletedResources, deleter.err()
+		}
+	}
+
+	if err := g.kubeClient.DeleteNamespace(namespace); err != nil && !k8serr.IsNotFound(err) {
+		g.logger.Errorf("Failed to delete namespace name='%s': %s", namespace, err)
+		deleter.fail(&Resource{Kind: "Namespace", Name: namespace}, err)
+		return deletedResources, deleter.err()
+	}
+	//namespaces are only deleted if they are empty: await them only if their deletion was triggered
+	nsTarget := &deletionTarget{
+		resource: &Resource{Kind: "Namespace", Name: namespace},
+		gvr:      schema.GroupVersionResource{Version: "v1", Resource: "namespaces"},
+	}
+	if ns, err := deleter.client(nsTarget).Get(ctx, namespace, metav1.GetOptions{}); err == nil && ns.GetDeletionTimestamp() != nil {
+		deleter.awaitRemoval(ctx, []*deletionTarget{nsTarget})
+	}
+
+	return deletedResources, deleter.err()
+}
+
+func (g *kubeClientAdapter) deletionTarget(unstruct *unstructured.Unstructured) (*deletionTarget, error) {
+	target := &deletionTarget{
+		resource: &Resource{
+			Kind:      unstruct.GetKind(),
+			Name:      unstruct.GetName(),
+			Namespace: unstruct.GetNamespace(),
+		},
+	}
+	mapping, err := g.kubeClient.RESTMapping(unstruct.GroupVersionKind())
+	if err != nil {
+		return target, err
+	}
+	target.gvr = mapping.Resource
+	target.namespaced = mapping.Scope.Name() == meta.RESTScopeNameNamespace
+	if !target.namespaced {
+		target.resource.Namespace = ""
+	}
+	return target, nil
+}
+
 // check if resource exists in the cluster
 func (g *kubeClientAdapter) resourceExists(kind, name, namespace string) bool {
 	_, err := g.kubeClient.Get(kind, name, namespace)
@@ -311,10 +552,15 @@ func (g *kubeClientAdapter) newProgressTracker() (*progress.Tracker, error) {
 	if err != nil {
 		return nil, err
 	}
-	return progress.NewProgressTracker(clientSet, g.logger, progress.Config{
-		Interval: g.config.ProgressInterval,
-		Timeout:  g.config.ProgressTimeout,
+	pt, err := progress.NewProgressTracker(clientSet, g.logger, progress.Config{
+		Interval:        g.config.ProgressInterval,
+		Timeout:         g.config.ProgressTimeout,
+		ConditionChecks: g.config.ReadinessChecks,
 	})
+	if err != nil {
+		return nil, err
+	}
+	return pt.WithDynamicClient(g.kubeClient.DynamicClient()), nil
 }
 
 func (g *kubeClientAdapter) Clientset() (kubernetes.Interface, error) {
@@ -325,6 +571,21 @@ func (g *kubeClientAdapter) ListResource(resource string, lo metav1.ListOptions)
 	return g.kubeClient.ListResource(resource, lo)
 }
 
+// GetResource returns a resource identified by its GVK: the GVK is resolved by the REST mapper and the
+// namespace is ignored for cluster-scoped resources
+func (g *kubeClientAdapter) GetResource(ctx context.Context, gvk schema.GroupVersionKind, name, namespace string) (*unstructured.Unstructured, error) {
+	mapping, err := g.kubeClient.RESTMapping(gvk)
+	if err != nil {
+		return nil, err
+	}
+	ctx = internal.WithWarningLogger(ctx, g.logger)
+	client := g.kubeClient.DynamicClient().Resource(mapping.Resource)
+	if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+		return client.Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+	}
+	return client.Get(ctx, name, metav1.GetOptions{})
+}
+
 func (g *kubeClientAdapter) GetStatefulSet(ctx context.Context, name, namespace string) (*v1apps.StatefulSet, error) {
 	if namespace == "" {
 		namespace = defaultNamespace
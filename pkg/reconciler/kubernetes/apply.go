@@ -0,0 +1,134 @@
+package kubernetes
+
+import (
+	"fmt"
+	"strings"
+)
+
+type ApplyMode string
+
+const (
+	//ClientSideApplyMode updates resources by a three-way-merge or by replacing them (default)
+	ClientSideApplyMode ApplyMode = "client-side"
+	//ServerSideApplyMode lets the API server merge resources and track field ownership
+	ServerSideApplyMode ApplyMode = "server-side"
+)
+
+type ConflictPolicy string
+
+const (
+	//FailConflictPolicy aborts a server-side apply if a field is owned by another field manager
+	FailConflictPolicy ConflictPolicy = "fail"
+	//ForceConflictPolicy takes over the ownership of conflicting fields
+	ForceConflictPolicy ConflictPolicy = "force"
+
+	AnyKind = "*"
+)
+
+func NewApplyMode(mode string) (ApplyMode, error) {
+	switch strings.ToLower(mode) {
+	case "", string(ClientSideApplyMode):
+		return ClientSideApplyMode, nil
+	case string(ServerSideApplyMode):
+		return ServerSideApplyMode, nil
+	default:
+		return "", fmt.Errorf("apply mode '%s' is not supported (supported are '%s' and '%s')",
+			mode, ClientSideApplyMode, ServerSideApplyMode)
+	}
+}
+
+func NewConflictPolicy(policy string) (ConflictPolicy, error) {
+	switch strings.ToLower(policy) {
+	case string(FailConflictPolicy):
+		return FailConflictPolicy, nil
+	case string(ForceConflictPolicy):
+		return ForceConflictPolicy, nil
+	default:
+		return "", fmt.Errorf("conflict policy '%s' is not supported (supported are '%s' and '%s')",
+			policy, FailConflictPolicy, ForceConflictPolicy)
+	}
+}
+
+// ApplyConfig defines how resources are applied on the cluster
+type ApplyConfig struct {
+	Mode ApplyMode
+	//ConflictPolicies maps a kind (case-insensitive) to the policy used for server-side apply conflicts.
+	//The policy of kind '*' is used for all kinds without a dedicated policy (fallback is 'fail').
+	ConflictPolicies map[string]ConflictPolicy
+}
+
+// NewApplyConfig creates an apply configuration from its string representation.
+// Conflict policies are passed as map of kind to policy (e.g. {"*": "fail", "horizontalpodautoscaler": "force"}).
+func NewApplyConfig(mode string, conflictPolicies map[string]string) (*ApplyConfig, error) {
+	applyMode, err := NewApplyMode(mode)
+	if err != nil {
+		return nil, err
+	}
+	policies := make(map[string]ConflictPolicy, len(conflictPolicies))
+	for kind, policy := range conflictPolicies {
+		conflictPolicy, err := NewConflictPolicy(policy)
+		if err != nil {
+			return nil, err
+		}
+		policies[strings.ToLower(kind)] = conflictPolicy
+	}
+	return &ApplyConfig{
+		Mode:             applyMode,
+		ConflictPolicies: policies,
+	}, nil
+}
+
+// ParseConflictPolicies parses conflict policies which are defined as comma separated
+// list of 'kind=policy' pairs (e.g. "*=fail,deployment=force").
+func ParseConflictPolicies(policies string) (map[string]string, error) {
+	result := make(map[string]string)
+	for _, pair := range strings.Split(policies, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		kindAndPolicy := strings.SplitN(pair, "=", 2)
+		if len(kindAndPolicy) != 2 || strings.TrimSpace(kindAndPolicy[0]) == "" {
+			return nil, fmt.Errorf("conflict policy '%s' is invalid: expected format is 'kind=policy'", pair)
+		}
+		result[strings.TrimSpace(kindAndPolicy[0])] = strings.TrimSpace(kindAndPolicy[1])
+	}
+	return result, nil
+}
+
+// Merge returns a copy of the config which is overwritten by all settings defined in the override
+func (c *ApplyConfig) Merge(override *ApplyConfig) *ApplyConfig {
+	result := &ApplyConfig{
+		Mode:             ClientSideApplyMode,
+		ConflictPolicies: make(map[string]ConflictPolicy),
+	}
+	for _, cfg := range []*ApplyConfig{c, override} {
+		if cfg == nil {
+			continue
+		}
+		if cfg.Mode != "" {
+			result.Mode = cfg.Mode
+		}
+		for kind, policy := range cfg.ConflictPolicies {
+			result.ConflictPolicies[kind] = policy
+		}
+	}
+	return result
+}
+
+func (c *ApplyConfig) serverSide() bool {
+	return c != nil && c.Mode == ServerSideApplyMode
+}
+
+func (c *ApplyConfig) ConflictPolicy(kind string) ConflictPolicy {
+	if c == nil {
+		return FailConflictPolicy
+	}
+	if policy, ok := c.ConflictPolicies[strings.ToLower(kind)]; ok {
+		return policy
+	}
+	if policy, ok := c.ConflictPolicies[AnyKind]; ok {
+		return policy
+	}
+	return FailConflictPolicy
+}
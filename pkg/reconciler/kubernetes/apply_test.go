@@ -0,0 +1,62 @@
+package kubernetes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyConfig(t *testing.T) {
+	t.Run("Create apply config", func(t *testing.T) {
+		applyConfig, err := NewApplyConfig("Server-Side", map[string]string{
+			"*":          "fail",
+			"Deployment": "force",
+		})
+		require.NoError(t, err)
+		require.Equal(t, ServerSideApplyMode, applyConfig.Mode)
+		require.True(t, applyConfig.serverSide())
+		require.Equal(t, ForceConflictPolicy, applyConfig.ConflictPolicy("deployment"))
+		require.Equal(t, FailConflictPolicy, applyConfig.ConflictPolicy("StatefulSet"))
+
+		_, err = NewApplyConfig("magic", nil)
+		require.Error(t, err)
+		_, err = NewApplyConfig("server-side", map[string]string{"*": "ignore"})
+		require.Error(t, err)
+	})
+
+	t.Run("Client-side apply is default", func(t *testing.T) {
+		var applyConfig *ApplyConfig
+		require.False(t, applyConfig.serverSide())
+		require.Equal(t, FailConflictPolicy, applyConfig.ConflictPolicy("deployment"))
+
+		applyConfig, err := NewApplyConfig("", nil)
+		require.NoError(t, err)
+		require.Equal(t, ClientSideApplyMode, applyConfig.Mode)
+	})
+
+	t.Run("Merge apply configs", func(t *testing.T) {
+		global := &ApplyConfig{
+			Mode:             ServerSideApplyMode,
+			ConflictPolicies: map[string]ConflictPolicy{AnyKind: ForceConflictPolicy},
+		}
+		merged := global.Merge(&ApplyConfig{
+			ConflictPolicies: map[string]ConflictPolicy{"deployment": FailConflictPolicy},
+		})
+		require.Equal(t, ServerSideApplyMode, merged.Mode)
+		require.Equal(t, FailConflictPolicy, merged.ConflictPolicy("deployment"))
+		require.Equal(t, ForceConflictPolicy, merged.ConflictPolicy("service"))
+		require.Len(t, global.ConflictPolicies, 1) //original config is untouched
+
+		var undefined *ApplyConfig
+		require.Equal(t, ClientSideApplyMode, undefined.Merge(nil).Mode)
+	})
+
+	t.Run("Parse conflict policies", func(t *testing.T) {
+		policies, err := ParseConflictPolicies("*=fail, deployment = force,")
+		require.NoError(t, err)
+		require.Equal(t, map[string]string{"*": "fail", "deployment": "force"}, policies)
+
+		_, err = ParseConflictPolicies("=force")
+		require.Error(t, err)
+	})
+}
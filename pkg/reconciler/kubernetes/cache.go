@@ -0,0 +1,176 @@
+package kubernetes
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes/internal"
+	"go.uber.org/zap"
+)
+
+type cacheEntry struct {
+	kubeClient *internal.KubeClient
+	created    time.Time
+	lastUsed   time.Time
+}
+
+// ClientCache shares Kubernetes clients (REST config, API discovery information and Helm client) between
+// all tasks which target the same cluster. Clients are identified by a hash of their kubeconfig and are
+// evicted after the TTL expired or when the cache exceeds its size (least recently used clients first).
+// Evicted clients are closed: their idle connections and cached API discovery information are dropped.
+type ClientCache struct {
+	ttl     time.Duration
+	maxSize int
+	entries map[string]*cacheEntry
+	mu      sync.Mutex
+	now     func() time.Time
+	//newKubeClient and closeKubeClient are replaceable for testing purposes
+	newKubeClient   func(kubeconfig string, logger *zap.SugaredLogger) (*internal.KubeClient, error)
+	closeKubeClient func(kubeClient *internal.KubeClient) error
+}
+
+func NewClientCache(ttl time.Duration, maxSize int) (*ClientCache, error) {
+	if ttl <= 0 {
+		return nil, fmt.Errorf("TTL of Kubernetes client cache has to be > 0 (got %.1f secs)", ttl.Seconds())
+	}
+	if maxSize <= 0 {
+		return nil, fmt.Errorf("size of Kubernetes client cache has to be > 0 (got %d)", maxSize)
+	}
+	return &ClientCache{
+		ttl:           ttl,
+		maxSize:       maxSize,
+		entries:       make(map[string]*cacheEntry),
+		now:           time.Now,
+		newKubeClient: internal.NewKubeClient,
+		closeKubeClient: func(kubeClient *internal.KubeClient) error {
+			return kubeClient.Close()
+		},
+	}, nil
+}
+
+// Get returns a client for the cluster of the kubeconfig. The underlying connection is shared with
+// other clients of the same cluster, the logger and config are only applied to the returned client.
+func (c *ClientCache) Get(kubeconfig string, logger *zap.SugaredLogger, config *Config) (Client, error) {
+	kubeClient, err := c.get(kubeconfig, logger)
+	if err != nil {
+		return nil, err
+	}
+	return adapt(kubeClient.WithLogger(logger), kubeconfig, logger, config), nil
+}
+
+func (c *ClientCache) get(kubeconfig string, logger *zap.SugaredLogger) (*internal.KubeClient, error) {
+	key := c.key(kubeconfig)
+
+	c.mu.Lock()
+	evicted := c.evictExpired()
+	entry, ok := c.entries[key]
+	if ok {
+		entry.lastUsed = c.now()
+	}
+	c.mu.Unlock()
+	c.close(evicted...)
+
+	if ok {
+		return entry.kubeClient, nil
+	}
+
+	kubeClient, err := c.newKubeClient(kubeconfig, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	if existing, ok := c.entries[key]; ok { //client was created concurrently: use the cached one
+		c.mu.Unlock()
+		c.close(&cacheEntry{kubeClient: kubeClient})
+		return existing.kubeClient, nil
+	}
+	now := c.now()
+	c.entries[key] = &cacheEntry{
+		kubeClient: kubeClient,
+		created:    now,
+		lastUsed:   now,
+	}
+	evicted = c.evictOversize()
+	c.mu.Unlock()
+	c.close(evicted...)
+
+	return kubeClient, nil
+}
+
+// Invalidate removes the client of the kubeconfig from the cache and closes it
+func (c *ClientCache) Invalidate(kubeconfig string) {
+	key := c.key(kubeconfig)
+
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	delete(c.entries, key)
+	c.mu.Unlock()
+
+	if ok {
+		c.close(entry)
+	}
+}
+
+// Purge removes all clients from the cache and closes them
+func (c *ClientCache) Purge() {
+	c.mu.Lock()
+	evicted := make([]*cacheEntry, 0, len(c.entries))
+	for _, entry := range c.entries {
+		evicted = append(evicted, entry)
+	}
+	c.entries = make(map[string]*cacheEntry)
+	c.mu.Unlock()
+
+	c.close(evicted...)
+}
+
+func (c *ClientCache) Size() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.entries)
+}
+
+func (c *ClientCache) key(kubeconfig string) string {
+	hash := sha256.Sum256([]byte(kubeconfig))
+	return hex.EncodeToString(hash[:])
+}
+
+//close releases the connections of evicted clients (tasks which still use them re-connect on demand)
+func (c *ClientCache) close(entries ...*cacheEntry) {
+	for _, entry := range entries {
+		//closing can only fail for an invalid REST config which wouldn't have been cached
+		_ = c.closeKubeClient(entry.kubeClient)
+	}
+}
+
+func (c *ClientCache) evictExpired() []*cacheEntry {
+	var evicted []*cacheEntry
+	now := c.now()
+	for key, entry := range c.entries {
+		if now.Sub(entry.created) >= c.ttl {
+			evicted = append(evicted, entry)
+			delete(c.entries, key)
+		}
+	}
+	return evicted
+}
+
+func (c *ClientCache) evictOversize() []*cacheEntry {
+	var evicted []*cacheEntry
+	for len(c.entries) > c.maxSize {
+		var lruKey string
+		var lruEntry *cacheEntry
+		for key, entry := range c.entries {
+			if lruEntry == nil || entry.lastUsed.Before(lruEntry.lastUsed) {
+				lruKey, lruEntry = key, entry
+			}
+		}
+		evicted = append(evicted, lruEntry)
+		delete(c.entries, lruKey)
+	}
+	return evicted
+}
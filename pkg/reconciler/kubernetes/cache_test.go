@@ -0,0 +1,152 @@
+package kubernetes
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes/internal"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+const kubeconfigTemplate = `apiVersion: v1
+kind: Config
+clusters:
+- cluster:
+    server: https://%s:6443
+  name: test
+contexts:
+- context:
+    cluster: test
+    user: test
+  name: test
+current-context: test
+users:
+- name: test
+  user:
+    token: abc
+`
+
+func newTestCache(t *testing.T, ttl time.Duration, size int) (*ClientCache, *time.Time, *int, *int) {
+	cache, err := NewClientCache(ttl, size)
+	require.NoError(t, err)
+
+	now := time.Now()
+	cache.now = func() time.Time {
+		return now
+	}
+
+	created := 0
+	newKubeClient := cache.newKubeClient
+	cache.newKubeClient = func(kubeconfig string, logger *zap.SugaredLogger) (*internal.KubeClient, error) {
+		created++
+		return newKubeClient(kubeconfig, logger)
+	}
+
+	closed := 0
+	closeKubeClient := cache.closeKubeClient
+	cache.closeKubeClient = func(kubeClient *internal.KubeClient) error {
+		closed++
+		return closeKubeClient(kubeClient)
+	}
+	return cache, &now, &created, &closed
+}
+
+func TestClientCache(t *testing.T) {
+	logger := zap.NewNop().Sugar()
+	kubeconfig1 := fmt.Sprintf(kubeconfigTemplate, "cluster1")
+	kubeconfig2 := fmt.Sprintf(kubeconfigTemplate, "cluster2")
+	kubeconfig3 := fmt.Sprintf(kubeconfigTemplate, "cluster3")
+
+	t.Run("Validate settings", func(t *testing.T) {
+		_, err := NewClientCache(0, 1)
+		require.Error(t, err)
+		_, err = NewClientCache(time.Minute, 0)
+		require.Error(t, err)
+	})
+
+	t.Run("Clients of the same cluster share the connection", func(t *testing.T) {
+		cache, _, created, _ := newTestCache(t, time.Minute, 10)
+
+		client1, err := cache.Get(kubeconfig1, logger, &Config{ApplyWorkers: 1})
+		require.NoError(t, err)
+		client2, err := cache.Get(kubeconfig1, logger, &Config{ApplyWorkers: 2})
+		require.NoError(t, err)
+		require.Equal(t, 1, *created)
+		//logger and config aren't shared
+		require.NotSame(t, client1.(*kubeClientAdapter).kubeClient, client2.(*kubeClientAdapter).kubeClient)
+		require.Equal(t, 2, client2.(*kubeClientAdapter).config.ApplyWorkers)
+
+		_, err = cache.Get(kubeconfig2, logger, nil)
+		require.NoError(t, err)
+		require.Equal(t, 2, *created)
+		require.Equal(t, 2, cache.Size())
+	})
+
+	t.Run("Clients expire after TTL", func(t *testing.T) {
+		cache, now, created, closed := newTestCache(t, time.Minute, 10)
+
+		_, err := cache.Get(kubeconfig1, logger, nil)
+		require.NoError(t, err)
+		*now = now.Add(59 * time.Second)
+		_, err = cache.Get(kubeconfig1, logger, nil)
+		require.NoError(t, err)
+		require.Equal(t, 1, *created)
+
+		*now = now.Add(1 * time.Second)
+		_, err = cache.Get(kubeconfig1, logger, nil)
+		require.NoError(t, err)
+		require.Equal(t, 2, *created)
+		require.Equal(t, 1, *closed)
+	})
+
+	t.Run("Least recently used clients are evicted", func(t *testing.T) {
+		cache, now, created, closed := newTestCache(t, time.Hour, 2)
+
+		_, err := cache.Get(kubeconfig1, logger, nil)
+		require.NoError(t, err)
+		*now = now.Add(time.Second)
+		_, err = cache.Get(kubeconfig2, logger, nil)
+		require.NoError(t, err)
+		*now = now.Add(time.Second)
+		_, err = cache.Get(kubeconfig1, logger, nil) //kubeconfig2 is now the least recently used
+		require.NoError(t, err)
+		*now = now.Add(time.Second)
+		_, err = cache.Get(kubeconfig3, logger, nil)
+		require.NoError(t, err)
+		require.Equal(t, 3, *created)
+		require.Equal(t, 2, cache.Size())
+		require.Equal(t, 1, *closed)
+
+		_, err = cache.Get(kubeconfig1, logger, nil)
+		require.NoError(t, err)
+		require.Equal(t, 3, *created)
+		_, err = cache.Get(kubeconfig2, logger, nil)
+		require.NoError(t, err)
+		require.Equal(t, 4, *created)
+	})
+
+	t.Run("Invalidate and purge clients", func(t *testing.T) {
+		cache, _, _, closed := newTestCache(t, time.Hour, 10)
+
+		_, err := cache.Get(kubeconfig1, logger, nil)
+		require.NoError(t, err)
+		_, err = cache.Get(kubeconfig2, logger, nil)
+		require.NoError(t, err)
+
+		cache.Invalidate(kubeconfig1)
+		require.Equal(t, 1, cache.Size())
+		require.Equal(t, 1, *closed)
+		cache.Purge()
+		require.Equal(t, 0, cache.Size())
+		require.Equal(t, 2, *closed)
+	})
+
+	t.Run("Invalid kubeconfig", func(t *testing.T) {
+		cache, _, _, _ := newTestCache(t, time.Hour, 10)
+		_, err := cache.Get("not a kubeconfig", logger, nil)
+		require.Error(t, err)
+		require.Equal(t, 0, cache.Size())
+	})
+}
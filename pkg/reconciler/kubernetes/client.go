@@ -9,6 +9,7 @@ import (
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
 )
@@ -35,12 +36,36 @@ type ResourceInterceptor interface {
 	Intercept(resource *unstructured.Unstructured, namespace string) (InterceptionResult, error)
 }
 
+// DeleteOption customizes the deletion of a manifest
+type DeleteOption func(*DeleteOptions)
+
+type DeleteOptions struct {
+	//BeforeNamespaceDeletion is called after the resources of the manifest were deleted and before their
+	//namespace is deleted (e.g. to run post-delete hooks): the namespace is kept if it returns an error
+	BeforeNamespaceDeletion func(ctx context.Context) error
+}
+
+// WithBeforeNamespaceDeletion registers a function which is called before the namespace of the manifest is deleted
+func WithBeforeNamespaceDeletion(fn func(ctx context.Context) error) DeleteOption {
+	return func(opts *DeleteOptions) {
+		opts.BeforeNamespaceDeletion = fn
+	}
+}
+
+func newDeleteOptions(opts []DeleteOption) *DeleteOptions {
+	result := &DeleteOptions{}
+	for _, opt := range opts {
+		opt(result)
+	}
+	return result
+}
+
 //go:generate mockery --name Client
 type Client interface {
 	Kubeconfig() string
 	DeleteResource(kind, name, namespace string) (*Resource, error)
 	Deploy(ctx context.Context, manifest, namespace string, interceptors ...ResourceInterceptor) ([]*Resource, error)
-	Delete(ctx context.Context, manifest, namespace string) ([]*Resource, error)
+	Delete(ctx context.Context, manifest, namespace string, opts ...DeleteOption) ([]*Resource, error)
 	PatchUsingStrategy(kind, name, namespace string, p []byte, strategy types.PatchType) error
 	Clientset() (kubernetes.Interface, error)
 
@@ -51,6 +76,7 @@ type Client interface {
 	GetJob(ctx context.Context, name, namespace string) (*batchv1.Job, error)
 	GetPersistentVolumeClaim(ctx context.Context, name, namespace string) (*v1.PersistentVolumeClaim, error)
 	ListResource(resource string, lo metav1.ListOptions) (*unstructured.UnstructuredList, error)
+	GetResource(ctx context.Context, gvk schema.GroupVersionKind, name, namespace string) (*unstructured.Unstructured, error)
 
 	GetHost() string
 }
@@ -0,0 +1,425 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/dynamic"
+)
+
+type DeletionMode string
+
+const (
+	//DefaultDeletionMode deletes the resources of a manifest in reverse order and waits for their termination
+	DefaultDeletionMode DeletionMode = "default"
+	//FinalizerAwareDeletionMode deletes custom resources of the manifest's CRDs first (while their controllers
+	//still exist) and detects resources which are stuck on finalizers
+	FinalizerAwareDeletionMode DeletionMode = "finalizer-aware"
+
+	defaultFinalizerTimeout = 2 * time.Minute
+	deletionCheckInterval   = 2 * time.Second
+)
+
+func NewDeletionMode(mode string) (DeletionMode, error) {
+	switch strings.ToLower(mode) {
+	case "", string(DefaultDeletionMode):
+		return DefaultDeletionMode, nil
+	case string(FinalizerAwareDeletionMode):
+		return FinalizerAwareDeletionMode, nil
+	default:
+		return "", fmt.Errorf("deletion mode '%s' is not supported (supported are '%s' and '%s')",
+			mode, DefaultDeletionMode, FinalizerAwareDeletionMode)
+	}
+}
+
+// DeletionConfig defines how the resources of a manifest are deleted
+type DeletionConfig struct {
+	Mode DeletionMode
+	//FinalizerTimeout is the time a deleted resource can exist before it's considered as stuck on its finalizers
+	FinalizerTimeout time.Duration
+	//StripFinalizers removes the finalizers of stuck resources (otherwise the deletion fails)
+	StripFinalizers bool
+}
+
+func NewDeletionConfig(mode string, finalizerTimeout time.Duration, stripFinalizers bool) (*DeletionConfig, error) {
+	deletionMode, err := NewDeletionMode(mode)
+	if err != nil {
+		return nil, err
+	}
+	if finalizerTimeout < 0 {
+		return nil, fmt.Errorf("finalizer timeout cannot be < 0 (got %.1f secs)", finalizerTimeout.Seconds())
+	}
+	if finalizerTimeout == 0 {
+		finalizerTimeout = defaultFinalizerTimeout
+	}
+	return &DeletionConfig{
+		Mode:             deletionMode,
+		FinalizerTimeout: finalizerTimeout,
+		StripFinalizers:  stripFinalizers,
+	}, nil
+}
+
+func (c *DeletionConfig) finalizerAware() bool {
+	return c != nil && c.Mode == FinalizerAwareDeletionMode
+}
+
+type DeletionStatus string
+
+const (
+	DeletedStatus           DeletionStatus = "deleted"
+	NotFoundStatus          DeletionStatus = "notFound"
+	FinalizersRemovedStatus DeletionStatus = "finalizersRemoved"
+	StuckStatus             DeletionStatus = "stuck"
+	FailedStatus            DeletionStatus = "failed"
+)
+
+type DeletedResource struct {
+	Resource
+	Status     DeletionStatus
+	Finalizers []string //finalizers of stuck resources or finalizers which were removed
+	Error      string
+}
+
+// DeletionReport collects the deletion status of each resource. A resource is listed only once: later
+// results (e.g. a deleted resource which got stuck on its finalizers) replace previous results.
+type DeletionReport struct {
+	resources []*DeletedResource
+	mu        sync.Mutex
+}
+
+func NewDeletionReport() *DeletionReport {
+	return &DeletionReport{}
+}
+
+func (r *DeletionReport) add(resource *Resource, status DeletionStatus, finalizers []string, err error) {
+	if r == nil {
+		return
+	}
+	deleted := &DeletedResource{
+		Resource:   *resource,
+		Status:     status,
+		Finalizers: finalizers,
+	}
+	if err != nil {
+		deleted.Error = err.Error()
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for idx, existing := range r.resources {
+		if existing.Kind == resource.Kind && existing.Namespace == resource.Namespace && existing.Name == resource.Name {
+			r.resources[idx] = deleted
+			return
+		}
+	}
+	r.resources = append(r.resources, deleted)
+}
+
+// Resources returns the deletion status of all resources in the order they were deleted
+func (r *DeletionReport) Resources() []*DeletedResource {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	result := make([]*DeletedResource, len(r.resources))
+	copy(result, r.resources)
+	return result
+}
+
+//deletionTarget is a resource which can be deleted by the dynamic client
+type deletionTarget struct {
+	resource   *Resource
+	gvr        schema.GroupVersionResource
+	namespaced bool
+}
+
+func (t *deletionTarget) isNamespace() bool {
+	return t.gvr.Group == "" && t.gvr.Resource == "namespaces"
+}
+
+func (t *deletionTarget) String() string {
+	return t.resource.String()
+}
+
+//finalizerAwareDeleter deletes resources, awaits their removal and handles resources which are stuck on finalizers
+type finalizerAwareDeleter struct {
+	dynamicClient dynamic.Interface
+	config        *DeletionConfig
+	report        *DeletionReport
+	logger        *zap.SugaredLogger
+	interval      time.Duration
+	errs          []string
+}
+
+func (d *finalizerAwareDeleter) client(target *deletionTarget) dynamic.ResourceInterface {
+	if target.namespaced {
+		return d.dynamicClient.Resource(target.gvr).Namespace(target.resource.Namespace)
+	}
+	return d.dynamicClient.Resource(target.gvr)
+}
+
+//deleteCustomResources deletes all custom resources of the CRDs in all namespaces and awaits their removal
+func (d *finalizerAwareDeleter) deleteCustomResources(ctx context.Context, crds []*unstructured.Unstructured) {
+	var targets []*deletionTarget
+	for _, crd := range crds {
+		gvr, namespaced, kind, err := customResourceDefinition(crd)
+		if err != nil {
+			d.fail(&Resource{Kind: crd.GetKind(), Name: crd.GetName()}, err)
+			continue
+		}
+		crs, err := d.dynamicClient.Resource(gvr).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			if !k8serr.IsNotFound(err) { //CRD doesn't exist anymore
+				d.fail(&Resource{Kind: crd.GetKind(), Name: crd.GetName()},
+					errors.Wrapf(err, "failed to list custom resources of CRD '%s'", crd.GetName()))
+			}
+			continue
+		}
+		for i := range crs.Items {
+			target := &deletionTarget{
+				resource: &Resource{
+					Kind:      kind,
+					Name:      crs.Items[i].GetName(),
+					Namespace: crs.Items[i].GetNamespace(),
+				},
+				gvr:        gvr,
+				namespaced: namespaced,
+			}
+			if d.delete(ctx, target) {
+				targets = append(targets, target)
+			}
+		}
+	}
+	d.awaitRemoval(ctx, targets)
+}
+
+//delete triggers the deletion of the target and returns true if the target has to be awaited
+func (d *finalizerAwareDeleter) delete(ctx context.Context, target *deletionTarget) bool {
+	d.logger.Debugf("Deleting resource %s", target)
+	err := d.client(target).Delete(ctx, target.resource.Name, metav1.DeleteOptions{})
+	switch {
+	case err == nil:
+		d.report.add(target.resource, DeletedStatus, nil, nil)
+		return true
+	case k8serr.IsNotFound(err):
+		d.report.add(target.resource, NotFoundStatus, nil, nil)
+	default:
+		d.fail(target.resource, err)
+	}
+	return false
+}
+
+//awaitRemoval waits until all targets are removed and handles the targets which still exist after the finalizer timeout
+func (d *finalizerAwareDeleter) awaitRemoval(ctx context.Context, targets []*deletionTarget) {
+	if len(targets) == 0 {
+		return
+	}
+	pending := make(map[*deletionTarget]*unstructured.Unstructured)
+	for _, target := range targets {
+		pending[target] = nil
+	}
+
+	err := wait.PollImmediate(d.interval, d.config.FinalizerTimeout, func() (bool, error) {
+		if ctx.Err() != nil {
+			return false, ctx.Err()
+		}
+		for target := range pending {
+			obj, err := d.client(target).Get(ctx, target.resource.Name, metav1.GetOptions{})
+			switch {
+			case k8serr.IsNotFound(err):
+				delete(pending, target)
+			case err == nil:
+				pending[target] = obj
+			default:
+				d.logger.Debugf("Failed to retrieve deleted resource %s: %s", target, err)
+			}
+		}
+		return len(pending) == 0, nil
+	})
+	if err == nil {
+		return
+	}
+	if err != wait.ErrWaitTimeout {
+		for target := range pending {
+			d.fail(target.resource, errors.Wrap(err, "failed to await removal"))
+		}
+		return
+	}
+
+	for _, target := range targets { //iterate over the targets to handle stuck resources in deletion order
+		if obj, ok := pending[target]; ok {
+			d.handleStuck(ctx, target, obj)
+		}
+	}
+}
+
+func (d *finalizerAwareDeleter) handleStuck(ctx context.Context, target *deletionTarget, obj *unstructured.Unstructured) {
+	if target.isNamespace() {
+		d.handleStuckNamespace(ctx, target, obj)
+		return
+	}
+
+	var finalizers []string
+	if obj != nil {
+		finalizers = obj.GetFinalizers()
+	}
+
+	if len(finalizers) == 0 || !d.config.StripFinalizers {
+		d.stuck(target, finalizers, fmt.Errorf("resource %s still exists %.1f secs after its deletion (finalizers: %s)",
+			target, d.config.FinalizerTimeout.Seconds(), strings.Join(finalizers, ",")))
+		return
+	}
+
+	if _, removed := d.removeFinalizers(ctx, target); !removed {
+		return
+	}
+	d.logger.Warnf("Audit: removed finalizers [%s] of resource %s which was stuck in deletion since %s",
+		strings.Join(finalizers, ","), target, deletionTimestamp(obj))
+	d.report.add(target.resource, FinalizersRemovedStatus, finalizers, nil)
+}
+
+//handleStuckNamespace handles a namespace which is stuck in deletion: besides the finalizers in its metadata,
+//a namespace is blocked by the finalizers in its spec which can only be removed using the finalize subresource
+func (d *finalizerAwareDeleter) handleStuckNamespace(ctx context.Context, target *deletionTarget, obj *unstructured.Unstructured) {
+	var finalizers, specFinalizers, conditions []string
+	if obj != nil {
+		finalizers = obj.GetFinalizers()
+		specFinalizers, _, _ = unstructured.NestedStringSlice(obj.Object, "spec", "finalizers")
+		conditions = namespaceDeletionConditions(obj)
+	}
+	allFinalizers := append(append([]string{}, finalizers...), specFinalizers...)
+
+	if len(allFinalizers) == 0 || !d.config.StripFinalizers {
+		d.stuck(target, allFinalizers, fmt.Errorf("namespace %s still exists %.1f secs after its deletion "+
+			"(finalizers: %s, spec finalizers: %s, conditions: %s)", target, d.config.FinalizerTimeout.Seconds(),
+			strings.Join(finalizers, ","), strings.Join(specFinalizers, ","), strings.Join(conditions, "; ")))
+		return
+	}
+
+	if len(finalizers) > 0 {
+		patched, removed := d.removeFinalizers(ctx, target)
+		if !removed {
+			return
+		}
+		obj = patched
+	}
+	if len(specFinalizers) > 0 {
+		finalized := obj.DeepCopy()
+		unstructured.RemoveNestedField(finalized.Object, "spec", "finalizers")
+		if _, err := d.client(target).Update(ctx, finalized, metav1.UpdateOptions{}, "finalize"); err != nil {
+			if k8serr.IsNotFound(err) { //namespace was removed in the meantime
+				d.report.add(target.resource, DeletedStatus, nil, nil)
+				return
+			}
+			d.fail(target.resource, errors.Wrap(err, "failed to finalize namespace"))
+			return
+		}
+	}
+	d.logger.Warnf("Audit: removed finalizers [%s] and spec finalizers [%s] of namespace %s which was stuck "+
+		"in deletion since %s (conditions: %s)", strings.Join(finalizers, ","), strings.Join(specFinalizers, ","),
+		target, deletionTimestamp(obj), strings.Join(conditions, "; "))
+	d.report.add(target.resource, FinalizersRemovedStatus, allFinalizers, nil)
+}
+
+func (d *finalizerAwareDeleter) stuck(target *deletionTarget, finalizers []string, err error) {
+	d.logger.Warn(err.Error())
+	d.errs = append(d.errs, err.Error())
+	d.report.add(target.resource, StuckStatus, finalizers, err)
+}
+
+//removeFinalizers removes the finalizers from the metadata of the target and returns the patched target
+//(or false if the finalizers weren't removed)
+func (d *finalizerAwareDeleter) removeFinalizers(ctx context.Context, target *deletionTarget) (*unstructured.Unstructured, bool) {
+	patch := []byte(`{"metadata":{"finalizers":null}}`)
+	patched, err := d.client(target).Patch(ctx, target.resource.Name, types.MergePatchType, patch, metav1.PatchOptions{})
+	if err != nil {
+		if k8serr.IsNotFound(err) { //resource was removed in the meantime
+			d.report.add(target.resource, DeletedStatus, nil, nil)
+			return nil, false
+		}
+		d.fail(target.resource, errors.Wrap(err, "failed to remove finalizers"))
+		return nil, false
+	}
+	return patched, true
+}
+
+func (d *finalizerAwareDeleter) fail(resource *Resource, err error) {
+	d.logger.Errorf("Failed to delete resource %s: %s", resource, err)
+	d.errs = append(d.errs, fmt.Sprintf("%s: %s", resource, err))
+	d.report.add(resource, FailedStatus, nil, err)
+}
+
+func (d *finalizerAwareDeleter) err() error {
+	if len(d.errs) == 0 {
+		return nil
+	}
+	return fmt.Errorf("deletion of %d resources failed: %s", len(d.errs), strings.Join(d.errs, "; "))
+}
+
+func deletionTimestamp(obj *unstructured.Unstructured) string {
+	if timestamp := obj.GetDeletionTimestamp(); timestamp != nil {
+		return timestamp.UTC().Format(time.RFC3339)
+	}
+	return "unknown"
+}
+
+//namespaceDeletionConditions returns the active conditions of a namespace which block its deletion
+func namespaceDeletionConditions(namespace *unstructured.Unstructured) []string {
+	var result []string
+	conditions, _, _ := unstructured.NestedSlice(namespace.Object, "status", "conditions")
+	for _, condition := range conditions {
+		conditionMap, ok := condition.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		conditionType, _, _ := unstructured.NestedString(conditionMap, "type")
+		status, _, _ := unstructured.NestedString(conditionMap, "status")
+		if status != "True" ||
+			(conditionType != "NamespaceFinalizersRemaining" && conditionType != "NamespaceContentRemaining") {
+			continue
+		}
+		message, _, _ := unstructured.NestedString(conditionMap, "message")
+		result = append(result, fmt.Sprintf("%s: %s", conditionType, message))
+	}
+	return result
+}
+
+//customResourceDefinition returns the resource, the scope and the kind of the custom resources defined by a CRD
+func customResourceDefinition(crd *unstructured.Unstructured) (schema.GroupVersionResource, bool, string, error) {
+	group, _, _ := unstructured.NestedString(crd.Object, "spec", "group")
+	plural, _, _ := unstructured.NestedString(crd.Object, "spec", "names", "plural")
+	kind, _, _ := unstructured.NestedString(crd.Object, "spec", "names", "kind")
+	scope, _, _ := unstructured.NestedString(crd.Object, "spec", "scope")
+	version, _, _ := unstructured.NestedString(crd.Object, "spec", "version") //apiextensions.k8s.io/v1beta1
+
+	versions, _, _ := unstructured.NestedSlice(crd.Object, "spec", "versions")
+	for _, v := range versions {
+		versionMap, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name, _, _ := unstructured.NestedString(versionMap, "name")
+		storage, _, _ := unstructured.NestedBool(versionMap, "storage")
+		if version == "" || storage {
+			version = name
+		}
+	}
+
+	if group == "" || plural == "" || kind == "" || version == "" {
+		return schema.GroupVersionResource{}, false, "", fmt.Errorf("CRD '%s' is incomplete: group, version, "+
+			"plural name and kind are required", crd.GetName())
+	}
+	return schema.GroupVersionResource{Group: group, Version: version, Resource: plural}, scope != "Cluster", kind, nil
+}
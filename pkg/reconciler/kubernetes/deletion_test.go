@@ -0,0 +1,264 @@
+package kubernetes
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	log "github.com/kyma-incubator/reconciler/pkg/logger"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+var (
+	testGVR          = schema.GroupVersionResource{Group: "test.kyma-project.io", Version: "v1", Resource: "tests"}
+	namespaceTestGVR = schema.GroupVersionResource{Version: "v1", Resource: "namespaces"}
+)
+
+func newTestCRD() *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "apiextensions.k8s.io/v1",
+		"kind":       crdKind,
+		"metadata":   map[string]interface{}{"name": "tests.test.kyma-project.io"},
+		"spec": map[string]interface{}{
+			"group": "test.kyma-project.io",
+			"names": map[string]interface{}{"kind": "Test", "plural": "tests"},
+			"scope": "Namespaced",
+			"versions": []interface{}{
+				map[string]interface{}{"name": "v1alpha1", "served": true, "storage": false},
+				map[string]interface{}{"name": "v1", "served": true, "storage": true},
+			},
+		},
+	}}
+}
+
+func newTestCR(namespace, name string, finalizers ...string) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(schema.GroupVersionKind{Group: testGVR.Group, Version: testGVR.Version, Kind: "Test"})
+	u.SetNamespace(namespace)
+	u.SetName(name)
+	u.SetFinalizers(finalizers)
+	return u
+}
+
+func newTestDeleter(stripFinalizers bool, objects ...runtime.Object) (*finalizerAwareDeleter, *dynamicfake.FakeDynamicClient) {
+	client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{testGVR: "TestList"}, objects...)
+	return &finalizerAwareDeleter{
+		dynamicClient: client,
+		config: &DeletionConfig{
+			Mode:             FinalizerAwareDeletionMode,
+			FinalizerTimeout: 200 * time.Millisecond,
+			StripFinalizers:  stripFinalizers,
+		},
+		report:   NewDeletionReport(),
+		logger:   log.NewLogger(true),
+		interval: 10 * time.Millisecond,
+	}, client
+}
+
+//ignoreDeletion simulates resources which are stuck on their finalizers
+func ignoreDeletion(client *dynamicfake.FakeDynamicClient) {
+	client.PrependReactor("delete", "tests", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, nil
+	})
+}
+
+func TestDeletionConfig(t *testing.T) {
+	cfg, err := NewDeletionConfig("", 0, false)
+	require.NoError(t, err)
+	require.Equal(t, DefaultDeletionMode, cfg.Mode)
+	require.Equal(t, defaultFinalizerTimeout, cfg.FinalizerTimeout)
+	require.False(t, cfg.finalizerAware())
+
+	cfg, err = NewDeletionConfig("Finalizer-Aware", time.Minute, true)
+	require.NoError(t, err)
+	require.True(t, cfg.finalizerAware())
+	require.True(t, cfg.StripFinalizers)
+
+	_, err = NewDeletionConfig("forceful", 0, false)
+	require.Error(t, err)
+	_, err = NewDeletionConfig("", -1*time.Second, false)
+	require.Error(t, err)
+}
+
+func TestDeletionReport(t *testing.T) {
+	report := NewDeletionReport()
+	report.add(&Resource{Kind: "Test", Namespace: "ns", Name: "a"}, DeletedStatus, nil, nil)
+	report.add(&Resource{Kind: "Test", Namespace: "ns", Name: "b"}, NotFoundStatus, nil, nil)
+	report.add(&Resource{Kind: "Test", Namespace: "ns", Name: "a"}, StuckStatus, []string{"test"}, nil)
+
+	resources := report.Resources()
+	require.Len(t, resources, 2)
+	require.Equal(t, "a", resources[0].Name)
+	require.Equal(t, StuckStatus, resources[0].Status)
+	require.Equal(t, []string{"test"}, resources[0].Finalizers)
+	require.Equal(t, NotFoundStatus, resources[1].Status)
+
+	var nilReport *DeletionReport
+	nilReport.add(&Resource{Kind: "Test"}, DeletedStatus, nil, nil) //nil report is ignored
+	require.Empty(t, nilReport.Resources())
+}
+
+func TestCustomResourceDefinition(t *testing.T) {
+	gvr, namespaced, kind, err := customResourceDefinition(newTestCRD())
+	require.NoError(t, err)
+	require.Equal(t, testGVR, gvr)
+	require.True(t, namespaced)
+	require.Equal(t, "Test", kind)
+
+	crd := newTestCRD()
+	unstructured.RemoveNestedField(crd.Object, "spec", "names")
+	_, _, _, err = customResourceDefinition(crd)
+	require.Error(t, err)
+}
+
+func newTestNamespace(name string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Namespace",
+		"metadata": map[string]interface{}{
+			"name":       name,
+			"finalizers": []interface{}{"test.kyma-project.io/finalizer"},
+		},
+		"spec": map[string]interface{}{
+			"finalizers": []interface{}{"kubernetes"},
+		},
+		"status": map[string]interface{}{
+			"phase": "Terminating",
+			"conditions": []interface{}{
+				map[string]interface{}{
+					"type":    "NamespaceContentRemaining",
+					"status":  "True",
+					"message": "Some resources are remaining: tests.test.kyma-project.io has 1 resource instances",
+				},
+				map[string]interface{}{
+					"type":   "NamespaceDeletionDiscoveryFailure",
+					"status": "False",
+				},
+			},
+		},
+	}}
+}
+
+func TestFinalizerAwareDeleter(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Delete custom resources of CRDs", func(t *testing.T) {
+		deleter, client := newTestDeleter(false, newTestCR("ns1", "cr1"), newTestCR("ns2", "cr2"))
+		deleter.deleteCustomResources(ctx, []*unstructured.Unstructured{newTestCRD()})
+		require.NoError(t, deleter.err())
+
+		resources := deleter.report.Resources()
+		require.Len(t, resources, 2)
+		for _, resource := range resources {
+			require.Equal(t, DeletedStatus, resource.Status)
+		}
+		crs, err := client.Resource(testGVR).List(ctx, metav1.ListOptions{})
+		require.NoError(t, err)
+		require.Empty(t, crs.Items)
+	})
+
+	t.Run("Report resources stuck on finalizers", func(t *testing.T) {
+		deleter, client := newTestDeleter(false, newTestCR("ns", "stuck", "test.kyma-project.io/finalizer"))
+		ignoreDeletion(client)
+		deleter.deleteCustomResources(ctx, []*unstructured.Unstructured{newTestCRD()})
+		require.Error(t, deleter.err())
+
+		resources := deleter.report.Resources()
+		require.Len(t, resources, 1)
+		require.Equal(t, StuckStatus, resources[0].Status)
+		require.Equal(t, []string{"test.kyma-project.io/finalizer"}, resources[0].Finalizers)
+		require.NotEmpty(t, resources[0].Error)
+	})
+
+	t.Run("Strip finalizers of stuck resources", func(t *testing.T) {
+		deleter, client := newTestDeleter(true, newTestCR("ns", "stuck", "test.kyma-project.io/finalizer"))
+		ignoreDeletion(client)
+		deleter.deleteCustomResources(ctx, []*unstructured.Unstructured{newTestCRD()})
+		require.NoError(t, deleter.err())
+
+		resources := deleter.report.Resources()
+		require.Len(t, resources, 1)
+		require.Equal(t, FinalizersRemovedStatus, resources[0].Status)
+		require.Equal(t, []string{"test.kyma-project.io/finalizer"}, resources[0].Finalizers)
+
+		cr, err := client.Resource(testGVR).Namespace("ns").Get(ctx, "stuck", metav1.GetOptions{})
+		require.NoError(t, err)
+		require.Empty(t, cr.GetFinalizers())
+	})
+
+	t.Run("Report namespaces stuck on spec finalizers", func(t *testing.T) {
+		deleter, client := newTestDeleter(false, newTestNamespace("stuck"))
+		client.PrependReactor("delete", "namespaces", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			return true, nil, nil
+		})
+		target := &deletionTarget{
+			resource: &Resource{Kind: "Namespace", Name: "stuck"},
+			gvr:      namespaceTestGVR,
+		}
+		require.True(t, deleter.delete(ctx, target))
+		deleter.awaitRemoval(ctx, []*deletionTarget{target})
+		require.Error(t, deleter.err())
+
+		resources := deleter.report.Resources()
+		require.Len(t, resources, 1)
+		require.Equal(t, StuckStatus, resources[0].Status)
+		require.Equal(t, []string{"test.kyma-project.io/finalizer", "kubernetes"}, resources[0].Finalizers)
+		require.Contains(t, resources[0].Error, "spec finalizers: kubernetes")
+		require.Contains(t, resources[0].Error, "NamespaceContentRemaining: Some resources are remaining")
+		require.NotContains(t, resources[0].Error, "NamespaceDeletionDiscoveryFailure")
+	})
+
+	t.Run("Strip spec finalizers of stuck namespaces", func(t *testing.T) {
+		deleter, client := newTestDeleter(true, newTestNamespace("stuck"))
+		client.PrependReactor("delete", "namespaces", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			return true, nil, nil
+		})
+		target := &deletionTarget{
+			resource: &Resource{Kind: "Namespace", Name: "stuck"},
+			gvr:      namespaceTestGVR,
+		}
+		require.True(t, deleter.delete(ctx, target))
+		deleter.awaitRemoval(ctx, []*deletionTarget{target})
+		require.NoError(t, deleter.err())
+
+		resources := deleter.report.Resources()
+		require.Len(t, resources, 1)
+		require.Equal(t, FinalizersRemovedStatus, resources[0].Status)
+		require.Equal(t, []string{"test.kyma-project.io/finalizer", "kubernetes"}, resources[0].Finalizers)
+
+		//spec finalizers are removed using the finalize subresource
+		var finalized bool
+		for _, action := range client.Actions() {
+			if action.GetVerb() == "update" && action.GetSubresource() == "finalize" {
+				finalized = true
+			}
+		}
+		require.True(t, finalized)
+
+		namespace, err := client.Resource(namespaceTestGVR).Get(ctx, "stuck", metav1.GetOptions{})
+		require.NoError(t, err)
+		require.Empty(t, namespace.GetFinalizers())
+		specFinalizers, _, err := unstructured.NestedStringSlice(namespace.Object, "spec", "finalizers")
+		require.NoError(t, err)
+		require.Empty(t, specFinalizers)
+	})
+
+	t.Run("Report resources which don't exist", func(t *testing.T) {
+		deleter, _ := newTestDeleter(false)
+		target := &deletionTarget{
+			resource:   &Resource{Kind: "Test", Namespace: "ns", Name: "missing"},
+			gvr:        testGVR,
+			namespaced: true,
+		}
+		require.False(t, deleter.delete(ctx, target))
+		require.NoError(t, deleter.err())
+		require.Equal(t, NotFoundStatus, deleter.report.Resources()[0].Status)
+	})
+}
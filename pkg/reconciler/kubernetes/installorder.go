@@ -0,0 +1,68 @@
+package kubernetes
+
+import (
+	"sort"
+
+	"helm.sh/helm/v3/pkg/releaseutil"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const crdKind = "CustomResourceDefinition"
+
+//webhookKinds are applied at the very end: webhooks can block the deployment of other resources
+//as long as their backing services aren't ready
+var webhookKinds = []string{"MutatingWebhookConfiguration", "ValidatingWebhookConfiguration"}
+
+// installStage returns the stage in which a resource of the given kind is applied.
+// The stages follow Helm's install order, resources of unknown kinds (e.g. custom resources) are applied after all
+// known kinds and webhook configurations are applied last.
+func installStage(kind string) int {
+	for idx, installKind := range releaseutil.InstallOrder {
+		if installKind == kind {
+			return idx
+		}
+	}
+	for _, webhookKind := range webhookKinds {
+		if webhookKind == kind {
+			return len(releaseutil.InstallOrder) + 1
+		}
+	}
+	return len(releaseutil.InstallOrder)
+}
+
+// sortByInstallOrder groups the resources by their install stage. Resources of the same stage are independent
+// of each other and keep their order of the manifest.
+func sortByInstallOrder(unstructs []*unstructured.Unstructured) [][]*unstructured.Unstructured {
+	sorted := make([]*unstructured.Unstructured, len(unstructs))
+	copy(sorted, unstructs)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return installStage(sorted[i].GetKind()) < installStage(sorted[j].GetKind())
+	})
+
+	var stages [][]*unstructured.Unstructured
+	for idx, unstruct := range sorted {
+		if idx == 0 || installStage(sorted[idx-1].GetKind()) != installStage(unstruct.GetKind()) {
+			stages = append(stages, []*unstructured.Unstructured{})
+		}
+		stages[len(stages)-1] = append(stages[len(stages)-1], unstruct)
+	}
+	return stages
+}
+
+// isEstablished checks whether the CRD is accepted by the API server and its custom resources can be created
+func isEstablished(crd *unstructured.Unstructured) bool {
+	conditions, _, err := unstructured.NestedSlice(crd.Object, "status", "conditions")
+	if err != nil {
+		return false
+	}
+	for _, condition := range conditions {
+		condMap, ok := condition.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if condMap["type"] == "Established" && condMap["status"] == "True" {
+			return true
+		}
+	}
+	return false
+}
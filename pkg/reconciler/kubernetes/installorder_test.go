@@ -0,0 +1,67 @@
+package kubernetes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestSortByInstallOrder(t *testing.T) {
+	newUnstruct := func(kind, name string) *unstructured.Unstructured {
+		u := &unstructured.Unstructured{}
+		u.SetKind(kind)
+		u.SetName(name)
+		return u
+	}
+
+	unstructs := []*unstructured.Unstructured{
+		newUnstruct("ValidatingWebhookConfiguration", "webhook"),
+		newUnstruct("Gateway", "gateway"),
+		newUnstruct("Deployment", "deployment1"),
+		newUnstruct("Service", "service"),
+		newUnstruct("Deployment", "deployment2"),
+		newUnstruct("CustomResourceDefinition", "crd"),
+		newUnstruct("ConfigMap", "configmap"),
+		newUnstruct("ServiceAccount", "serviceaccount"),
+		newUnstruct("Namespace", "namespace"),
+	}
+
+	var result [][]string
+	for _, stage := range sortByInstallOrder(unstructs) {
+		var names []string
+		for _, unstruct := range stage {
+			names = append(names, unstruct.GetName())
+		}
+		result = append(result, names)
+	}
+
+	require.Equal(t, [][]string{
+		{"namespace"},
+		{"serviceaccount"},
+		{"configmap"},
+		{"crd"},
+		{"service"},
+		{"deployment1", "deployment2"},
+		{"gateway"},
+		{"webhook"},
+	}, result)
+	require.Empty(t, sortByInstallOrder(nil))
+}
+
+func TestIsEstablished(t *testing.T) {
+	crd := &unstructured.Unstructured{Object: map[string]interface{}{}}
+	require.False(t, isEstablished(crd))
+
+	require.NoError(t, unstructured.SetNestedSlice(crd.Object, []interface{}{
+		map[string]interface{}{"type": "NamesAccepted", "status": "True"},
+		map[string]interface{}{"type": "Established", "status": "False"},
+	}, "status", "conditions"))
+	require.False(t, isEstablished(crd))
+
+	require.NoError(t, unstructured.SetNestedSlice(crd.Object, []interface{}{
+		map[string]interface{}{"type": "NamesAccepted", "status": "True"},
+		map[string]interface{}{"type": "Established", "status": "True"},
+	}, "status", "conditions"))
+	require.True(t, isEstablished(crd))
+}
@@ -4,18 +4,21 @@ package internal
 
 import (
 	"context"
+	"net/http"
 	"helm.sh/helm/v3/pkg/kube"
 	k8serr "k8s.io/apimachinery/pkg/api/errors"
 	"strings"
 
 	"go.uber.org/zap"
 
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/types"
 
 	"github.com/pkg/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilnet "k8s.io/apimachinery/pkg/util/net"
 	"k8s.io/cli-runtime/pkg/resource"
 	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/discovery/cached/memory"
@@ -38,11 +41,15 @@ type Metadata struct {
 	Kind      string
 }
 
+// FieldManager is the name of the field manager used for server-side apply
+const FieldManager = "reconciler"
+
 type KubeClient struct {
 	dynamicClient dynamic.Interface
 	config        *rest.Config
 	mapper        *restmapper.DeferredDiscoveryRESTMapper
 	helmClient    *kube.Client
+	logger        *zap.SugaredLogger
 }
 
 func NewKubeClient(kubeconfig string, logger *zap.SugaredLogger) (*KubeClient, error) {
@@ -65,7 +72,46 @@ func NewInClusterClient(logger *zap.SugaredLogger) (*KubeClient, error) {
 	return newForConfig(config)
 }
 
+// WithLogger returns a client which logs warnings of the API server with the given logger. The returned client
+// shares the connection, the cached API discovery information, the dynamic client and the Helm client with
+// this client.
+func (k *KubeClient) WithLogger(logger *zap.SugaredLogger) *KubeClient {
+	return &KubeClient{
+		dynamicClient: k.dynamicClient,
+		config:        k.config,
+		mapper:        k.mapper,
+		helmClient:    k.helmClient,
+		logger:        logger,
+	}
+}
+
+// Close drops the cached API discovery information and closes idle connections to the API server
+func (k *KubeClient) Close() error {
+	k.mapper.Reset()
+	transport, err := rest.TransportFor(k.config)
+	if err != nil {
+		return err
+	}
+	closeIdleConnections(transport)
+	return nil
+}
+
+func closeIdleConnections(rt http.RoundTripper) {
+	for rt != nil {
+		if closer, ok := rt.(interface{ CloseIdleConnections() }); ok {
+			closer.CloseIdleConnections()
+			return
+		}
+		wrapper, ok := rt.(utilnet.RoundTripperWrapper)
+		if !ok {
+			return
+		}
+		rt = wrapper.WrappedRoundTripper()
+	}
+}
+
 func newForConfig(config *rest.Config) (*KubeClient, error) {
+	config = withWarningTransport(config)
 	dynamicClient, err := dynamic.NewForConfig(config)
 	if err != nil {
 		return nil, err
@@ -84,6 +130,11 @@ func newForConfig(config *rest.Config) (*KubeClient, error) {
 	}, nil
 }
 
+//context returns the context of requests sent by this client: warnings of the API server are logged with its logger
+func (k *KubeClient) context() context.Context {
+	return WithWarningLogger(context.Background(), k.logger)
+}
+
 func (k *KubeClient) Apply(u *unstructured.Unstructured) (*Metadata, error) {
 	return k.ApplyWithNamespaceOverride(u, "")
 }
@@ -94,33 +145,7 @@ func (k *KubeClient) Apply(u *unstructured.Unstructured) (*Metadata, error) {
 // We only override the namespace if the manifest is NOT cluster scoped (i.e. a ClusterRole) and namespaceOverride is NOT an
 // empty string.
 func (k *KubeClient) ApplyWithNamespaceOverride(u *unstructured.Unstructured, namespaceOverride string) (*Metadata, error) {
-	gvk := u.GroupVersionKind()
-	metadata := &Metadata{
-		Kind: gvk.Kind,
-		Name: u.GetName(),
-	}
-
-	restMapping, err := k.mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
-	if err != nil {
-		return nil, err
-	}
-
-	gv := gvk.GroupVersion()
-	k.config.GroupVersion = &gv
-
-	restClient, err := newRestClient(*k.config, gv)
-	if err != nil {
-		return nil, err
-	}
-
-	helper := resource.NewHelper(restClient, restMapping)
-
-	setDefaultNamespaceIfScopedAndNoneSet(namespaceOverride, u, helper)
-	setNamespaceIfScoped(namespaceOverride, u, helper)
-	metadata.Namespace = u.GetNamespace()
-
-	updateStrategyResolver := newDefaultUpdateStrategyResolver(helper)
-	strategy, err := updateStrategyResolver.Resolve(u)
+	metadata, restMapping, restClient, strategy, err := k.prepareApply(u, namespaceOverride)
 	if err != nil {
 		return nil, err
 	}
@@ -168,12 +193,94 @@ func (k *KubeClient) ApplyWithNamespaceOverride(u *unstructured.Unstructured, na
 	return metadata, nil
 }
 
+// ServerSideApplyWithNamespaceOverride applies a given manifest by using server-side apply with the field manager
+// of the reconciler. The namespace handling is equal to ApplyWithNamespaceOverride.
+// If force is false, the apply fails with a conflict error if a field is owned by another field manager.
+// Otherwise the ownership of conflicting fields is taken over.
+func (k *KubeClient) ServerSideApplyWithNamespaceOverride(u *unstructured.Unstructured, namespaceOverride string, force bool) (*Metadata, error) {
+	metadata, restMapping, _, strategy, err := k.prepareApply(u, namespaceOverride)
+	if err != nil {
+		return nil, err
+	}
+
+	if strategy == SkipUpdateStrategy {
+		return metadata, nil
+	}
+
+	data, err := u.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	patchOptions := metav1.PatchOptions{
+		FieldManager: FieldManager,
+		Force:        &force,
+	}
+	if restMapping.Scope.Name() == meta.RESTScopeNameNamespace {
+		_, err = k.dynamicClient.
+			Resource(restMapping.Resource).
+			Namespace(u.GetNamespace()).
+			Patch(k.context(), u.GetName(), types.ApplyPatchType, data, patchOptions)
+	} else {
+		_, err = k.dynamicClient.
+			Resource(restMapping.Resource).
+			Patch(k.context(), u.GetName(), types.ApplyPatchType, data, patchOptions)
+	}
+
+	if err != nil {
+		if k8serr.IsConflict(err) {
+			return nil, errors.Wrapf(err, "server-side apply of %s '%s' (namespace '%s') conflicts with fields "+
+				"owned by other field managers", metadata.Kind, metadata.Name, metadata.Namespace)
+		}
+		return nil, err
+	}
+
+	return metadata, nil
+}
+
+func (k *KubeClient) prepareApply(u *unstructured.Unstructured, namespaceOverride string) (*Metadata, *meta.RESTMapping, rest.Interface, UpdateStrategy, error) {
+	gvk := u.GroupVersionKind()
+	metadata := &Metadata{
+		Kind: gvk.Kind,
+		Name: u.GetName(),
+	}
+
+	restMapping, err := k.restMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return nil, nil, nil, "", err
+	}
+
+	//don't modify the shared rest config: resources are applied concurrently
+	restClient, err := newRestClient(*k.config, gvk.GroupVersion())
+	if err != nil {
+		return nil, nil, nil, "", err
+	}
+
+	helper := resource.NewHelper(restClient, restMapping)
+
+	setDefaultNamespaceIfScopedAndNoneSet(namespaceOverride, u, helper)
+	setNamespaceIfScoped(namespaceOverride, u, helper)
+	metadata.Namespace = u.GetNamespace()
+
+	updateStrategyResolver := newDefaultUpdateStrategyResolver(helper)
+	strategy, err := updateStrategyResolver.Resolve(u)
+	if err != nil {
+		return nil, nil, nil, "", err
+	}
+
+	return metadata, restMapping, restClient, strategy, nil
+}
+
+func (k *KubeClient) DynamicClient() dynamic.Interface {
+	return k.dynamicClient
+}
+
 func (k *KubeClient) GetClientSet() (*kubernetes.Clientset, error) {
 	return kubernetes.NewForConfig(k.config)
 }
 
 func (k *KubeClient) DeleteResourceByKindAndNameAndNamespace(kind, name, namespace string, do metav1.DeleteOptions) (*Metadata, error) {
-	gvk, err := k.mapper.KindFor(schema.GroupVersionResource{
+	gvk, err := k.kindFor(schema.GroupVersionResource{
 		Resource: kind,
 	})
 	if err != nil {
@@ -186,7 +293,7 @@ func (k *KubeClient) DeleteResourceByKindAndNameAndNamespace(kind, name, namespa
 		namespace = "default"
 	}
 
-	restMapping, err := k.mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	restMapping, err := k.restMapping(gvk.GroupKind(), gvk.Version)
 	if err != nil {
 		return nil, err
 	}
@@ -202,11 +309,11 @@ func (k *KubeClient) DeleteResourceByKindAndNameAndNamespace(kind, name, namespa
 		err = k.dynamicClient.
 			Resource(restMapping.Resource).
 			Namespace(namespace).
-			Delete(context.TODO(), name, do)
+			Delete(k.context(), name, do)
 	} else {
 		err = k.dynamicClient.
 			Resource(restMapping.Resource).
-			Delete(context.TODO(), name, do)
+			Delete(k.context(), name, do)
 	}
 
 	//return deleted resource
@@ -223,12 +330,12 @@ func (k *KubeClient) DeleteResourceByKindAndNameAndNamespace(kind, name, namespa
 // Get a manifest by resource/kind (example: 'pods' or 'pod'),
 // name (example: 'my-pod'), and namespace (example: 'my-namespace').
 func (k *KubeClient) Get(kind, name, namespace string) (*unstructured.Unstructured, error) {
-	gvk, err := k.mapper.KindFor(schema.GroupVersionResource{Resource: kind})
+	gvk, err := k.kindFor(schema.GroupVersionResource{Resource: kind})
 	if err != nil {
 		return nil, err
 	}
 
-	restMapping, err := k.mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	restMapping, err := k.restMapping(gvk.GroupKind(), gvk.Version)
 	if err != nil {
 		return nil, err
 	}
@@ -245,11 +352,11 @@ func (k *KubeClient) Get(kind, name, namespace string) (*unstructured.Unstructur
 		u, err = k.dynamicClient.
 			Resource(restMapping.Resource).
 			Namespace(namespace).
-			Get(context.TODO(), name, metav1.GetOptions{})
+			Get(k.context(), name, metav1.GetOptions{})
 	} else {
 		u, err = k.dynamicClient.
 			Resource(restMapping.Resource).
-			Get(context.TODO(), name, metav1.GetOptions{})
+			Get(k.context(), name, metav1.GetOptions{})
 	}
 
 	return u, err
@@ -257,11 +364,11 @@ func (k *KubeClient) Get(kind, name, namespace string) (*unstructured.Unstructur
 
 // ListResource lists all resources by their kind or resource (e.g. "replicaset" or "replicasets").
 func (k *KubeClient) ListResource(resource string, lo metav1.ListOptions) (*unstructured.UnstructuredList, error) {
-	gvr, err := k.mapper.ResourceFor(schema.GroupVersionResource{Resource: resource})
+	gvr, err := k.resourceFor(schema.GroupVersionResource{Resource: resource})
 	if err != nil {
 		return nil, err
 	}
-	return k.dynamicClient.Resource(gvr).List(context.TODO(), lo)
+	return k.dynamicClient.Resource(gvr).List(k.context(), lo)
 }
 
 func (k *KubeClient) Patch(kind, name, namespace string, p []byte) (*Metadata, *unstructured.Unstructured, error) {
@@ -270,12 +377,12 @@ func (k *KubeClient) Patch(kind, name, namespace string, p []byte) (*Metadata, *
 
 func (k *KubeClient) PatchUsingStrategy(kind, name, namespace string, p []byte, strategy types.PatchType) (*Metadata, *unstructured.Unstructured, error) {
 	metadata := &Metadata{}
-	gvk, err := k.mapper.KindFor(schema.GroupVersionResource{Resource: kind})
+	gvk, err := k.kindFor(schema.GroupVersionResource{Resource: kind})
 	if err != nil {
 		return metadata, nil, err
 	}
 
-	restMapping, err := k.mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	restMapping, err := k.restMapping(gvk.GroupKind(), gvk.Version)
 	if err != nil {
 		return metadata, nil, err
 	}
@@ -293,11 +400,11 @@ func (k *KubeClient) PatchUsingStrategy(kind, name, namespace string, p []byte,
 		u, err = k.dynamicClient.
 			Resource(restMapping.Resource).
 			Namespace(namespace).
-			Patch(context.TODO(), name, strategy, p, metav1.PatchOptions{})
+			Patch(k.context(), name, strategy, p, metav1.PatchOptions{})
 	} else {
 		u, err = k.dynamicClient.
 			Resource(restMapping.Resource).
-			Patch(context.TODO(), name, strategy, p, metav1.PatchOptions{})
+			Patch(k.context(), name, strategy, p, metav1.PatchOptions{})
 	}
 
 	if err != nil {
@@ -338,11 +445,50 @@ func (k *KubeClient) DeleteNamespace(namespace string) error {
 		namespaceRes := schema.GroupVersionResource{Version: "v1", Resource: "namespaces"}
 		err = k.dynamicClient.
 			Resource(namespaceRes).
-			Delete(context.TODO(), namespace, metav1.DeleteOptions{})
+			Delete(k.context(), namespace, metav1.DeleteOptions{})
 	}
 	return err
 }
 
+// restMapping resolves the REST mapping of a kind. The cached API discovery information is
+// refreshed if the kind is unknown (e.g. because its CRD was installed after the cache was filled).
+func (k *KubeClient) restMapping(gk schema.GroupKind, versions ...string) (*meta.RESTMapping, error) {
+	restMapping, err := k.mapper.RESTMapping(gk, versions...)
+	if meta.IsNoMatchError(err) {
+		k.mapper.Reset()
+		restMapping, err = k.mapper.RESTMapping(gk, versions...)
+	}
+	return restMapping, err
+}
+
+func (k *KubeClient) kindFor(resource schema.GroupVersionResource) (schema.GroupVersionKind, error) {
+	gvk, err := k.mapper.KindFor(resource)
+	if meta.IsNoMatchError(err) {
+		k.mapper.Reset()
+		gvk, err = k.mapper.KindFor(resource)
+	}
+	return gvk, err
+}
+
+func (k *KubeClient) resourceFor(resource schema.GroupVersionResource) (schema.GroupVersionResource, error) {
+	gvr, err := k.mapper.ResourceFor(resource)
+	if meta.IsNoMatchError(err) {
+		k.mapper.Reset()
+		gvr, err = k.mapper.ResourceFor(resource)
+	}
+	return gvr, err
+}
+
+// RESTMapping returns the REST mapping of a kind (e.g. to resolve its resource name and scope)
+func (k *KubeClient) RESTMapping(gvk schema.GroupVersionKind) (*meta.RESTMapping, error) {
+	return k.restMapping(gvk.GroupKind(), gvk.Version)
+}
+
+// ResetRESTMapper invalidates the cached API discovery information (required after new CRDs were installed)
+func (k *KubeClient) ResetRESTMapper() {
+	k.mapper.Reset()
+}
+
 func (k *KubeClient) GetHost() string {
 	if k.config == nil {
 		return ""
@@ -1,9 +1,16 @@
 package internal
 
 import (
+	"context"
+	"net/http"
+
 	"go.uber.org/zap"
+	utilnet "k8s.io/apimachinery/pkg/util/net"
+	"k8s.io/client-go/rest"
 )
 
+type warningLoggerKey struct{}
+
 type loggingWarningHandler struct {
 	logger *zap.SugaredLogger
 }
@@ -17,3 +24,53 @@ func (lwh *loggingWarningHandler) HandleWarningHeader(code int, agent string, te
 
 	lwh.logger.Warn(text)
 }
+
+// WithWarningLogger returns a context whose requests log the warnings of the API server with the given logger
+func WithWarningLogger(ctx context.Context, logger *zap.SugaredLogger) context.Context {
+	if logger == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, warningLoggerKey{}, logger)
+}
+
+//warningTransport passes the warnings of the API server to the logger of the request context. Requests without
+//logger fall back to the warning handler of the REST config. This allows sharing clients between tasks which
+//log with different loggers.
+type warningTransport struct {
+	delegate http.RoundTripper
+	fallback rest.WarningHandler
+}
+
+func (wt *warningTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := wt.delegate.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+	handler := wt.fallback
+	if logger, ok := req.Context().Value(warningLoggerKey{}).(*zap.SugaredLogger); ok {
+		handler = &loggingWarningHandler{logger: logger}
+	}
+	if handler == nil {
+		return resp, nil
+	}
+	warnings, _ := utilnet.ParseWarningHeaders(resp.Header["Warning"])
+	for _, warning := range warnings {
+		handler.HandleWarningHeader(warning.Code, warning.Agent, warning.Text)
+	}
+	return resp, nil
+}
+
+func (wt *warningTransport) WrappedRoundTripper() http.RoundTripper {
+	return wt.delegate
+}
+
+//withWarningTransport moves the warning handler of the REST config into the transport
+func withWarningTransport(config *rest.Config) *rest.Config {
+	config = rest.CopyConfig(config)
+	fallback := config.WarningHandler
+	config.WarningHandler = rest.NoWarnings{}
+	config.Wrap(func(rt http.RoundTripper) http.RoundTripper {
+		return &warningTransport{delegate: rt, fallback: fallback}
+	})
+	return config
+}
@@ -1,13 +1,18 @@
 package internal
 
 import (
+	"context"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 	"go.uber.org/zap/zaptest/observer"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
 )
 
 func TestLoggingWarningHandler(t *testing.T) {
@@ -28,3 +33,44 @@ func TestLoggingWarningHandler(t *testing.T) {
 		require.Len(t, logs, 0)
 	})
 }
+
+func TestKubeClientWithLogger(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Warning", `299 - "deprecated API"`)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"test","namespace":"default"}}`))
+	}))
+	defer server.Close()
+
+	baseCore, baseRecorded := observer.New(zapcore.WarnLevel)
+	base, err := newForConfig(&rest.Config{
+		Host:           server.URL,
+		WarningHandler: &loggingWarningHandler{logger: zap.New(baseCore).Sugar()},
+	})
+	require.NoError(t, err)
+
+	taskCore, taskRecorded := observer.New(zapcore.WarnLevel)
+	kubeClient := base.WithLogger(zap.New(taskCore).Sugar())
+	require.Same(t, base.mapper, kubeClient.mapper) //API discovery information is shared
+	require.Same(t, base.helmClient, kubeClient.helmClient)
+	require.Equal(t, base.dynamicClient, kubeClient.dynamicClient)
+
+	configMaps := kubeClient.DynamicClient().
+		Resource(schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}).
+		Namespace("default")
+
+	//requests with the logger of the task in their context
+	_, err = configMaps.Get(WithWarningLogger(context.Background(), kubeClient.logger), "test", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Len(t, taskRecorded.All(), 1)
+	require.Equal(t, "deprecated API", taskRecorded.All()[0].Message)
+	require.Empty(t, baseRecorded.All())
+
+	//requests without logger fall back to the warning handler of the REST config
+	_, err = configMaps.Get(context.Background(), "test", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Len(t, taskRecorded.All(), 1)
+	require.Len(t, baseRecorded.All(), 1)
+
+	require.NoError(t, kubeClient.Close())
+}
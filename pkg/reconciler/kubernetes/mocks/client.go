@@ -15,6 +15,8 @@ import (
 
 	mock "github.com/stretchr/testify/mock"
 
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+
 	reconcilerkubernetes "github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes"
 
 	types "k8s.io/apimachinery/pkg/types"
@@ -52,13 +54,20 @@ func (_m *Client) Clientset() (kubernetes.Interface, error) {
 	return r0, r1
 }
 
-// Delete provides a mock function with given fields: ctx, manifest, namespace
-func (_m *Client) Delete(ctx context.Context, manifest string, namespace string) ([]*reconcilerkubernetes.Resource, error) {
-	ret := _m.Called(ctx, manifest, namespace)
+// Delete provides a mock function with given fields: ctx, manifest, namespace, opts
+func (_m *Client) Delete(ctx context.Context, manifest string, namespace string, opts ...reconcilerkubernetes.DeleteOption) ([]*reconcilerkubernetes.Resource, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, manifest, namespace)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
 
 	var r0 []*reconcilerkubernetes.Resource
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) []*reconcilerkubernetes.Resource); ok {
-		r0 = rf(ctx, manifest, namespace)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, ...reconcilerkubernetes.DeleteOption) []*reconcilerkubernetes.Resource); ok {
+		r0 = rf(ctx, manifest, namespace, opts...)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*reconcilerkubernetes.Resource)
@@ -66,8 +75,8 @@ func (_m *Client) Delete(ctx context.Context, manifest string, namespace string)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = rf(ctx, manifest, namespace)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, ...reconcilerkubernetes.DeleteOption) error); ok {
+		r1 = rf(ctx, manifest, namespace, opts...)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -211,6 +220,29 @@ func (_m *Client) GetPod(ctx context.Context, name string, namespace string) (*c
 	return r0, r1
 }
 
+// GetResource provides a mock function with given fields: ctx, gvk, name, namespace
+func (_m *Client) GetResource(ctx context.Context, gvk schema.GroupVersionKind, name string, namespace string) (*unstructured.Unstructured, error) {
+	ret := _m.Called(ctx, gvk, name, namespace)
+
+	var r0 *unstructured.Unstructured
+	if rf, ok := ret.Get(0).(func(context.Context, schema.GroupVersionKind, string, string) *unstructured.Unstructured); ok {
+		r0 = rf(ctx, gvk, name, namespace)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*unstructured.Unstructured)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, schema.GroupVersionKind, string, string) error); ok {
+		r1 = rf(ctx, gvk, name, namespace)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetSecret provides a mock function with given fields: ctx, name, namespace
 func (_m *Client) GetSecret(ctx context.Context, name string, namespace string) (*corev1.Secret, error) {
 	ret := _m.Called(ctx, name, namespace)
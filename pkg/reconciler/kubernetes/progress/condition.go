@@ -0,0 +1,163 @@
+package progress
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/util/jsonpath"
+	"sigs.k8s.io/yaml"
+)
+
+const defaultConditionStatus = "True"
+
+var (
+	crdCheck = &ConditionCheck{
+		GVK:           schema.GroupVersionKind{Group: "apiextensions.k8s.io", Version: "v1", Kind: "CustomResourceDefinition"},
+		ConditionType: "Established",
+	}
+	apiServiceCheck = &ConditionCheck{
+		GVK:           schema.GroupVersionKind{Group: "apiregistration.k8s.io", Version: "v1", Kind: "APIService"},
+		ConditionType: "Available",
+	}
+)
+
+// ConditionCheck verifies the readiness of a resource (typically a custom resource) by evaluating
+// either one of its status conditions or a JSONPath expression.
+type ConditionCheck struct {
+	GVK             schema.GroupVersionKind
+	Resource        string //plural resource name (e.g. 'gateways'), is guessed from the kind if undefined
+	ConditionType   string //type of the status condition which indicates readiness (e.g. 'Ready')
+	ConditionStatus string //expected status of the condition (default is 'True')
+	JSONPath        string //alternative to a condition: JSONPath expression (e.g. '{.status.phase}')
+	Value           string //expected result of the JSONPath expression (any non-empty result is accepted if undefined)
+}
+
+//conditionCheckSpec is the format of a readiness check in a readiness checks file
+type conditionCheckSpec struct {
+	APIVersion      string `json:"apiVersion"`
+	Kind            string `json:"kind"`
+	Resource        string `json:"resource,omitempty"`
+	ConditionType   string `json:"conditionType,omitempty"`
+	ConditionStatus string `json:"conditionStatus,omitempty"`
+	JSONPath        string `json:"jsonPath,omitempty"`
+	Value           string `json:"value,omitempty"`
+}
+
+// LoadConditionChecks reads the readiness checks defined in a YAML or JSON file (a list of checks with the fields
+// 'apiVersion', 'kind', 'resource', 'conditionType', 'conditionStatus', 'jsonPath' and 'value')
+func LoadConditionChecks(file string) ([]*ConditionCheck, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read readiness checks file '%s'", file)
+	}
+	var specs []conditionCheckSpec
+	if err := yaml.UnmarshalStrict(data, &specs); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse readiness checks file '%s'", file)
+	}
+
+	checks := make([]*ConditionCheck, 0, len(specs))
+	for _, spec := range specs {
+		gv, err := schema.ParseGroupVersion(spec.APIVersion)
+		if err != nil {
+			return nil, errors.Wrapf(err, "API version of readiness check for kind '%s' is invalid", spec.Kind)
+		}
+		check := &ConditionCheck{
+			GVK:             gv.WithKind(spec.Kind),
+			Resource:        spec.Resource,
+			ConditionType:   spec.ConditionType,
+			ConditionStatus: spec.ConditionStatus,
+			JSONPath:        spec.JSONPath,
+			Value:           spec.Value,
+		}
+		if err := check.validate(); err != nil {
+			return nil, err
+		}
+		checks = append(checks, check)
+	}
+	return checks, nil
+}
+
+func (c *ConditionCheck) validate() error {
+	if c.GVK.Kind == "" || c.GVK.Version == "" {
+		return fmt.Errorf("readiness check requires a kind and a version (got '%s')", c.GVK)
+	}
+	if (c.ConditionType == "") == (c.JSONPath == "") {
+		return fmt.Errorf("readiness check of '%s' requires either a condition type or a JSONPath expression", c.GVK)
+	}
+	if c.JSONPath != "" {
+		if err := jsonpath.New(c.GVK.Kind).Parse(c.JSONPath); err != nil {
+			return errors.Wrapf(err, "JSONPath expression of readiness check for '%s' is invalid", c.GVK)
+		}
+	}
+	return nil
+}
+
+func (c *ConditionCheck) groupVersionResource() schema.GroupVersionResource {
+	if c.Resource != "" {
+		return c.GVK.GroupVersion().WithResource(c.Resource)
+	}
+	return c.GVK.GroupVersion().WithResource(guessResource(c.GVK.Kind))
+}
+
+//guessResource derives the plural resource name from a kind (e.g. 'Gateway' => 'gateways', 'Policy' => 'policies')
+func guessResource(kind string) string {
+	resource := strings.ToLower(kind)
+	switch {
+	case resource == "":
+		return resource
+	case strings.HasSuffix(resource, "y") && !strings.ContainsAny(resource[len(resource)-2:len(resource)-1], "aeiou"):
+		return resource[:len(resource)-1] + "ies"
+	case strings.HasSuffix(resource, "s"), strings.HasSuffix(resource, "x"),
+		strings.HasSuffix(resource, "ch"), strings.HasSuffix(resource, "sh"):
+		return resource + "es"
+	default:
+		return resource + "s"
+	}
+}
+
+func (c *ConditionCheck) isReady(u *unstructured.Unstructured) (bool, error) {
+	if c.JSONPath != "" {
+		return c.isJSONPathMatching(u)
+	}
+	return c.isConditionMatching(u)
+}
+
+func (c *ConditionCheck) isConditionMatching(u *unstructured.Unstructured) (bool, error) {
+	expectedStatus := c.ConditionStatus
+	if expectedStatus == "" {
+		expectedStatus = defaultConditionStatus
+	}
+	conditions, _, err := unstructured.NestedSlice(u.Object, "status", "conditions")
+	if err != nil {
+		return false, err
+	}
+	for _, condition := range conditions {
+		condMap, ok := condition.(map[string]interface{})
+		if !ok || condMap["type"] != c.ConditionType {
+			continue
+		}
+		return fmt.Sprint(condMap["status"]) == expectedStatus, nil
+	}
+	return false, nil
+}
+
+func (c *ConditionCheck) isJSONPathMatching(u *unstructured.Unstructured) (bool, error) {
+	jp := jsonpath.New(c.GVK.Kind).AllowMissingKeys(true)
+	if err := jp.Parse(c.JSONPath); err != nil {
+		return false, err
+	}
+	buf := &bytes.Buffer{}
+	if err := jp.Execute(buf, u.Object); err != nil {
+		return false, err
+	}
+	result := strings.TrimSpace(buf.String())
+	if c.Value == "" {
+		return result != "", nil
+	}
+	return result == c.Value, nil
+}
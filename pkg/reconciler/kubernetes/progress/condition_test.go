@@ -0,0 +1,163 @@
+package progress
+
+import (
+	"context"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	log "github.com/kyma-incubator/reconciler/pkg/logger"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+var gatewayGVK = schema.GroupVersionKind{Group: "networking.istio.io", Version: "v1alpha3", Kind: "Gateway"}
+
+func newUnstruct(gvk schema.GroupVersionKind, namespace, name string, status map[string]interface{}) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{Object: map[string]interface{}{"status": status}}
+	u.SetGroupVersionKind(gvk)
+	u.SetNamespace(namespace)
+	u.SetName(name)
+	return u
+}
+
+func TestConditionCheck(t *testing.T) {
+	t.Run("Validate condition check", func(t *testing.T) {
+		require.Error(t, (&ConditionCheck{ConditionType: "Ready"}).validate())
+		require.Error(t, (&ConditionCheck{GVK: gatewayGVK}).validate())
+		require.Error(t, (&ConditionCheck{GVK: gatewayGVK, ConditionType: "Ready", JSONPath: "{.status.phase}"}).validate())
+		require.Error(t, (&ConditionCheck{GVK: gatewayGVK, JSONPath: "{.status.phase"}).validate())
+		require.NoError(t, (&ConditionCheck{GVK: gatewayGVK, ConditionType: "Ready"}).validate())
+		require.NoError(t, (&ConditionCheck{GVK: gatewayGVK, JSONPath: "{.status.phase}"}).validate())
+	})
+
+	t.Run("Check status condition", func(t *testing.T) {
+		check := &ConditionCheck{GVK: gatewayGVK, ConditionType: "Ready"}
+		ready, err := check.isReady(newUnstruct(gatewayGVK, "ns", "gw", map[string]interface{}{}))
+		require.NoError(t, err)
+		require.False(t, ready)
+
+		ready, err = check.isReady(newUnstruct(gatewayGVK, "ns", "gw", map[string]interface{}{
+			"conditions": []interface{}{map[string]interface{}{"type": "Ready", "status": "True"}},
+		}))
+		require.NoError(t, err)
+		require.True(t, ready)
+
+		check.ConditionStatus = "False"
+		ready, err = check.isReady(newUnstruct(gatewayGVK, "ns", "gw", map[string]interface{}{
+			"conditions": []interface{}{map[string]interface{}{"type": "Ready", "status": "True"}},
+		}))
+		require.NoError(t, err)
+		require.False(t, ready)
+	})
+
+	t.Run("Check JSONPath expression", func(t *testing.T) {
+		check := &ConditionCheck{GVK: gatewayGVK, JSONPath: "{.status.phase}", Value: "Running"}
+		ready, err := check.isReady(newUnstruct(gatewayGVK, "ns", "gw", map[string]interface{}{"phase": "Pending"}))
+		require.NoError(t, err)
+		require.False(t, ready)
+
+		ready, err = check.isReady(newUnstruct(gatewayGVK, "ns", "gw", map[string]interface{}{"phase": "Running"}))
+		require.NoError(t, err)
+		require.True(t, ready)
+
+		check.Value = ""
+		ready, err = check.isReady(newUnstruct(gatewayGVK, "ns", "gw", map[string]interface{}{}))
+		require.NoError(t, err)
+		require.False(t, ready)
+	})
+
+	t.Run("Resource is guessed from kind", func(t *testing.T) {
+		require.Equal(t, "gateways", (&ConditionCheck{GVK: gatewayGVK}).groupVersionResource().Resource)
+		require.Equal(t, "gws", (&ConditionCheck{GVK: gatewayGVK, Resource: "gws"}).groupVersionResource().Resource)
+		require.Equal(t, "authorizationpolicies", guessResource("AuthorizationPolicy"))
+		require.Equal(t, "ingresses", guessResource("Ingress"))
+		require.Equal(t, "oauth2clients", guessResource("OAuth2Client"))
+	})
+
+	t.Run("Load condition checks from file", func(t *testing.T) {
+		dir := t.TempDir()
+		checksFile := filepath.Join(dir, "checks.yaml")
+		require.NoError(t, ioutil.WriteFile(checksFile, []byte(`
+- apiVersion: networking.istio.io/v1alpha3
+  kind: Gateway
+  conditionType: Ready
+- apiVersion: example.com/v1
+  kind: Backup
+  resource: backups
+  jsonPath: '{.status.phase}'
+  value: Completed
+`), 0600))
+		checks, err := LoadConditionChecks(checksFile)
+		require.NoError(t, err)
+		require.Equal(t, []*ConditionCheck{
+			{GVK: gatewayGVK, ConditionType: "Ready"},
+			{
+				GVK:      schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Backup"},
+				Resource: "backups",
+				JSONPath: "{.status.phase}",
+				Value:    "Completed",
+			},
+		}, checks)
+
+		for name, content := range map[string]string{
+			"nocheck.yaml": "- apiVersion: v1\n  kind: Pod\n",
+			"unknown.yaml": "- apiVersion: v1\n  kind: Pod\n  conditionType: Ready\n  unknown: true\n",
+		} {
+			file := filepath.Join(dir, name)
+			require.NoError(t, ioutil.WriteFile(file, []byte(content), 0600))
+			_, err := LoadConditionChecks(file)
+			require.Error(t, err, name)
+		}
+	})
+}
+
+func TestTrackerWithConditionChecks(t *testing.T) {
+	crdGVK := crdCheck.GVK
+	crd := newUnstruct(crdGVK, "", "gateways.networking.istio.io", map[string]interface{}{
+		"conditions": []interface{}{map[string]interface{}{"type": "Established", "status": "True"}},
+	})
+	gateway := newUnstruct(gatewayGVK, "istio-system", "kyma-gateway", map[string]interface{}{
+		"conditions": []interface{}{map[string]interface{}{"type": "Ready", "status": "False"}},
+	})
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{
+			crdCheck.groupVersionResource():                                              "CustomResourceDefinitionList",
+			{Group: gatewayGVK.Group, Version: gatewayGVK.Version, Resource: "gateways"}: "GatewayList",
+		}, crd)
+	gatewayGVR := (&ConditionCheck{GVK: gatewayGVK}).groupVersionResource()
+	_, err := dynamicClient.Resource(gatewayGVR).Namespace(gateway.GetNamespace()).
+		Create(context.Background(), gateway, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	pt, err := NewProgressTracker(fake.NewSimpleClientset(), log.NewLogger(true), Config{
+		ConditionChecks: []*ConditionCheck{{GVK: gatewayGVK, ConditionType: "Ready"}},
+	})
+	require.NoError(t, err)
+	pt.WithDynamicClient(dynamicClient)
+
+	pt.AddResource(CustomResourceDefinition, "default", crd.GetName()) //namespace is ignored for CRDs
+	require.True(t, pt.AddCustomResource(gatewayGVK, gateway.GetNamespace(), gateway.GetName()))
+	require.False(t, pt.AddCustomResource(schema.GroupVersionKind{Group: "a", Version: "v1", Kind: "B"}, "ns", "name"))
+
+	pending, err := pt.pendingObjects(context.Background(), ReadyState, pt.objects)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	require.Equal(t, gateway.GetName(), pending[0].name)
+
+	require.NoError(t, unstructured.SetNestedSlice(gateway.Object, []interface{}{
+		map[string]interface{}{"type": "Ready", "status": "True"},
+	}, "status", "conditions"))
+	_, err = dynamicClient.Resource(gatewayGVR).
+		Namespace(gateway.GetNamespace()).Update(context.Background(), gateway, metav1.UpdateOptions{})
+	require.NoError(t, err)
+
+	pending, err = pt.pendingObjects(context.Background(), ReadyState, pt.objects)
+	require.NoError(t, err)
+	require.Empty(t, pending)
+}
@@ -4,7 +4,9 @@ import (
 	"context"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 	appsclient "k8s.io/client-go/kubernetes/typed/apps/v1"
@@ -99,6 +101,63 @@ func isJobReady(ctx context.Context, client kubernetes.Interface, object *resour
 	return true, err
 }
 
+func isPersistentVolumeClaimReady(ctx context.Context, client kubernetes.Interface, object *resource) (bool, error) {
+	pvc, err := client.CoreV1().PersistentVolumeClaims(object.namespace).Get(ctx, object.name, metav1.GetOptions{})
+	if err != nil {
+		return false, err
+	}
+	switch pvc.Status.Phase {
+	case corev1.ClaimBound:
+		return true, nil
+	case corev1.ClaimPending:
+		//volumes of storage classes with binding mode 'WaitForFirstConsumer' are bound when the first pod uses the claim
+		return isWaitingForFirstConsumer(ctx, client, pvc)
+	default:
+		return false, nil
+	}
+}
+
+func isWaitingForFirstConsumer(ctx context.Context, client kubernetes.Interface, pvc *corev1.PersistentVolumeClaim) (bool, error) {
+	var storageClass *storagev1.StorageClass
+	if pvc.Spec.StorageClassName == nil {
+		storageClasses, err := client.StorageV1().StorageClasses().List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return false, err
+		}
+		for idx := range storageClasses.Items {
+			if isDefaultStorageClass(&storageClasses.Items[idx]) {
+				storageClass = &storageClasses.Items[idx]
+				break
+			}
+		}
+	} else if *pvc.Spec.StorageClassName != "" {
+		var err error
+		storageClass, err = client.StorageV1().StorageClasses().Get(ctx, *pvc.Spec.StorageClassName, metav1.GetOptions{})
+		if err != nil && !k8serr.IsNotFound(err) {
+			return false, err
+		}
+	}
+	return storageClass != nil && storageClass.VolumeBindingMode != nil &&
+		*storageClass.VolumeBindingMode == storagev1.VolumeBindingWaitForFirstConsumer, nil
+}
+
+func isDefaultStorageClass(storageClass *storagev1.StorageClass) bool {
+	return storageClass.Annotations["storageclass.kubernetes.io/is-default-class"] == "true" ||
+		storageClass.Annotations["storageclass.beta.kubernetes.io/is-default-class"] == "true"
+}
+
+func isServiceReady(ctx context.Context, client kubernetes.Interface, object *resource) (bool, error) {
+	service, err := client.CoreV1().Services(object.namespace).Get(ctx, object.name, metav1.GetOptions{})
+	if err != nil {
+		return false, err
+	}
+	if service.Spec.Type != corev1.ServiceTypeLoadBalancer {
+		return true, nil
+	}
+	//load balancer services are ready when an ingress was assigned
+	return len(service.Status.LoadBalancer.Ingress) > 0, nil
+}
+
 func getLatestReplicaSet(ctx context.Context, deployment *appsv1.Deployment, client appsclient.AppsV1Interface) (*appsv1.ReplicaSet, error) {
 	selector, err := metav1.LabelSelectorAsSelector(deployment.Spec.Selector)
 	if err != nil {
@@ -5,6 +5,7 @@ import (
 	"github.com/stretchr/testify/require"
 	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes/fake"
@@ -260,3 +261,88 @@ func TestIsDaemonSetReady(t *testing.T) {
 		})
 	}
 }
+
+func TestIsPersistentVolumeClaimReady(t *testing.T) {
+	pvc := &v1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: "pvc", Namespace: "kyma-system"},
+		Status:     v1.PersistentVolumeClaimStatus{Phase: v1.ClaimPending},
+	}
+	client := fake.NewSimpleClientset(pvc)
+	object := &resource{kind: PersistentVolumeClaim, name: "pvc", namespace: "kyma-system"}
+
+	ready, err := isPersistentVolumeClaimReady(context.Background(), client, object)
+	require.NoError(t, err)
+	require.False(t, ready)
+
+	pvc.Status.Phase = v1.ClaimBound
+	_, err = client.CoreV1().PersistentVolumeClaims("kyma-system").Update(context.Background(), pvc, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	ready, err = isPersistentVolumeClaimReady(context.Background(), client, object)
+	require.NoError(t, err)
+	require.True(t, ready)
+}
+
+func TestIsPersistentVolumeClaimWaitingForFirstConsumer(t *testing.T) {
+	waitForFirstConsumer := storagev1.VolumeBindingWaitForFirstConsumer
+	immediate := storagev1.VolumeBindingImmediate
+	newPVC := func(name string, storageClassName *string) *v1.PersistentVolumeClaim {
+		return &v1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "kyma-system"},
+			Spec:       v1.PersistentVolumeClaimSpec{StorageClassName: storageClassName},
+			Status:     v1.PersistentVolumeClaimStatus{Phase: v1.ClaimPending},
+		}
+	}
+	lazyClass, immediateClass, noClass := "lazy", "immediate", ""
+	client := fake.NewSimpleClientset(
+		&storagev1.StorageClass{
+			ObjectMeta:        metav1.ObjectMeta{Name: lazyClass},
+			VolumeBindingMode: &waitForFirstConsumer,
+		},
+		&storagev1.StorageClass{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        immediateClass,
+				Annotations: map[string]string{"storageclass.kubernetes.io/is-default-class": "true"},
+			},
+			VolumeBindingMode: &immediate,
+		},
+		newPVC("lazy", &lazyClass),
+		newPVC("immediate", &immediateClass),
+		newPVC("default", nil),
+		newPVC("static", &noClass),
+	)
+
+	for name, expected := range map[string]bool{"lazy": true, "immediate": false, "default": false, "static": false} {
+		ready, err := isPersistentVolumeClaimReady(context.Background(), client,
+			&resource{kind: PersistentVolumeClaim, name: name, namespace: "kyma-system"})
+		require.NoError(t, err)
+		require.Equal(t, expected, ready, name)
+	}
+}
+
+func TestIsServiceReady(t *testing.T) {
+	clusterIPSvc := &v1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "clusterip", Namespace: "kyma-system"},
+		Spec:       v1.ServiceSpec{Type: v1.ServiceTypeClusterIP},
+	}
+	lbSvc := &v1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "lb", Namespace: "kyma-system"},
+		Spec:       v1.ServiceSpec{Type: v1.ServiceTypeLoadBalancer},
+	}
+	client := fake.NewSimpleClientset(clusterIPSvc, lbSvc)
+
+	ready, err := isServiceReady(context.Background(), client, &resource{kind: Service, name: "clusterip", namespace: "kyma-system"})
+	require.NoError(t, err)
+	require.True(t, ready)
+
+	lbObject := &resource{kind: Service, name: "lb", namespace: "kyma-system"}
+	ready, err = isServiceReady(context.Background(), client, lbObject)
+	require.NoError(t, err)
+	require.False(t, ready)
+
+	lbSvc.Status.LoadBalancer.Ingress = []v1.LoadBalancerIngress{{IP: "10.0.0.1"}}
+	_, err = client.CoreV1().Services("kyma-system").UpdateStatus(context.Background(), lbSvc, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	ready, err = isServiceReady(context.Background(), client, lbObject)
+	require.NoError(t, err)
+	require.True(t, ready)
+}
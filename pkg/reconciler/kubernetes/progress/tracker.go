@@ -5,10 +5,14 @@ import (
 	"fmt"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"strings"
 	"time"
 
 	e "github.com/kyma-incubator/reconciler/pkg/error"
 	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 )
 
@@ -24,6 +28,7 @@ type State string
 
 type resource struct {
 	kind      WatchableResource
+	gvk       schema.GroupVersionKind //only set for custom resources
 	name      string
 	namespace string
 }
@@ -33,8 +38,9 @@ func (o *resource) String() string {
 }
 
 type Config struct {
-	Interval time.Duration
-	Timeout  time.Duration
+	Interval        time.Duration
+	Timeout         time.Duration
+	ConditionChecks []*ConditionCheck //readiness checks of custom resources
 }
 
 func (ptc *Config) validate() error {
@@ -54,15 +60,22 @@ func (ptc *Config) validate() error {
 		return fmt.Errorf("progress tracker will never run because configured timeout "+
 			"is <= as the check interval :%.0f secs <= %.0f secs", ptc.Timeout.Seconds(), ptc.Interval.Seconds())
 	}
+	for _, check := range ptc.ConditionChecks {
+		if err := check.validate(); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
 type Tracker struct {
-	objects  []*resource
-	client   kubernetes.Interface
-	interval time.Duration
-	timeout  time.Duration
-	logger   *zap.SugaredLogger
+	objects         []*resource
+	client          kubernetes.Interface
+	dynamicClient   dynamic.Interface
+	conditionChecks map[schema.GroupVersionKind]*ConditionCheck
+	interval        time.Duration
+	timeout         time.Duration
+	logger          *zap.SugaredLogger
 }
 
 func NewProgressTracker(client kubernetes.Interface, logger *zap.SugaredLogger, config Config) (*Tracker, error) {
@@ -70,14 +83,29 @@ func NewProgressTracker(client kubernetes.Interface, logger *zap.SugaredLogger,
 		return nil, err
 	}
 
+	conditionChecks := make(map[schema.GroupVersionKind]*ConditionCheck, len(config.ConditionChecks))
+	for _, check := range config.ConditionChecks {
+		conditionChecks[check.GVK] = check
+	}
+
 	return &Tracker{
-		client:   client,
-		interval: config.Interval,
-		timeout:  config.Timeout,
-		logger:   logger,
+		client:          client,
+		conditionChecks: conditionChecks,
+		interval:        config.Interval,
+		timeout:         config.Timeout,
+		logger:          logger,
 	}, nil
 }
 
+// WithDynamicClient sets the client used to verify CRDs, APIServices and custom resources
+func (pt *Tracker) WithDynamicClient(dynamicClient dynamic.Interface) *Tracker {
+	pt.dynamicClient = dynamicClient
+	return pt
+}
+
+// Watch waits until all added resources reached the target state. The state of a resource is re-evaluated
+// whenever a watch event for it is received. Resources whose watch isn't running are polled in the configured
+// interval (fallback). All watches are stopped when the method returns.
 func (pt *Tracker) Watch(ctx context.Context, targetState State) error {
 	if len(pt.objects) == 0 { //check if any watchable resources were added
 		pt.logger.Debugf("No watchable resources defined: transition to state '%s' "+
@@ -86,32 +114,39 @@ func (pt *Tracker) Watch(ctx context.Context, targetState State) error {
 	}
 
 	//initial installation status check
-	inState, err := pt.allWatchableInState(ctx, targetState)
+	pending, err := pt.pendingObjects(ctx, targetState, pt.objects)
 	if err != nil {
 		pt.logger.Warnf("Failed to verify initial Kubernetes resource state: %v", err)
 	}
-	if inState {
+	if len(pending) == 0 {
 		//we are already done
 		pt.logger.Debugf("Watchable resources are already in target state '%s': no recurring checks triggered", targetState)
 		return nil
 	}
 
-	//start verifying the installation status in an interval
+	//start watches (they are torn down as soon as the transition is finished)
+	watchCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	watches := pt.startWatches(watchCtx, pending)
+
+	//re-evaluate resources when events were received and poll resources which aren't watched
 	timer := time.NewTicker(pt.interval)
+	defer timer.Stop()
 	timeout := time.After(pt.timeout)
 	for {
 		select {
+		case event := <-watches.events:
+			pending, err = pt.pendingObjects(ctx, targetState, pending, event.matches)
+			if err != nil {
+				pt.logger.Warnf("Failed to check progress of resource transition to state '%s' "+
+					"after watch event but will retry until timeout is reached: %s", targetState, err)
+			}
 		case <-timer.C:
-			inState, err := pt.allWatchableInState(ctx, targetState)
+			pending, err = pt.pendingObjects(ctx, targetState, pending, watches.isInactive)
 			if err != nil {
 				pt.logger.Warnf("Failed to check progress of resource transition to state '%s' "+
 					"but will retry until timeout is reached: %s", targetState, err)
 			}
-			if inState {
-				timer.Stop()
-				pt.logger.Debugf("Watchable resources reached target state '%s'", targetState)
-				return nil
-			}
 		case <-ctx.Done():
 			pt.logger.Debugf("Stop checking progress of resource transition to state '%s' "+
 				"because parent context got closed", targetState)
@@ -121,93 +156,184 @@ func (pt *Tracker) Watch(ctx context.Context, targetState State) error {
 			}
 		case <-timeout:
 			err := fmt.Errorf("progress tracker reached timeout (%.0f secs): "+
-				"stop checking progress of resource transition to state '%s'",
-				pt.timeout.Seconds(), targetState)
+				"stop checking progress of resource transition to state '%s' (resources not in target state: %s)",
+				pt.timeout.Seconds(), targetState, resourcesToString(pending))
 			pt.logger.Warn(err.Error())
 			return err
 		}
+		if len(pending) == 0 {
+			pt.logger.Debugf("Watchable resources reached target state '%s'", targetState)
+			return nil
+		}
 	}
 }
 
-func (pt *Tracker) AddResource(kind WatchableResource, namespace, name string) {
-	pt.objects = append(pt.objects, &resource{
-		kind:      kind,
-		namespace: namespace,
-		name:      name,
-	})
+// pendingObjects returns all objects which aren't in the target state. Only objects accepted
+// by all filters are re-evaluated, the remaining objects are treated as still pending.
+func (pt *Tracker) pendingObjects(ctx context.Context, targetState State, objects []*resource, filters ...func(*resource) bool) ([]*resource, error) {
+	var pending []*resource
+	var errs []string
+
+LoopObjects:
+	for _, object := range objects {
+		for _, filter := range filters {
+			if !filter(object) {
+				pending = append(pending, object)
+				continue LoopObjects
+			}
+		}
+		inState, err := pt.isInState(ctx, object, targetState)
+		if err != nil {
+			errs = append(errs, err.Error())
+		}
+		if !inState {
+			pending = append(pending, object)
+		}
+	}
+
+	if len(errs) > 0 {
+		return pending, fmt.Errorf("failed to verify state of resources: %s", strings.Join(errs, ", "))
+	}
+	return pending, nil
 }
 
-func (pt *Tracker) allWatchableInState(ctx context.Context, targetState State) (bool, error) {
+func (pt *Tracker) isInState(ctx context.Context, object *resource, targetState State) (bool, error) {
 	switch targetState {
 	case ReadyState:
-		return pt.isInReadyState(ctx)
+		ready, err := pt.isReady(ctx, object)
+		if err != nil {
+			pt.logger.Errorf("Failed to get resource of %v: %s", object, err)
+			return false, err
+		}
+		if !ready {
+			pt.logger.Debugf("Transition of %s to ready state is still ongoing", object.name)
+		}
+		return ready, nil
 	case TerminatedState:
-		return pt.isInTerminatedState(ctx)
+		terminated, err := pt.isTerminated(ctx, object)
+		if err != nil {
+			pt.logger.Errorf("Failed to get resource %v: %s", object, err)
+			return false, err
+		}
+		if !terminated {
+			pt.logger.Debugf("Termination of %s is still ongoing", object.name)
+		}
+		return terminated, nil
 	default:
 		return false, fmt.Errorf("state '%s' not supported", targetState)
 	}
 }
 
-func (pt *Tracker) isInReadyState(ctx context.Context) (bool, error) {
-	for _, object := range pt.objects {
-		var err error
-		ready := true
+func (pt *Tracker) AddResource(kind WatchableResource, namespace, name string) {
+	pt.objects = append(pt.objects, &resource{
+		kind:      kind,
+		namespace: namespace,
+		name:      name,
+	})
+}
 
-		switch object.kind {
-		case Pod:
-			ready, err = isPodReady(ctx, pt.client, object)
-		case Deployment:
-			ready, err = isDeploymentReady(ctx, pt.client, object)
-		case DaemonSet:
-			ready, err = isDaemonSetReady(ctx, pt.client, object)
-		case StatefulSet:
-			ready, err = isStatefulSetReady(ctx, pt.client, object)
-		case Job:
-			ready, err = isJobReady(ctx, pt.client, object)
-		}
+// AddCustomResource adds a custom resource to the tracker. It returns false if the resource
+// isn't watchable because no readiness check is configured for its kind.
+func (pt *Tracker) AddCustomResource(gvk schema.GroupVersionKind, namespace, name string) bool {
+	if _, ok := pt.conditionChecks[gvk]; !ok {
+		return false
+	}
+	pt.objects = append(pt.objects, &resource{
+		kind:      CustomResource,
+		gvk:       gvk,
+		namespace: namespace,
+		name:      name,
+	})
+	return true
+}
 
+func (pt *Tracker) isReady(ctx context.Context, object *resource) (bool, error) {
+	switch object.kind {
+	case Pod:
+		return isPodReady(ctx, pt.client, object)
+	case Deployment:
+		return isDeploymentReady(ctx, pt.client, object)
+	case DaemonSet:
+		return isDaemonSetReady(ctx, pt.client, object)
+	case StatefulSet:
+		return isStatefulSetReady(ctx, pt.client, object)
+	case Job:
+		return isJobReady(ctx, pt.client, object)
+	case PersistentVolumeClaim:
+		return isPersistentVolumeClaimReady(ctx, pt.client, object)
+	case Service:
+		return isServiceReady(ctx, pt.client, object)
+	case CustomResourceDefinition, APIService, CustomResource:
+		check := pt.conditionCheck(object)
+		u, err := pt.getUnstructured(ctx, check, object)
 		if err != nil {
-			pt.logger.Errorf("Failed to get resource of %v: %s", object, err)
 			return false, err
 		}
-		if !ready {
-			pt.logger.Debugf("Transition of %s to ready state is still ongoing", object.name)
-			return false, nil
-		}
+		return check.isReady(u)
+	default:
+		return true, nil
 	}
+}
 
-	pt.logger.Debug("All resources are ready")
-	return true, nil
+func (pt *Tracker) conditionCheck(object *resource) *ConditionCheck {
+	switch object.kind {
+	case CustomResourceDefinition:
+		return crdCheck
+	case APIService:
+		return apiServiceCheck
+	default:
+		return pt.conditionChecks[object.gvk]
+	}
+}
 
+func (pt *Tracker) getUnstructured(ctx context.Context, check *ConditionCheck, object *resource) (*unstructured.Unstructured, error) {
+	if pt.dynamicClient == nil {
+		return nil, fmt.Errorf("progress tracker has no dynamic client configured: cannot verify %v", object)
+	}
+	namespace := object.namespace
+	if object.kind != CustomResource { //CRDs and APIServices are cluster scoped
+		namespace = ""
+	}
+	return pt.dynamicClient.Resource(check.groupVersionResource()).
+		Namespace(namespace).
+		Get(ctx, object.name, metav1.GetOptions{})
 }
 
-func (pt *Tracker) isInTerminatedState(ctx context.Context) (bool, error) {
-	for _, object := range pt.objects {
-		var err error
+func (pt *Tracker) isTerminated(ctx context.Context, object *resource) (bool, error) {
+	var err error
 
-		switch object.kind {
-		case Pod:
-			_, err = pt.client.CoreV1().Pods(object.namespace).Get(ctx, object.name, metav1.GetOptions{})
-		case Deployment:
-			_, err = pt.client.AppsV1().Deployments(object.namespace).Get(ctx, object.name, metav1.GetOptions{})
-		case DaemonSet:
-			_, err = pt.client.AppsV1().DaemonSets(object.namespace).Get(ctx, object.name, metav1.GetOptions{})
-		case StatefulSet:
-			_, err = pt.client.AppsV1().StatefulSets(object.namespace).Get(ctx, object.name, metav1.GetOptions{})
-		case Job:
-			_, err = pt.client.BatchV1().Jobs(object.namespace).Get(ctx, object.name, metav1.GetOptions{})
-		}
+	switch object.kind {
+	case Pod:
+		_, err = pt.client.CoreV1().Pods(object.namespace).Get(ctx, object.name, metav1.GetOptions{})
+	case Deployment:
+		_, err = pt.client.AppsV1().Deployments(object.namespace).Get(ctx, object.name, metav1.GetOptions{})
+	case DaemonSet:
+		_, err = pt.client.AppsV1().DaemonSets(object.namespace).Get(ctx, object.name, metav1.GetOptions{})
+	case StatefulSet:
+		_, err = pt.client.AppsV1().StatefulSets(object.namespace).Get(ctx, object.name, metav1.GetOptions{})
+	case Job:
+		_, err = pt.client.BatchV1().Jobs(object.namespace).Get(ctx, object.name, metav1.GetOptions{})
+	case PersistentVolumeClaim:
+		_, err = pt.client.CoreV1().PersistentVolumeClaims(object.namespace).Get(ctx, object.name, metav1.GetOptions{})
+	case Service:
+		_, err = pt.client.CoreV1().Services(object.namespace).Get(ctx, object.name, metav1.GetOptions{})
+	case CustomResourceDefinition, APIService, CustomResource:
+		_, err = pt.getUnstructured(ctx, pt.conditionCheck(object), object)
+	}
 
-		if err == nil {
-			pt.logger.Debugf("Termination of %s is still ongoing", object.name)
-			return false, nil
-		}
-		if !errors.IsNotFound(err) {
-			pt.logger.Errorf("Failed to get resource %v: %s", object, err)
-			return false, err
-		}
+	if err == nil {
+		return false, nil
+	}
+	if errors.IsNotFound(err) {
+		return true, nil
 	}
+	return false, err
+}
 
-	pt.logger.Debug("All resources are terminated")
-	return true, nil
+func resourcesToString(objects []*resource) string {
+	var result []string
+	for _, object := range objects {
+		result = append(result, object.String())
+	}
+	return strings.Join(result, ", ")
 }
@@ -0,0 +1,174 @@
+package progress
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+//watchKey identifies a namespace scoped watch of one kind
+type watchKey struct {
+	kind      WatchableResource
+	gvk       schema.GroupVersionKind //only set for custom resources
+	namespace string
+}
+
+func newWatchKey(object *resource) watchKey {
+	key := watchKey{kind: object.kind, gvk: object.gvk, namespace: object.namespace}
+	if object.kind == CustomResourceDefinition || object.kind == APIService {
+		key.namespace = "" //cluster scoped
+	}
+	return key
+}
+
+func (k watchKey) String() string {
+	if k.kind == CustomResource {
+		return fmt.Sprintf("%s [namespace:%s]", k.gvk, k.namespace)
+	}
+	return fmt.Sprintf("%s [namespace:%s]", k.kind, k.namespace)
+}
+
+//watchEvent indicates that the objects of a watch have changed: an empty name means all objects of the watch
+type watchEvent struct {
+	key  watchKey
+	name string
+}
+
+func (e *watchEvent) matches(object *resource) bool {
+	return newWatchKey(object) == e.key && (e.name == "" || e.name == object.name)
+}
+
+type watches struct {
+	events chan *watchEvent
+	active map[watchKey]bool
+	mu     sync.Mutex
+}
+
+func (w *watches) setActive(key watchKey, active bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.active[key] = active
+}
+
+func (w *watches) isInactive(object *resource) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return !w.active[newWatchKey(object)]
+}
+
+// startWatches starts one watch per kind and namespace of the given objects. The watches are
+// restarted if they get closed and stopped when the context is closed.
+func (pt *Tracker) startWatches(ctx context.Context, objects []*resource) *watches {
+	w := &watches{
+		events: make(chan *watchEvent),
+		active: make(map[watchKey]bool),
+	}
+	var keys []watchKey
+	for _, object := range objects {
+		key := newWatchKey(object)
+		if _, ok := w.active[key]; !ok {
+			w.active[key] = false
+			keys = append(keys, key)
+		}
+	}
+	for _, key := range keys {
+		go pt.runWatch(ctx, key, w)
+	}
+	return w
+}
+
+func (pt *Tracker) runWatch(ctx context.Context, key watchKey, w *watches) {
+	for {
+		watcher, err := pt.newWatch(ctx, key)
+		if err == nil {
+			w.setActive(key, true)
+			//re-evaluate all objects: changes between the last check and the start of the watch could be missed
+			if !pt.sendEvent(ctx, w, &watchEvent{key: key}) {
+				watcher.Stop()
+				return
+			}
+			if !pt.forwardEvents(ctx, key, watcher, w) {
+				return
+			}
+			pt.logger.Debugf("Watch of %s was closed: polling resources until watch is restarted", key)
+		} else {
+			pt.logger.Warnf("Failed to watch %s: polling resources until watch could be started: %s", key, err)
+		}
+		w.setActive(key, false)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(pt.interval):
+		}
+	}
+}
+
+//forwardEvents forwards the events of the watcher and returns false if the context was closed
+func (pt *Tracker) forwardEvents(ctx context.Context, key watchKey, watcher watch.Interface, w *watches) bool {
+	defer watcher.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return false
+		case event, ok := <-watcher.ResultChan():
+			if !ok {
+				return true
+			}
+			if event.Type == watch.Error {
+				pt.logger.Debugf("Watch of %s returned an error: %v", key, event.Object)
+				return true
+			}
+			accessor, err := meta.Accessor(event.Object)
+			if err != nil {
+				continue
+			}
+			if !pt.sendEvent(ctx, w, &watchEvent{key: key, name: accessor.GetName()}) {
+				return false
+			}
+		}
+	}
+}
+
+func (pt *Tracker) sendEvent(ctx context.Context, w *watches, event *watchEvent) bool {
+	select {
+	case w.events <- event:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+func (pt *Tracker) newWatch(ctx context.Context, key watchKey) (watch.Interface, error) {
+	opts := metav1.ListOptions{}
+	switch key.kind {
+	case Pod:
+		return pt.client.CoreV1().Pods(key.namespace).Watch(ctx, opts)
+	case Deployment:
+		return pt.client.AppsV1().Deployments(key.namespace).Watch(ctx, opts)
+	case DaemonSet:
+		return pt.client.AppsV1().DaemonSets(key.namespace).Watch(ctx, opts)
+	case StatefulSet:
+		return pt.client.AppsV1().StatefulSets(key.namespace).Watch(ctx, opts)
+	case Job:
+		return pt.client.BatchV1().Jobs(key.namespace).Watch(ctx, opts)
+	case PersistentVolumeClaim:
+		return pt.client.CoreV1().PersistentVolumeClaims(key.namespace).Watch(ctx, opts)
+	case Service:
+		return pt.client.CoreV1().Services(key.namespace).Watch(ctx, opts)
+	case CustomResourceDefinition, APIService, CustomResource:
+		if pt.dynamicClient == nil {
+			return nil, fmt.Errorf("progress tracker has no dynamic client configured")
+		}
+		check := pt.conditionCheck(&resource{kind: key.kind, gvk: key.gvk})
+		return pt.dynamicClient.Resource(check.groupVersionResource()).Namespace(key.namespace).Watch(ctx, opts)
+	default:
+		return nil, fmt.Errorf("watching of '%s' is not supported", key.kind)
+	}
+}
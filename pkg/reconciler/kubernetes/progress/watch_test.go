@@ -0,0 +1,74 @@
+package progress
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	log "github.com/kyma-incubator/reconciler/pkg/logger"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newPod(name string, phase corev1.PodPhase) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "watch-test"},
+		Status:     corev1.PodStatus{Phase: phase},
+	}
+}
+
+func TestWatchTracker(t *testing.T) {
+	//the polling interval is long: state transitions have to be detected by watch events
+	config := Config{Interval: 1 * time.Minute, Timeout: 2 * time.Minute}
+
+	t.Run("Ready state is detected by watch events", func(t *testing.T) {
+		client := fake.NewSimpleClientset(newPod("pod1", corev1.PodPending), newPod("pod2", corev1.PodRunning))
+		pt, err := NewProgressTracker(client, log.NewLogger(true), config)
+		require.NoError(t, err)
+		pt.AddResource(Pod, "watch-test", "pod1")
+		pt.AddResource(Pod, "watch-test", "pod2")
+
+		go func() {
+			time.Sleep(500 * time.Millisecond)
+			_, err := client.CoreV1().Pods("watch-test").
+				UpdateStatus(context.Background(), newPod("pod1", corev1.PodRunning), metav1.UpdateOptions{})
+			require.NoError(t, err)
+		}()
+
+		startTime := time.Now()
+		require.NoError(t, pt.Watch(context.Background(), ReadyState))
+		require.WithinDuration(t, startTime, time.Now(), 10*time.Second)
+	})
+
+	t.Run("Terminated state is detected by watch events", func(t *testing.T) {
+		client := fake.NewSimpleClientset(newPod("pod1", corev1.PodRunning))
+		pt, err := NewProgressTracker(client, log.NewLogger(true), config)
+		require.NoError(t, err)
+		pt.AddResource(Pod, "watch-test", "pod1")
+
+		go func() {
+			time.Sleep(500 * time.Millisecond)
+			require.NoError(t, client.CoreV1().Pods("watch-test").
+				Delete(context.Background(), "pod1", metav1.DeleteOptions{}))
+		}()
+
+		startTime := time.Now()
+		require.NoError(t, pt.Watch(context.Background(), TerminatedState))
+		require.WithinDuration(t, startTime, time.Now(), 10*time.Second)
+	})
+
+	t.Run("Timeout reports resources which are not ready", func(t *testing.T) {
+		client := fake.NewSimpleClientset(newPod("pod1", corev1.PodPending), newPod("pod2", corev1.PodRunning))
+		pt, err := NewProgressTracker(client, log.NewLogger(true), Config{Interval: 1 * time.Second, Timeout: 2 * time.Second})
+		require.NoError(t, err)
+		pt.AddResource(Pod, "watch-test", "pod1")
+		pt.AddResource(Pod, "watch-test", "pod2")
+
+		err = pt.Watch(context.Background(), ReadyState)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "name:pod1")
+		require.NotContains(t, err.Error(), "name:pod2")
+	})
+}
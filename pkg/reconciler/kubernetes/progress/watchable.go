@@ -6,11 +6,17 @@ import (
 )
 
 const (
-	Deployment  WatchableResource = "Deployment"
-	Pod         WatchableResource = "Pod"
-	DaemonSet   WatchableResource = "DaemonSet"
-	StatefulSet WatchableResource = "StatefulSet"
-	Job         WatchableResource = "Job"
+	Deployment               WatchableResource = "Deployment"
+	Pod                      WatchableResource = "Pod"
+	DaemonSet                WatchableResource = "DaemonSet"
+	StatefulSet              WatchableResource = "StatefulSet"
+	Job                      WatchableResource = "Job"
+	PersistentVolumeClaim    WatchableResource = "PersistentVolumeClaim"
+	Service                  WatchableResource = "Service"
+	CustomResourceDefinition WatchableResource = "CustomResourceDefinition"
+	APIService               WatchableResource = "APIService"
+	//CustomResource is used for resources whose readiness is verified by a configured ConditionCheck
+	CustomResource WatchableResource = "CustomResource"
 )
 
 type WatchableResource string
@@ -27,6 +33,14 @@ func NewWatchableResource(kind string) (WatchableResource, error) {
 		return StatefulSet, nil
 	case strings.ToLower(string(Job)):
 		return Job, nil
+	case strings.ToLower(string(PersistentVolumeClaim)):
+		return PersistentVolumeClaim, nil
+	case strings.ToLower(string(Service)):
+		return Service, nil
+	case strings.ToLower(string(CustomResourceDefinition)):
+		return CustomResourceDefinition, nil
+	case strings.ToLower(string(APIService)):
+		return APIService, nil
 	default:
 		return "", fmt.Errorf("WatchableResource '%s' is not supported", kind)
 	}
@@ -9,7 +9,8 @@ import (
 
 func TestWatchable(t *testing.T) {
 	t.Run("Test existing watchables", func(t *testing.T) {
-		for _, expected := range []WatchableResource{Deployment, Pod, DaemonSet, StatefulSet, Job} {
+		for _, expected := range []WatchableResource{Deployment, Pod, DaemonSet, StatefulSet, Job,
+			PersistentVolumeClaim, Service, CustomResourceDefinition, APIService} {
 			got, err := NewWatchableResource(strings.ToLower(string(expected)))
 			require.NoError(t, err)
 			require.Equal(t, expected, got)
@@ -6,6 +6,7 @@ import (
 
 	"github.com/kyma-incubator/reconciler/pkg/keb"
 	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/secret"
 )
 
 type Configuration struct {
@@ -38,13 +39,15 @@ type Task struct {
 	Version         string                 `json:"version"`
 	URL             string                 `json:"url"`
 	Profile         string                 `json:"profile"`
+	ProfileValues   map[string]interface{} `json:"profileValues,omitempty"` //ProfileValues of a custom profile which are applied on top of the chart profile
 	Configuration   map[string]interface{} `json:"configuration"`
+	SecretKeys      []string               `json:"secretKeys,omitempty"` //SecretKeys are configuration keys whose values must not be exposed
 	Kubeconfig      string                 `json:"kubeconfig"`
 	Metadata        keb.Metadata           `json:"metadata"`
 	CallbackURL     string                 `json:"callbackURL"` //CallbackURL is mandatory when component-reconciler runs in separate process
 	CorrelationID   string                 `json:"correlationID"`
 	Repository      *Repository            `json:"repository"`
-	Type            model.OperationType    `json:"type"` // Supported task types are: reconcile, delete
+	Type            model.OperationType    `json:"type"` // Supported task types are: reconcile, delete, preflight
 
 	//These fields are not part of HTTP request coming from reconciler-controller:
 	CallbackFunc func(msg *CallbackMessage) error `json:"-"` //CallbackFunc is mandatory when component-reconciler runs embedded in another process
@@ -55,6 +58,25 @@ func (r *Task) String() string {
 		r.Component, r.Version, r.Namespace, r.Profile, r.Type)
 }
 
+// Masked returns a copy of the task which hides the kubeconfig and all secret configuration values.
+// Use it whenever a task has to be dumped (e.g. for debugging purposes).
+func (r *Task) Masked() *Task {
+	masked := *r
+	if masked.Kubeconfig != "" {
+		masked.Kubeconfig = secret.MaskedValue
+	}
+	masked.Configuration = make(map[string]interface{}, len(r.Configuration))
+	for key, value := range r.Configuration {
+		masked.Configuration[key] = value
+	}
+	for _, secretKey := range r.SecretKeys {
+		if _, ok := masked.Configuration[secretKey]; ok {
+			masked.Configuration[secretKey] = secret.MaskedValue
+		}
+	}
+	return &masked
+}
+
 func (r *Task) Validate() error {
 	//check mandatory fields are defined
 	var errFields []string
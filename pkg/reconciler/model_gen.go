@@ -3,6 +3,19 @@
 // Code generated by github.com/deepmap/oapi-codegen version v1.8.2 DO NOT EDIT.
 package reconciler
 
+// Defines values for DeletionStatus.
+const (
+	DeletionStatusDeleted DeletionStatus = "deleted"
+
+	DeletionStatusFailed DeletionStatus = "failed"
+
+	DeletionStatusFinalizersRemoved DeletionStatus = "finalizersRemoved"
+
+	DeletionStatusNotFound DeletionStatus = "notFound"
+
+	DeletionStatusStuck DeletionStatus = "stuck"
+)
+
 // Defines values for Status.
 const (
 	StatusError Status = "error"
@@ -18,10 +31,24 @@ const (
 
 // CallbackMessage defines model for callbackMessage.
 type CallbackMessage struct {
-	Error  string `json:"error"`
-	Status Status `json:"status"`
+	DeletionReport *[]DeletedResource `json:"deletionReport,omitempty"`
+	Error          string             `json:"error"`
+	Status         Status             `json:"status"`
 }
 
+// DeletedResource defines model for deletedResource.
+type DeletedResource struct {
+	Error      *string        `json:"error,omitempty"`
+	Finalizers *[]string      `json:"finalizers,omitempty"`
+	Kind       string         `json:"kind"`
+	Name       string         `json:"name"`
+	Namespace  string         `json:"namespace"`
+	Status     DeletionStatus `json:"status"`
+}
+
+// DeletionStatus defines model for deletionStatus.
+type DeletionStatus string
+
 // Status defines model for status.
 type Status string
 
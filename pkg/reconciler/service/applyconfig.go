@@ -0,0 +1,54 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/kyma-incubator/reconciler/pkg/reconciler"
+	k8s "github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes"
+	"github.com/pkg/errors"
+)
+
+const (
+	//ConfigKeyPrefix is the prefix of all component configuration keys which are evaluated by the reconciler
+	//(e.g. apply settings and interceptors) and not by the charts
+	ConfigKeyPrefix = "reconciler."
+	//ApplyModeConfigKey is the component configuration key used to select the apply mode of a component
+	//('client-side' or 'server-side')
+	ApplyModeConfigKey = ConfigKeyPrefix + "apply.mode"
+	//ConflictPolicyConfigKey is the component configuration key used to define the server-side apply conflict
+	//policies of a component (e.g. "*=fail,horizontalpodautoscaler=force")
+	ConflictPolicyConfigKey = ConfigKeyPrefix + "apply.conflictPolicy"
+)
+
+//componentApplyConfig returns the apply settings defined in the component configuration (nil if nothing is defined)
+func componentApplyConfig(task *reconciler.Task) (*k8s.ApplyConfig, error) {
+	mode, hasMode := task.Configuration[ApplyModeConfigKey]
+	policies, hasPolicies := task.Configuration[ConflictPolicyConfigKey]
+	if !hasMode && !hasPolicies {
+		return nil, nil
+	}
+
+	var conflictPolicies map[string]string
+	if hasPolicies {
+		var err error
+		conflictPolicies, err = k8s.ParseConflictPolicies(fmt.Sprint(policies))
+		if err != nil {
+			return nil, errors.Wrapf(err, "configuration '%s' of component '%s' is invalid",
+				ConflictPolicyConfigKey, task.Component)
+		}
+	}
+
+	var applyMode string
+	if hasMode {
+		applyMode = fmt.Sprint(mode)
+	}
+	applyConfig, err := k8s.NewApplyConfig(applyMode, conflictPolicies)
+	if err != nil {
+		return nil, errors.Wrapf(err, "configuration '%s' of component '%s' is invalid",
+			ApplyModeConfigKey, task.Component)
+	}
+	if !hasMode {
+		applyConfig.Mode = "" //don't overwrite the apply mode configured for the reconciler
+	}
+	return applyConfig, nil
+}
@@ -0,0 +1,56 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/kyma-incubator/reconciler/pkg/reconciler"
+	k8s "github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComponentApplyConfig(t *testing.T) {
+	t.Run("No apply configuration defined", func(t *testing.T) {
+		applyConfig, err := componentApplyConfig(&reconciler.Task{
+			Configuration: map[string]interface{}{"a.b": "c"},
+		})
+		require.NoError(t, err)
+		require.Nil(t, applyConfig)
+	})
+
+	t.Run("Apply mode and conflict policies defined", func(t *testing.T) {
+		applyConfig, err := componentApplyConfig(&reconciler.Task{
+			Configuration: map[string]interface{}{
+				ApplyModeConfigKey:      "server-side",
+				ConflictPolicyConfigKey: "*=fail, HorizontalPodAutoscaler=force",
+			},
+		})
+		require.NoError(t, err)
+		require.Equal(t, k8s.ServerSideApplyMode, applyConfig.Mode)
+		require.Equal(t, k8s.ForceConflictPolicy, applyConfig.ConflictPolicy("horizontalpodautoscaler"))
+		require.Equal(t, k8s.FailConflictPolicy, applyConfig.ConflictPolicy("Deployment"))
+	})
+
+	t.Run("Only conflict policies defined", func(t *testing.T) {
+		applyConfig, err := componentApplyConfig(&reconciler.Task{
+			Configuration: map[string]interface{}{
+				ConflictPolicyConfigKey: "deployment=force",
+			},
+		})
+		require.NoError(t, err)
+		merged := (&k8s.ApplyConfig{Mode: k8s.ServerSideApplyMode}).Merge(applyConfig)
+		require.Equal(t, k8s.ServerSideApplyMode, merged.Mode)
+		require.Equal(t, k8s.ForceConflictPolicy, merged.ConflictPolicy("deployment"))
+	})
+
+	t.Run("Invalid apply configuration", func(t *testing.T) {
+		_, err := componentApplyConfig(&reconciler.Task{
+			Configuration: map[string]interface{}{ApplyModeConfigKey: "magic"},
+		})
+		require.Error(t, err)
+
+		_, err = componentApplyConfig(&reconciler.Task{
+			Configuration: map[string]interface{}{ConflictPolicyConfigKey: "deployment"},
+		})
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,39 @@
+package service
+
+import (
+	k8s "github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const (
+	HelmManagedByLabel             = "app.kubernetes.io/managed-by"
+	HelmReleaseNameAnnotation      = "meta.helm.sh/release-name"
+	HelmReleaseNamespaceAnnotation = "meta.helm.sh/release-namespace"
+	helmManagedByValue             = "Helm"
+)
+
+// HelmReleaseInterceptor adds the ownership metadata of a Helm release to a resource: Helm
+// accepts only resources with this metadata as part of a release (e.g. for a rollback).
+type HelmReleaseInterceptor struct {
+	ReleaseName      string
+	ReleaseNamespace string
+}
+
+func (h *HelmReleaseInterceptor) Intercept(resource *unstructured.Unstructured, _ string) (k8s.InterceptionResult, error) {
+	labels := resource.GetLabels()
+	if labels == nil {
+		labels = make(map[string]string)
+	}
+	labels[HelmManagedByLabel] = helmManagedByValue
+	resource.SetLabels(labels)
+
+	annotations := resource.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	annotations[HelmReleaseNameAnnotation] = h.ReleaseName
+	annotations[HelmReleaseNamespaceAnnotation] = h.ReleaseNamespace
+	resource.SetAnnotations(annotations)
+
+	return k8s.ContinueInterceptionResult, nil
+}
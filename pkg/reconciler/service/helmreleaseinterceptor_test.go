@@ -0,0 +1,28 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestHelmReleaseInterceptor(t *testing.T) {
+	resource := &unstructured.Unstructured{}
+	resource.SetLabels(map[string]string{"app": "test"})
+
+	interceptor := &HelmReleaseInterceptor{ReleaseName: "component", ReleaseNamespace: "kyma-system"}
+	result, err := interceptor.Intercept(resource, "")
+	require.NoError(t, err)
+	require.Equal(t, kubernetes.ContinueInterceptionResult, result)
+
+	require.Equal(t, map[string]string{
+		"app":              "test",
+		HelmManagedByLabel: helmManagedByValue,
+	}, resource.GetLabels())
+	require.Equal(t, map[string]string{
+		HelmReleaseNameAnnotation:      "component",
+		HelmReleaseNamespaceAnnotation: "kyma-system",
+	}, resource.GetAnnotations())
+}
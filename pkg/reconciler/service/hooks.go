@@ -0,0 +1,201 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/chart"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+const (
+	defaultHookTimeout  = 5 * time.Minute
+	defaultHookInterval = 2 * time.Second
+)
+
+// hookRunner executes Helm hooks: hooks are applied in the order of their weight and
+// Jobs and Pods are awaited until they are completed. Hook resources are deleted
+// according to their delete policies.
+type hookRunner struct {
+	kubeClient   kubernetes.Client
+	logger       *zap.SugaredLogger
+	namespace    string
+	interceptors []kubernetes.ResourceInterceptor
+	timeout      time.Duration
+	interval     time.Duration
+}
+
+func (h *hookRunner) run(ctx context.Context, hooks chart.Hooks, event chart.HookEvent) error {
+	eventHooks := hooks.Filter(event)
+	if len(eventHooks) == 0 {
+		return nil
+	}
+	h.logger.Debugf("Executing %d %s hooks", len(eventHooks), event)
+	for _, hook := range eventHooks {
+		if err := h.execute(ctx, hook); err != nil {
+			return errors.Wrapf(err, "%s hook '%s' failed", event, hook.Name)
+		}
+	}
+	return nil
+}
+
+func (h *hookRunner) execute(ctx context.Context, hook *chart.Hook) error {
+	unstruct, err := h.hookResource(hook)
+	if err != nil {
+		return err
+	}
+	namespace := kubernetes.ResolveNamespace(unstruct, h.namespace)
+
+	if hook.HasDeletePolicy(chart.BeforeHookCreationPolicy) {
+		if err := h.delete(ctx, hook, namespace); err != nil {
+			return err
+		}
+		if err := h.awaitRemoval(ctx, hook, unstruct.GroupVersionKind(), namespace); err != nil {
+			return err
+		}
+	}
+
+	h.logger.Debugf("Applying hook %s '%s' (weight: %d)", hook.Kind, hook.Name, hook.Weight)
+	_, err = h.kubeClient.Deploy(ctx, hook.Manifest, namespace, h.interceptors...)
+	if err == nil {
+		err = h.awaitCompletion(ctx, hook, namespace)
+	}
+
+	if err != nil {
+		if hook.HasDeletePolicy(chart.HookFailedPolicy) {
+			if deleteErr := h.delete(ctx, hook, namespace); deleteErr != nil {
+				h.logger.Warnf("Failed to delete failed hook %s '%s': %s", hook.Kind, hook.Name, deleteErr)
+			}
+		}
+		return err
+	}
+
+	if hook.HasDeletePolicy(chart.HookSucceededPolicy) {
+		return h.delete(ctx, hook, namespace)
+	}
+	return nil
+}
+
+func (h *hookRunner) hookResource(hook *chart.Hook) (*unstructured.Unstructured, error) {
+	unstructs, err := kubernetes.ToUnstructured([]byte(hook.Manifest), true)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse manifest of hook '%s'", hook.Name)
+	}
+	if len(unstructs) != 1 {
+		return nil, fmt.Errorf("manifest of hook '%s' has to contain exactly one resource (got %d)",
+			hook.Name, len(unstructs))
+	}
+	return unstructs[0], nil
+}
+
+//awaitCompletion waits until hook Jobs or Pods are completed: other resources are completed when they were applied
+func (h *hookRunner) awaitCompletion(ctx context.Context, hook *chart.Hook, namespace string) error {
+	var completed func() (bool, error)
+	switch strings.ToLower(hook.Kind) {
+	case "job":
+		completed = func() (bool, error) {
+			job, err := h.kubeClient.GetJob(ctx, hook.Name, namespace)
+			if err != nil || job == nil {
+				return false, err
+			}
+			for _, condition := range job.Status.Conditions {
+				if condition.Status != v1.ConditionTrue {
+					continue
+				}
+				if condition.Type == batchv1.JobComplete {
+					return true, nil
+				}
+				if condition.Type == batchv1.JobFailed {
+					return false, fmt.Errorf("job failed: %s", condition.Message)
+				}
+			}
+			return false, nil
+		}
+	case "pod":
+		completed = func() (bool, error) {
+			pod, err := h.kubeClient.GetPod(ctx, hook.Name, namespace)
+			if err != nil || pod == nil {
+				return false, err
+			}
+			switch pod.Status.Phase {
+			case v1.PodSucceeded:
+				return true, nil
+			case v1.PodFailed:
+				return false, fmt.Errorf("pod failed: %s", pod.Status.Message)
+			}
+			return false, nil
+		}
+	default:
+		return nil
+	}
+
+	err := wait.PollImmediate(h.interval, h.timeout, func() (bool, error) {
+		if ctx.Err() != nil {
+			return false, ctx.Err()
+		}
+		return completed()
+	})
+	if err == wait.ErrWaitTimeout {
+		return fmt.Errorf("%s '%s' wasn't completed within %.1f secs", hook.Kind, hook.Name, h.timeout.Seconds())
+	}
+	return err
+}
+
+func (h *hookRunner) delete(ctx context.Context, hook *chart.Hook, namespace string) error {
+	h.logger.Debugf("Deleting hook %s '%s'", hook.Kind, hook.Name)
+	if strings.ToLower(hook.Kind) == "job" { //delete also the pods of the job
+		clientset, err := h.kubeClient.Clientset()
+		if err != nil {
+			return err
+		}
+		propagation := metav1.DeletePropagationBackground
+		err = clientset.BatchV1().Jobs(namespace).Delete(ctx, hook.Name, metav1.DeleteOptions{
+			PropagationPolicy: &propagation,
+		})
+		if err != nil && !k8serr.IsNotFound(err) {
+			return errors.Wrapf(err, "failed to delete hook job '%s'", hook.Name)
+		}
+		return nil
+	}
+	_, err := h.kubeClient.DeleteResource(hook.Kind, hook.Name, namespace)
+	return err
+}
+
+func (h *hookRunner) awaitRemoval(ctx context.Context, hook *chart.Hook, gvk schema.GroupVersionKind, namespace string) error {
+	err := wait.PollImmediate(h.interval, h.timeout, func() (bool, error) {
+		if ctx.Err() != nil {
+			return false, ctx.Err()
+		}
+		exists, err := resourceExists(ctx, h.kubeClient, gvk, hook.Name, namespace)
+		return !exists, err
+	})
+	if err == wait.ErrWaitTimeout {
+		return fmt.Errorf("previous %s '%s' wasn't removed within %.1f secs", hook.Kind, hook.Name, h.timeout.Seconds())
+	}
+	return err
+}
+
+//resourceExists checks whether a resource exists: resources whose kind isn't known by the cluster (e.g. because
+//its CRD isn't installed yet) don't exist
+func resourceExists(ctx context.Context, kubeClient kubernetes.Client, gvk schema.GroupVersionKind, name, namespace string) (bool, error) {
+	_, err := kubeClient.GetResource(ctx, gvk, name, namespace)
+	if err != nil {
+		if k8serr.IsNotFound(err) || meta.IsNoMatchError(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
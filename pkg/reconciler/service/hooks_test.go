@@ -0,0 +1,167 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/logger"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/chart"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+const (
+	jobHookManifest = `apiVersion: batch/v1
+kind: Job
+metadata:
+  name: migration`
+	configMapHookManifest = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: %s`
+)
+
+func newHookRunner(kubeClient *mocks.Client) *hookRunner {
+	return &hookRunner{
+		kubeClient: kubeClient,
+		logger:     logger.NewLogger(true),
+		namespace:  "kyma-system",
+		timeout:    time.Second,
+		interval:   10 * time.Millisecond,
+	}
+}
+
+func newJob(conditionType batchv1.JobConditionType) *batchv1.Job {
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{Name: "migration", Namespace: "kyma-system"},
+		Status: batchv1.JobStatus{
+			Conditions: []batchv1.JobCondition{{Type: conditionType, Status: v1.ConditionTrue, Message: "test"}},
+		},
+	}
+}
+
+func TestHookRunner(t *testing.T) {
+	ctx := context.Background()
+	jobHook := &chart.Hook{
+		Name:           "migration",
+		Kind:           "Job",
+		Manifest:       jobHookManifest,
+		Events:         []chart.HookEvent{chart.PreUpgradeHook},
+		DeletePolicies: []chart.HookDeletePolicy{chart.HookSucceededPolicy, chart.HookFailedPolicy},
+	}
+
+	t.Run("Delete succeeded job hook", func(t *testing.T) {
+		job := newJob(batchv1.JobComplete)
+		clientset := fake.NewSimpleClientset(job)
+		kubeClient := &mocks.Client{}
+		kubeClient.On("Deploy", ctx, jobHookManifest, "kyma-system").Return(nil, nil)
+		kubeClient.On("GetJob", ctx, "migration", "kyma-system").Return(job, nil)
+		kubeClient.On("Clientset").Return(clientset, nil)
+
+		require.NoError(t, newHookRunner(kubeClient).run(ctx, chart.Hooks{jobHook}, chart.PreUpgradeHook))
+		jobs, err := clientset.BatchV1().Jobs("kyma-system").List(ctx, metav1.ListOptions{})
+		require.NoError(t, err)
+		require.Empty(t, jobs.Items)
+	})
+
+	t.Run("Delete failed job hook", func(t *testing.T) {
+		job := newJob(batchv1.JobFailed)
+		clientset := fake.NewSimpleClientset(job)
+		kubeClient := &mocks.Client{}
+		kubeClient.On("Deploy", ctx, jobHookManifest, "kyma-system").Return(nil, nil)
+		kubeClient.On("GetJob", ctx, "migration", "kyma-system").Return(job, nil)
+		kubeClient.On("Clientset").Return(clientset, nil)
+
+		err := newHookRunner(kubeClient).run(ctx, chart.Hooks{jobHook}, chart.PreUpgradeHook)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "pre-upgrade hook 'migration' failed")
+		jobs, err := clientset.BatchV1().Jobs("kyma-system").List(ctx, metav1.ListOptions{})
+		require.NoError(t, err)
+		require.Empty(t, jobs.Items)
+	})
+
+	t.Run("Ignore hooks of other events", func(t *testing.T) {
+		kubeClient := &mocks.Client{}
+		require.NoError(t, newHookRunner(kubeClient).run(ctx, chart.Hooks{jobHook}, chart.PreInstallHook))
+		kubeClient.AssertNotCalled(t, "Deploy", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Replace previous hooks in order of their weight", func(t *testing.T) {
+		hooks := chart.Hooks{
+			{Name: "second", Kind: "ConfigMap", Manifest: configMapHook("second"), Events: []chart.HookEvent{chart.PostInstallHook}, Weight: 10},
+			{Name: "first", Kind: "ConfigMap", Manifest: configMapHook("first"), Events: []chart.HookEvent{chart.PostInstallHook}, Weight: -1},
+		}
+		var deployed []string
+		kubeClient := &mocks.Client{}
+		kubeClient.On("DeleteResource", "ConfigMap", mock.Anything, "kyma-system").Return(nil, nil)
+		kubeClient.On("GetResource", ctx, configMapGVK, mock.Anything, "kyma-system").Return(nil, notFound)
+		kubeClient.On("Deploy", ctx, mock.Anything, "kyma-system").Return(nil, nil).Run(func(args mock.Arguments) {
+			deployed = append(deployed, args.String(1))
+		})
+
+		require.NoError(t, newHookRunner(kubeClient).run(ctx, hooks, chart.PostInstallHook))
+		require.Equal(t, []string{configMapHook("first"), configMapHook("second")}, deployed)
+		kubeClient.AssertCalled(t, "DeleteResource", "ConfigMap", "first", "kyma-system")
+		kubeClient.AssertCalled(t, "DeleteResource", "ConfigMap", "second", "kyma-system")
+	})
+}
+
+var (
+	configMapGVK = schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}
+	notFound     = k8serr.NewNotFound(schema.GroupResource{Resource: "configmaps"}, "test")
+)
+
+func configMapHook(name string) string {
+	return fmt.Sprintf(configMapHookManifest, name)
+}
+
+func TestIsInstalled(t *testing.T) {
+	ctx := context.Background()
+	manifest := &chart.Manifest{
+		Manifest: fmt.Sprintf("%s\n---\n%s", configMapHook("first"), configMapHook("second")),
+	}
+
+	t.Run("Component is installed if any resource exists", func(t *testing.T) {
+		kubeClient := &mocks.Client{}
+		kubeClient.On("GetResource", ctx, configMapGVK, "first", "kyma-system").Return(nil, notFound)
+		kubeClient.On("GetResource", ctx, configMapGVK, "second", "kyma-system").Return(&unstructured.Unstructured{}, nil)
+
+		installed, err := NewInstall(logger.NewLogger(true)).isInstalled(ctx, kubeClient, manifest, "kyma-system")
+		require.NoError(t, err)
+		require.True(t, installed)
+	})
+
+	t.Run("Component is not installed if no resource exists", func(t *testing.T) {
+		kubeClient := &mocks.Client{}
+		kubeClient.On("GetResource", ctx, configMapGVK, mock.Anything, "kyma-system").Return(nil, notFound)
+
+		installed, err := NewInstall(logger.NewLogger(true)).isInstalled(ctx, kubeClient, manifest, "kyma-system")
+		require.NoError(t, err)
+		require.False(t, installed)
+	})
+
+	t.Run("Resources are looked up by their group and version", func(t *testing.T) {
+		serviceGVK := schema.GroupVersionKind{Group: "example.com", Version: "v1alpha1", Kind: "Service"}
+		kubeClient := &mocks.Client{}
+		kubeClient.On("GetResource", ctx, serviceGVK, "custom", "kyma-system").
+			Return(nil, &meta.NoKindMatchError{GroupKind: serviceGVK.GroupKind()})
+
+		installed, err := NewInstall(logger.NewLogger(true)).isInstalled(ctx, kubeClient, &chart.Manifest{
+			Manifest: "apiVersion: example.com/v1alpha1\nkind: Service\nmetadata:\n  name: custom",
+		}, "kyma-system")
+		require.NoError(t, err)
+		require.False(t, installed)
+		kubeClient.AssertNumberOfCalls(t, "GetResource", 1)
+	})
+}
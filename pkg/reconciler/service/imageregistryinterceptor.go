@@ -0,0 +1,74 @@
+package service
+
+import (
+	"strings"
+
+	k8s "github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const (
+	defaultImageRegistry = "docker.io"
+	anyImageRegistry     = "*"
+)
+
+//ImageRegistryInterceptor rewrites the registry of container images to a mirror registry
+type ImageRegistryInterceptor struct {
+	//Mirrors maps the original registry (e.g. "eu.gcr.io") to the mirror registry ("*" applies to all registries)
+	Mirrors map[string]string
+}
+
+func (i *ImageRegistryInterceptor) Intercept(resource *unstructured.Unstructured, _ string) (k8s.InterceptionResult, error) {
+	spec, found, err := podSpec(resource)
+	if err != nil {
+		return k8s.ErrorInterceptionResult, err
+	}
+	if !found {
+		return k8s.ContinueInterceptionResult, nil
+	}
+
+	for _, field := range []string{"initContainers", "containers"} {
+		containers, ok := spec[field].([]interface{})
+		if !ok {
+			continue
+		}
+		for _, container := range containers {
+			container, ok := container.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if image, ok := container["image"].(string); ok {
+				container["image"] = i.rewrite(image)
+			}
+		}
+	}
+
+	if err := setPodSpec(resource, spec); err != nil {
+		return k8s.ErrorInterceptionResult, err
+	}
+	return k8s.ContinueInterceptionResult, nil
+}
+
+func (i *ImageRegistryInterceptor) rewrite(image string) string {
+	registry, path := splitImage(image)
+	mirror, ok := i.Mirrors[registry]
+	if !ok {
+		if mirror, ok = i.Mirrors[anyImageRegistry]; !ok {
+			return image
+		}
+	}
+	return strings.TrimSuffix(mirror, "/") + "/" + path
+}
+
+//splitImage separates the registry from the repository path of an image reference
+//(e.g. "nginx:1.21" is split into "docker.io" and "library/nginx:1.21")
+func splitImage(image string) (string, string) {
+	parts := strings.SplitN(image, "/", 2)
+	if len(parts) == 2 && (strings.ContainsAny(parts[0], ".:") || parts[0] == "localhost") {
+		return parts[0], parts[1]
+	}
+	if len(parts) == 1 {
+		return defaultImageRegistry, "library/" + image
+	}
+	return defaultImageRegistry, image
+}
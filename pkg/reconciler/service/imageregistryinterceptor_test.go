@@ -0,0 +1,67 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestImageRegistryInterceptor(t *testing.T) {
+	interceptor := &ImageRegistryInterceptor{Mirrors: map[string]string{
+		anyImageRegistry: "mirror.example.com/",
+		"eu.gcr.io":      "gcr.example.com/kyma",
+	}}
+
+	t.Run("Rewrite images of workload", func(t *testing.T) {
+		deployment := &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "apps/v1",
+			"kind":       "Deployment",
+			"spec": map[string]interface{}{
+				"template": map[string]interface{}{
+					"spec": map[string]interface{}{
+						"initContainers": []interface{}{
+							map[string]interface{}{"name": "init", "image": "busybox"},
+						},
+						"containers": []interface{}{
+							map[string]interface{}{"name": "a", "image": "eu.gcr.io/kyma-project/app:1.0"},
+							map[string]interface{}{"name": "b", "image": "localhost:5000/app@sha256:abc"},
+							map[string]interface{}{"name": "c", "image": "bitnami/redis:6"},
+						},
+					},
+				},
+			},
+		}}
+		result, err := interceptor.Intercept(deployment, "")
+		require.NoError(t, err)
+		require.Equal(t, kubernetes.ContinueInterceptionResult, result)
+
+		var images []string
+		for _, field := range []string{"initContainers", "containers"} {
+			containers, _, err := unstructured.NestedSlice(deployment.Object, "spec", "template", "spec", field)
+			require.NoError(t, err)
+			for _, container := range containers {
+				images = append(images, container.(map[string]interface{})["image"].(string))
+			}
+		}
+		require.Equal(t, []string{
+			"mirror.example.com/library/busybox",
+			"gcr.example.com/kyma/kyma-project/app:1.0",
+			"mirror.example.com/app@sha256:abc",
+			"mirror.example.com/bitnami/redis:6",
+		}, images)
+	})
+
+	t.Run("Ignore resources without pod spec", func(t *testing.T) {
+		configMap := &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"data":       map[string]interface{}{"image": "busybox"},
+		}}
+		result, err := interceptor.Intercept(configMap, "")
+		require.NoError(t, err)
+		require.Equal(t, kubernetes.ContinueInterceptionResult, result)
+		require.Equal(t, "busybox", configMap.Object["data"].(map[string]interface{})["image"])
+	})
+}
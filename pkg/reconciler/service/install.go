@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/kyma-incubator/reconciler/pkg/model"
 	"github.com/kyma-incubator/reconciler/pkg/reconciler"
@@ -13,11 +14,27 @@ import (
 )
 
 type Install struct {
-	logger *zap.SugaredLogger
+	logger       *zap.SugaredLogger
+	hookTimeout  time.Duration
+	hookInterval time.Duration
+	releaseMode  chart.ReleaseMode
 }
 
 func NewInstall(logger *zap.SugaredLogger) *Install {
-	return &Install{logger: logger}
+	return &Install{
+		logger:       logger,
+		hookTimeout:  defaultHookTimeout,
+		hookInterval: defaultHookInterval,
+		releaseMode:  chart.NoReleaseMode,
+	}
+}
+
+// WithReleaseMode defines whether deployments of Helm charts are recorded as Helm releases on the cluster
+func (r *Install) WithReleaseMode(releaseMode chart.ReleaseMode) *Install {
+	if releaseMode != "" {
+		r.releaseMode = releaseMode
+	}
+	return r
 }
 
 //go:generate mockery --name=Operation --output=mocks --outpkg=mocks --case=underscore
@@ -27,7 +44,7 @@ type Operation interface {
 
 func (r *Install) Invoke(ctx context.Context, chartProvider chart.Provider, task *reconciler.Task, kubeClient kubernetes.Client) error {
 	var err error
-	var manifest string
+	var manifest *chart.Manifest
 	if task.Component == model.CRDComponent {
 		manifest, err = r.renderCRDs(chartProvider, task)
 	} else {
@@ -37,40 +54,144 @@ func (r *Install) Invoke(ctx context.Context, chartProvider chart.Provider, task
 		return err
 	}
 
+	interceptors := []kubernetes.ResourceInterceptor{
+		&LabelsInterceptor{
+			Version: task.Version,
+		},
+		&AnnotationsInterceptor{},
+		&ServicesInterceptor{
+			kubeClient: kubeClient,
+		},
+	}
+	//add the interceptors which are defined in the component configuration
+	configuredInterceptors, err := componentInterceptors(task)
+	if err != nil {
+		return err
+	}
+	interceptors = append(interceptors, configuredInterceptors...)
+	recordRelease := r.releaseMode.Enabled() && manifest.Release != nil
+	if recordRelease {
+		interceptors = append(interceptors, &HelmReleaseInterceptor{
+			ReleaseName:      manifest.Release.Name,
+			ReleaseNamespace: manifest.Release.Namespace,
+		})
+	}
+	hooks := &hookRunner{
+		kubeClient:   kubeClient,
+		logger:       r.logger,
+		namespace:    task.Namespace,
+		interceptors: interceptors,
+		timeout:      r.hookTimeout,
+		interval:     r.hookInterval,
+	}
+
 	if task.Type == model.OperationTypeDelete {
 		if task.Component == model.CRDComponent {
 			return nil
 		}
-		resources, err := kubeClient.Delete(ctx, manifest, task.Namespace)
+		if err := hooks.run(ctx, manifest.Hooks, chart.PreDeleteHook); err != nil {
+			return err
+		}
+		//post-delete hooks run after the resources were deleted but before the namespace is deleted
+		resources, err := kubeClient.Delete(ctx, manifest.Manifest, task.Namespace,
+			kubernetes.WithBeforeNamespaceDeletion(func(ctx context.Context) error {
+				return hooks.run(ctx, manifest.Hooks, chart.PostDeleteHook)
+			}))
 		if err == nil {
 			r.logger.Debugf("Deletion of manifest finished successfully: %d resources deleted", len(resources))
 		} else {
 			r.logger.Warnf("Failed to delete manifests on target cluster: %s", err)
 			return err
 		}
+		if recordRelease {
+			recorder, err := r.releaseRecorder(kubeClient, manifest)
+			if err != nil {
+				return err
+			}
+			return recorder.Remove(manifest.Release.Name)
+		}
+		return nil
+	}
+
+	//pre/post-install hooks run only if the component wasn't deployed before, otherwise pre/post-upgrade hooks
+	preHook, postHook := chart.PreInstallHook, chart.PostInstallHook
+	if len(manifest.Hooks) > 0 {
+		installed, err := r.isInstalled(ctx, kubeClient, manifest, task.Namespace)
+		if err != nil {
+			return err
+		}
+		if installed {
+			preHook, postHook = chart.PreUpgradeHook, chart.PostUpgradeHook
+		}
+	}
+
+	if err := hooks.run(ctx, manifest.Hooks, preHook); err != nil {
+		return err
+	}
+	resources, err := kubeClient.Deploy(ctx, manifest.Manifest, task.Namespace, interceptors...)
+	if err == nil {
+		r.logger.Debugf("Deployment of manifest finished successfully: %d resources deployed", len(resources))
 	} else {
-		resources, err := kubeClient.Deploy(ctx, manifest, task.Namespace,
-			&LabelsInterceptor{
-				Version: task.Version,
-			},
-			&AnnotationsInterceptor{},
-			&ServicesInterceptor{
-				kubeClient: kubeClient,
-			},
-		)
-		if err == nil {
-			r.logger.Debugf("Deployment of manifest finished successfully: %d resources deployed", len(resources))
-		} else {
-			r.logger.Warnf("Failed to deploy manifests on target cluster: %s", err)
+		r.logger.Warnf("Failed to deploy manifests on target cluster: %s", err)
+		return err
+	}
+	//Deploy returns after all resources are ready
+	if err := hooks.run(ctx, manifest.Hooks, postHook); err != nil {
+		return err
+	}
+	if recordRelease {
+		recorder, err := r.releaseRecorder(kubeClient, manifest)
+		if err != nil {
 			return err
 		}
+		return recorder.Record(manifest)
 	}
 	return nil
 }
 
-func (r *Install) renderManifest(chartProvider chart.Provider, model *reconciler.Task) (string, error) {
+func (r *Install) releaseRecorder(kubeClient kubernetes.Client, manifest *chart.Manifest) (*chart.ReleaseRecorder, error) {
+	clientset, err := kubeClient.Clientset()
+	if err != nil {
+		return nil, err
+	}
+	return chart.NewReleaseRecorder(clientset, manifest.Release.Namespace, r.releaseMode, r.logger), nil
+}
+
+//isInstalled checks whether the component was deployed before: either its Helm release is recorded or
+//any of the resources of its manifest exists already (e.g. after a partial deployment)
+func (r *Install) isInstalled(ctx context.Context, kubeClient kubernetes.Client, manifest *chart.Manifest, namespace string) (bool, error) {
+	if r.releaseMode.Enabled() && manifest.Release != nil {
+		recorder, err := r.releaseRecorder(kubeClient, manifest)
+		if err != nil {
+			return false, err
+		}
+		exists, err := recorder.Exists(manifest.Release.Name)
+		if err != nil || exists {
+			return exists, err
+		}
+	}
+
+	unstructs, err := kubernetes.ToUnstructured([]byte(manifest.Manifest), true)
+	if err != nil {
+		return false, err
+	}
+	for _, unstruct := range unstructs {
+		if unstruct.GetKind() == "Namespace" {
+			continue
+		}
+		exists, err := resourceExists(ctx, kubeClient, unstruct.GroupVersionKind(), unstruct.GetName(),
+			kubernetes.ResolveNamespace(unstruct, namespace))
+		if err != nil || exists {
+			return exists, err
+		}
+	}
+	return false, nil
+}
+
+func (r *Install) renderManifest(chartProvider chart.Provider, model *reconciler.Task) (*chart.Manifest, error) {
 	component := chart.NewComponentBuilder(model.Version, model.Component).
 		WithProfile(model.Profile).
+		WithProfileValues(model.ProfileValues).
 		WithNamespace(model.Namespace).
 		WithConfiguration(model.Configuration).
 		WithURL(model.URL).
@@ -86,18 +207,22 @@ func (r *Install) renderManifest(chartProvider chart.Provider, model *reconciler
 				model.URL)
 		}
 		r.logger.Errorf("%s: %s", msg, err)
-		return "", errors.Wrap(err, msg)
+		return nil, errors.Wrap(err, msg)
 	}
 
-	return chartManifest.Manifest, nil
+	return chartManifest, nil
 }
 
-func (r *Install) renderCRDs(chartProvider chart.Provider, model *reconciler.Task) (string, error) {
+func (r *Install) renderCRDs(chartProvider chart.Provider, model *reconciler.Task) (*chart.Manifest, error) {
 	crdManifests, err := chartProvider.RenderCRD(model.Version)
 	if err != nil {
 		msg := fmt.Sprintf("Failed to get CRD manifests for Kyma version '%s'", model.Version)
 		r.logger.Errorf("%s: %s", msg, err)
-		return "", errors.Wrap(err, msg)
+		return nil, errors.Wrap(err, msg)
 	}
-	return chart.MergeManifests(crdManifests...), nil
+	return &chart.Manifest{
+		Type:     chart.CRD,
+		Name:     model.Component,
+		Manifest: chart.MergeManifests(crdManifests...),
+	}, nil
 }
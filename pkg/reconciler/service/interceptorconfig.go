@@ -0,0 +1,154 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/kyma-incubator/reconciler/pkg/reconciler"
+	k8s "github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes"
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	//InterceptorsConfigKeyPrefix is the prefix of all component configuration keys which configure interceptors
+	InterceptorsConfigKeyPrefix = ConfigKeyPrefix + "interceptors."
+	//ImageRegistryConfigKey is the component configuration key used to rewrite the registry of container images
+	//to a mirror (e.g. "mirror.example.com" or "eu.gcr.io=mirror.example.com/gcr,docker.io=mirror.example.com/hub")
+	ImageRegistryConfigKey = InterceptorsConfigKeyPrefix + "imageRegistry"
+	//LabelsConfigKey is the component configuration key used to add labels to all resources (e.g. "team=kyma,env=dev")
+	LabelsConfigKey = InterceptorsConfigKeyPrefix + "labels"
+	//AnnotationsConfigKey is the component configuration key used to add annotations to all resources
+	AnnotationsConfigKey = InterceptorsConfigKeyPrefix + "annotations"
+	//PriorityClassConfigKey is the component configuration key used to set the priority class of workloads
+	PriorityClassConfigKey = InterceptorsConfigKeyPrefix + "priorityClassName"
+	//NodeSelectorConfigKey is the component configuration key used to add node selectors to workloads
+	//(e.g. "node.kubernetes.io/pool=kyma")
+	NodeSelectorConfigKey = InterceptorsConfigKeyPrefix + "nodeSelector"
+	//TolerationsConfigKey is the component configuration key used to add tolerations to workloads
+	//(a YAML or JSON list of tolerations)
+	TolerationsConfigKey = InterceptorsConfigKeyPrefix + "tolerations"
+	//ResourcesConfigKey is the component configuration key used to override the resource requests and limits of
+	//containers (a YAML or JSON map of container names to resource requirements, "*" matches all containers)
+	ResourcesConfigKey = InterceptorsConfigKeyPrefix + "resources"
+)
+
+//componentInterceptors returns the interceptors which are defined in the component configuration
+func componentInterceptors(task *reconciler.Task) ([]k8s.ResourceInterceptor, error) {
+	var interceptors []k8s.ResourceInterceptor
+
+	if value, ok := task.Configuration[ImageRegistryConfigKey]; ok {
+		mirrors, err := configMap(value, anyImageRegistry)
+		if err != nil {
+			return nil, interceptorConfigError(err, ImageRegistryConfigKey, task)
+		}
+		interceptors = append(interceptors, &ImageRegistryInterceptor{Mirrors: mirrors})
+	}
+
+	labels, err := optionalConfigMap(task, LabelsConfigKey)
+	if err != nil {
+		return nil, err
+	}
+	annotations, err := optionalConfigMap(task, AnnotationsConfigKey)
+	if err != nil {
+		return nil, err
+	}
+	if len(labels) > 0 || len(annotations) > 0 {
+		interceptors = append(interceptors, &MetadataInterceptor{
+			Labels:      labels,
+			Annotations: annotations,
+		})
+	}
+
+	scheduling := &SchedulingInterceptor{}
+	if value, ok := task.Configuration[PriorityClassConfigKey]; ok {
+		scheduling.PriorityClassName = strings.TrimSpace(fmt.Sprint(value))
+	}
+	if scheduling.NodeSelector, err = optionalConfigMap(task, NodeSelectorConfigKey); err != nil {
+		return nil, err
+	}
+	if value, ok := task.Configuration[TolerationsConfigKey]; ok {
+		if err := configObject(value, &scheduling.Tolerations); err != nil {
+			return nil, interceptorConfigError(err, TolerationsConfigKey, task)
+		}
+	}
+	if scheduling.PriorityClassName != "" || len(scheduling.NodeSelector) > 0 || len(scheduling.Tolerations) > 0 {
+		interceptors = append(interceptors, scheduling)
+	}
+
+	if value, ok := task.Configuration[ResourcesConfigKey]; ok {
+		var overrides map[string]v1.ResourceRequirements
+		if err := configObject(value, &overrides); err != nil {
+			return nil, interceptorConfigError(err, ResourcesConfigKey, task)
+		}
+		if err := validateResourceOverrides(overrides); err != nil {
+			return nil, interceptorConfigError(err, ResourcesConfigKey, task)
+		}
+		interceptors = append(interceptors, &ResourcesInterceptor{Overrides: overrides})
+	}
+
+	return interceptors, nil
+}
+
+func optionalConfigMap(task *reconciler.Task, key string) (map[string]string, error) {
+	value, ok := task.Configuration[key]
+	if !ok {
+		return nil, nil
+	}
+	result, err := configMap(value, "")
+	if err != nil {
+		return nil, interceptorConfigError(err, key, task)
+	}
+	return result, nil
+}
+
+//configMap converts a configuration value into a map. The value can be a map or a string with comma separated
+//key-value pairs ("key1=value1,key2=value2"). A string without key is stored using the default key
+//(if no default key is given, the value is invalid).
+func configMap(value interface{}, defaultKey string) (map[string]string, error) {
+	if values, ok := value.(map[string]interface{}); ok {
+		result := make(map[string]string, len(values))
+		for key, value := range values {
+			result[key] = fmt.Sprint(value)
+		}
+		return result, nil
+	}
+
+	result := make(map[string]string)
+	for _, pair := range strings.Split(fmt.Sprint(value), ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		keyAndValue := strings.SplitN(pair, "=", 2)
+		if len(keyAndValue) == 1 && defaultKey != "" {
+			keyAndValue = []string{defaultKey, keyAndValue[0]}
+		}
+		if len(keyAndValue) != 2 || strings.TrimSpace(keyAndValue[0]) == "" {
+			return nil, fmt.Errorf("entry '%s' is invalid: expected format is 'key=value'", pair)
+		}
+		result[strings.TrimSpace(keyAndValue[0])] = strings.TrimSpace(keyAndValue[1])
+	}
+	return result, nil
+}
+
+//configObject converts a configuration value (either a YAML/JSON string or an already structured value)
+//into the target object
+func configObject(value interface{}, target interface{}) error {
+	var data []byte
+	if str, ok := value.(string); ok {
+		data = []byte(str)
+	} else {
+		var err error
+		if data, err = json.Marshal(value); err != nil {
+			return err
+		}
+	}
+	return yaml.UnmarshalStrict(data, target)
+}
+
+func interceptorConfigError(err error, key string, task *reconciler.Task) error {
+	return errors.Wrapf(err, "configuration '%s' of component '%s' is invalid", key, task.Component)
+}
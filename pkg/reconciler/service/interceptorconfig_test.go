@@ -0,0 +1,80 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/kyma-incubator/reconciler/pkg/reconciler"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestComponentInterceptors(t *testing.T) {
+	t.Run("No interceptors defined", func(t *testing.T) {
+		interceptors, err := componentInterceptors(&reconciler.Task{
+			Configuration: map[string]interface{}{"a.b": "c"},
+		})
+		require.NoError(t, err)
+		require.Empty(t, interceptors)
+	})
+
+	t.Run("All interceptors defined", func(t *testing.T) {
+		interceptors, err := componentInterceptors(&reconciler.Task{
+			Configuration: map[string]interface{}{
+				ImageRegistryConfigKey: "mirror.example.com, eu.gcr.io=gcr.example.com",
+				LabelsConfigKey:        "team=kyma",
+				AnnotationsConfigKey:   map[string]interface{}{"owner": "kyma"},
+				PriorityClassConfigKey: "kyma-system-priority",
+				NodeSelectorConfigKey:  "pool=kyma",
+				TolerationsConfigKey:   "- key: dedicated\n  operator: Equal\n  value: kyma\n  effect: NoSchedule",
+				ResourcesConfigKey: map[string]interface{}{
+					"*": map[string]interface{}{"requests": map[string]interface{}{"cpu": "10m"}},
+				},
+			},
+		})
+		require.NoError(t, err)
+		require.Len(t, interceptors, 4)
+
+		require.Equal(t, &ImageRegistryInterceptor{Mirrors: map[string]string{
+			anyImageRegistry: "mirror.example.com",
+			"eu.gcr.io":      "gcr.example.com",
+		}}, interceptors[0])
+		require.Equal(t, &MetadataInterceptor{
+			Labels:      map[string]string{"team": "kyma"},
+			Annotations: map[string]string{"owner": "kyma"},
+		}, interceptors[1])
+		require.Equal(t, &SchedulingInterceptor{
+			PriorityClassName: "kyma-system-priority",
+			NodeSelector:      map[string]string{"pool": "kyma"},
+			Tolerations: []v1.Toleration{
+				{Key: "dedicated", Operator: v1.TolerationOpEqual, Value: "kyma", Effect: v1.TaintEffectNoSchedule},
+			},
+		}, interceptors[2])
+		overrides := interceptors[3].(*ResourcesInterceptor).Overrides
+		require.True(t, resource.MustParse("10m").Equal(overrides["*"].Requests[v1.ResourceCPU]))
+	})
+
+	t.Run("Invalid configuration", func(t *testing.T) {
+		for key, value := range map[string]interface{}{
+			LabelsConfigKey:      "team",
+			TolerationsConfigKey: "key: dedicated",
+			ResourcesConfigKey:   "'*': {requests: {cpu: abc}}",
+		} {
+			_, err := componentInterceptors(&reconciler.Task{
+				Component:     "component-1",
+				Configuration: map[string]interface{}{key: value},
+			})
+			require.Error(t, err, key)
+			require.Contains(t, err.Error(), key)
+		}
+
+		_, err := componentInterceptors(&reconciler.Task{
+			Component: "component-1",
+			Configuration: map[string]interface{}{
+				ResourcesConfigKey: "app: {requests: {memory: 512Mi}, limits: {memory: 256Mi}}",
+			},
+		})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "request of resource 'memory' (512Mi) exceeds its limit (256Mi)")
+	})
+}
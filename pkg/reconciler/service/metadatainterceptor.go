@@ -0,0 +1,32 @@
+package service
+
+import (
+	k8s "github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+//MetadataInterceptor adds additional labels and annotations to all resources
+type MetadataInterceptor struct {
+	Labels      map[string]string
+	Annotations map[string]string
+}
+
+func (m *MetadataInterceptor) Intercept(resource *unstructured.Unstructured, _ string) (k8s.InterceptionResult, error) {
+	if len(m.Labels) > 0 {
+		resource.SetLabels(mergeStringMaps(resource.GetLabels(), m.Labels))
+	}
+	if len(m.Annotations) > 0 {
+		resource.SetAnnotations(mergeStringMaps(resource.GetAnnotations(), m.Annotations))
+	}
+	return k8s.ContinueInterceptionResult, nil
+}
+
+func mergeStringMaps(target, source map[string]string) map[string]string {
+	if target == nil {
+		target = make(map[string]string, len(source))
+	}
+	for key, value := range source {
+		target[key] = value
+	}
+	return target
+}
@@ -0,0 +1,27 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestMetadataInterceptor(t *testing.T) {
+	resource := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata": map[string]interface{}{
+			"labels": map[string]interface{}{"app": "test"},
+		},
+	}}
+	result, err := (&MetadataInterceptor{
+		Labels:      map[string]string{"team": "kyma"},
+		Annotations: map[string]string{"owner": "kyma"},
+	}).Intercept(resource, "")
+	require.NoError(t, err)
+	require.Equal(t, kubernetes.ContinueInterceptionResult, result)
+	require.Equal(t, map[string]string{"app": "test", "team": "kyma"}, resource.GetLabels())
+	require.Equal(t, map[string]string{"owner": "kyma"}, resource.GetAnnotations())
+}
@@ -0,0 +1,39 @@
+package service
+
+import (
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+//podSpecFields returns the path to the pod spec of a workload resource (nil if the resource has no pod spec)
+func podSpecFields(kind string) []string {
+	switch strings.ToLower(kind) {
+	case "pod":
+		return []string{"spec"}
+	case "deployment", "statefulset", "daemonset", "replicaset", "replicationcontroller", "job":
+		return []string{"spec", "template", "spec"}
+	case "cronjob":
+		return []string{"spec", "jobTemplate", "spec", "template", "spec"}
+	default:
+		return nil
+	}
+}
+
+//podSpec returns a copy of the pod spec of a workload resource. Modifications have to be
+//written back by using setPodSpec.
+func podSpec(resource *unstructured.Unstructured) (map[string]interface{}, bool, error) {
+	fields := podSpecFields(resource.GetKind())
+	if fields == nil {
+		return nil, false, nil
+	}
+	spec, found, err := unstructured.NestedMap(resource.Object, fields...)
+	if err != nil || !found {
+		return nil, false, err
+	}
+	return spec, true, nil
+}
+
+func setPodSpec(resource *unstructured.Unstructured, spec map[string]interface{}) error {
+	return unstructured.SetNestedMap(resource.Object, spec, podSpecFields(resource.GetKind())...)
+}
@@ -0,0 +1,170 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	k8s "github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes"
+	"github.com/pkg/errors"
+	v1apps "k8s.io/api/apps/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PreflightCheck is the default preflight action which runs before a cluster gets deleted. It blocks the deletion
+// if the namespace of the component contains workloads or PVCs which aren't part of the component manifest
+// (e.g. workloads or volumes of customers) because they would get lost when Kyma is removed.
+type PreflightCheck struct {
+}
+
+func (p *PreflightCheck) Run(context *ActionContext) error {
+	namespace := context.Task.Namespace
+	clientset, err := context.KubeClient.Clientset()
+	if err != nil {
+		return errors.Wrap(err, "failed to retrieve clientset for preflight check")
+	}
+
+	if _, err := clientset.CoreV1().Namespaces().Get(context.Context, namespace, metav1.GetOptions{}); err != nil {
+		if k8serr.IsNotFound(err) {
+			context.Logger.Debugf("Preflight check skipped: namespace '%s' doesn't exist", namespace)
+			return nil
+		}
+		return errors.Wrapf(err, "failed to retrieve namespace '%s'", namespace)
+	}
+
+	kymaResources, err := manifestResources(context, namespace)
+	if err != nil {
+		return err
+	}
+	isKymaResource := func(kind string, meta metav1.ObjectMeta) bool {
+		return kymaResources[fmt.Sprintf("%s/%s", kind, meta.Name)] || isManaged(meta)
+	}
+
+	var foreign []string
+	statefulSets, err := clientset.AppsV1().StatefulSets(namespace).List(context.Context, metav1.ListOptions{})
+	if err != nil {
+		return errors.Wrapf(err, "failed to list statefulsets in namespace '%s'", namespace)
+	}
+	var claimPrefixes []string //PVCs created from volume claim templates of Kyma statefulsets
+	for i := range statefulSets.Items {
+		sts := &statefulSets.Items[i]
+		if !isKymaResource("StatefulSet", sts.ObjectMeta) {
+			foreign = append(foreign, fmt.Sprintf("StatefulSet/%s", sts.Name))
+			continue
+		}
+		claimPrefixes = append(claimPrefixes, volumeClaimPrefixes(sts)...)
+	}
+
+	deployments, err := clientset.AppsV1().Deployments(namespace).List(context.Context, metav1.ListOptions{})
+	if err != nil {
+		return errors.Wrapf(err, "failed to list deployments in namespace '%s'", namespace)
+	}
+	for i := range deployments.Items {
+		if !isKymaResource("Deployment", deployments.Items[i].ObjectMeta) {
+			foreign = append(foreign, fmt.Sprintf("Deployment/%s", deployments.Items[i].Name))
+		}
+	}
+
+	daemonSets, err := clientset.AppsV1().DaemonSets(namespace).List(context.Context, metav1.ListOptions{})
+	if err != nil {
+		return errors.Wrapf(err, "failed to list daemonsets in namespace '%s'", namespace)
+	}
+	for i := range daemonSets.Items {
+		if !isKymaResource("DaemonSet", daemonSets.Items[i].ObjectMeta) {
+			foreign = append(foreign, fmt.Sprintf("DaemonSet/%s", daemonSets.Items[i].Name))
+		}
+	}
+
+	pvcs, err := clientset.CoreV1().PersistentVolumeClaims(namespace).List(context.Context, metav1.ListOptions{})
+	if err != nil {
+		return errors.Wrapf(err, "failed to list persistent volume claims in namespace '%s'", namespace)
+	}
+	for i := range pvcs.Items {
+		pvc := &pvcs.Items[i]
+		if !isKymaResource("PersistentVolumeClaim", pvc.ObjectMeta) && !hasPrefix(pvc.Name, claimPrefixes) {
+			foreign = append(foreign, fmt.Sprintf("PersistentVolumeClaim/%s", pvc.Name))
+		}
+	}
+
+	if len(foreign) > 0 {
+		return NewPreflightBlockedError("deletion of component '%s' is blocked: namespace '%s' contains resources "+
+			"which are not part of the component: %s", context.Task.Component, namespace, strings.Join(foreign, ", "))
+	}
+	context.Logger.Debugf("Preflight check of component '%s' passed: namespace '%s' contains no foreign resources",
+		context.Task.Component, namespace)
+	return nil
+}
+
+// PreflightBlockedError is returned by preflight actions which block the deletion of a cluster. The runner doesn't
+// retry preflight actions which failed with this error (other errors, e.g. of the API server, are retried).
+type PreflightBlockedError struct {
+	reason string
+}
+
+func (err *PreflightBlockedError) Error() string {
+	return err.reason
+}
+
+func NewPreflightBlockedError(format string, args ...interface{}) error {
+	return &PreflightBlockedError{
+		reason: fmt.Sprintf(format, args...),
+	}
+}
+
+func IsPreflightBlockedError(err error) bool {
+	var blockedErr *PreflightBlockedError
+	return errors.As(err, &blockedErr)
+}
+
+//manifestResources returns the resources of the component manifest (incl. hooks) in the namespace as 'kind/name'
+func manifestResources(context *ActionContext, namespace string) (map[string]bool, error) {
+	if context.ChartProvider == nil {
+		return nil, errors.New("preflight check requires a chart provider to render the component manifest")
+	}
+	manifest, err := NewInstall(context.Logger).renderManifest(context.ChartProvider, context.Task)
+	if err != nil {
+		return nil, err
+	}
+
+	manifests := []string{manifest.Manifest}
+	for _, hook := range manifest.Hooks {
+		manifests = append(manifests, hook.Manifest)
+	}
+	result := make(map[string]bool)
+	for _, rawManifest := range manifests {
+		unstructs, err := k8s.ToUnstructured([]byte(rawManifest), true)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse manifest of component '%s'", context.Task.Component)
+		}
+		for _, unstruct := range unstructs {
+			if k8s.ResolveNamespace(unstruct, namespace) == namespace {
+				result[fmt.Sprintf("%s/%s", unstruct.GetKind(), unstruct.GetName())] = true
+			}
+		}
+	}
+	return result, nil
+}
+
+//isManaged returns true if the resource is labelled as deployed by the reconciler (e.g. by a previous Kyma version)
+//or is owned by another resource
+func isManaged(meta metav1.ObjectMeta) bool {
+	return meta.Labels[ManagedByLabel] == LabelReconcilerValue || len(meta.OwnerReferences) > 0
+}
+
+//volumeClaimPrefixes returns the name prefixes of PVCs which are created for the volume claim templates of a statefulset
+func volumeClaimPrefixes(sts *v1apps.StatefulSet) []string {
+	var result []string
+	for _, template := range sts.Spec.VolumeClaimTemplates {
+		result = append(result, fmt.Sprintf("%s-%s-", template.Name, sts.Name))
+	}
+	return result
+}
+
+func hasPrefix(name string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
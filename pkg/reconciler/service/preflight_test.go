@@ -0,0 +1,133 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/avast/retry-go"
+	"github.com/kyma-incubator/reconciler/pkg/logger"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/chart"
+	chartmocks "github.com/kyma-incubator/reconciler/pkg/reconciler/chart/mocks"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes/mocks"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	v1apps "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+const preflightManifest = `apiVersion: apps/v1
+kind: StatefulSet
+metadata:
+  name: db
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: deployment
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: other-namespace
+  namespace: other`
+
+func newPreflightContext(objects ...runtime.Object) *ActionContext {
+	kubeClient := &mocks.Client{}
+	kubeClient.On("Clientset").Return(fake.NewSimpleClientset(objects...), nil)
+	chartProvider := &chartmocks.Provider{}
+	chartProvider.On("RenderManifest", mock.Anything).Return(&chart.Manifest{
+		Type:     chart.HelmChart,
+		Name:     "component",
+		Manifest: preflightManifest,
+		Hooks:    chart.Hooks{{Name: "migration", Kind: "Job", Manifest: jobHookManifest}},
+	}, nil)
+	return &ActionContext{
+		KubeClient:    kubeClient,
+		ChartProvider: chartProvider,
+		Context:       context.Background(),
+		Logger:        logger.NewLogger(true),
+		Task: &reconciler.Task{
+			Component: "component",
+			Namespace: "kyma-system",
+		},
+	}
+}
+
+func namespacedMeta(name string) metav1.ObjectMeta {
+	return metav1.ObjectMeta{Name: name, Namespace: "kyma-system"}
+}
+
+func managedMeta(name string) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:      name,
+		Namespace: "kyma-system",
+		Labels:    map[string]string{ManagedByLabel: LabelReconcilerValue},
+	}
+}
+
+func TestPreflightCheck(t *testing.T) {
+	namespace := &v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "kyma-system"}}
+	kymaStatefulSet := &v1apps.StatefulSet{
+		ObjectMeta: namespacedMeta("db"),
+		Spec: v1apps.StatefulSetSpec{
+			VolumeClaimTemplates: []v1.PersistentVolumeClaim{{ObjectMeta: metav1.ObjectMeta{Name: "storage"}}},
+		},
+	}
+
+	t.Run("Pass if namespace doesn't exist", func(t *testing.T) {
+		require.NoError(t, (&PreflightCheck{}).Run(newPreflightContext()))
+	})
+
+	t.Run("Pass if all resources are part of the component", func(t *testing.T) {
+		ctx := newPreflightContext(namespace, kymaStatefulSet,
+			&v1apps.Deployment{ObjectMeta: namespacedMeta("deployment")},
+			&v1apps.Deployment{ObjectMeta: managedMeta("removed-in-new-version")},
+			&v1apps.DaemonSet{ObjectMeta: metav1.ObjectMeta{
+				Name:            "owned",
+				Namespace:       "kyma-system",
+				OwnerReferences: []metav1.OwnerReference{{Kind: "Operator", Name: "operator"}},
+			}},
+			&v1.PersistentVolumeClaim{ObjectMeta: namespacedMeta("storage-db-0")})
+		require.NoError(t, (&PreflightCheck{}).Run(ctx))
+	})
+
+	t.Run("Fail if namespace contains foreign resources", func(t *testing.T) {
+		ctx := newPreflightContext(namespace, kymaStatefulSet,
+			&v1apps.Deployment{ObjectMeta: namespacedMeta("customer-app")},
+			&v1apps.Deployment{ObjectMeta: namespacedMeta("other-namespace")},
+			&v1.PersistentVolumeClaim{ObjectMeta: namespacedMeta("customer-data")})
+		err := (&PreflightCheck{}).Run(ctx)
+		require.Error(t, err)
+		require.True(t, IsPreflightBlockedError(err))
+		require.Contains(t, err.Error(), "Deployment/customer-app")
+		require.Contains(t, err.Error(), "Deployment/other-namespace")
+		require.Contains(t, err.Error(), "PersistentVolumeClaim/customer-data")
+		require.NotContains(t, err.Error(), "StatefulSet/db")
+	})
+}
+
+func TestPreflightBlockedErrorIsNotRetried(t *testing.T) {
+	attempts := 0
+	err := retry.Do(func() error {
+		attempts++
+		return retryableError(errors.Wrap(NewPreflightBlockedError("deletion of component '%s' is blocked", "comp"), "failed"))
+	}, retry.Attempts(5), retry.Delay(time.Millisecond))
+	require.Error(t, err)
+	require.Equal(t, 1, attempts)
+	require.Contains(t, err.Error(), "deletion of component 'comp' is blocked")
+
+	//other errors of preflight actions are retried
+	attempts = 0
+	err = retry.Do(func() error {
+		attempts++
+		return retryableError(errors.New("failed to list deployments"))
+	}, retry.Attempts(3), retry.Delay(time.Millisecond))
+	require.Error(t, err)
+	require.Equal(t, 3, attempts)
+}
@@ -10,6 +10,11 @@ import (
 	"github.com/kyma-incubator/reconciler/pkg/reconciler"
 	"github.com/kyma-incubator/reconciler/pkg/reconciler/callback"
 	"github.com/kyma-incubator/reconciler/pkg/reconciler/chart"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/git"
+	k8s "github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes/progress"
+	"github.com/kyma-incubator/reconciler/pkg/signature"
+	"github.com/kyma-incubator/reconciler/pkg/ssl"
 	"go.uber.org/zap"
 )
 
@@ -20,6 +25,9 @@ const (
 	defaultTimeout    = 10 * time.Minute
 	defaultWorkers    = 100
 	defaultWorkspace  = "."
+	defaultCacheTTL   = 10 * time.Minute
+	defaultCacheSize  = 50
+	callbackTimeout   = 1 * time.Minute
 )
 
 var (
@@ -29,9 +37,23 @@ var (
 
 type ComponentReconciler struct {
 	workspace             string
+	workspaceGCConfig     *chart.WorkspaceGCConfig
+	renderCacheConfig     *chart.RenderCacheConfig
+	gitCredentials        *git.CredentialsMapping
+	offlineWorkspace      bool
+	verificationKeys      chart.VerificationKeys
 	dependencies          []string
 	heartbeatSenderConfig heartbeatSenderConfig
 	progressTrackerConfig progressTrackerConfig
+	applyConfig           *k8s.ApplyConfig
+	applyWorkers          int
+	readinessChecks       []*progress.ConditionCheck
+	deletionConfig        *k8s.DeletionConfig
+	releaseMode           chart.ReleaseMode
+	clientCacheConfig     clientCacheConfig
+	clientCache           *k8s.ClientCache
+	callbackClientConfig  *ssl.ClientConfig
+	signatureKeyFile      string
 	//reconcile actions:
 	preReconcileAction  Action
 	reconcileAction     Action
@@ -40,6 +62,8 @@ type ComponentReconciler struct {
 	preDeleteAction  Action
 	deleteAction     Action
 	postDeleteAction Action
+	//preflight action (runs before a cluster gets deleted):
+	preflightAction Action
 	//retry:
 	maxRetries int
 	retryDelay time.Duration
@@ -61,6 +85,11 @@ type progressTrackerConfig struct {
 	timeout  time.Duration
 }
 
+type clientCacheConfig struct {
+	ttl  time.Duration
+	size int
+}
+
 func NewComponentReconciler(reconcilerName string) (*ComponentReconciler, error) {
 	recon := &ComponentReconciler{
 		workspace: defaultWorkspace,
@@ -83,6 +112,14 @@ func UseGlobalWorkspaceFactory(workspaceFactory chart.Factory) error {
 	return nil
 }
 
+//GlobalWorkspaceFactory returns the workspace factory shared by all component reconcilers (nil if undefined)
+func GlobalWorkspaceFactory() chart.Factory {
+	m.Lock()
+	defer m.Unlock()
+
+	return wsFactory
+}
+
 //Deprecated: do not switch global workspace at any time!
 func RefreshGlobalWorkspaceFactory(workspaceFactory chart.Factory) error {
 	m.Lock()
@@ -107,7 +144,13 @@ func (r *ComponentReconciler) workspaceFactory(repo *reconciler.Repository) (*ch
 	var err error
 	if wsFactory == nil {
 		r.logger.Debugf("Creating new workspace factory using storage directory '%s'", r.workspace)
-		wsFactory, err = chart.NewFactory(repo, r.workspace, r.logger)
+		var defaultFactory *chart.DefaultFactory
+		defaultFactory, err = chart.NewFactory(repo, r.workspace, r.logger)
+		if err == nil {
+			wsFactory = defaultFactory.WithGitCredentials(r.gitCredentials).
+				WithVerificationKeys(r.verificationKeys).
+				WithOffline(r.offlineWorkspace)
+		}
 	}
 
 	return &wsFactory, err
@@ -145,6 +188,28 @@ func (r *ComponentReconciler) validate() error {
 	if r.progressTrackerConfig.timeout == 0 {
 		r.progressTrackerConfig.timeout = defaultTimeout
 	}
+	if r.clientCacheConfig.ttl < 0 {
+		return fmt.Errorf("client cache TTL cannot be < 0 (got %.1f secs)", r.clientCacheConfig.ttl.Seconds())
+	}
+	if r.clientCacheConfig.ttl == 0 {
+		r.clientCacheConfig.ttl = defaultCacheTTL
+	}
+	if r.clientCacheConfig.size < 0 {
+		return fmt.Errorf("client cache size cannot be < 0 (got %d)", r.clientCacheConfig.size)
+	}
+	if r.clientCacheConfig.size == 0 {
+		r.clientCacheConfig.size = defaultCacheSize
+	}
+	if r.clientCache == nil {
+		clientCache, err := k8s.NewClientCache(r.clientCacheConfig.ttl, r.clientCacheConfig.size)
+		if err != nil {
+			return err
+		}
+		r.clientCache = clientCache
+	}
+	if r.applyWorkers < 0 {
+		return fmt.Errorf("apply workers cannot be < 0 (got %d)", r.applyWorkers)
+	}
 	if r.maxRetries < 0 {
 		return fmt.Errorf("max-retries cannot be < 0 (got %d)", r.maxRetries)
 	}
@@ -183,6 +248,37 @@ func (r *ComponentReconciler) WithWorkspace(workspace string) *ComponentReconcil
 	return r
 }
 
+// WithWorkspaceGC enables the garbage collection of workspaces which exceed the disk quota or the max age
+// (applies only to remotely started reconcilers)
+func (r *ComponentReconciler) WithWorkspaceGC(workspaceGCConfig *chart.WorkspaceGCConfig) *ComponentReconciler {
+	r.workspaceGCConfig = workspaceGCConfig
+	return r
+}
+
+// WithRenderCache configures the cache of rendered charts (nil disables caching)
+func (r *ComponentReconciler) WithRenderCache(renderCacheConfig *chart.RenderCacheConfig) *ComponentReconciler {
+	r.renderCacheConfig = renderCacheConfig
+	return r
+}
+
+// WithGitCredentials configures the credentials used to access GIT repositories of Kyma and external components
+func (r *ComponentReconciler) WithGitCredentials(gitCredentials *git.CredentialsMapping) *ComponentReconciler {
+	r.gitCredentials = gitCredentials
+	return r
+}
+
+// WithVerificationKeys defines the public keys which are trusted to verify signatures of component sources
+func (r *ComponentReconciler) WithVerificationKeys(keys chart.VerificationKeys) *ComponentReconciler {
+	r.verificationKeys = keys
+	return r
+}
+
+// WithOfflineWorkspace refuses the download of Kyma sources: workspaces have to be imported from a bundle
+func (r *ComponentReconciler) WithOfflineWorkspace(offline bool) *ComponentReconciler {
+	r.offlineWorkspace = offline
+	return r
+}
+
 //Deprecated: support for dependencies will be dropped with https://github.com/kyma-incubator/reconciler/issues/278
 //Please implement a component reconciler in way that it can verify its dependencies internally or
 //ensure that it will work after the reconciler was retried and the dependency became available.
@@ -233,6 +329,14 @@ func (r *ComponentReconciler) WithPostDeleteAction(postDeleteAction Action) *Com
 	return r
 }
 
+// WithPreflightAction replaces the default preflight check (see PreflightCheck) which is executed before a cluster
+// gets deleted. A failing preflight action blocks the deletion of the cluster: actions should report a blocked
+// deletion with NewPreflightBlockedError to avoid that they get retried.
+func (r *ComponentReconciler) WithPreflightAction(preflightAction Action) *ComponentReconciler {
+	r.preflightAction = preflightAction
+	return r
+}
+
 func (r *ComponentReconciler) WithHeartbeatSenderConfig(interval, timeout time.Duration) *ComponentReconciler {
 	r.heartbeatSenderConfig.interval = interval
 	r.heartbeatSenderConfig.timeout = timeout
@@ -245,6 +349,57 @@ func (r *ComponentReconciler) WithProgressTrackerConfig(interval, timeout time.D
 	return r
 }
 
+// WithClientCacheConfig defines how long and how many Kubernetes clients are cached. Clients are shared
+// by all tasks which target the same cluster.
+func (r *ComponentReconciler) WithClientCacheConfig(ttl time.Duration, size int) *ComponentReconciler {
+	r.clientCacheConfig.ttl = ttl
+	r.clientCacheConfig.size = size
+	r.clientCache = nil //cache will be re-created with the new settings
+	return r
+}
+
+// WithApplyConfig defines how resources are applied on the cluster (e.g. by using server-side apply).
+// Components can override these settings by their configuration (see ApplyModeConfigKey).
+func (r *ComponentReconciler) WithApplyConfig(applyConfig *k8s.ApplyConfig) *ComponentReconciler {
+	r.applyConfig = applyConfig
+	return r
+}
+
+// WithApplyWorkers defines how many independent resources are applied in parallel on the cluster
+// (0 means the default of the Kubernetes client is used)
+func (r *ComponentReconciler) WithApplyWorkers(workers int) *ComponentReconciler {
+	r.applyWorkers = workers
+	return r
+}
+
+// WithReadinessChecks defines how the readiness of custom resources is verified. Custom resources are only
+// awaited after a deployment if a readiness check for their kind exists.
+func (r *ComponentReconciler) WithReadinessChecks(checks ...*progress.ConditionCheck) *ComponentReconciler {
+	r.readinessChecks = checks
+	return r
+}
+
+// WithDeletionConfig defines how the resources of a component are deleted (e.g. by the finalizer-aware deletion mode
+// which removes custom resources first and reports or strips finalizers of stuck resources).
+func (r *ComponentReconciler) WithDeletionConfig(deletionConfig *k8s.DeletionConfig) *ComponentReconciler {
+	r.deletionConfig = deletionConfig
+	return r
+}
+
+// WithReleaseMode defines whether deployments of components are recorded as Helm releases on the cluster
+// (see chart.ReleaseMode). Recorded releases can be inspected and rolled back by the Helm CLI.
+func (r *ComponentReconciler) WithReleaseMode(releaseMode chart.ReleaseMode) *ComponentReconciler {
+	r.releaseMode = releaseMode
+	return r
+}
+
+// WithCallbackSecurity configures mutual TLS and request signing for callbacks sent to the mothership reconciler
+func (r *ComponentReconciler) WithCallbackSecurity(clientConfig *ssl.ClientConfig, signatureKeyFile string) *ComponentReconciler {
+	r.callbackClientConfig = clientConfig
+	r.signatureKeyFile = signatureKeyFile
+	return r
+}
+
 func (r *ComponentReconciler) StartLocal(ctx context.Context, model *reconciler.Task, logger *zap.SugaredLogger) error {
 	//ensure model is valid
 	if err := model.Validate(); err != nil {
@@ -268,9 +423,24 @@ func (r *ComponentReconciler) StartRemote(ctx context.Context) (*WorkerPool, err
 	if err := r.validate(); err != nil {
 		return nil, err
 	}
+	httpClient, err := ssl.NewHTTPClient(r.callbackClientConfig, callbackTimeout)
+	if err != nil {
+		return nil, err
+	}
+	signer, err := signature.NewSignerFromFile(r.signatureKeyFile)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.initRenderCache(); err != nil {
+		return nil, err
+	}
+	if r.workspaceGCConfig.Enabled() {
+		go r.collectWorkspaces(ctx)
+	}
 	return newWorkerPoolBuilder(&dependencyChecker{r.dependencies}, r.newRunnerFunc).
 		WithPoolSize(r.workers).
 		WithDebug(r.debug).
+		WithCallbackSecurity(httpClient, signer).
 		Build(ctx)
 }
 
@@ -279,6 +449,6 @@ func (r *ComponentReconciler) newRunnerFunc(ctx context.Context, model *reconcil
 	return func() error {
 		timeoutCtx, cancel := context.WithTimeout(ctx, r.timeout)
 		defer cancel()
-		return (&runner{r, NewInstall(logger), logger}).Run(timeoutCtx, model, callback)
+		return (&runner{r, NewInstall(logger).WithReleaseMode(r.releaseMode), logger}).Run(timeoutCtx, model, callback)
 	}
 }
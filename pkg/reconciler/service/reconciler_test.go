@@ -5,6 +5,8 @@ import (
 	"testing"
 	"time"
 
+	k8s "github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes/progress"
 	"github.com/stretchr/testify/require"
 )
 
@@ -80,6 +82,25 @@ func TestReconciler(t *testing.T) {
 		recon.WithWorkers(888, 999*time.Second)
 		require.Equal(t, 888, recon.workers)
 		require.Equal(t, 999*time.Second, recon.timeout)
+
+		applyConfig := &k8s.ApplyConfig{Mode: k8s.ServerSideApplyMode}
+		recon.WithApplyConfig(applyConfig)
+		require.Equal(t, applyConfig, recon.applyConfig)
+
+		recon.WithApplyWorkers(5)
+		require.Equal(t, 5, recon.applyWorkers)
+
+		recon.WithClientCacheConfig(5*time.Minute, 3)
+		require.Equal(t, 5*time.Minute, recon.clientCacheConfig.ttl)
+		require.Equal(t, 3, recon.clientCacheConfig.size)
+
+		readinessCheck := &progress.ConditionCheck{ConditionType: "Ready"}
+		recon.WithReadinessChecks(readinessCheck)
+		require.Equal(t, []*progress.ConditionCheck{readinessCheck}, recon.readinessChecks)
+
+		deletionConfig := &k8s.DeletionConfig{Mode: k8s.FinalizerAwareDeletionMode, StripFinalizers: true}
+		recon.WithDeletionConfig(deletionConfig)
+		require.Equal(t, deletionConfig, recon.deletionConfig)
 	})
 
 }
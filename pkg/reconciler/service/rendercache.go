@@ -0,0 +1,40 @@
+package service
+
+import (
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/chart"
+)
+
+var renderCache *chart.RenderCache //singleton shared by all component reconcilers
+
+//initRenderCache creates the global render cache if it's enabled and wasn't created yet
+func (r *ComponentReconciler) initRenderCache() error {
+	m.Lock()
+	defer m.Unlock()
+
+	if renderCache != nil || !r.renderCacheConfig.Enabled() {
+		return nil
+	}
+	cache, err := chart.NewRenderCache(r.renderCacheConfig, r.logger)
+	if err != nil {
+		return err
+	}
+	r.logger.Infof("Starting render cache (memory size: %d bytes, disk size: %d bytes, directory: '%s')",
+		r.renderCacheConfig.MemorySize, r.renderCacheConfig.DiskSize, r.renderCacheConfig.Dir)
+	renderCache = cache
+	return nil
+}
+
+// RenderCacheStats returns the statistics of the render cache (nil if the render cache is disabled)
+func RenderCacheStats() *chart.RenderCacheStats {
+	cache := globalRenderCache()
+	if cache == nil {
+		return nil
+	}
+	return cache.Stats()
+}
+
+func globalRenderCache() *chart.RenderCache {
+	m.Lock()
+	defer m.Unlock()
+	return renderCache
+}
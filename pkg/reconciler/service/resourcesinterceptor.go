@@ -0,0 +1,126 @@
+package service
+
+import (
+	"fmt"
+
+	k8s "github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes"
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const anyContainer = "*"
+
+//ResourcesInterceptor overrides the resource requests and limits of containers. Overridden requests which exceed
+//the limit of the container are clamped to the limit (otherwise the API server would reject the workload).
+type ResourcesInterceptor struct {
+	//Overrides maps the container name to the resource requirements which are set ("*" applies to all containers)
+	Overrides map[string]v1.ResourceRequirements
+}
+
+func (r *ResourcesInterceptor) Intercept(resource *unstructured.Unstructured, _ string) (k8s.InterceptionResult, error) {
+	spec, found, err := podSpec(resource)
+	if err != nil {
+		return k8s.ErrorInterceptionResult, err
+	}
+	if !found {
+		return k8s.ContinueInterceptionResult, nil
+	}
+
+	containers, ok := spec["containers"].([]interface{})
+	if !ok {
+		return k8s.ContinueInterceptionResult, nil
+	}
+	for _, container := range containers {
+		container, ok := container.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name, _ := container["name"].(string)
+		overridden := false
+		for _, key := range []string{anyContainer, name} { //container specific overrides take precedence
+			override, ok := r.Overrides[key]
+			if !ok {
+				continue
+			}
+			if err := overrideResources(container, "requests", override.Requests); err != nil {
+				return k8s.ErrorInterceptionResult, err
+			}
+			if err := overrideResources(container, "limits", override.Limits); err != nil {
+				return k8s.ErrorInterceptionResult, err
+			}
+			overridden = true
+		}
+		if overridden {
+			if err := clampRequests(container); err != nil {
+				return k8s.ErrorInterceptionResult, errors.Wrapf(err, "failed to override resources of container '%s'", name)
+			}
+		}
+	}
+
+	if err := setPodSpec(resource, spec); err != nil {
+		return k8s.ErrorInterceptionResult, err
+	}
+	return k8s.ContinueInterceptionResult, nil
+}
+
+func overrideResources(container map[string]interface{}, field string, resources v1.ResourceList) error {
+	if len(resources) == 0 {
+		return nil
+	}
+	values, _, err := unstructured.NestedMap(container, "resources", field)
+	if err != nil {
+		return err
+	}
+	if values == nil {
+		values = make(map[string]interface{}, len(resources))
+	}
+	for name, quantity := range resources {
+		values[string(name)] = quantity.String()
+	}
+	return unstructured.SetNestedMap(container, values, "resources", field)
+}
+
+//clampRequests lowers requests of the container which exceed the limit of the same resource
+func clampRequests(container map[string]interface{}) error {
+	requests, _, err := unstructured.NestedMap(container, "resources", "requests")
+	if err != nil || len(requests) == 0 {
+		return err
+	}
+	limits, _, err := unstructured.NestedMap(container, "resources", "limits")
+	if err != nil || len(limits) == 0 {
+		return err
+	}
+	for name, request := range requests {
+		limit, ok := limits[name]
+		if !ok {
+			continue
+		}
+		requestQuantity, err := resource.ParseQuantity(fmt.Sprint(request))
+		if err != nil {
+			return errors.Wrapf(err, "invalid request of resource '%s'", name)
+		}
+		limitQuantity, err := resource.ParseQuantity(fmt.Sprint(limit))
+		if err != nil {
+			return errors.Wrapf(err, "invalid limit of resource '%s'", name)
+		}
+		if requestQuantity.Cmp(limitQuantity) > 0 {
+			requests[name] = limitQuantity.String()
+		}
+	}
+	return unstructured.SetNestedMap(container, requests, "resources", "requests")
+}
+
+//validateResourceOverrides rejects overrides which request more of a resource than they limit
+func validateResourceOverrides(overrides map[string]v1.ResourceRequirements) error {
+	for container, override := range overrides {
+		for name, request := range override.Requests {
+			if limit, ok := override.Limits[name]; ok && request.Cmp(limit) > 0 {
+				return fmt.Errorf("request of resource '%s' (%s) exceeds its limit (%s) for container '%s'",
+					name, request.String(), limit.String(), container)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestResourcesInterceptor(t *testing.T) {
+	interceptor := &ResourcesInterceptor{Overrides: map[string]v1.ResourceRequirements{
+		anyContainer: {
+			Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse("10m")},
+		},
+		"app": {
+			Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse("100m")},
+			Limits:   v1.ResourceList{v1.ResourceMemory: resource.MustParse("256Mi")},
+		},
+	}}
+
+	pod := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Pod",
+		"spec": map[string]interface{}{
+			"containers": []interface{}{
+				map[string]interface{}{
+					"name": "app",
+					"resources": map[string]interface{}{
+						"requests": map[string]interface{}{"memory": "64Mi"},
+					},
+				},
+				map[string]interface{}{"name": "sidecar"},
+			},
+		},
+	}}
+	result, err := interceptor.Intercept(pod, "")
+	require.NoError(t, err)
+	require.Equal(t, kubernetes.ContinueInterceptionResult, result)
+
+	containers, _, err := unstructured.NestedSlice(pod.Object, "spec", "containers")
+	require.NoError(t, err)
+	require.Equal(t, map[string]interface{}{
+		"requests": map[string]interface{}{"cpu": "100m", "memory": "64Mi"},
+		"limits":   map[string]interface{}{"memory": "256Mi"},
+	}, containers[0].(map[string]interface{})["resources"])
+	require.Equal(t, map[string]interface{}{
+		"requests": map[string]interface{}{"cpu": "10m"},
+	}, containers[1].(map[string]interface{})["resources"])
+}
+
+func TestResourcesInterceptorClampsRequests(t *testing.T) {
+	interceptor := &ResourcesInterceptor{Overrides: map[string]v1.ResourceRequirements{
+		"app": {
+			Requests: v1.ResourceList{
+				v1.ResourceCPU:    resource.MustParse("500m"),
+				v1.ResourceMemory: resource.MustParse("128Mi"),
+			},
+		},
+	}}
+
+	deployment := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"spec": map[string]interface{}{
+			"template": map[string]interface{}{
+				"spec": map[string]interface{}{
+					"containers": []interface{}{
+						map[string]interface{}{
+							"name": "app",
+							"resources": map[string]interface{}{
+								"limits": map[string]interface{}{"cpu": "200m", "memory": "1Gi"},
+							},
+						},
+					},
+				},
+			},
+		},
+	}}
+	_, err := interceptor.Intercept(deployment, "")
+	require.NoError(t, err)
+
+	containers, _, err := unstructured.NestedSlice(deployment.Object, "spec", "template", "spec", "containers")
+	require.NoError(t, err)
+	require.Equal(t, map[string]interface{}{
+		"requests": map[string]interface{}{"cpu": "200m", "memory": "128Mi"}, //CPU request clamped to the limit
+		"limits":   map[string]interface{}{"cpu": "200m", "memory": "1Gi"},
+	}, containers[0].(map[string]interface{})["resources"])
+}
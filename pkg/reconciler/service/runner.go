@@ -10,6 +10,7 @@ import (
 	"github.com/kyma-incubator/reconciler/pkg/model"
 	"github.com/kyma-incubator/reconciler/pkg/reconciler"
 	"github.com/kyma-incubator/reconciler/pkg/reconciler/callback"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/chart"
 	"github.com/kyma-incubator/reconciler/pkg/reconciler/heartbeat"
 	k8s "github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes"
 	"github.com/pkg/errors"
@@ -31,12 +32,14 @@ func (r *runner) Run(ctx context.Context, task *reconciler.Task, callback callba
 		return err
 	}
 
+	var deletionReport *k8s.DeletionReport
 	retryable := func() error {
 		if err := heartbeatSender.Running(); err != nil {
 			r.logger.Warnf("Runner: failed to start status updater: %s", err)
 			return err
 		}
-		err := r.reconcile(ctx, task)
+		deletionReport = k8s.NewDeletionReport() //report only the results of the latest attempt
+		err := r.reconcile(ctx, task, deletionReport)
 		if err != nil {
 			r.logger.Warnf("Runner: failing reconciliation of '%s' in version '%s' with profile '%s': %s",
 				task.Component, task.Version, task.Profile, err)
@@ -44,7 +47,7 @@ func (r *runner) Run(ctx context.Context, task *reconciler.Task, callback callba
 				err = errors.Wrap(err, heartbeatErr.Error())
 			}
 		}
-		return err
+		return retryableError(err)
 	}
 
 	//retry the reconciliation in case of an error
@@ -54,6 +57,10 @@ func (r *runner) Run(ctx context.Context, task *reconciler.Task, callback callba
 		retry.LastErrorOnly(false),
 		retry.Context(ctx))
 
+	if task.Type == model.OperationTypeDelete {
+		heartbeatSender.WithDeletionReport(toCallbackDeletionReport(deletionReport))
+	}
+
 	if err == nil {
 		r.logger.Infof("Runner: reconciliation of component '%s' for version '%s' finished successfully",
 			task.Component, task.Version)
@@ -75,34 +82,53 @@ func (r *runner) Run(ctx context.Context, task *reconciler.Task, callback callba
 	return err
 }
 
-func (r *runner) reconcile(ctx context.Context, task *reconciler.Task) error {
-	kubeClient, err := k8s.NewKubernetesClient(task.Kubeconfig, r.logger, &k8s.Config{
+func (r *runner) reconcile(ctx context.Context, task *reconciler.Task, deletionReport *k8s.DeletionReport) error {
+	applyConfig, err := componentApplyConfig(task)
+	if err != nil {
+		return err
+	}
+
+	kubeClient, err := r.newKubernetesClient(task.Kubeconfig, &k8s.Config{
 		ProgressInterval: r.progressTrackerConfig.interval,
 		ProgressTimeout:  r.progressTrackerConfig.timeout,
+		Apply:            r.applyConfig.Merge(applyConfig),
+		ApplyWorkers:     r.applyWorkers,
+		ReadinessChecks:  r.readinessChecks,
+		Deletion:         r.deletionConfig,
+		DeletionReport:   deletionReport,
 	})
 	if err != nil {
 		return err
 	}
 
-	chartProvider, err := r.newChartProvider(task.Repository)
+	wsFactory, err := r.workspaceFactory(task.Repository)
 	if err != nil {
-		return errors.Wrap(err, "Failed to create chart provider instance")
+		return err
 	}
 
-	wsFactory, err := r.workspaceFactory(task.Repository)
+	//protect the workspaces used by this task against garbage collection
+	taskWsFactory, releaseWorkspaces := chart.LeaseWorkspaces(*wsFactory)
+	defer releaseWorkspaces()
+
+	chartProvider, err := chart.NewDefaultProvider(taskWsFactory, r.logger)
 	if err != nil {
-		return err
+		return errors.Wrap(err, "Failed to create chart provider instance")
 	}
+	chartProvider.WithRenderCache(globalRenderCache())
 
 	actionHelper := &ActionContext{
 		KubeClient:       kubeClient,
-		WorkspaceFactory: *wsFactory,
+		WorkspaceFactory: taskWsFactory,
 		Context:          ctx,
 		Logger:           r.logger,
 		ChartProvider:    chartProvider,
 		Task:             task,
 	}
 
+	if task.Type == model.OperationTypePreflight {
+		return r.preflight(actionHelper)
+	}
+
 	// Identify the right action set to use (reconcile/delete)
 	pre, act, post := r.preReconcileAction, r.reconcileAction, r.postReconcileAction
 	if task.Type == model.OperationTypeDelete {
@@ -141,3 +167,55 @@ func (r *runner) reconcile(ctx context.Context, task *reconciler.Task) error {
 
 	return nil
 }
+
+//retryableError marks errors which can't be solved by retrying the reconciliation as unrecoverable
+func retryableError(err error) error {
+	if IsPreflightBlockedError(err) { //a blocked deletion has to be restored or forced by the user
+		return retry.Unrecoverable(err)
+	}
+	return err
+}
+
+//preflight runs the checks which have to pass before the component gets deleted
+func (r *runner) preflight(actionHelper *ActionContext) error {
+	preflight := r.preflightAction
+	if preflight == nil {
+		preflight = &PreflightCheck{}
+	}
+	if err := preflight.Run(actionHelper); err != nil {
+		r.logger.Debugf("Runner: preflight action of '%s' with version '%s' failed: %s",
+			actionHelper.Task.Component, actionHelper.Task.Version, err)
+		return err
+	}
+	return nil
+}
+
+//newKubernetesClient returns a client which shares its connection with other tasks for the same cluster
+func (r *runner) newKubernetesClient(kubeconfig string, config *k8s.Config) (k8s.Client, error) {
+	if r.clientCache == nil {
+		return k8s.NewKubernetesClient(kubeconfig, r.logger, config)
+	}
+	return r.clientCache.Get(kubeconfig, r.logger, config)
+}
+
+func toCallbackDeletionReport(report *k8s.DeletionReport) []reconciler.DeletedResource {
+	var result []reconciler.DeletedResource
+	for _, resource := range report.Resources() {
+		deleted := reconciler.DeletedResource{
+			Kind:      resource.Kind,
+			Name:      resource.Name,
+			Namespace: resource.Namespace,
+			Status:    reconciler.DeletionStatus(resource.Status),
+		}
+		if len(resource.Finalizers) > 0 {
+			finalizers := resource.Finalizers
+			deleted.Finalizers = &finalizers
+		}
+		if resource.Error != "" {
+			errMsg := resource.Error
+			deleted.Error = &errMsg
+		}
+		result = append(result, deleted)
+	}
+	return result
+}
@@ -0,0 +1,80 @@
+package service
+
+import (
+	"reflect"
+
+	k8s "github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+//SchedulingInterceptor injects a priority class, node selectors and tolerations into the pod spec of workloads
+type SchedulingInterceptor struct {
+	PriorityClassName string
+	NodeSelector      map[string]string
+	Tolerations       []v1.Toleration
+}
+
+func (s *SchedulingInterceptor) Intercept(resource *unstructured.Unstructured, _ string) (k8s.InterceptionResult, error) {
+	spec, found, err := podSpec(resource)
+	if err != nil {
+		return k8s.ErrorInterceptionResult, err
+	}
+	if !found {
+		return k8s.ContinueInterceptionResult, nil
+	}
+
+	if s.PriorityClassName != "" {
+		spec["priorityClassName"] = s.PriorityClassName
+		delete(spec, "priority") //the priority is resolved by the admission controller using the priority class
+	}
+
+	if len(s.NodeSelector) > 0 {
+		nodeSelector, _, err := unstructured.NestedStringMap(spec, "nodeSelector")
+		if err != nil {
+			return k8s.ErrorInterceptionResult, err
+		}
+		if err := unstructured.SetNestedStringMap(spec, mergeStringMaps(nodeSelector, s.NodeSelector), "nodeSelector"); err != nil {
+			return k8s.ErrorInterceptionResult, err
+		}
+	}
+
+	if len(s.Tolerations) > 0 {
+		if err := s.addTolerations(spec); err != nil {
+			return k8s.ErrorInterceptionResult, err
+		}
+	}
+
+	if err := setPodSpec(resource, spec); err != nil {
+		return k8s.ErrorInterceptionResult, err
+	}
+	return k8s.ContinueInterceptionResult, nil
+}
+
+//addTolerations appends all tolerations which are not already defined in the pod spec
+func (s *SchedulingInterceptor) addTolerations(spec map[string]interface{}) error {
+	tolerations, _, err := unstructured.NestedSlice(spec, "tolerations")
+	if err != nil {
+		return err
+	}
+	for _, toleration := range s.Tolerations {
+		unstructToleration, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&toleration)
+		if err != nil {
+			return err
+		}
+		if !containsValue(tolerations, unstructToleration) {
+			tolerations = append(tolerations, unstructToleration)
+		}
+	}
+	return unstructured.SetNestedSlice(spec, tolerations, "tolerations")
+}
+
+func containsValue(values []interface{}, value interface{}) bool {
+	for _, v := range values {
+		if reflect.DeepEqual(v, value) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,55 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/kubernetes"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestSchedulingInterceptor(t *testing.T) {
+	interceptor := &SchedulingInterceptor{
+		PriorityClassName: "kyma-system-priority",
+		NodeSelector:      map[string]string{"pool": "kyma"},
+		Tolerations: []v1.Toleration{
+			{Key: "dedicated", Operator: v1.TolerationOpEqual, Value: "kyma", Effect: v1.TaintEffectNoSchedule},
+		},
+	}
+
+	cronJob := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "batch/v1",
+		"kind":       "CronJob",
+		"spec": map[string]interface{}{
+			"jobTemplate": map[string]interface{}{
+				"spec": map[string]interface{}{
+					"template": map[string]interface{}{
+						"spec": map[string]interface{}{
+							"priority":     int64(100),
+							"nodeSelector": map[string]interface{}{"os": "linux"},
+							"tolerations": []interface{}{
+								map[string]interface{}{"key": "dedicated", "operator": "Equal", "value": "kyma", "effect": "NoSchedule"},
+							},
+						},
+					},
+				},
+			},
+		},
+	}}
+
+	//intercept twice to verify that tolerations aren't duplicated
+	for i := 0; i < 2; i++ {
+		result, err := interceptor.Intercept(cronJob, "")
+		require.NoError(t, err)
+		require.Equal(t, kubernetes.ContinueInterceptionResult, result)
+	}
+
+	spec, found, err := podSpec(cronJob)
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, "kyma-system-priority", spec["priorityClassName"])
+	require.NotContains(t, spec, "priority")
+	require.Equal(t, map[string]interface{}{"os": "linux", "pool": "kyma"}, spec["nodeSelector"])
+	require.Len(t, spec["tolerations"], 1)
+}
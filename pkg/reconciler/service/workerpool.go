@@ -2,10 +2,12 @@ package service
 
 import (
 	"context"
+	"net/http"
 
 	"github.com/kyma-incubator/reconciler/pkg/logger"
 	"github.com/kyma-incubator/reconciler/pkg/reconciler"
 	"github.com/kyma-incubator/reconciler/pkg/reconciler/callback"
+	"github.com/kyma-incubator/reconciler/pkg/signature"
 	"github.com/panjf2000/ants/v2"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
@@ -22,6 +24,8 @@ type WorkerPool struct {
 	antsPool     *ants.Pool
 	newRunnerFct func(context.Context, *reconciler.Task, callback.Handler, *zap.SugaredLogger) func() error
 	depChecker   *dependencyChecker
+	httpClient   *http.Client
+	signer       *signature.Signer
 }
 
 func newWorkerPoolBuilder(depChecker *dependencyChecker, newRunnerFct func(context.Context, *reconciler.Task, callback.Handler, *zap.SugaredLogger) func() error) *workPoolBuilder {
@@ -44,6 +48,12 @@ func (pb *workPoolBuilder) WithDebug(debug bool) *workPoolBuilder {
 	return pb
 }
 
+func (pb *workPoolBuilder) WithCallbackSecurity(httpClient *http.Client, signer *signature.Signer) *workPoolBuilder {
+	pb.workerPool.httpClient = httpClient
+	pb.workerPool.signer = signer
+	return pb
+}
+
 func (pb *workPoolBuilder) Build(ctx context.Context) (*WorkerPool, error) {
 	//add logger
 	log := logger.NewLogger(pb.workerPool.debug)
@@ -83,6 +93,7 @@ func (wa *WorkerPool) AssignWorker(ctx context.Context, model *reconciler.Task)
 			"Could not create remote callback handler - not able to process : %s", model, err)
 		return err
 	}
+	remoteCbh.WithHTTPClient(wa.httpClient).WithSigner(wa.signer, model.CorrelationID)
 
 	//assign runner to worker
 	err = wa.antsPool.Submit(func() {
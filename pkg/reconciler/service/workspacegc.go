@@ -0,0 +1,56 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/chart"
+)
+
+//collectWorkspaces runs the garbage collection of the global workspace factory until the context gets closed
+func (r *ComponentReconciler) collectWorkspaces(ctx context.Context) {
+	r.logger.Infof("Starting workspace garbage collection (quota: %d bytes, max age: %s, interval: %s)",
+		r.workspaceGCConfig.Quota, r.workspaceGCConfig.MaxAge, r.workspaceGCConfig.Interval)
+
+	ticker := time.NewTicker(r.workspaceGCConfig.Interval)
+	defer ticker.Stop()
+	for {
+		r.collectWorkspacesOnce()
+		select {
+		case <-ctx.Done():
+			r.logger.Info("Stopping workspace garbage collection because parent context got closed")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (r *ComponentReconciler) collectWorkspacesOnce() {
+	factory, ok := globalWorkspaceFactory().(*chart.DefaultFactory)
+	if !ok {
+		return //factory not created yet or doesn't support garbage collection
+	}
+	stats, err := factory.CollectGarbage(r.workspaceGCConfig)
+	if err != nil {
+		r.logger.Warnf("Workspace garbage collection failed: %s", err)
+		return
+	}
+	r.logger.Debugf("Workspace garbage collection finished: %d workspaces deleted, %d workspaces "+
+		"(%d in use) with %d bytes remaining", stats.Deleted, stats.Count, stats.InUse, stats.Size)
+}
+
+// WorkspaceStats returns the statistics of the last workspace garbage collection run
+// (nil if no garbage collection was executed)
+func WorkspaceStats() *chart.WorkspaceStats {
+	factory, ok := globalWorkspaceFactory().(*chart.DefaultFactory)
+	if !ok {
+		return nil
+	}
+	return factory.WorkspaceStats()
+}
+
+func globalWorkspaceFactory() chart.Factory {
+	m.Lock()
+	defer m.Unlock()
+	return wsFactory
+}
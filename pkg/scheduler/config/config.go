@@ -16,11 +16,29 @@ type SchedulerConfig struct {
 	Reconcilers   map[string]ComponentReconciler
 }
 
+// SecurityConfig defines how the mothership authenticates itself when calling component reconcilers
+type SecurityConfig struct {
+	ClientCrtFile    string //client certificate used for mutual TLS
+	ClientKeyFile    string
+	ClientCAFile     string //CA bundle used to verify the certificates of the component reconcilers
+	SignatureKeyFile string //shared secret used to sign tasks and to verify callbacks
+}
+
+// SecretsConfig defines the provider used to resolve secret references in component configurations
+type SecretsConfig struct {
+	Provider   string //"kubernetes" or "file" (secret references are not supported if empty)
+	Kubeconfig string //kubeconfig of the cluster hosting the secrets (in-cluster configuration is used if empty)
+	Directory  string //base directory of the file provider
+}
+
 type Config struct {
-	Scheme    string
-	Host      string
-	Port      int
-	Scheduler SchedulerConfig
+	Scheme       string
+	Host         string
+	Port         int
+	Scheduler    SchedulerConfig
+	Security     SecurityConfig
+	Secrets      SecretsConfig
+	ProfilesFile string //file with custom Kyma profiles (custom profiles of the KV store take precedence)
 }
 
 func (c *Config) Validate() error {
@@ -3,12 +3,13 @@ package invoker
 import (
 	"context"
 	"fmt"
-	"strings"
+	"sort"
 
 	"github.com/kyma-incubator/reconciler/pkg/cluster"
 	"github.com/kyma-incubator/reconciler/pkg/keb"
-	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/profile"
 	"github.com/kyma-incubator/reconciler/pkg/reconciler"
+	"github.com/kyma-incubator/reconciler/pkg/secret"
 )
 
 type Invoker interface {
@@ -23,38 +24,57 @@ type Params struct {
 	CorrelationID        string
 }
 
-func (p *Params) newLocalTask(callbackFunc func(msg *reconciler.CallbackMessage) error) *reconciler.Task {
-	model := p.newTask()
+func (p *Params) newLocalTask(ctx context.Context, resolver *secret.Resolver, profiles *profile.Resolver, callbackFunc func(msg *reconciler.CallbackMessage) error) (*reconciler.Task, error) {
+	model, err := p.newTask(ctx, resolver, profiles)
+	if err != nil {
+		return nil, err
+	}
 	model.CallbackFunc = callbackFunc
-	return model
+	return model, nil
 }
 
-func (p *Params) newRemoteTask(callbackURL string) *reconciler.Task {
-	model := p.newTask()
+func (p *Params) newRemoteTask(ctx context.Context, resolver *secret.Resolver, profiles *profile.Resolver, callbackURL string) (*reconciler.Task, error) {
+	model, err := p.newTask(ctx, resolver, profiles)
+	if err != nil {
+		return nil, err
+	}
 	model.CallbackURL = callbackURL
-	return model
+	return model, nil
 }
 
-func (p *Params) newTask() *reconciler.Task {
-	version := p.ClusterState.Configuration.KymaVersion
-	// version := p.ComponentToReconcile.Version
+func (p *Params) newTask(ctx context.Context, resolver *secret.Resolver, profiles *profile.Resolver) (*reconciler.Task, error) {
+	version := p.ComponentToReconcile.ResolveVersion(p.ClusterState.Configuration.KymaVersion)
 	url := p.ComponentToReconcile.URL
-	if url != "" && strings.HasSuffix(url, ".git") {
-		version = p.ComponentToReconcile.Version // ok even if it was empty. We handle it later
-	} else if p.ComponentToReconcile.Version != "" {
-		version = p.ComponentToReconcile.Version
+
+	//secret references are resolved as late as possible to avoid that secret values are persisted
+	configuration, resolvedKeys, err := resolver.Resolve(ctx, p.ComponentToReconcile.ConfigurationAsMap())
+	if err != nil {
+		return nil, err
+	}
+	secretKeys := resolvedKeys
+	for _, cfg := range p.ComponentToReconcile.Configuration {
+		if cfg.Secret && !secret.IsReference(cfg.Value) {
+			secretKeys = append(secretKeys, cfg.Key)
+		}
 	}
+	sort.Strings(secretKeys)
 
-	configuration := p.ComponentToReconcile.ConfigurationAsMap()
 	tokenNamespace := configuration["repo.token.namespace"]
 	if tokenNamespace == nil {
 		tokenNamespace = ""
 	}
 
-	taskType := model.OperationTypeReconcile
-	if p.ClusterState.Status.Status.IsDeletion() {
-		taskType = model.OperationTypeDelete
+	//custom profiles are passed as values which the component reconciler applies on top of the chart profile
+	kymaProfile, err := profiles.Resolve(p.ClusterState.Configuration.KymaProfile)
+	if err != nil {
+		return nil, err
 	}
+	profileValues, err := kymaProfile.Values(p.ComponentToReconcile.Component)
+	if err != nil {
+		return nil, err
+	}
+
+	taskType := p.ClusterState.Status.Status.OperationType()
 
 	return &reconciler.Task{
 		ComponentsReady: p.ComponentsReady,
@@ -62,8 +82,10 @@ func (p *Params) newTask() *reconciler.Task {
 		Namespace:       p.ComponentToReconcile.Namespace,
 		Version:         version,
 		URL:             url,
-		Profile:         p.ClusterState.Configuration.KymaProfile,
+		Profile:         kymaProfile.ChartProfile,
+		ProfileValues:   profileValues,
 		Configuration:   configuration,
+		SecretKeys:      secretKeys,
 		Kubeconfig:      p.ClusterState.Cluster.Kubeconfig,
 		Metadata:        *p.ClusterState.Cluster.Metadata,
 		CorrelationID:   p.CorrelationID,
@@ -72,5 +94,5 @@ func (p *Params) newTask() *reconciler.Task {
 			TokenNamespace: fmt.Sprint(tokenNamespace),
 		},
 		Type: taskType,
-	}
+	}, nil
 }
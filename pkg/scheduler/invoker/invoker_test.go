@@ -1,10 +1,24 @@
 package invoker
 
 import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/kyma-incubator/reconciler/pkg/logger"
+	"github.com/kyma-incubator/reconciler/pkg/profile"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/chart"
+	chartmocks "github.com/kyma-incubator/reconciler/pkg/reconciler/chart/mocks"
+	"github.com/kyma-incubator/reconciler/pkg/reconciler/service"
+	"github.com/kyma-incubator/reconciler/pkg/secret"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
 )
 
 func TestInvoker(t *testing.T) {
@@ -29,7 +43,119 @@ func TestInvoker(t *testing.T) {
 			CorrelationID:   "",
 		}
 
-		model := params.newTask()
+		model, err := params.newTask(context.Background(), nil, nil)
+		require.NoError(t, err)
 		assert.Equal(t, "", model.Repository.TokenNamespace)
 	})
+
+	t.Run("Should resolve secret references and mark secret keys", func(t *testing.T) {
+		params := Params{
+			ComponentToReconcile: &keb.Component{
+				Component: "ory",
+				Configuration: []keb.Configuration{
+					{Key: "plain", Value: "value"},
+					{Key: "password", Secret: true, Value: "s3cr3t"},
+					{Key: "dsn", Secret: true, Value: "secretRef://kyma-system/ory-db#dsn"},
+				},
+			},
+			ClusterState: clusterStateMock,
+		}
+
+		provider := secret.NewKubernetesProviderWithClient(fake.NewSimpleClientset(&v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "ory-db", Namespace: "kyma-system"},
+			Data:       map[string][]byte{"dsn": []byte("postgres://db")},
+		}))
+
+		model, err := params.newTask(context.Background(), secret.NewResolver(provider), nil)
+		require.NoError(t, err)
+		assert.Equal(t, "postgres://db", model.Configuration["dsn"])
+		assert.Equal(t, "value", model.Configuration["plain"])
+		assert.Equal(t, []string{"dsn", "password"}, model.SecretKeys)
+
+		//masked task hides secrets
+		masked := model.Masked()
+		assert.Equal(t, secret.MaskedValue, masked.Configuration["dsn"])
+		assert.Equal(t, secret.MaskedValue, masked.Configuration["password"])
+		assert.Equal(t, "value", masked.Configuration["plain"])
+		assert.Equal(t, "postgres://db", model.Configuration["dsn"]) //original task is untouched
+	})
+
+	t.Run("Should resolve custom profiles", func(t *testing.T) {
+		profilesFile := filepath.Join(t.TempDir(), "profiles.yaml")
+		require.NoError(t, ioutil.WriteFile(profilesFile, []byte(`
+trial-small:
+  base: evaluation
+  values:
+    global.replicas: 1
+  components:
+    ory:
+      hydra.enabled: false
+`), 0600))
+		fileSource, err := profile.NewFileSource(profilesFile)
+		require.NoError(t, err)
+
+		clusterConfig := *clusterStateMock.Configuration
+		clusterConfig.KymaProfile = "trial-small"
+		clusterState := *clusterStateMock
+		clusterState.Configuration = &clusterConfig
+
+		params := Params{
+			ComponentToReconcile: &keb.Component{Component: "ory"},
+			ClusterState:         &clusterState,
+		}
+		model, err := params.newTask(context.Background(), nil, profile.NewResolver(fileSource))
+		require.NoError(t, err)
+		assert.Equal(t, "evaluation", model.Profile)
+		assert.Equal(t, map[string]interface{}{
+			"global": map[string]interface{}{"replicas": float64(1)},
+			"hydra":  map[string]interface{}{"enabled": false},
+		}, model.ProfileValues)
+	})
+
+	t.Run("Should fail for secret references without provider", func(t *testing.T) {
+		params := Params{
+			ComponentToReconcile: &keb.Component{
+				Component: "ory",
+				Configuration: []keb.Configuration{
+					{Key: "dsn", Secret: true, Value: "secretRef://kyma-system/ory-db#dsn"},
+				},
+			},
+			ClusterState: clusterStateMock,
+		}
+		_, err := params.newTask(context.Background(), nil, nil)
+		require.Error(t, err)
+	})
+}
+
+func TestLocalInvokerVerifyChartProfile(t *testing.T) {
+	resourceDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(resourceDir, "comp"), 0700))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(resourceDir, "comp", "profile-evaluation.yaml"), []byte{}, 0600))
+	wsFactory := &chartmocks.Factory{}
+	wsFactory.On("Get", "1.2.3").Return(&chart.KymaWorkspace{ResourceDir: resourceDir}, nil)
+	require.NoError(t, service.RefreshGlobalWorkspaceFactory(wsFactory))
+	defer func() {
+		require.NoError(t, service.RefreshGlobalWorkspaceFactory(nil))
+	}()
+
+	newParams := func(kymaProfile string) *Params {
+		clusterConfig := *clusterStateMock.Configuration
+		clusterConfig.KymaProfile = kymaProfile
+		clusterState := *clusterStateMock
+		clusterState.Configuration = &clusterConfig
+		return &Params{ComponentToReconcile: &keb.Component{Component: "comp"}, ClusterState: &clusterState}
+	}
+
+	invoker := NewLocalReconcilerInvoker(nil, nil, logger.NewLogger(true))
+	require.NoError(t, invoker.verifyChartProfile(newParams("")))
+	require.NoError(t, invoker.verifyChartProfile(newParams("evaluation")))
+
+	//custom profiles are rejected without resolver instead of deploying the default values
+	err := invoker.verifyChartProfile(newParams("trial-small"))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "profile 'trial-small' isn't shipped with the charts")
+
+	//custom profiles are resolved by the resolver
+	require.NoError(t, invoker.WithProfiles(profile.NewResolver()).verifyChartProfile(newParams("trial-small")))
 }
+
@@ -4,8 +4,10 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"sync"
 
 	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/profile"
 	"github.com/kyma-incubator/reconciler/pkg/scheduler/config"
 	"github.com/kyma-incubator/reconciler/pkg/scheduler/reconciliation"
 	"github.com/pkg/errors"
@@ -22,6 +24,10 @@ type LocalReconcilerInvoker struct {
 	reconRepo  reconciliation.Repository
 	logger     *zap.SugaredLogger
 	statusFunc ReconcilerStatusFunc
+	profiles   *profile.Resolver
+	//chart profiles which were verified already (key: '<version>/<profile>')
+	verifiedProfiles   map[string]bool
+	verifiedProfilesMu sync.Mutex
 }
 
 func NewLocalReconcilerInvoker(reconRepo reconciliation.Repository, statusFunc ReconcilerStatusFunc, logger *zap.SugaredLogger) *LocalReconcilerInvoker {
@@ -32,6 +38,13 @@ func NewLocalReconcilerInvoker(reconRepo reconciliation.Repository, statusFunc R
 	}
 }
 
+//WithProfiles sets the resolver of custom Kyma profiles (without resolver, only profiles shipped with the charts
+//are accepted)
+func (i *LocalReconcilerInvoker) WithProfiles(profiles *profile.Resolver) *LocalReconcilerInvoker {
+	i.profiles = profiles
+	return i
+}
+
 func (i *LocalReconcilerInvoker) Invoke(ctx context.Context, params *Params) error {
 	if params.ComponentToReconcile == nil {
 		return fmt.Errorf("illegal state: local invoker was called without providing a component to reconcile "+
@@ -58,11 +71,62 @@ func (i *LocalReconcilerInvoker) Invoke(ctx context.Context, params *Params) err
 	i.logger.Debugf("Local invoker is calling reconciler for component '%s' (schedulingID:%s/correlationID:%s)",
 		component, params.SchedulingID, params.CorrelationID)
 
-	reconModel := params.newLocalTask(i.newCallbackFunc(params))
+	if err := i.verifyChartProfile(params); err != nil {
+		return err
+	}
+
+	//secret references aren't supported by the local invoker: no secret provider is available
+	reconModel, err := params.newLocalTask(ctx, nil, i.profiles, i.newCallbackFunc(params))
+	if err != nil {
+		return err
+	}
 
 	return compRecon.StartLocal(ctx, reconModel, i.logger)
 }
 
+//verifyChartProfile ensures that the Kyma profile is shipped with the charts if no resolver of custom profiles is
+//defined: otherwise a custom profile would silently be deployed with the default values of the charts
+func (i *LocalReconcilerInvoker) verifyChartProfile(params *Params) error {
+	kymaProfile := params.ClusterState.Configuration.KymaProfile
+	if i.profiles != nil || kymaProfile == "" {
+		return nil
+	}
+	version := params.ClusterState.Configuration.KymaVersion
+
+	i.verifiedProfilesMu.Lock()
+	defer i.verifiedProfilesMu.Unlock()
+
+	cacheKey := fmt.Sprintf("%s/%s", version, kymaProfile)
+	if i.verifiedProfiles[cacheKey] {
+		return nil
+	}
+
+	wsFactory := reconRegistry.GlobalWorkspaceFactory()
+	if wsFactory == nil {
+		return fmt.Errorf("profile '%s' can't be verified: neither a resolver of custom profiles "+
+			"nor a global workspace factory is defined", kymaProfile)
+	}
+	ws, err := wsFactory.Get(version)
+	if err != nil {
+		return errors.Wrapf(err, "failed to retrieve charts of Kyma version '%s' to verify profile '%s'",
+			version, kymaProfile)
+	}
+	exists, err := ws.HasProfile(kymaProfile)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("profile '%s' isn't shipped with the charts of Kyma version '%s' "+
+			"(custom profiles require a resolver of custom profiles)", kymaProfile, version)
+	}
+
+	if i.verifiedProfiles == nil {
+		i.verifiedProfiles = make(map[string]bool)
+	}
+	i.verifiedProfiles[cacheKey] = true
+	return nil
+}
+
 func (i *LocalReconcilerInvoker) newCallbackFunc(params *Params) func(msg *reconciler.CallbackMessage) error {
 	return func(msg *reconciler.CallbackMessage) error {
 		if i.statusFunc == nil {
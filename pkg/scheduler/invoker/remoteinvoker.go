@@ -9,32 +9,72 @@ import (
 	"net/http"
 	"net/http/httputil"
 	"strings"
+	"time"
 
 	"github.com/kyma-incubator/reconciler/pkg/model"
+	"github.com/kyma-incubator/reconciler/pkg/profile"
 	"github.com/kyma-incubator/reconciler/pkg/reconciler"
 	"github.com/kyma-incubator/reconciler/pkg/scheduler/config"
 	"github.com/kyma-incubator/reconciler/pkg/scheduler/reconciliation"
+	"github.com/kyma-incubator/reconciler/pkg/secret"
+	"github.com/kyma-incubator/reconciler/pkg/signature"
+	"github.com/kyma-incubator/reconciler/pkg/ssl"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 )
 
-const callbackURLTemplate = "%s://%s:%d/v1/operations/%s/callback/%s"
+const (
+	callbackURLTemplate = "%s://%s:%d/v1/operations/%s/callback/%s"
+	httpTimeout         = 1 * time.Minute
+)
 
 type RemoteReconcilerInvoker struct {
-	reconRepo reconciliation.Repository
-	config    *config.Config
-	logger    *zap.SugaredLogger
+	reconRepo  reconciliation.Repository
+	config     *config.Config
+	logger     *zap.SugaredLogger
+	httpClient *http.Client
+	signer     *signature.Signer
+	resolver   *secret.Resolver
+	profiles   *profile.Resolver
 }
 
-func NewRemoteReoncilerInvoker(reconRepo reconciliation.Repository, cfg *config.Config, logger *zap.SugaredLogger) *RemoteReconcilerInvoker {
-	return &RemoteReconcilerInvoker{
-		reconRepo: reconRepo,
-		config:    cfg,
-		logger:    logger,
+func NewRemoteReoncilerInvoker(reconRepo reconciliation.Repository, cfg *config.Config, logger *zap.SugaredLogger) (*RemoteReconcilerInvoker, error) {
+	httpClient, err := ssl.NewHTTPClient(&ssl.ClientConfig{
+		CrtFile: cfg.Security.ClientCrtFile,
+		KeyFile: cfg.Security.ClientKeyFile,
+		CAFile:  cfg.Security.ClientCAFile,
+	}, httpTimeout)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create HTTP client of remote invoker")
+	}
+	signer, err := signature.NewSignerFromFile(cfg.Security.SignatureKeyFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create request signer of remote invoker")
+	}
+	var secretProvider secret.Provider
+	if cfg.Secrets.Provider != "" {
+		secretProvider, err = secret.NewProvider(cfg.Secrets.Provider, cfg.Secrets.Kubeconfig, cfg.Secrets.Directory)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create secret provider of remote invoker")
+		}
 	}
+	return &RemoteReconcilerInvoker{
+		reconRepo:  reconRepo,
+		config:     cfg,
+		logger:     logger,
+		httpClient: httpClient,
+		signer:     signer,
+		resolver:   secret.NewResolver(secretProvider),
+	}, nil
 }
 
-func (i *RemoteReconcilerInvoker) Invoke(_ context.Context, params *Params) error {
+//WithProfiles sets the resolver of custom Kyma profiles (without resolver, profiles are passed as they are)
+func (i *RemoteReconcilerInvoker) WithProfiles(profiles *profile.Resolver) *RemoteReconcilerInvoker {
+	i.profiles = profiles
+	return i
+}
+
+func (i *RemoteReconcilerInvoker) Invoke(ctx context.Context, params *Params) error {
 	if err := i.ensureOperationNotInProgress(params); err != nil {
 		return err
 	}
@@ -44,7 +84,7 @@ func (i *RemoteReconcilerInvoker) Invoke(_ context.Context, params *Params) erro
 		return err
 	}
 
-	resp, err := i.sendHTTPRequest(params)
+	resp, err := i.sendHTTPRequest(ctx, params)
 	if err != nil {
 		return i.fireError("send HTTP request", params, err)
 	}
@@ -125,7 +165,7 @@ func (i *RemoteReconcilerInvoker) reportUnmarshalError(httpCode int, body []byte
 		httpCode, string(body), err)
 }
 
-func (i *RemoteReconcilerInvoker) sendHTTPRequest(params *Params) (*http.Response, error) {
+func (i *RemoteReconcilerInvoker) sendHTTPRequest(ctx context.Context, params *Params) (*http.Response, error) {
 	component := params.ComponentToReconcile.Component
 
 	callbackURL := fmt.Sprintf(callbackURLTemplate,
@@ -134,7 +174,10 @@ func (i *RemoteReconcilerInvoker) sendHTTPRequest(params *Params) (*http.Respons
 		i.config.Port,
 		params.SchedulingID,
 		params.CorrelationID)
-	payload := params.newRemoteTask(callbackURL)
+	payload, err := params.newRemoteTask(ctx, i.resolver, i.profiles, callbackURL)
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("failed to create task for component '%s'", component))
+	}
 
 	jsonPayload, err := json.Marshal(payload)
 	if err != nil {
@@ -159,7 +202,16 @@ func (i *RemoteReconcilerInvoker) sendHTTPRequest(params *Params) (*http.Respons
 		"for component '%s' (schedulingID:%s/correlationID:%s)",
 		compRecon.URL, params.ComponentToReconcile.Component, params.SchedulingID, params.CorrelationID)
 
-	resp, err := http.Post(compRecon.URL, "application/json", bytes.NewBuffer(jsonPayload))
+	req, err := http.NewRequest(http.MethodPost, compRecon.URL, bytes.NewBuffer(jsonPayload))
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("failed to create HTTP request for remote reconciler (URL: %s)", compRecon.URL))
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if err := i.signer.Sign(req, params.CorrelationID, jsonPayload); err != nil {
+		return nil, errors.Wrap(err, "failed to sign HTTP request")
+	}
+
+	resp, err := i.httpClient.Do(req)
 	if err == nil {
 		respDump, err := httputil.DumpResponse(resp, true)
 		if err == nil {
@@ -3,7 +3,10 @@ package invoker
 import (
 	"context"
 	"encoding/json"
+	"io/ioutil"
 	"net/http"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -16,6 +19,7 @@ import (
 	"github.com/kyma-incubator/reconciler/pkg/scheduler/config"
 	"github.com/kyma-incubator/reconciler/pkg/scheduler/reconciliation"
 	"github.com/kyma-incubator/reconciler/pkg/server"
+	"github.com/kyma-incubator/reconciler/pkg/signature"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 )
@@ -32,8 +36,13 @@ func TestRemoteInvoker(t *testing.T) {
 	require.NoError(t, err)
 	require.Len(t, opEntities, 6)
 
+	secretFile := filepath.Join(t.TempDir(), "signature.key")
+	require.NoError(t, ioutil.WriteFile(secretFile, []byte(strings.Repeat("s", 32)), 0600))
+	signer, err := signature.NewSignerFromFile(secretFile)
+	require.NoError(t, err)
+
 	ctx, cancel := context.WithCancel(context.Background())
-	startServer(ctx, t)
+	startServer(ctx, t, signer)
 	defer shotdownServer(cancel, t)
 
 	t.Run("Invoke without base reconciler", func(t *testing.T) {
@@ -151,6 +160,49 @@ func TestRemoteInvoker(t *testing.T) {
 
 		requireOperationState(t, reconRepo, opEntities[5], model.OperationStateClientError)
 	})
+
+	t.Run("Invoke component-reconciler: signed request", func(t *testing.T) {
+		cfg := &config.Config{
+			Scheme: "https",
+			Host:   "mothership-reconciler",
+			Port:   443,
+			Scheduler: config.SchedulerConfig{
+				PreComponents: nil,
+				Reconcilers: map[string]config.ComponentReconciler{
+					"base": {
+						URL: "http://127.0.0.1:5555/signed",
+					},
+				},
+			},
+			Security: config.SecurityConfig{
+				SignatureKeyFile: secretFile,
+			},
+		}
+		err := invokeRemoteInvoker(reconRepo, opEntities[2], cfg)
+		require.NoError(t, err)
+
+		requireOperationState(t, reconRepo, opEntities[2], model.OperationStateInProgress)
+	})
+
+	t.Run("Invoke component-reconciler: unsigned request is rejected", func(t *testing.T) {
+		cfg := &config.Config{
+			Scheme: "https",
+			Host:   "mothership-reconciler",
+			Port:   443,
+			Scheduler: config.SchedulerConfig{
+				PreComponents: nil,
+				Reconcilers: map[string]config.ComponentReconciler{
+					"base": {
+						URL: "http://127.0.0.1:5555/signed",
+					},
+				},
+			},
+		}
+		err := invokeRemoteInvoker(reconRepo, opEntities[3], cfg)
+		require.NoError(t, err)
+
+		requireOperationState(t, reconRepo, opEntities[3], model.OperationStateFailed)
+	})
 }
 
 func invokeRemoteInvoker(reconRepo reconciliation.Repository, op *model.OperationEntity, cfg *config.Config) error {
@@ -159,7 +211,10 @@ func invokeRemoteInvoker(reconRepo reconciliation.Repository, op *model.Operatio
 		return err
 	}
 
-	invoker := NewRemoteReoncilerInvoker(reconRepo, cfg, logger.NewLogger(true))
+	invoker, err := NewRemoteReoncilerInvoker(reconRepo, cfg, logger.NewLogger(true))
+	if err != nil {
+		return err
+	}
 	return invoker.Invoke(context.Background(), &Params{
 		ComponentToReconcile: &keb.Component{
 			Component: model.CRDComponent,
@@ -177,7 +232,7 @@ func shotdownServer(cancel context.CancelFunc, t *testing.T) {
 	test.WaitForFreeTCPSocket(t, "127.0.0.1", 5555, 5*time.Second)
 }
 
-func startServer(ctx context.Context, t *testing.T) {
+func startServer(ctx context.Context, t *testing.T, signer *signature.Signer) {
 	go func() {
 		//react on provided URL
 		router := mux.NewRouter()
@@ -221,6 +276,28 @@ func startServer(ctx context.Context, t *testing.T) {
 			}).
 			Methods("PUT", "POST")
 
+		router.HandleFunc(
+			"/signed",
+			func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("content-type", "application/json")
+				body, err := ioutil.ReadAll(r.Body)
+				require.NoError(t, err)
+				task := &reconciler.Task{}
+				require.NoError(t, json.Unmarshal(body, task))
+				if err := signer.Verify(r, task.CorrelationID, body); err != nil {
+					server.SendHTTPError(w, http.StatusUnauthorized, &reconciler.HTTPErrorResponse{
+						Error: err.Error(),
+					})
+					return
+				}
+				if err := json.NewEncoder(w).Encode(&reconciler.HTTPReconciliationResponse{}); err != nil {
+					server.SendHTTPError(w, http.StatusInternalServerError, &reconciler.HTTPErrorResponse{
+						Error: errors.Wrap(err, "failed to encode response payload to JSON").Error(),
+					})
+				}
+			}).
+			Methods("PUT", "POST")
+
 		//start server
 		err := (&server.Webserver{
 			Logger: logger.NewLogger(true),
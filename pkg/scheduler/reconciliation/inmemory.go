@@ -57,10 +57,7 @@ func (r *InMemoryReconciliationRepository) CreateReconciliation(state *cluster.S
 		r.operations[reconEntity.SchedulingID] = make(map[string]*model.OperationEntity)
 	}
 
-	opType := model.OperationTypeReconcile
-	if state.Status.Status.IsDeletion() {
-		opType = model.OperationTypeDelete
-	}
+	opType := state.Status.Status.OperationType()
 
 	for idx, components := range reconSeq.Queue {
 		priority := idx + 1
@@ -167,6 +164,30 @@ func (r *InMemoryReconciliationRepository) GetOperations(schedulingID string, st
 	return result, nil
 }
 
+func (r *InMemoryReconciliationRepository) GetOperationsOfClusterConfigStatuses(clusterConfigStatuses []int64, states ...model.OperationState) ([]*model.OperationEntity, error) {
+	r.mu.Lock()
+	var schedulingIDs []string
+	for _, reconciliation := range r.reconciliations {
+		for _, clusterConfigStatus := range clusterConfigStatuses {
+			if reconciliation.ClusterConfigStatus == clusterConfigStatus {
+				schedulingIDs = append(schedulingIDs, reconciliation.SchedulingID)
+				break
+			}
+		}
+	}
+	r.mu.Unlock()
+
+	var result []*model.OperationEntity
+	for _, schedulingID := range schedulingIDs {
+		ops, err := r.GetOperations(schedulingID, states...)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, ops...)
+	}
+	return result, nil
+}
+
 func (r *InMemoryReconciliationRepository) GetOperation(schedulingID, correlationID string) (*model.OperationEntity, error) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
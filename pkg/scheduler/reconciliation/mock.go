@@ -12,6 +12,7 @@ type MockRepository struct {
 	GetReconciliationsResult       []*model.ReconciliationEntity
 	FinishReconciliationResult     error
 	GetOperationsResult            []*model.OperationEntity
+	GetOperationsOfStatusesResult  []*model.OperationEntity
 	GetOperationResult             *model.OperationEntity
 	GetProcessableOperationsResult []*model.OperationEntity
 	GetReconcilingOperationsResult []*model.OperationEntity
@@ -42,6 +43,10 @@ func (mr *MockRepository) GetOperations(schedulingID string, state ...model.Oper
 	return mr.GetOperationsResult, nil
 }
 
+func (mr *MockRepository) GetOperationsOfClusterConfigStatuses(clusterConfigStatuses []int64, state ...model.OperationState) ([]*model.OperationEntity, error) {
+	return mr.GetOperationsOfStatusesResult, nil
+}
+
 func (mr *MockRepository) GetOperation(schedulingID, correlationID string) (*model.OperationEntity, error) {
 	return mr.GetOperationResult, nil
 }
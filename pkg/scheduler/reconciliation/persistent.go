@@ -80,10 +80,7 @@ func (r *PersistentReconciliationRepository) CreateReconciliation(state *cluster
 		//get reconciliation sequence
 		reconSeq := state.Configuration.GetReconciliationSequence(preComponents)
 
-		opType := model.OperationTypeReconcile
-		if state.Status.Status.IsDeletion() {
-			opType = model.OperationTypeDelete
-		}
+		opType := state.Status.Status.OperationType()
 
 		//iterate over reconciliation sequence and create operations with proper priorities
 		var opsList bytes.Buffer
@@ -286,6 +283,74 @@ func (r *PersistentReconciliationRepository) GetOperations(schedulingID string,
 	return result, nil
 }
 
+func (r *PersistentReconciliationRepository) GetOperationsOfClusterConfigStatuses(clusterConfigStatuses []int64, states ...model.OperationState) ([]*model.OperationEntity, error) {
+	if len(clusterConfigStatuses) == 0 {
+		return nil, nil
+	}
+
+	reconEntity := &model.ReconciliationEntity{}
+	reconColHandler, err := db.NewColumnHandler(reconEntity, r.Conn, r.Logger)
+	if err != nil {
+		return nil, err
+	}
+	schedulingIDCol, err := reconColHandler.ColumnName("SchedulingID")
+	if err != nil {
+		return nil, err
+	}
+	clusterConfigStatusCol, err := reconColHandler.ColumnName("ClusterConfigStatus")
+	if err != nil {
+		return nil, err
+	}
+
+	q, err := db.NewQuery(r.Conn, &model.OperationEntity{}, r.Logger)
+	if err != nil {
+		return nil, err
+	}
+
+	//select the operations of all reconciliations of the cluster statuses within one query:
+	/*
+		select * from scheduler_operations where scheduling_id in (
+			select scheduling_id from scheduler_reconciliations where cluster_config_status in (x, y, ...)
+		) and state in (a, b, ...)
+	*/
+	var args []interface{}
+	var statusPlaceholders bytes.Buffer
+	for _, clusterConfigStatus := range clusterConfigStatuses {
+		args = append(args, clusterConfigStatus)
+		if statusPlaceholders.Len() > 0 {
+			statusPlaceholders.WriteRune(',')
+		}
+		statusPlaceholders.WriteString(fmt.Sprintf("$%d", len(args)))
+	}
+	selectQ := q.Select().
+		WhereIn("SchedulingID", fmt.Sprintf("SELECT %s FROM %s WHERE %s IN (%s)",
+			schedulingIDCol, reconEntity.Table(), clusterConfigStatusCol, statusPlaceholders.String()), args...)
+
+	if len(states) > 0 {
+		var stateArgs []interface{}
+		var statePlaceholders bytes.Buffer
+		for _, state := range states {
+			stateArgs = append(stateArgs, state)
+			if statePlaceholders.Len() > 0 {
+				statePlaceholders.WriteRune(',')
+			}
+			statePlaceholders.WriteString(fmt.Sprintf("$%d", len(args)+len(stateArgs)))
+		}
+		selectQ.WhereIn("State", statePlaceholders.String(), stateArgs...)
+	}
+
+	ops, err := selectQ.GetMany()
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*model.OperationEntity
+	for _, op := range ops {
+		result = append(result, op.(*model.OperationEntity))
+	}
+	return result, nil
+}
+
 func (r *PersistentReconciliationRepository) GetOperation(schedulingID, correlationID string) (*model.OperationEntity, error) {
 	q, err := db.NewQuery(r.Conn, &model.OperationEntity{}, r.Logger)
 	if err != nil {
@@ -22,6 +22,8 @@ type Repository interface {
 	GetReconciliations(filter Filter) ([]*model.ReconciliationEntity, error)
 	FinishReconciliation(schedulingID string, status *model.ClusterStatusEntity) error
 	GetOperations(schedulingID string, state ...model.OperationState) ([]*model.OperationEntity, error)
+	//GetOperationsOfClusterConfigStatuses returns the operations of all reconciliations which were started for the given cluster statuses
+	GetOperationsOfClusterConfigStatuses(clusterConfigStatuses []int64, state ...model.OperationState) ([]*model.OperationEntity, error)
 	GetOperation(schedulingID, correlationID string) (*model.OperationEntity, error)
 	//GetProcessableOperations returns all operations which can be assigned to a worker
 	GetProcessableOperations(maxParallelOpsPerRecon int) ([]*model.OperationEntity, error)
@@ -168,7 +168,8 @@ func (bk *bookkeeper) finishReconciliation(reconResult *ReconciliationResult) bo
 		}
 	}
 
-	if newClusterStatus.IsFinal() {
+	//passed preflight checks finish the reconciliation: the deletion is scheduled by a new reconciliation
+	if newClusterStatus.IsFinal() || newClusterStatus == model.ClusterStatusDeletePending {
 		err := bk.transition.FinishReconciliation(recon.SchedulingID, newClusterStatus)
 		if err == nil {
 			bk.logger.Infof("Bookkeeper updated cluster '%s' to status '%s' "+
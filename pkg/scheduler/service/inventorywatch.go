@@ -47,7 +47,7 @@ func (w *inventoryWatcher) Run(ctx context.Context, queue inventoryQueue) error
 }
 
 func (w *inventoryWatcher) processClustersToReconcile(queue inventoryQueue) {
-	clusterStates, err := w.inventory.ClustersToReconcile(w.config.ClusterReconcileInterval)
+	clusterStates, err := w.inventory.ClustersToReconcile(w.config.ClusterReconcileInterval, w.config.DeletionGracePeriod)
 	if err != nil {
 		w.logger.Errorf("Inventory watchers failed to fetch clusters to reconcile from inventory "+
 			"(using reconcile interval of %.0f secs): %s",
@@ -65,12 +65,9 @@ func (rs *ReconciliationResult) GetOperations() []*model.OperationEntity {
 }
 
 func (rs *ReconciliationResult) GetResult() model.Status {
-	isDelete := true
-	for _, op := range rs.GetOperations() {
-		if op.Type != model.OperationTypeDelete {
-			isDelete = false
-			break
-		}
+	isDelete := rs.allOperationsOfType(model.OperationTypeDelete)
+	if len(rs.GetOperations()) > 0 && rs.allOperationsOfType(model.OperationTypePreflight) {
+		return rs.getPreflightResult()
 	}
 	if len(rs.error) > 0 {
 		if isDelete {
@@ -94,6 +91,27 @@ func (rs *ReconciliationResult) GetResult() model.Status {
 	return model.ClusterStatusReconcileError
 }
 
+//getPreflightResult blocks the deletion if any preflight check failed, otherwise the deletion can start
+func (rs *ReconciliationResult) getPreflightResult() model.Status {
+	if len(rs.error) > 0 {
+		return model.ClusterStatusDeleteBlocked
+	}
+	if len(rs.other) > 0 {
+		return model.ClusterStatusDeletePreflight
+	}
+	return model.ClusterStatusDeletePending
+}
+
+//allOperationsOfType returns true if no operation has a different type (also true if there are no operations)
+func (rs *ReconciliationResult) allOperationsOfType(opType model.OperationType) bool {
+	for _, op := range rs.GetOperations() {
+		if op.Type != opType {
+			return false
+		}
+	}
+	return true
+}
+
 func (rs *ReconciliationResult) GetOrphans() []*model.OperationEntity {
 	var orphaned []*model.OperationEntity
 	for _, op := range rs.other {
@@ -157,6 +157,73 @@ func TestReconciliationResult(t *testing.T) {
 			},
 			expectedResult: model.ClusterStatusReconcileError,
 		},
+		{
+			operations: []*model.OperationEntity{
+				{
+					Priority:      1,
+					SchedulingID:  "schedulingID",
+					CorrelationID: "1.1",
+					Type:          model.OperationTypePreflight,
+					State:         model.OperationStateDone,
+					Updated:       time.Now(),
+				},
+				{
+					Priority:      1,
+					SchedulingID:  "schedulingID",
+					CorrelationID: "1.2",
+					Type:          model.OperationTypePreflight,
+					State:         model.OperationStateError,
+					Updated:       time.Now(),
+				},
+			},
+			expectedResult: model.ClusterStatusDeleteBlocked,
+		},
+		{
+			operations: []*model.OperationEntity{
+				{
+					Priority:      1,
+					SchedulingID:  "schedulingID",
+					CorrelationID: "1.1",
+					Type:          model.OperationTypePreflight,
+					State:         model.OperationStateDone,
+					Updated:       time.Now(),
+				},
+				{
+					Priority:      1,
+					SchedulingID:  "schedulingID",
+					CorrelationID: "1.2",
+					Type:          model.OperationTypePreflight,
+					State:         model.OperationStateInProgress,
+					Updated:       time.Now(),
+				},
+			},
+			expectedResult: model.ClusterStatusDeletePreflight,
+		},
+		{
+			operations: []*model.OperationEntity{
+				{
+					Priority:      1,
+					SchedulingID:  "schedulingID",
+					CorrelationID: "1.1",
+					Type:          model.OperationTypePreflight,
+					State:         model.OperationStateDone,
+					Updated:       time.Now(),
+				},
+				{
+					Priority:      1,
+					SchedulingID:  "schedulingID",
+					CorrelationID: "1.2",
+					Type:          model.OperationTypePreflight,
+					State:         model.OperationStateDone,
+					Updated:       time.Now(),
+				},
+			},
+			expectedResult: model.ClusterStatusDeletePending,
+		},
+		{
+			operations:     nil, //no operations are neither a preflight check nor a finished reconciliation
+			expectedResult: model.ClusterStatusReconcileError,
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -168,6 +235,11 @@ func TestReconciliationResult(t *testing.T) {
 		require.NoError(t, reconResult.AddOperations(testCase.operations))
 
 		require.Equal(t, reconResult.GetResult(), testCase.expectedResult)
+		if len(testCase.operations) == 0 {
+			//an empty result matches every operation type
+			require.True(t, reconResult.allOperationsOfType(model.OperationTypeDelete))
+			require.True(t, reconResult.allOperationsOfType(model.OperationTypePreflight))
+		}
 
 		//check detected orphans
 		allDetectedOrphans := make(map[string]*model.OperationEntity)
@@ -7,6 +7,7 @@ import (
 
 	"github.com/kyma-incubator/reconciler/pkg/cluster"
 	"github.com/kyma-incubator/reconciler/pkg/db"
+	"github.com/kyma-incubator/reconciler/pkg/profile"
 	"github.com/kyma-incubator/reconciler/pkg/scheduler/config"
 	"github.com/kyma-incubator/reconciler/pkg/scheduler/invoker"
 	"github.com/kyma-incubator/reconciler/pkg/scheduler/reconciliation"
@@ -34,7 +35,7 @@ func (rb *RuntimeBuilder) newWorkerPool(retriever worker.ClusterStateRetriever,
 }
 
 func (rb *RuntimeBuilder) RunLocal(preComponents [][]string, statusFunc invoker.ReconcilerStatusFunc) *RunLocal {
-	runL := &RunLocal{rb, statusFunc}
+	runL := &RunLocal{runtimeBuilder: rb, statusFunc: statusFunc}
 	runL.runtimeBuilder.preComponents = preComponents
 	//Make sure local runner will NOT retry if the local invoker returns an error!
 	//If retries are enabled, operations which are reaching a final state (e.g. 'error') would try to switch back
@@ -53,7 +54,7 @@ func (rb *RuntimeBuilder) RunRemote(
 	inventory cluster.Inventory,
 	config *config.Config) *RunRemote {
 
-	runR := &RunRemote{rb, conn, inventory, config, &SchedulerConfig{}, &BookkeeperConfig{}, &CleanerConfig{}}
+	runR := &RunRemote{rb, conn, inventory, config, &SchedulerConfig{}, &BookkeeperConfig{}, &CleanerConfig{}, nil}
 	runR.runtimeBuilder.preComponents = config.Scheduler.PreComponents
 	return runR
 }
@@ -69,6 +70,7 @@ func (rb *RuntimeBuilder) newCleaner() *cleaner {
 type RunLocal struct {
 	runtimeBuilder *RuntimeBuilder
 	statusFunc     invoker.ReconcilerStatusFunc
+	profiles       *profile.Resolver
 }
 
 func (l *RunLocal) logger() *zap.SugaredLogger { //convenient function
@@ -84,6 +86,11 @@ func (l *RunLocal) WithWorkerPoolSize(size int) *RunLocal {
 	return l
 }
 
+func (l *RunLocal) WithProfiles(profiles *profile.Resolver) *RunLocal {
+	l.profiles = profiles
+	return l
+}
+
 func (l *RunLocal) Run(ctx context.Context, clusterState *cluster.State) (*ReconciliationResult, error) {
 	//enqueue cluster state and create reconciliation entity
 	l.logger().Info("Starting local scheduler")
@@ -96,7 +103,8 @@ func (l *RunLocal) Run(ctx context.Context, clusterState *cluster.State) (*Recon
 
 	//start worker pool
 	l.logger().Info("Starting worker pool")
-	localInvoker := invoker.NewLocalReconcilerInvoker(l.runtimeBuilder.reconRepo, l.statusFunc, l.logger())
+	localInvoker := invoker.NewLocalReconcilerInvoker(l.runtimeBuilder.reconRepo, l.statusFunc, l.logger()).
+		WithProfiles(l.profiles)
 	workerPool, err := l.runtimeBuilder.newWorkerPool(&worker.PassThroughRetriever{State: clusterState}, localInvoker)
 	if err != nil {
 		l.logger().Errorf("Failed to create worker pool: %s", err)
@@ -141,6 +149,7 @@ type RunRemote struct {
 	schedulerConfig  *SchedulerConfig
 	bookkeeperConfig *BookkeeperConfig
 	cleanerConfig    *CleanerConfig
+	profiles         *profile.Resolver
 }
 
 func (r *RunRemote) logger() *zap.SugaredLogger { //convenient function
@@ -171,6 +180,11 @@ func (r *RunRemote) WithCleanerConfig(cfg *CleanerConfig) *RunRemote {
 	return r
 }
 
+func (r *RunRemote) WithProfiles(profiles *profile.Resolver) *RunRemote {
+	r.profiles = profiles
+	return r
+}
+
 func (r *RunRemote) Run(ctx context.Context) error {
 	if err := r.config.Validate(); err != nil {
 		return err
@@ -185,7 +199,11 @@ func (r *RunRemote) Run(ctx context.Context) error {
 
 	//start worker pool
 	go func() {
-		remoteInvoker := invoker.NewRemoteReoncilerInvoker(r.reconciliationRepository(), r.config, r.logger())
+		remoteInvoker, err := invoker.NewRemoteReoncilerInvoker(r.reconciliationRepository(), r.config, r.logger())
+		if err != nil {
+			r.logger().Fatalf("Failed to create remote invoker: %s", err)
+		}
+		remoteInvoker.WithProfiles(r.profiles)
 		workerPool, err := r.runtimeBuilder.newWorkerPool(&worker.InventoryRetriever{Inventory: r.inventory}, remoteInvoker)
 		if err == nil {
 			r.logger().Info("Worker pool created")
@@ -20,6 +20,8 @@ type SchedulerConfig struct {
 	InventoryWatchInterval   time.Duration
 	ClusterReconcileInterval time.Duration
 	ClusterQueueSize         int
+	//DeletionGracePeriod is the time a cluster stays scheduled for deletion before it gets deleted (0 means immediately)
+	DeletionGracePeriod time.Duration
 }
 
 func (wc *SchedulerConfig) validate() error {
@@ -35,6 +37,9 @@ func (wc *SchedulerConfig) validate() error {
 	if wc.ClusterReconcileInterval == 0 {
 		wc.ClusterReconcileInterval = defaultClusterReconcileInterval
 	}
+	if wc.DeletionGracePeriod < 0 {
+		return errors.New("deletion grace period cannot be < 0")
+	}
 	if wc.ClusterQueueSize < 0 {
 		return errors.New("cluster queue cannot be < 0")
 	}
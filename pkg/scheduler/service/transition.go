@@ -61,7 +61,13 @@ func (t *ClusterStatusTransition) StartReconciliation(runtimeID string, configVe
 
 		//set cluster status to reconciling or deleting depending on previous state
 		var targetState model.Status
-		if oldClusterState.Status.Status.IsDeleteCandidate() {
+		if oldClusterState.Status.Status.IsDeleteScheduled() {
+			//grace period expired: run preflight checks of the components unless the deletion was forced
+			targetState = model.ClusterStatusDeletePreflight
+			if oldClusterState.Status.DeletionForced {
+				targetState = model.ClusterStatusDeleting
+			}
+		} else if oldClusterState.Status.Status.IsDeleteCandidate() {
 			targetState = model.ClusterStatusDeleting
 		} else if oldClusterState.Status.Status.IsReconcileCandidate() {
 			targetState = model.ClusterStatusReconciling
@@ -77,7 +83,7 @@ func (t *ClusterStatusTransition) StartReconciliation(runtimeID string, configVe
 			return err
 		}
 		t.logger.Debugf("Starting reconciliation for cluster '%s': set cluster status to '%s'",
-			newClusterState.Cluster.RuntimeID, model.ClusterStatusReconciling)
+			newClusterState.Cluster.RuntimeID, targetState)
 
 		//create reconciliation entity
 		reconEntity, err := t.reconRepo.CreateReconciliation(newClusterState, preComponents)
@@ -131,4 +131,39 @@ func TestTransition(t *testing.T) {
 		require.Equal(t, model.ClusterStatusDeletePending, newClusterState.Status.Status)
 	})
 
+	t.Run("Start preflight checks of scheduled deletion", func(t *testing.T) {
+		currentClusterState, err := transition.inventory.GetLatest(clusterState.Cluster.RuntimeID)
+		require.NoError(t, err)
+		_, err = transition.inventory.UpdateStatus(currentClusterState, model.ClusterStatusReady)
+		require.NoError(t, err)
+		_, err = transition.inventory.MarkForDeletion(clusterState.Cluster.RuntimeID, false)
+		require.NoError(t, err)
+
+		err = transition.StartReconciliation(clusterState.Cluster.RuntimeID, clusterState.Configuration.Version, nil)
+		require.NoError(t, err)
+
+		//verify cluster status and operation types
+		newClusterState, err := transition.inventory.GetLatest(clusterState.Cluster.RuntimeID)
+		require.NoError(t, err)
+		require.Equal(t, model.ClusterStatusDeletePreflight, newClusterState.Status.Status)
+
+		reconEntities, err := reconRepo.GetReconciliations(&reconciliation.CurrentlyReconcilingWithRuntimeID{
+			RuntimeID: clusterState.Cluster.RuntimeID,
+		})
+		require.NoError(t, err)
+		require.Len(t, reconEntities, 1)
+		ops, err := reconRepo.GetOperations(reconEntities[0].SchedulingID)
+		require.NoError(t, err)
+		require.NotEmpty(t, ops)
+		for _, op := range ops {
+			require.Equal(t, model.OperationTypePreflight, op.Type)
+		}
+
+		//passed preflight checks release the deletion
+		err = transition.FinishReconciliation(reconEntities[0].SchedulingID, model.ClusterStatusDeletePending)
+		require.NoError(t, err)
+		newClusterState, err = transition.inventory.GetLatest(clusterState.Cluster.RuntimeID)
+		require.NoError(t, err)
+		require.Equal(t, model.ClusterStatusDeletePending, newClusterState.Status.Status)
+	})
 }
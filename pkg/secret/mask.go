@@ -0,0 +1,68 @@
+package secret
+
+import (
+	"encoding/json"
+
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+)
+
+const MaskedValue = "********"
+
+// MaskConfiguration returns a copy of the configuration entries with masked secret values.
+// Secret references are not masked as they don't contain the secret value.
+func MaskConfiguration(configuration []keb.Configuration) []keb.Configuration {
+	if configuration == nil {
+		return nil
+	}
+	result := make([]keb.Configuration, len(configuration))
+	for idx, cfg := range configuration {
+		if cfg.Secret && !IsReference(cfg.Value) {
+			cfg.Value = MaskedValue
+		}
+		result[idx] = cfg
+	}
+	return result
+}
+
+// MaskComponents returns a copy of the components with masked secret configuration values
+func MaskComponents(components []keb.Component) []keb.Component {
+	result := make([]keb.Component, len(components))
+	for idx, component := range components {
+		component.Configuration = MaskConfiguration(component.Configuration)
+		result[idx] = component
+	}
+	return result
+}
+
+// MaskJSON masks the values of all configuration entries flagged as secret in an arbitrary JSON document
+// (e.g. a cluster payload sent by KEB). Data which isn't valid JSON is returned unchanged.
+func MaskJSON(data []byte) []byte {
+	var doc interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return data
+	}
+	masked, err := json.Marshal(maskJSONNode(doc))
+	if err != nil {
+		return data
+	}
+	return masked
+}
+
+func maskJSONNode(node interface{}) interface{} {
+	switch value := node.(type) {
+	case map[string]interface{}:
+		if isSecret, ok := value["secret"].(bool); ok && isSecret {
+			if _, hasValue := value["value"]; hasValue && !IsReference(value["value"]) {
+				value["value"] = MaskedValue
+			}
+		}
+		for key, child := range value {
+			value[key] = maskJSONNode(child)
+		}
+	case []interface{}:
+		for idx, child := range value {
+			value[idx] = maskJSONNode(child)
+		}
+	}
+	return node
+}
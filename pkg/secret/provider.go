@@ -0,0 +1,104 @@
+package secret
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+
+	file "github.com/kyma-incubator/reconciler/pkg/files"
+	k8s "github.com/kyma-incubator/reconciler/pkg/kubernetes"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+const (
+	ProviderKubernetes = "kubernetes"
+	ProviderFile       = "file"
+)
+
+// Provider returns the value a secret reference points to
+type Provider interface {
+	Get(ctx context.Context, ref *Reference) (string, error)
+}
+
+// KubernetesProvider reads referenced values from secrets of the cluster the mothership is running in
+type KubernetesProvider struct {
+	clientSet kubernetes.Interface
+}
+
+// NewKubernetesProvider creates a provider using the given kubeconfig file or, if empty, the in-cluster configuration
+func NewKubernetesProvider(kubeconfigFile string) (*KubernetesProvider, error) {
+	var clientSet kubernetes.Interface
+	var err error
+	if kubeconfigFile == "" {
+		var restConfig *rest.Config
+		restConfig, err = rest.InClusterConfig()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to load in-cluster configuration of secret provider")
+		}
+		clientSet, err = kubernetes.NewForConfig(restConfig)
+	} else {
+		clientSet, err = (&k8s.ClientBuilder{}).WithFile(kubeconfigFile).Build(false)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &KubernetesProvider{clientSet: clientSet}, nil
+}
+
+func NewKubernetesProviderWithClient(clientSet kubernetes.Interface) *KubernetesProvider {
+	return &KubernetesProvider{clientSet: clientSet}
+}
+
+func (p *KubernetesProvider) Get(ctx context.Context, ref *Reference) (string, error) {
+	secret, err := p.clientSet.CoreV1().Secrets(ref.Namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+	if err != nil {
+		return "", errors.Wrap(err, fmt.Sprintf("failed to retrieve secret '%s/%s'", ref.Namespace, ref.Name))
+	}
+	value, ok := secret.Data[ref.Key]
+	if !ok {
+		return "", fmt.Errorf("secret '%s/%s' has no key '%s'", ref.Namespace, ref.Name, ref.Key)
+	}
+	return string(value), nil
+}
+
+// FileProvider reads referenced values from files stored in the layout <dir>/<namespace>/<name>/<key>
+// (compatible with secrets mounted as volumes)
+type FileProvider struct {
+	dir string
+}
+
+func NewFileProvider(dir string) (*FileProvider, error) {
+	if !file.DirExists(dir) {
+		return nil, fmt.Errorf("directory '%s' of file secret provider not found", dir)
+	}
+	return &FileProvider{dir: dir}, nil
+}
+
+func (p *FileProvider) Get(_ context.Context, ref *Reference) (string, error) {
+	path := filepath.Join(p.dir, filepath.Base(ref.Namespace), filepath.Base(ref.Name), filepath.Base(ref.Key))
+	if !file.Exists(path) {
+		return "", fmt.Errorf("file '%s' for secret reference '%s' not found", path, ref)
+	}
+	value, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", errors.Wrap(err, fmt.Sprintf("failed to read secret reference '%s'", ref))
+	}
+	return string(value), nil
+}
+
+// NewProvider creates the provider with the given name
+func NewProvider(name, kubeconfigFile, dir string) (Provider, error) {
+	switch name {
+	case ProviderKubernetes:
+		return NewKubernetesProvider(kubeconfigFile)
+	case ProviderFile:
+		return NewFileProvider(dir)
+	default:
+		return nil, fmt.Errorf("secret provider '%s' is not supported: choose between '%s' and '%s'",
+			name, ProviderKubernetes, ProviderFile)
+	}
+}
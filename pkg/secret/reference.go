@@ -0,0 +1,50 @@
+package secret
+
+import (
+	"fmt"
+	"strings"
+)
+
+const RefPrefix = "secretRef://"
+
+// Reference points to a key of a secret which is resolved at dispatch time:
+// secretRef://<namespace>/<name>#<key>
+type Reference struct {
+	Namespace string
+	Name      string
+	Key       string
+}
+
+func (r *Reference) String() string {
+	return fmt.Sprintf("%s%s/%s#%s", RefPrefix, r.Namespace, r.Name, r.Key)
+}
+
+// IsReference returns true if the value is a string using the secret reference scheme
+func IsReference(value interface{}) bool {
+	strValue, ok := value.(string)
+	return ok && strings.HasPrefix(strValue, RefPrefix)
+}
+
+func ParseReference(value string) (*Reference, error) {
+	if !strings.HasPrefix(value, RefPrefix) {
+		return nil, fmt.Errorf("value is not a secret reference: expected prefix '%s'", RefPrefix)
+	}
+	path := strings.TrimPrefix(value, RefPrefix)
+
+	pathAndKey := strings.SplitN(path, "#", 2)
+	if len(pathAndKey) != 2 || pathAndKey[1] == "" {
+		return nil, fmt.Errorf("secret reference '%s' has no key: expected format '%s<namespace>/<name>#<key>'",
+			value, RefPrefix)
+	}
+	nsAndName := strings.Split(pathAndKey[0], "/")
+	if len(nsAndName) != 2 || nsAndName[0] == "" || nsAndName[1] == "" {
+		return nil, fmt.Errorf("secret reference '%s' is invalid: expected format '%s<namespace>/<name>#<key>'",
+			value, RefPrefix)
+	}
+
+	return &Reference{
+		Namespace: nsAndName[0],
+		Name:      nsAndName[1],
+		Key:       pathAndKey[1],
+	}, nil
+}
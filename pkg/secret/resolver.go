@@ -0,0 +1,45 @@
+package secret
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+// Resolver replaces secret references in a configuration with the referenced values
+type Resolver struct {
+	provider Provider
+}
+
+// NewResolver creates a resolver. A resolver without provider fails for any configuration containing references.
+func NewResolver(provider Provider) *Resolver {
+	return &Resolver{provider: provider}
+}
+
+// Resolve returns a copy of the configuration with resolved references and the keys whose values were resolved
+func (r *Resolver) Resolve(ctx context.Context, configuration map[string]interface{}) (map[string]interface{}, []string, error) {
+	result := make(map[string]interface{}, len(configuration))
+	var resolvedKeys []string
+	for key, value := range configuration {
+		if !IsReference(value) {
+			result[key] = value
+			continue
+		}
+		if r == nil || r.provider == nil {
+			return nil, nil, fmt.Errorf("configuration key '%s' contains a secret reference "+
+				"but no secret provider is configured", key)
+		}
+		ref, err := ParseReference(value.(string))
+		if err != nil {
+			return nil, nil, errors.Wrap(err, fmt.Sprintf("configuration key '%s' is invalid", key))
+		}
+		resolved, err := r.provider.Get(ctx, ref)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, fmt.Sprintf("failed to resolve configuration key '%s'", key))
+		}
+		result[key] = resolved
+		resolvedKeys = append(resolvedKeys, key)
+	}
+	return result, resolvedKeys, nil
+}
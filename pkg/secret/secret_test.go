@@ -0,0 +1,77 @@
+package secret
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kyma-incubator/reconciler/pkg/keb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseReference(t *testing.T) {
+	ref, err := ParseReference("secretRef://kyma-system/my-secret#password")
+	require.NoError(t, err)
+	require.Equal(t, &Reference{Namespace: "kyma-system", Name: "my-secret", Key: "password"}, ref)
+	require.Equal(t, "secretRef://kyma-system/my-secret#password", ref.String())
+
+	for _, invalid := range []string{
+		"kyma-system/my-secret#password",
+		"secretRef://kyma-system/my-secret",
+		"secretRef://my-secret#password",
+		"secretRef:///my-secret#password",
+		"secretRef://kyma-system/my-secret#",
+	} {
+		_, err := ParseReference(invalid)
+		require.Error(t, err, invalid)
+	}
+}
+
+func TestFileProvider(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "kyma-system", "my-secret"), 0700))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "kyma-system", "my-secret", "password"), []byte("s3cr3t"), 0600))
+
+	provider, err := NewFileProvider(dir)
+	require.NoError(t, err)
+
+	resolved, keys, err := NewResolver(provider).Resolve(context.Background(), map[string]interface{}{
+		"plain":    123,
+		"password": "secretRef://kyma-system/my-secret#password",
+	})
+	require.NoError(t, err)
+	require.Equal(t, map[string]interface{}{"plain": 123, "password": "s3cr3t"}, resolved)
+	require.Equal(t, []string{"password"}, keys)
+
+	_, _, err = NewResolver(provider).Resolve(context.Background(), map[string]interface{}{
+		"password": "secretRef://kyma-system/my-secret#unknown",
+	})
+	require.Error(t, err)
+}
+
+func TestMask(t *testing.T) {
+	t.Run("Mask configuration", func(t *testing.T) {
+		cfg := []keb.Configuration{
+			{Key: "plain", Value: "value"},
+			{Key: "password", Secret: true, Value: "s3cr3t"},
+			{Key: "ref", Secret: true, Value: "secretRef://ns/name#key"},
+		}
+		masked := MaskConfiguration(cfg)
+		require.Equal(t, "value", masked[0].Value)
+		require.Equal(t, MaskedValue, masked[1].Value)
+		require.Equal(t, "secretRef://ns/name#key", masked[2].Value)
+		require.Equal(t, "s3cr3t", cfg[1].Value) //original is untouched
+	})
+
+	t.Run("Mask JSON", func(t *testing.T) {
+		masked := MaskJSON([]byte(`{"kymaConfig":{"components":[{"component":"ory","configuration":[` +
+			`{"key":"password","secret":true,"value":"s3cr3t"},{"key":"plain","secret":false,"value":"value"}]}]}}`))
+		require.NotContains(t, string(masked), "s3cr3t")
+		require.Contains(t, string(masked), MaskedValue)
+		require.Contains(t, string(masked), `"value":"value"`)
+
+		require.Equal(t, "no json", string(MaskJSON([]byte("no json"))))
+	})
+}
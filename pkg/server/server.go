@@ -7,16 +7,18 @@ import (
 	"time"
 
 	"github.com/gorilla/mux"
+	"github.com/kyma-incubator/reconciler/pkg/ssl"
 	"go.uber.org/zap"
 )
 
 type Webserver struct {
-	Logger     *zap.SugaredLogger
-	Port       int
-	SSLCrtFile string
-	SSLKeyFile string
-	Router     *mux.Router
-	server     *http.Server
+	Logger       *zap.SugaredLogger
+	Port         int
+	SSLCrtFile   string
+	SSLKeyFile   string
+	ClientCAFile string //enables mutual TLS: clients have to present a certificate signed by this CA
+	Router       *mux.Router
+	server       *http.Server
 }
 
 func (s *Webserver) logger() *zap.SugaredLogger {
@@ -28,15 +30,28 @@ func (s *Webserver) logger() *zap.SugaredLogger {
 
 func (s *Webserver) Start(ctx context.Context) error {
 	s.logger().Infof("Webserver starting and listening on port %d", s.Port)
-	s.startServer(s.Router)
+	if err := s.startServer(s.Router); err != nil {
+		return err
+	}
 	<-ctx.Done()
 	s.logger().Info("Webserver stopping (context got closed)")
 	return s.stopServer()
 }
 
-func (s *Webserver) startServer(router *mux.Router) {
+func (s *Webserver) startServer(router *mux.Router) error {
 	//start server
 	s.server = &http.Server{Addr: fmt.Sprintf(":%d", s.Port), Handler: router}
+	if s.ClientCAFile != "" {
+		if s.SSLCrtFile == "" || s.SSLKeyFile == "" {
+			return fmt.Errorf("mutual TLS requires an SSL certificate and key")
+		}
+		tlsConfig, err := ssl.NewServerTLSConfig(s.ClientCAFile)
+		if err != nil {
+			return err
+		}
+		s.server.TLSConfig = tlsConfig
+		s.logger().Infof("Webserver requires client certificates signed by CA '%s'", s.ClientCAFile)
+	}
 	go func() {
 		var err error
 		if s.SSLCrtFile != "" && s.SSLKeyFile != "" {
@@ -48,6 +63,7 @@ func (s *Webserver) startServer(router *mux.Router) {
 			s.logger().Errorf("Webserver startup failed: %s", err)
 		}
 	}()
+	return nil
 }
 
 func (s *Webserver) stopServer() error {
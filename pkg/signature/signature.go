@@ -0,0 +1,115 @@
+package signature
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	HeaderSignature = "X-Reconciler-Signature"
+	HeaderTimestamp = "X-Reconciler-Timestamp"
+
+	minKeyLength  = 32
+	maxClockSkew  = 5 * time.Minute
+	signatureAlgo = "hmac-sha256"
+)
+
+// Signer signs and verifies HTTP requests exchanged between mothership and component reconcilers.
+//
+// Each operation uses its own signing key which is derived from the shared secret and the
+// correlation ID of the operation. The signature covers the timestamp and the body of the request.
+// A nil Signer neither signs nor verifies requests (signing is disabled).
+type Signer struct {
+	secret []byte
+	now    func() time.Time
+}
+
+func NewSigner(secret []byte) (*Signer, error) {
+	if len(secret) < minKeyLength {
+		return nil, fmt.Errorf("signature secret has to be at least %d bytes long", minKeyLength)
+	}
+	return &Signer{secret: secret, now: time.Now}, nil
+}
+
+// NewSignerFromFile reads the shared secret from the given file. It returns a nil Signer
+// if no file is defined.
+func NewSignerFromFile(secretFile string) (*Signer, error) {
+	if secretFile == "" {
+		return nil, nil
+	}
+	secret, err := ioutil.ReadFile(secretFile)
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("failed to read signature secret file '%s'", secretFile))
+	}
+	return NewSigner([]byte(strings.TrimSpace(string(secret))))
+}
+
+// Sign adds the signature headers for the given payload to the request
+func (s *Signer) Sign(req *http.Request, correlationID string, payload []byte) error {
+	if s == nil {
+		return nil
+	}
+	if correlationID == "" {
+		return errors.New("correlation ID is required to sign a request")
+	}
+	timestamp := strconv.FormatInt(s.now().Unix(), 10)
+	req.Header.Set(HeaderTimestamp, timestamp)
+	req.Header.Set(HeaderSignature, fmt.Sprintf("%s=%s", signatureAlgo, s.signature(correlationID, timestamp, payload)))
+	return nil
+}
+
+// Verify checks that the request was signed for the given operation and that the signature isn't outdated
+func (s *Signer) Verify(req *http.Request, correlationID string, payload []byte) error {
+	if s == nil {
+		return nil
+	}
+	header := req.Header.Get(HeaderSignature)
+	if header == "" {
+		return fmt.Errorf("request signature is missing: header '%s' undefined", HeaderSignature)
+	}
+	parts := strings.SplitN(header, "=", 2)
+	if len(parts) != 2 || parts[0] != signatureAlgo {
+		return fmt.Errorf("request signature in header '%s' is malformed or uses an unsupported algorithm", HeaderSignature)
+	}
+
+	timestamp := req.Header.Get(HeaderTimestamp)
+	unixTime, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return errors.Wrap(err, fmt.Sprintf("request timestamp in header '%s' is invalid", HeaderTimestamp))
+	}
+	if skew := s.now().Sub(time.Unix(unixTime, 0)); skew > maxClockSkew || skew < -maxClockSkew {
+		return fmt.Errorf("request signature is outdated (timestamp %s)", time.Unix(unixTime, 0).UTC().Format(time.RFC3339))
+	}
+
+	expected, err := hex.DecodeString(s.signature(correlationID, timestamp, payload))
+	if err != nil {
+		return err
+	}
+	received, err := hex.DecodeString(parts[1])
+	if err != nil || !hmac.Equal(expected, received) {
+		return fmt.Errorf("request signature is invalid for operation with correlation ID '%s'", correlationID)
+	}
+	return nil
+}
+
+func (s *Signer) signature(correlationID, timestamp string, payload []byte) string {
+	//derive operation specific key
+	keyMac := hmac.New(sha256.New, s.secret)
+	keyMac.Write([]byte(correlationID))
+	operationKey := keyMac.Sum(nil)
+
+	mac := hmac.New(sha256.New, operationKey)
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte{'\n'})
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,77 @@
+package signature
+
+import (
+	"bytes"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRequest(t *testing.T, payload []byte) *http.Request {
+	req, err := http.NewRequest(http.MethodPost, "http://localhost/v1/run", bytes.NewBuffer(payload))
+	require.NoError(t, err)
+	return req
+}
+
+func TestSigner(t *testing.T) {
+	signer, err := NewSigner([]byte(strings.Repeat("a", 32)))
+	require.NoError(t, err)
+	payload := []byte(`{"component":"istio"}`)
+
+	t.Run("Valid signature", func(t *testing.T) {
+		req := newTestRequest(t, payload)
+		require.NoError(t, signer.Sign(req, "corr-1", payload))
+		require.NoError(t, signer.Verify(req, "corr-1", payload))
+	})
+
+	t.Run("Signature of another operation", func(t *testing.T) {
+		req := newTestRequest(t, payload)
+		require.NoError(t, signer.Sign(req, "corr-1", payload))
+		require.Error(t, signer.Verify(req, "corr-2", payload))
+	})
+
+	t.Run("Manipulated payload", func(t *testing.T) {
+		req := newTestRequest(t, payload)
+		require.NoError(t, signer.Sign(req, "corr-1", payload))
+		require.Error(t, signer.Verify(req, "corr-1", []byte(`{"component":"ory"}`)))
+	})
+
+	t.Run("Different secret", func(t *testing.T) {
+		otherSigner, err := NewSigner([]byte(strings.Repeat("b", 32)))
+		require.NoError(t, err)
+		req := newTestRequest(t, payload)
+		require.NoError(t, otherSigner.Sign(req, "corr-1", payload))
+		require.Error(t, signer.Verify(req, "corr-1", payload))
+	})
+
+	t.Run("Outdated signature", func(t *testing.T) {
+		oldSigner, err := NewSigner([]byte(strings.Repeat("a", 32)))
+		require.NoError(t, err)
+		oldSigner.now = func() time.Time {
+			return time.Now().Add(-1 * time.Hour)
+		}
+		req := newTestRequest(t, payload)
+		require.NoError(t, oldSigner.Sign(req, "corr-1", payload))
+		require.Error(t, signer.Verify(req, "corr-1", payload))
+	})
+
+	t.Run("Missing signature", func(t *testing.T) {
+		require.Error(t, signer.Verify(newTestRequest(t, payload), "corr-1", payload))
+	})
+
+	t.Run("Disabled signer", func(t *testing.T) {
+		var disabled *Signer
+		req := newTestRequest(t, payload)
+		require.NoError(t, disabled.Sign(req, "corr-1", payload))
+		require.Empty(t, req.Header.Get(HeaderSignature))
+		require.NoError(t, disabled.Verify(req, "corr-1", payload))
+	})
+
+	t.Run("Secret too short", func(t *testing.T) {
+		_, err := NewSigner([]byte("short"))
+		require.Error(t, err)
+	})
+}
@@ -2,8 +2,11 @@ package ssl
 
 import (
 	"crypto/tls"
+	"crypto/x509"
 	"fmt"
 	"io/ioutil"
+	"net/http"
+	"time"
 
 	file "github.com/kyma-incubator/reconciler/pkg/files"
 	"github.com/pkg/errors"
@@ -31,3 +34,72 @@ func VerifyKeyPair(sslCrtFile, sslKeyFile string) error {
 	}
 	return fmt.Errorf("SSL certificate cannot be verified: either key or certificate file is missing")
 }
+
+// ClientConfig defines the certificates used by an HTTP client for mutual TLS
+type ClientConfig struct {
+	CrtFile string //client certificate presented to the server
+	KeyFile string //key of the client certificate
+	CAFile  string //CA bundle used to verify the server certificate (system pool is used if empty)
+}
+
+func (c *ClientConfig) Validate() error {
+	if c.CAFile != "" && !file.Exists(c.CAFile) {
+		return fmt.Errorf("CA file '%s' not found", c.CAFile)
+	}
+	return VerifyKeyPair(c.CrtFile, c.KeyFile)
+}
+
+// NewHTTPClient returns an HTTP client which authenticates with the configured client certificate
+// and verifies the server certificate against the configured CA bundle.
+func NewHTTPClient(cfg *ClientConfig, timeout time.Duration) (*http.Client, error) {
+	if cfg == nil || (cfg.CrtFile == "" && cfg.CAFile == "") {
+		return &http.Client{Timeout: timeout}, nil
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if cfg.CrtFile != "" {
+		crt, err := tls.LoadX509KeyPair(cfg.CrtFile, cfg.KeyFile)
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("failed to load client certificate '%s'", cfg.CrtFile))
+		}
+		tlsConfig.Certificates = []tls.Certificate{crt}
+	}
+	if cfg.CAFile != "" {
+		caPool, err := NewCertPool(cfg.CAFile)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.RootCAs = caPool
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	return &http.Client{Transport: transport, Timeout: timeout}, nil
+}
+
+// NewServerTLSConfig returns a TLS configuration which requires clients to present
+// a certificate signed by one of the CAs in the given CA bundle
+func NewServerTLSConfig(clientCAFile string) (*tls.Config, error) {
+	caPool, err := NewCertPool(clientCAFile)
+	if err != nil {
+		return nil, err
+	}
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ClientAuth: tls.RequireAndVerifyClientCert,
+		ClientCAs:  caPool,
+	}, nil
+}
+
+func NewCertPool(caFile string) (*x509.CertPool, error) {
+	ca, err := ioutil.ReadFile(caFile)
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("failed to read CA file '%s'", caFile))
+	}
+	caPool := x509.NewCertPool()
+	if !caPool.AppendCertsFromPEM(ca) {
+		return nil, fmt.Errorf("CA file '%s' doesn't contain any PEM encoded certificate", caFile)
+	}
+	return caPool, nil
+}